@@ -25,27 +25,20 @@ func addStyle(e *httpexpect.Expect, sId, name string) (GraphQLRequest, *httpexpe
 			"sceneId": sId,
 			"name":    name,
 			"value": map[string]any{
-				"type":           "ExampleType",
-				"url":            "https://example.com/data",
-				"value":          "sampleValue",
-				"layers":         "sampleLayerData",
-				"jsonProperties": []string{"prop1", "prop2"},
-				"updateInterval": 10,
-				"parameters": map[string]any{
-					"sampleKey": "sampleValue",
+				"marker": map[string]any{
+					"pointColor": map[string]any{
+						"expression": map[string]any{
+							"conditions": []any{
+								[]any{"${height} > 10", `color("red")`},
+								[]any{"true", `color("blue")`},
+							},
+						},
+					},
+					"pointSize":       10,
+					"heightReference": "clamp",
 				},
-				"time": map[string]any{
-					"property":          "time",
-					"interval":          5,
-					"updateClockOnLoad": true,
-				},
-				"csv": map[string]any{
-					"idColumn":              "id",
-					"latColumn":             "latitude",
-					"lngColumn":             "longitude",
-					"heightColumn":          "height",
-					"noHeader":              false,
-					"disableTypeConversion": true,
+				"polygon": map[string]any{
+					"fillColor": "#ffffff",
 				},
 			},
 		},
@@ -249,3 +242,56 @@ func TestStyleCRUD(t *testing.T) {
 		Value("styles").Array().
 		Length().Equal(0)
 }
+
+func TestValidateStyle(t *testing.T) {
+	e := StartServer(t, &config.Config{
+		Origins: []string{"https://example.com"},
+		AuthSrv: config.AuthSrvConfig{
+			Disabled: true,
+		},
+	}, true, baseSeeder)
+
+	pId := createProject(e)
+	_, _, sId := createScene(e, pId)
+
+	value := map[string]any{
+		"marker": map[string]any{
+			"pointColor": map[string]any{"expression": "${height} >"},
+		},
+	}
+
+	res := e.POST("/api/graphql").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(GraphQLRequest{
+			OperationName: "ValidateStyle",
+			Query: `query ValidateStyle($value: JSON!) {
+				validateStyle(value: $value) { valid errors { path position message } }
+			}`,
+			Variables: map[string]any{"value": value},
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON()
+
+	res.Path("$.data.validateStyle.valid").Equal(false)
+	res.Path("$.data.validateStyle.errors").Array().First().Object().
+		ValueEqual("path", "marker.pointColor.expression").
+		ValueEqual("position", 12).
+		ValueEqual("message", "unexpected end of expression")
+
+	res = e.POST("/api/graphql").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(GraphQLRequest{
+			OperationName: "AddStyle",
+			Query: `mutation AddStyle($sceneId: ID!, $value: JSON!) {
+				addStyle(input: { sceneId: $sceneId, name: "invalid", value: $value }) { style { id } }
+			}`,
+			Variables: map[string]any{"sceneId": sId, "value": value},
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON()
+
+	res.Path("$.errors").Array().First().Object().
+		Value("message").String().Contains("invalid style")
+}
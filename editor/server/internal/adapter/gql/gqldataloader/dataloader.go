@@ -17,3 +17,4 @@ package gqldataloader
 //go:generate go run github.com/vektah/dataloaden TagItemLoader github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel.ID *github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel.TagItem
 //go:generate go run github.com/vektah/dataloaden TagGroupLoader github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel.ID *github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel.TagGroup
 //go:generate go run github.com/vektah/dataloaden PolicyLoader github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel.ID *github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel.Policy
+//go:generate go run github.com/vektah/dataloaden LibraryStyleLoader github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel.ID *github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel.LibraryStyle
@@ -0,0 +1,224 @@
+// Code generated by github.com/vektah/dataloaden, DO NOT EDIT.
+
+package gqldataloader
+
+import (
+	"sync"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+)
+
+// LibraryStyleLoaderConfig captures the config to create a new LibraryStyleLoader
+type LibraryStyleLoaderConfig struct {
+	// Fetch is a method that provides the data for the loader
+	Fetch func(keys []gqlmodel.ID) ([]*gqlmodel.LibraryStyle, []error)
+
+	// Wait is how long wait before sending a batch
+	Wait time.Duration
+
+	// MaxBatch will limit the maximum number of keys to send in one batch, 0 = not limit
+	MaxBatch int
+}
+
+// NewLibraryStyleLoader creates a new LibraryStyleLoader given a fetch, wait, and maxBatch
+func NewLibraryStyleLoader(config LibraryStyleLoaderConfig) *LibraryStyleLoader {
+	return &LibraryStyleLoader{
+		fetch:    config.Fetch,
+		wait:     config.Wait,
+		maxBatch: config.MaxBatch,
+	}
+}
+
+// LibraryStyleLoader batches and caches requests
+type LibraryStyleLoader struct {
+	// this method provides the data for the loader
+	fetch func(keys []gqlmodel.ID) ([]*gqlmodel.LibraryStyle, []error)
+
+	// how long to done before sending a batch
+	wait time.Duration
+
+	// this will limit the maximum number of keys to send in one batch, 0 = no limit
+	maxBatch int
+
+	// INTERNAL
+
+	// lazily created cache
+	cache map[gqlmodel.ID]*gqlmodel.LibraryStyle
+
+	// the current batch. keys will continue to be collected until timeout is hit,
+	// then everything will be sent to the fetch method and out to the listeners
+	batch *libraryStyleLoaderBatch
+
+	// mutex to prevent races
+	mu sync.Mutex
+}
+
+type libraryStyleLoaderBatch struct {
+	keys    []gqlmodel.ID
+	data    []*gqlmodel.LibraryStyle
+	error   []error
+	closing bool
+	done    chan struct{}
+}
+
+// Load a LibraryStyle by key, batching and caching will be applied automatically
+func (l *LibraryStyleLoader) Load(key gqlmodel.ID) (*gqlmodel.LibraryStyle, error) {
+	return l.LoadThunk(key)()
+}
+
+// LoadThunk returns a function that when called will block waiting for a LibraryStyle.
+// This method should be used if you want one goroutine to make requests to many
+// different data loaders without blocking until the thunk is called.
+func (l *LibraryStyleLoader) LoadThunk(key gqlmodel.ID) func() (*gqlmodel.LibraryStyle, error) {
+	l.mu.Lock()
+	if it, ok := l.cache[key]; ok {
+		l.mu.Unlock()
+		return func() (*gqlmodel.LibraryStyle, error) {
+			return it, nil
+		}
+	}
+	if l.batch == nil {
+		l.batch = &libraryStyleLoaderBatch{done: make(chan struct{})}
+	}
+	batch := l.batch
+	pos := batch.keyIndex(l, key)
+	l.mu.Unlock()
+
+	return func() (*gqlmodel.LibraryStyle, error) {
+		<-batch.done
+
+		var data *gqlmodel.LibraryStyle
+		if pos < len(batch.data) {
+			data = batch.data[pos]
+		}
+
+		var err error
+		// its convenient to be able to return a single error for everything
+		if len(batch.error) == 1 {
+			err = batch.error[0]
+		} else if batch.error != nil {
+			err = batch.error[pos]
+		}
+
+		if err == nil {
+			l.mu.Lock()
+			l.unsafeSet(key, data)
+			l.mu.Unlock()
+		}
+
+		return data, err
+	}
+}
+
+// LoadAll fetches many keys at once. It will be broken into appropriate sized
+// sub batches depending on how the loader is configured
+func (l *LibraryStyleLoader) LoadAll(keys []gqlmodel.ID) ([]*gqlmodel.LibraryStyle, []error) {
+	results := make([]func() (*gqlmodel.LibraryStyle, error), len(keys))
+
+	for i, key := range keys {
+		results[i] = l.LoadThunk(key)
+	}
+
+	libraryStyles := make([]*gqlmodel.LibraryStyle, len(keys))
+	errors := make([]error, len(keys))
+	for i, thunk := range results {
+		libraryStyles[i], errors[i] = thunk()
+	}
+	return libraryStyles, errors
+}
+
+// LoadAllThunk returns a function that when called will block waiting for a LibraryStyles.
+// This method should be used if you want one goroutine to make requests to many
+// different data loaders without blocking until the thunk is called.
+func (l *LibraryStyleLoader) LoadAllThunk(keys []gqlmodel.ID) func() ([]*gqlmodel.LibraryStyle, []error) {
+	results := make([]func() (*gqlmodel.LibraryStyle, error), len(keys))
+	for i, key := range keys {
+		results[i] = l.LoadThunk(key)
+	}
+	return func() ([]*gqlmodel.LibraryStyle, []error) {
+		libraryStyles := make([]*gqlmodel.LibraryStyle, len(keys))
+		errors := make([]error, len(keys))
+		for i, thunk := range results {
+			libraryStyles[i], errors[i] = thunk()
+		}
+		return libraryStyles, errors
+	}
+}
+
+// Prime the cache with the provided key and value. If the key already exists, no change is made
+// and false is returned.
+// (To forcefully prime the cache, clear the key first with loader.clear(key).prime(key, value).)
+func (l *LibraryStyleLoader) Prime(key gqlmodel.ID, value *gqlmodel.LibraryStyle) bool {
+	l.mu.Lock()
+	var found bool
+	if _, found = l.cache[key]; !found {
+		// make a copy when writing to the cache, its easy to pass a pointer in from a loop var
+		// and end up with the whole cache pointing to the same value.
+		cpy := *value
+		l.unsafeSet(key, &cpy)
+	}
+	l.mu.Unlock()
+	return !found
+}
+
+// Clear the value at key from the cache, if it exists
+func (l *LibraryStyleLoader) Clear(key gqlmodel.ID) {
+	l.mu.Lock()
+	delete(l.cache, key)
+	l.mu.Unlock()
+}
+
+func (l *LibraryStyleLoader) unsafeSet(key gqlmodel.ID, value *gqlmodel.LibraryStyle) {
+	if l.cache == nil {
+		l.cache = map[gqlmodel.ID]*gqlmodel.LibraryStyle{}
+	}
+	l.cache[key] = value
+}
+
+// keyIndex will return the location of the key in the batch, if its not found
+// it will add the key to the batch
+func (b *libraryStyleLoaderBatch) keyIndex(l *LibraryStyleLoader, key gqlmodel.ID) int {
+	for i, existingKey := range b.keys {
+		if key == existingKey {
+			return i
+		}
+	}
+
+	pos := len(b.keys)
+	b.keys = append(b.keys, key)
+	if pos == 0 {
+		go b.startTimer(l)
+	}
+
+	if l.maxBatch != 0 && pos >= l.maxBatch-1 {
+		if !b.closing {
+			b.closing = true
+			l.batch = nil
+			go b.end(l)
+		}
+	}
+
+	return pos
+}
+
+func (b *libraryStyleLoaderBatch) startTimer(l *LibraryStyleLoader) {
+	time.Sleep(l.wait)
+	l.mu.Lock()
+
+	// we must have hit a batch limit and are already finalizing this batch
+	if b.closing {
+		l.mu.Unlock()
+		return
+	}
+
+	l.batch = nil
+	l.mu.Unlock()
+
+	b.end(l)
+}
+
+func (b *libraryStyleLoaderBatch) end(l *LibraryStyleLoader) {
+	b.data, b.error = l.fetch(b.keys)
+	close(b.done)
+}
@@ -1,7 +1,9 @@
 package gqlmodel
 
 import (
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/samber/lo"
 )
 
 func ToAsset(a *asset.Asset) *Asset {
@@ -17,6 +19,46 @@ func ToAsset(a *asset.Asset) *Asset {
 		Size:        a.Size(),
 		URL:         a.URL(),
 		ContentType: a.ContentType(),
+		Folder:      a.Folder(),
+		Tags:        a.Tags(),
+		Metadata:    ToAssetMetadata(a.Metadata()),
+	}
+}
+
+func ToAssetMetadata(m *asset.Metadata) *AssetMetadata {
+	if m == nil {
+		return nil
+	}
+
+	res := &AssetMetadata{
+		Width:        lo.EmptyableToPtr(m.Width),
+		Height:       lo.EmptyableToPtr(m.Height),
+		FeatureCount: lo.EmptyableToPtr(m.FeatureCount),
+	}
+	if m.BBox != nil {
+		res.Bbox = m.BBox[:]
+	}
+	return res
+}
+
+func ToAssetUsage(u interfaces.AssetUsage) *AssetUsage {
+	var t AssetUsageType
+	switch u.Type {
+	case interfaces.AssetUsageTypeScene:
+		t = AssetUsageTypeScene
+	case interfaces.AssetUsageTypeLayer:
+		t = AssetUsageTypeLayer
+	case interfaces.AssetUsageTypeNLSLayer:
+		t = AssetUsageTypeNlsLayer
+	case interfaces.AssetUsageTypeStory:
+		t = AssetUsageTypeStory
+	}
+
+	return &AssetUsage{
+		Type:       t,
+		SceneID:    IDFrom(u.SceneID),
+		ID:         ID(u.ID),
+		PropertyID: IDFromRef(u.PropertyID),
 	}
 }
 
@@ -0,0 +1,51 @@
+package gqlmodel
+
+import (
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/scene/presence"
+	"github.com/reearth/reearthx/util"
+)
+
+func ToSceneChangeEvent(e *interfaces.SceneChangeEvent) *SceneChangeEvent {
+	if e == nil {
+		return nil
+	}
+
+	return &SceneChangeEvent{
+		SceneID:   IDFrom(e.Scene),
+		HistoryID: IDFromRef(e.History),
+		UserID:    IDFromRef(e.User),
+		Operation: e.Operation,
+		Changes:   util.Map(e.Changes, ToSceneChange),
+	}
+}
+
+func ToSceneChange(c interfaces.SceneChange) *SceneChange {
+	kind := SceneChangeKindUpdated
+	if c.Created {
+		kind = SceneChangeKindCreated
+	} else if c.Removed {
+		kind = SceneChangeKindRemoved
+	}
+
+	return &SceneChange{
+		Type:    ToSceneHistoryEntityType(c.Type),
+		ID:      ID(c.ID),
+		Kind:    kind,
+		Version: c.Version,
+	}
+}
+
+func ToScenePresence(p presence.Presence) *ScenePresence {
+	return &ScenePresence{
+		SceneID:     IDFrom(p.Scene),
+		UserID:      IDFrom(p.User),
+		LayerID:     IDFromRef(p.Layer),
+		StoryPageID: IDFromRef(p.StoryPage),
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+func ToScenePresenceList(l presence.List) []*ScenePresence {
+	return append([]*ScenePresence{}, util.Map(l, ToScenePresence)...)
+}
@@ -156,6 +156,8 @@ func FromLayerEncodingFormat(v LayerEncodingFormat) decoding.LayerEncodingFormat
 		return decoding.LayerEncodingFormatSHAPE
 	case LayerEncodingFormatReearth:
 		return decoding.LayerEncodingFormatREEARTH
+	case LayerEncodingFormatCSV:
+		return decoding.LayerEncodingFormatCSV
 	}
 
 	return decoding.LayerEncodingFormat("")
@@ -22,6 +22,7 @@ func ToNLSLayerSimple(l *nlslayer.NLSLayerSimple) *NLSLayerSimple {
 		Config:    JSON(*l.Config()),
 		IsSketch:  l.IsSketch(),
 		Sketch:    ToNLSLayerSketchInfo(l.Sketch()),
+		ClusterID: IDFromRef(l.Cluster()),
 	}
 }
 
@@ -58,6 +59,7 @@ func ToNLSLayerGroup(l *nlslayer.NLSLayerGroup, parent *id.NLSLayerID) *NLSLayer
 		Config:      JSON(*l.Config()),
 		Infobox:     ToNLSInfobox(l.Infobox(), l.ID(), l.Scene()),
 		ChildrenIds: util.Map(l.Children().Layers(), IDFrom[id.NLSLayer]),
+		ClusterID:   IDFromRef(l.Cluster()),
 	}
 }
 
@@ -136,13 +138,7 @@ func ToNLSLayerSketchInfo(si *nlslayer.SketchInfo) *SketchInfo {
 
 	var features []*Feature
 	for _, f := range si.FeatureCollection().Features() {
-		feature := &Feature{
-			ID:         IDFrom(f.ID()),
-			Type:       f.FeatureType(),
-			Geometry:   convertGeometry(f.Geometry()),
-			Properties: *ToGoJsonRef(*f.Properties()),
-		}
-		features = append(features, feature)
+		features = append(features, ToFeature(f))
 	}
 
 	featureCollection := &FeatureCollection{
@@ -156,6 +152,20 @@ func ToNLSLayerSketchInfo(si *nlslayer.SketchInfo) *SketchInfo {
 	}
 }
 
+func ToFeature(f nlslayer.Feature) *Feature {
+	var properties JSON
+	if f.Properties() != nil {
+		properties = *ToGoJsonRef(*f.Properties())
+	}
+
+	return &Feature{
+		ID:         IDFrom(f.ID()),
+		Type:       f.FeatureType(),
+		Geometry:   convertGeometry(f.Geometry()),
+		Properties: properties,
+	}
+}
+
 func ToGoJsonRef(p JSON) *map[string]any {
 	if p == nil {
 		return nil
@@ -2,6 +2,7 @@ package gqlmodel
 
 import (
 	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/pluginkey"
 	"github.com/reearth/reearthx/util"
 )
 
@@ -38,6 +39,32 @@ func ToPlugin(p *plugin.Plugin) *Plugin {
 				AllTranslatedName:        pe.Name(),
 			}
 		}),
+		Permissions: ToPluginPermissions(p.Permissions()),
+		Signed:      p.Signature() != nil,
+	}
+}
+
+func ToPluginPermissions(p *plugin.Permissions) *PluginPermissions {
+	layers := PluginLayerAccessRead
+	if p.CanWriteLayers() {
+		layers = PluginLayerAccessWrite
+	}
+	return &PluginPermissions{
+		Network: append([]string{}, p.Network()...),
+		Layers:  layers,
+	}
+}
+
+func ToPluginKey(k *pluginkey.Key) *PluginKey {
+	if k == nil {
+		return nil
+	}
+	return &PluginKey{
+		ID:        IDFrom(k.ID()),
+		TeamID:    IDFrom(k.Workspace()),
+		Name:      k.Name(),
+		PublicKey: k.String(),
+		CreatedAt: k.CreatedAt(),
 	}
 }
 
@@ -3,6 +3,7 @@ package gqlmodel
 import (
 	"time"
 
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/project"
 )
 
@@ -52,6 +53,8 @@ func ToProject(p *project.Project) *Project {
 		Description:       p.Description(),
 		ImageURL:          p.ImageURL(),
 		PublishedAt:       publishedAtRes,
+		PublishAt:         p.PublishSchedule().PublishAt(),
+		UnpublishAt:       p.PublishSchedule().UnpublishAt(),
 		UpdatedAt:         p.UpdatedAt(),
 		Visualizer:        Visualizer(p.Visualizer()),
 		TeamID:            IDFrom(p.Workspace()),
@@ -65,3 +68,10 @@ func ToProject(p *project.Project) *Project {
 		TrackingID:        p.TrackingID(),
 	}
 }
+
+func ToPublishedVersion(v interfaces.PublishedVersion) *PublishedVersion {
+	return &PublishedVersion{
+		Version:     v.Version,
+		PublishedAt: v.PublishedAt,
+	}
+}
@@ -3,6 +3,7 @@ package gqlmodel
 import (
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
 )
 
 func ToSceneWidget(w *scene.Widget) *SceneWidget {
@@ -56,14 +57,17 @@ func ToScene(scene *scene.Scene) *Scene {
 		Clusters:          util.Map(scene.Clusters().Clusters(), ToCluster),
 		Widgets:           util.Map(scene.Widgets().Widgets(), ToSceneWidget),
 		WidgetAlignSystem: ToWidgetAlignSystem(scene.Widgets().Alignment()),
+		IsTemplate:        scene.Template(),
 	}
 }
 
 func ToStyle(v *scene.Style) *Style {
 	return &Style{
-		ID:    IDFrom(v.ID()),
-		Name:  v.Name(),
-		Value: JSON(*v.Value()),
+		ID:      IDFrom(v.ID()),
+		Name:    v.Name(),
+		Value:   ToStyleValueJSON(v.Value()),
+		SceneID: IDFrom(v.Scene()),
+		Library: ToStyleLibraryLink(v.Link()),
 	}
 }
 
@@ -80,6 +84,23 @@ func ToStyleValue(p JSON) *scene.StyleValue {
 	return &sv
 }
 
+func ToValidateStylePayload(errs scene.StyleErrors) *ValidateStylePayload {
+	return &ValidateStylePayload{
+		Valid: len(errs) == 0,
+		Errors: lo.Map(errs, func(e *scene.StyleError, _ int) *StyleValidationError {
+			var pos *int
+			if e.Pos > 0 {
+				pos = lo.ToPtr(e.Pos)
+			}
+			return &StyleValidationError{
+				Path:     e.Path,
+				Position: pos,
+				Message:  e.Message,
+			}
+		}),
+	}
+}
+
 func ToStyles(styles scene.StyleList) []*Style {
 	return util.Map(styles, func(s *scene.Style) *Style {
 		return ToStyle(s)
@@ -0,0 +1,60 @@
+package gqlmodel
+
+import (
+	"encoding/json"
+
+	"github.com/reearth/reearth/server/pkg/scene/history"
+	"github.com/reearth/reearthx/util"
+)
+
+func ToSceneHistory(h *history.History) *SceneHistory {
+	if h == nil {
+		return nil
+	}
+
+	return &SceneHistory{
+		ID:        IDFrom(h.ID()),
+		SceneID:   IDFrom(h.Scene()),
+		UserID:    IDFromRef(h.User()),
+		Operation: h.Operation(),
+		Changes:   util.Map(h.Changes(), ToSceneHistoryChange),
+		CreatedAt: h.CreatedAt(),
+		Undone:    h.Undone(),
+	}
+}
+
+func ToSceneHistoryChange(c history.Change) *SceneHistoryChange {
+	return &SceneHistoryChange{
+		Type:   ToSceneHistoryEntityType(c.Type),
+		ID:     ID(c.ID),
+		Before: toHistoryState(c.Before),
+		After:  toHistoryState(c.After),
+	}
+}
+
+func ToSceneHistoryEntityType(t history.EntityType) SceneHistoryEntityType {
+	switch t {
+	case history.EntityTypeScene:
+		return SceneHistoryEntityTypeScene
+	case history.EntityTypeNLSLayer:
+		return SceneHistoryEntityTypeNlsLayer
+	case history.EntityTypeStyle:
+		return SceneHistoryEntityTypeStyle
+	case history.EntityTypeStory:
+		return SceneHistoryEntityTypeStory
+	case history.EntityTypeProperty:
+		return SceneHistoryEntityTypeProperty
+	}
+	return ""
+}
+
+func toHistoryState(s *string) JSON {
+	if s == nil {
+		return nil
+	}
+	var res JSON
+	if err := json.Unmarshal([]byte(*s), &res); err != nil {
+		return nil
+	}
+	return res
+}
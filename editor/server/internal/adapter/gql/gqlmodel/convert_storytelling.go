@@ -13,6 +13,7 @@ func ToStory(s *storytelling.Story) *Story {
 		ID:                IDFrom(s.Id()),
 		Title:             s.Title(),
 		Alias:             s.Alias(),
+		Index:             s.Index(),
 		PropertyID:        IDFrom(s.Property()),
 		Property:          nil,
 		Pages:             ToPages(s.Pages()),
@@ -20,6 +21,8 @@ func ToStory(s *storytelling.Story) *Story {
 		CreatedAt:         s.Id().Timestamp(),
 		UpdatedAt:         s.UpdatedAt(),
 		PublishedAt:       s.PublishedAt(),
+		PublishAt:         s.PublishSchedule().PublishAt(),
+		UnpublishAt:       s.PublishSchedule().UnpublishAt(),
 		PanelPosition:     ToStoryPosition(s.PanelPosition()),
 		BgColor:           ToStoryBgColor(s.BgColor()),
 
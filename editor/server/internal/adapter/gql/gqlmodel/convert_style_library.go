@@ -0,0 +1,53 @@
+package gqlmodel
+
+import (
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/stylelib"
+	"github.com/samber/lo"
+)
+
+func ToLibraryStyle(s *stylelib.Style) *LibraryStyle {
+	if s == nil {
+		return nil
+	}
+
+	var value JSON
+	if l := s.Latest(); l != nil {
+		value = ToStyleValueJSON(l.Value())
+	}
+
+	return &LibraryStyle{
+		ID:            IDFrom(s.ID()),
+		TeamID:        IDFrom(s.Workspace()),
+		Name:          s.Name(),
+		LatestVersion: s.LatestVersion(),
+		Value:         value,
+		Versions: lo.Map(s.Versions(), func(v *stylelib.Version, _ int) *LibraryStyleVersion {
+			return &LibraryStyleVersion{
+				Version:   v.Version(),
+				Value:     ToStyleValueJSON(v.Value()),
+				UserID:    IDFromRef(v.User()),
+				CreatedAt: v.CreatedAt(),
+			}
+		}),
+		CreatedAt: s.CreatedAt(),
+		UpdatedAt: s.UpdatedAt(),
+	}
+}
+
+func ToStyleLibraryLink(l *scene.StyleLink) *StyleLibraryLink {
+	if l == nil {
+		return nil
+	}
+	return &StyleLibraryLink{
+		LibraryStyleID: IDFrom(l.Library()),
+		Version:        l.Version(),
+	}
+}
+
+func ToStyleValueJSON(v *scene.StyleValue) JSON {
+	if v == nil {
+		return JSON{}
+	}
+	return JSON(*v)
+}
@@ -2,7 +2,9 @@ package gqlmodel
 
 import (
 	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/usage"
 	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/util"
 )
 
 func ToWorkspace(t *workspace.Workspace) *Team {
@@ -72,5 +74,42 @@ func ToPolicy(p *policy.Policy) *Policy {
 		AssetStorageSize:      o.AssetStorageSize,
 		DatasetSchemaCount:    o.DatasetSchemaCount,
 		DatasetCount:          o.DatasetCount,
+		NlsLayerCount:         o.NLSLayerCount,
+		SketchFeatureCount:    o.SketchFeatureCount,
+		StoryCount:            o.StoryCount,
+		StoryPageCount:        o.StoryPageCount,
+		PublishedStoryCount:   o.PublishedStoryCount,
+	}
+}
+
+func ToWorkspaceUsage(u *usage.Usage) *WorkspaceUsage {
+	if u == nil {
+		return nil
+	}
+
+	return &WorkspaceUsage{
+		WorkspaceID:           IDFrom(u.Workspace()),
+		AssetStorageSize:      u.AssetStorageSize(),
+		ProjectCount:          u.ProjectCount(),
+		PublishedProjectCount: u.PublishedProjectCount(),
+		SceneCount:            u.SceneCount(),
+		LayerCount:            u.LayerCount(),
+		NlsLayerCount:         u.NLSLayerCount(),
+		StoryCount:            u.StoryCount(),
+		PublishedStoryCount:   u.PublishedStoryCount(),
+		PublishedSiteCount:    u.PublishedSiteCount(),
+		Projects: util.Map(u.Projects(), func(p usage.Project) *ProjectUsage {
+			return &ProjectUsage{
+				ProjectID:           IDFrom(p.ID),
+				Name:                p.Name,
+				SceneID:             IDFromRef(p.Scene),
+				Published:           p.Published,
+				LayerCount:          p.LayerCount,
+				NlsLayerCount:       p.NLSLayerCount,
+				StoryCount:          p.StoryCount,
+				PublishedStoryCount: p.PublishedStoryCount,
+			}
+		}),
+		UpdatedAt: u.UpdatedAt(),
 	}
 }
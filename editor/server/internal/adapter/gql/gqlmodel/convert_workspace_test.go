@@ -2,8 +2,12 @@ package gqlmodel
 
 import (
 	"testing"
+	"time"
 
+	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/usage"
+	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/account/accountdomain/workspace"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
@@ -36,6 +40,11 @@ func TestToPolicy(t *testing.T) {
 		AssetStorageSize:      lo.ToPtr(int64(5)),
 		DatasetCount:          lo.ToPtr(6),
 		DatasetSchemaCount:    lo.ToPtr(7),
+		NlsLayerCount:         lo.ToPtr(8),
+		SketchFeatureCount:    lo.ToPtr(9),
+		StoryCount:            lo.ToPtr(10),
+		StoryPageCount:        lo.ToPtr(11),
+		PublishedStoryCount:   lo.ToPtr(12),
 	}, ToPolicy(policy.New(policy.Option{
 		ID:                    policy.ID("x"),
 		Name:                  "aaa",
@@ -46,6 +55,38 @@ func TestToPolicy(t *testing.T) {
 		AssetStorageSize:      lo.ToPtr(int64(5)),
 		DatasetCount:          lo.ToPtr(6),
 		DatasetSchemaCount:    lo.ToPtr(7),
+		NLSLayerCount:         lo.ToPtr(8),
+		SketchFeatureCount:    lo.ToPtr(9),
+		StoryCount:            lo.ToPtr(10),
+		StoryPageCount:        lo.ToPtr(11),
+		PublishedStoryCount:   lo.ToPtr(12),
 	})))
 	assert.Nil(t, ToPolicy(nil))
 }
+
+func TestToWorkspaceUsage(t *testing.T) {
+	wid := accountdomain.NewWorkspaceID()
+	pid := id.NewProjectID()
+	sid := id.NewSceneID()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, &WorkspaceUsage{
+		WorkspaceID:           IDFrom(wid),
+		AssetStorageSize:      100,
+		ProjectCount:          1,
+		PublishedProjectCount: 1,
+		SceneCount:            1,
+		LayerCount:            2,
+		NlsLayerCount:         3,
+		StoryCount:            4,
+		PublishedStoryCount:   1,
+		PublishedSiteCount:    2,
+		Projects: []*ProjectUsage{
+			{ProjectID: IDFrom(pid), Name: "a", SceneID: IDFromRef(&sid), Published: true, LayerCount: 2, NlsLayerCount: 3, StoryCount: 4, PublishedStoryCount: 1},
+		},
+		UpdatedAt: now,
+	}, ToWorkspaceUsage(usage.New(wid, 100, []usage.Project{
+		{ID: pid, Name: "a", Scene: &sid, Published: true, LayerCount: 2, NLSLayerCount: 3, StoryCount: 4, PublishedStoryCount: 1},
+	}, now)))
+	assert.Nil(t, ToWorkspaceUsage(nil))
+}
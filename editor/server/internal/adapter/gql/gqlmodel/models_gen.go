@@ -54,6 +54,8 @@ type NLSLayer interface {
 	GetInfobox() *NLSInfobox
 	GetIsSketch() bool
 	GetSketch() *SketchInfo
+	GetClusterID() *ID
+	GetVersion() string
 }
 
 type Node interface {
@@ -171,6 +173,21 @@ type AddNLSInfoboxBlockPayload struct {
 	Layer        NLSLayer      `json:"layer"`
 }
 
+type AddNLSLayerGroupInput struct {
+	SceneID       ID     `json:"sceneId"`
+	Title         string `json:"title"`
+	ParentLayerID *ID    `json:"parentLayerId,omitempty"`
+	Index         *int   `json:"index,omitempty"`
+	Config        JSON   `json:"config,omitempty"`
+	Visible       *bool  `json:"visible,omitempty"`
+}
+
+type AddNLSLayerGroupPayload struct {
+	Layer       *NLSLayerGroup `json:"layer"`
+	ParentLayer *NLSLayerGroup `json:"parentLayer,omitempty"`
+	Index       *int           `json:"index,omitempty"`
+}
+
 type AddNLSLayerSimpleInput struct {
 	LayerType string `json:"layerType"`
 	Title     string `json:"title"`
@@ -185,6 +202,16 @@ type AddNLSLayerSimplePayload struct {
 	Layers *NLSLayerSimple `json:"layers"`
 }
 
+type AddPluginKeyInput struct {
+	TeamID    ID     `json:"teamId"`
+	Name      string `json:"name"`
+	PublicKey string `json:"publicKey"`
+}
+
+type AddPluginKeyPayload struct {
+	PluginKey *PluginKey `json:"pluginKey"`
+}
+
 type AddPropertyItemInput struct {
 	PropertyID     ID          `json:"propertyId"`
 	SchemaGroupID  ID          `json:"schemaGroupId"`
@@ -215,14 +242,18 @@ type AddWidgetPayload struct {
 }
 
 type Asset struct {
-	ID          ID        `json:"id"`
-	CreatedAt   time.Time `json:"createdAt"`
-	TeamID      ID        `json:"teamId"`
-	Name        string    `json:"name"`
-	Size        int64     `json:"size"`
-	URL         string    `json:"url"`
-	ContentType string    `json:"contentType"`
-	Team        *Team     `json:"team,omitempty"`
+	ID          ID             `json:"id"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	TeamID      ID             `json:"teamId"`
+	Name        string         `json:"name"`
+	Size        int64          `json:"size"`
+	URL         string         `json:"url"`
+	ContentType string         `json:"contentType"`
+	Folder      string         `json:"folder"`
+	Tags        []string       `json:"tags"`
+	Metadata    *AssetMetadata `json:"metadata,omitempty"`
+	Usages      []*AssetUsage  `json:"usages"`
+	Team        *Team          `json:"team,omitempty"`
 }
 
 func (Asset) IsNode()        {}
@@ -240,6 +271,22 @@ type AssetEdge struct {
 	Node   *Asset          `json:"node,omitempty"`
 }
 
+type AssetMetadata struct {
+	Width        *int `json:"width,omitempty"`
+	Height       *int `json:"height,omitempty"`
+	FeatureCount *int `json:"featureCount,omitempty"`
+	// [west, south, east, north] in degrees
+	Bbox []float64 `json:"bbox,omitempty"`
+}
+
+type AssetUsage struct {
+	Type    AssetUsageType `json:"type"`
+	SceneID ID             `json:"sceneId"`
+	// ID of the scene, the NLS layer or the story
+	ID         ID  `json:"id"`
+	PropertyID *ID `json:"propertyId,omitempty"`
+}
+
 type AttachTagItemToGroupInput struct {
 	ItemID  ID `json:"itemID"`
 	GroupID ID `json:"groupID"`
@@ -278,6 +325,8 @@ type Cluster struct {
 type CreateAssetInput struct {
 	TeamID ID             `json:"teamId"`
 	File   graphql.Upload `json:"file"`
+	Folder *string        `json:"folder,omitempty"`
+	Tags   []string       `json:"tags,omitempty"`
 }
 
 type CreateAssetPayload struct {
@@ -309,6 +358,7 @@ type CreateProjectInput struct {
 	Alias       *string    `json:"alias,omitempty"`
 	Archived    *bool      `json:"archived,omitempty"`
 	CoreSupport *bool      `json:"coreSupport,omitempty"`
+	TemplateID  *ID        `json:"templateId,omitempty"`
 }
 
 type CreateSceneInput struct {
@@ -350,6 +400,11 @@ type CreateStoryPageInput struct {
 	Index           *int    `json:"index,omitempty"`
 }
 
+type CreateStoryViewTokenInput struct {
+	StoryID   ID        `json:"storyId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
 type CreateTagGroupInput struct {
 	SceneID ID     `json:"sceneId"`
 	Label   string `json:"label"`
@@ -539,6 +594,11 @@ type DuplicateNLSLayerPayload struct {
 	Layer NLSLayer `json:"layer"`
 }
 
+type DuplicateStoryInput struct {
+	SceneID ID `json:"sceneId"`
+	StoryID ID `json:"storyId"`
+}
+
 type DuplicateStoryPageInput struct {
 	SceneID ID `json:"sceneId"`
 	StoryID ID `json:"storyId"`
@@ -553,6 +613,14 @@ type DuplicateStylePayload struct {
 	Style *Style `json:"style"`
 }
 
+type ExportProjectInput struct {
+	ProjectID ID `json:"projectId"`
+}
+
+type ExportProjectPayload struct {
+	ProjectDataPath string `json:"projectDataPath"`
+}
+
 type Feature struct {
 	Type       string   `json:"type"`
 	Geometry   Geometry `json:"geometry"`
@@ -590,6 +658,17 @@ type ImportDatasetPayload struct {
 	DatasetSchema *DatasetSchema `json:"datasetSchema"`
 }
 
+type ImportGeoJSONFeaturesInput struct {
+	LayerID ID                  `json:"layerId"`
+	File    graphql.Upload      `json:"file"`
+	Format  LayerEncodingFormat `json:"format"`
+}
+
+type ImportGeoJSONFeaturesPayload struct {
+	Layer    NLSLayer   `json:"layer"`
+	Features []*Feature `json:"features"`
+}
+
 type ImportLayerInput struct {
 	LayerID ID                  `json:"layerId"`
 	File    graphql.Upload      `json:"file"`
@@ -601,6 +680,21 @@ type ImportLayerPayload struct {
 	ParentLayer *LayerGroup `json:"parentLayer"`
 }
 
+type ImportProjectInput struct {
+	TeamID ID             `json:"teamId"`
+	File   graphql.Upload `json:"file"`
+}
+
+type ImportStyleInput struct {
+	LibraryStyleID ID   `json:"libraryStyleId"`
+	SceneID        ID   `json:"sceneId"`
+	Version        *int `json:"version,omitempty"`
+}
+
+type ImportStylePayload struct {
+	Style *Style `json:"style"`
+}
+
 type Infobox struct {
 	SceneID         ID              `json:"sceneId"`
 	LayerID         ID              `json:"layerId"`
@@ -784,6 +878,25 @@ func (LayerTagItem) IsLayerTag()       {}
 func (this LayerTagItem) GetTagID() ID { return this.TagID }
 func (this LayerTagItem) GetTag() Tag  { return this.Tag }
 
+type LibraryStyle struct {
+	ID            ID                     `json:"id"`
+	TeamID        ID                     `json:"teamId"`
+	Name          string                 `json:"name"`
+	LatestVersion int                    `json:"latestVersion"`
+	Value         JSON                   `json:"value"`
+	Versions      []*LibraryStyleVersion `json:"versions"`
+	CreatedAt     time.Time              `json:"createdAt"`
+	UpdatedAt     time.Time              `json:"updatedAt"`
+	Team          *Team                  `json:"team,omitempty"`
+}
+
+type LibraryStyleVersion struct {
+	Version   int       `json:"version"`
+	Value     JSON      `json:"value"`
+	UserID    *ID       `json:"userId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 type LineString struct {
 	Type                  string      `json:"type"`
 	LineStringCoordinates [][]float64 `json:"lineStringCoordinates"`
@@ -922,6 +1035,19 @@ type MoveNLSInfoboxBlockPayload struct {
 	Index          int      `json:"index"`
 }
 
+type MoveNLSLayerInput struct {
+	LayerID     ID   `json:"layerId"`
+	DestLayerID *ID  `json:"destLayerId,omitempty"`
+	Index       *int `json:"index,omitempty"`
+}
+
+type MoveNLSLayerPayload struct {
+	LayerID         ID             `json:"layerId"`
+	FromParentLayer *NLSLayerGroup `json:"fromParentLayer,omitempty"`
+	ToParentLayer   *NLSLayerGroup `json:"toParentLayer,omitempty"`
+	Index           int            `json:"index"`
+}
+
 type MovePropertyItemInput struct {
 	PropertyID    ID  `json:"propertyId"`
 	SchemaGroupID ID  `json:"schemaGroupId"`
@@ -1000,6 +1126,8 @@ type NLSLayerGroup struct {
 	Scene       *Scene      `json:"scene,omitempty"`
 	IsSketch    bool        `json:"isSketch"`
 	Sketch      *SketchInfo `json:"sketch,omitempty"`
+	ClusterID   *ID         `json:"clusterId,omitempty"`
+	Version     string      `json:"version"`
 }
 
 func (NLSLayerGroup) IsNLSLayer()                  {}
@@ -1012,6 +1140,8 @@ func (this NLSLayerGroup) GetVisible() bool        { return this.Visible }
 func (this NLSLayerGroup) GetInfobox() *NLSInfobox { return this.Infobox }
 func (this NLSLayerGroup) GetIsSketch() bool       { return this.IsSketch }
 func (this NLSLayerGroup) GetSketch() *SketchInfo  { return this.Sketch }
+func (this NLSLayerGroup) GetClusterID() *ID       { return this.ClusterID }
+func (this NLSLayerGroup) GetVersion() string      { return this.Version }
 
 type NLSLayerSimple struct {
 	ID        ID          `json:"id"`
@@ -1024,6 +1154,8 @@ type NLSLayerSimple struct {
 	Scene     *Scene      `json:"scene,omitempty"`
 	IsSketch  bool        `json:"isSketch"`
 	Sketch    *SketchInfo `json:"sketch,omitempty"`
+	ClusterID *ID         `json:"clusterId,omitempty"`
+	Version   string      `json:"version"`
 }
 
 func (NLSLayerSimple) IsNLSLayer()                  {}
@@ -1036,6 +1168,8 @@ func (this NLSLayerSimple) GetVisible() bool        { return this.Visible }
 func (this NLSLayerSimple) GetInfobox() *NLSInfobox { return this.Infobox }
 func (this NLSLayerSimple) GetIsSketch() bool       { return this.IsSketch }
 func (this NLSLayerSimple) GetSketch() *SketchInfo  { return this.Sketch }
+func (this NLSLayerSimple) GetClusterID() *ID       { return this.ClusterID }
+func (this NLSLayerSimple) GetVersion() string      { return this.Version }
 
 type PageInfo struct {
 	StartCursor     *usecasex.Cursor `json:"startCursor,omitempty"`
@@ -1076,6 +1210,8 @@ type Plugin struct {
 	TranslatedName           string             `json:"translatedName"`
 	TranslatedDescription    string             `json:"translatedDescription"`
 	PropertySchema           *PropertySchema    `json:"propertySchema,omitempty"`
+	Permissions              *PluginPermissions `json:"permissions"`
+	Signed                   bool               `json:"signed"`
 }
 
 type PluginExtension struct {
@@ -1098,6 +1234,20 @@ type PluginExtension struct {
 	TranslatedDescription    string              `json:"translatedDescription"`
 }
 
+type PluginKey struct {
+	ID        ID        `json:"id"`
+	TeamID    ID        `json:"teamId"`
+	Name      string    `json:"name"`
+	PublicKey string    `json:"publicKey"`
+	CreatedAt time.Time `json:"createdAt"`
+	Team      *Team     `json:"team,omitempty"`
+}
+
+type PluginPermissions struct {
+	Network []string          `json:"network"`
+	Layers  PluginLayerAccess `json:"layers"`
+}
+
 type Point struct {
 	Type             string    `json:"type"`
 	PointCoordinates []float64 `json:"pointCoordinates"`
@@ -1115,6 +1265,11 @@ type Policy struct {
 	AssetStorageSize      *int64 `json:"assetStorageSize,omitempty"`
 	DatasetSchemaCount    *int   `json:"datasetSchemaCount,omitempty"`
 	DatasetCount          *int   `json:"datasetCount,omitempty"`
+	NlsLayerCount         *int   `json:"nlsLayerCount,omitempty"`
+	SketchFeatureCount    *int   `json:"sketchFeatureCount,omitempty"`
+	StoryCount            *int   `json:"storyCount,omitempty"`
+	StoryPageCount        *int   `json:"storyPageCount,omitempty"`
+	PublishedStoryCount   *int   `json:"publishedStoryCount,omitempty"`
 }
 
 type Polygon struct {
@@ -1133,6 +1288,8 @@ type Project struct {
 	CreatedAt         time.Time         `json:"createdAt"`
 	UpdatedAt         time.Time         `json:"updatedAt"`
 	PublishedAt       *time.Time        `json:"publishedAt,omitempty"`
+	PublishAt         *time.Time        `json:"publishAt,omitempty"`
+	UnpublishAt       *time.Time        `json:"unpublishAt,omitempty"`
 	Name              string            `json:"name"`
 	Description       string            `json:"description"`
 	Alias             string            `json:"alias"`
@@ -1175,6 +1332,17 @@ type ProjectPayload struct {
 	Project *Project `json:"project"`
 }
 
+type ProjectUsage struct {
+	ProjectID           ID     `json:"projectId"`
+	Name                string `json:"name"`
+	SceneID             *ID    `json:"sceneId,omitempty"`
+	Published           bool   `json:"published"`
+	LayerCount          int    `json:"layerCount"`
+	NlsLayerCount       int    `json:"nlsLayerCount"`
+	StoryCount          int    `json:"storyCount"`
+	PublishedStoryCount int    `json:"publishedStoryCount"`
+}
+
 type Property struct {
 	ID       ID              `json:"id"`
 	SchemaID ID              `json:"schemaId"`
@@ -1182,6 +1350,7 @@ type Property struct {
 	Schema   *PropertySchema `json:"schema,omitempty"`
 	Layer    Layer           `json:"layer,omitempty"`
 	Merged   *MergedProperty `json:"merged,omitempty"`
+	Version  string          `json:"version"`
 }
 
 func (Property) IsNode()        {}
@@ -1307,15 +1476,36 @@ type PropertySchemaGroup struct {
 }
 
 type PublishProjectInput struct {
-	ProjectID ID                `json:"projectId"`
-	Alias     *string           `json:"alias,omitempty"`
-	Status    PublishmentStatus `json:"status"`
+	ProjectID   ID                `json:"projectId"`
+	Alias       *string           `json:"alias,omitempty"`
+	Status      PublishmentStatus `json:"status"`
+	PublishAt   *time.Time        `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time        `json:"unpublishAt,omitempty"`
 }
 
 type PublishStoryInput struct {
-	StoryID ID                `json:"storyId"`
-	Alias   *string           `json:"alias,omitempty"`
-	Status  PublishmentStatus `json:"status"`
+	StoryID     ID                `json:"storyId"`
+	Alias       *string           `json:"alias,omitempty"`
+	Status      PublishmentStatus `json:"status"`
+	PublishAt   *time.Time        `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time        `json:"unpublishAt,omitempty"`
+}
+
+type PublishStyleToWorkspaceInput struct {
+	StyleID        ID      `json:"styleId"`
+	LibraryStyleID *ID     `json:"libraryStyleId,omitempty"`
+	TeamID         *ID     `json:"teamId,omitempty"`
+	Name           *string `json:"name,omitempty"`
+}
+
+type PublishStyleToWorkspacePayload struct {
+	LibraryStyle *LibraryStyle `json:"libraryStyle"`
+	Style        *Style        `json:"style"`
+}
+
+type PublishedVersion struct {
+	Version     string    `json:"version"`
+	PublishedAt time.Time `json:"publishedAt"`
 }
 
 type Query struct {
@@ -1328,8 +1518,19 @@ type Rect struct {
 	North float64 `json:"north"`
 }
 
+type RedoInput struct {
+	SceneID ID `json:"sceneId"`
+}
+
+type RedoPayload struct {
+	SceneID ID            `json:"sceneId"`
+	History *SceneHistory `json:"history"`
+}
+
 type RemoveAssetInput struct {
 	AssetID ID `json:"assetId"`
+	// removes the asset even if it is still in use
+	Force *bool `json:"force,omitempty"`
 }
 
 type RemoveAssetPayload struct {
@@ -1382,6 +1583,14 @@ type RemoveLayerPayload struct {
 	ParentLayer *LayerGroup `json:"parentLayer"`
 }
 
+type RemoveLibraryStyleInput struct {
+	LibraryStyleID ID `json:"libraryStyleId"`
+}
+
+type RemoveLibraryStylePayload struct {
+	LibraryStyleID ID `json:"libraryStyleId"`
+}
+
 type RemoveMemberFromTeamInput struct {
 	TeamID ID `json:"teamId"`
 	UserID ID `json:"userId"`
@@ -1421,6 +1630,14 @@ type RemoveNLSLayerPayload struct {
 	LayerID ID `json:"layerId"`
 }
 
+type RemovePluginKeyInput struct {
+	PluginKeyID ID `json:"pluginKeyId"`
+}
+
+type RemovePluginKeyPayload struct {
+	PluginKeyID ID `json:"pluginKeyId"`
+}
+
 type RemovePropertyFieldInput struct {
 	PropertyID    ID  `json:"propertyId"`
 	SchemaGroupID *ID `json:"schemaGroupId,omitempty"`
@@ -1473,33 +1690,99 @@ type RemoveWidgetPayload struct {
 	WidgetID ID     `json:"widgetId"`
 }
 
+type RollbackPublishInput struct {
+	ProjectID *ID    `json:"projectId,omitempty"`
+	StoryID   *ID    `json:"storyId,omitempty"`
+	Version   string `json:"version"`
+}
+
+type RollbackPublishPayload struct {
+	Project *Project `json:"project,omitempty"`
+	Story   *Story   `json:"story,omitempty"`
+	Version string   `json:"version"`
+}
+
 type Scene struct {
-	ID                ID                       `json:"id"`
-	ProjectID         ID                       `json:"projectId"`
-	TeamID            ID                       `json:"teamId"`
-	PropertyID        ID                       `json:"propertyId"`
-	CreatedAt         time.Time                `json:"createdAt"`
-	UpdatedAt         time.Time                `json:"updatedAt"`
-	RootLayerID       ID                       `json:"rootLayerId"`
-	Widgets           []*SceneWidget           `json:"widgets"`
-	Plugins           []*ScenePlugin           `json:"plugins"`
-	WidgetAlignSystem *WidgetAlignSystem       `json:"widgetAlignSystem,omitempty"`
-	Project           *Project                 `json:"project,omitempty"`
-	Team              *Team                    `json:"team,omitempty"`
-	Property          *Property                `json:"property,omitempty"`
-	RootLayer         *LayerGroup              `json:"rootLayer,omitempty"`
-	NewLayers         []NLSLayer               `json:"newLayers"`
-	Stories           []*Story                 `json:"stories"`
-	Styles            []*Style                 `json:"styles"`
-	DatasetSchemas    *DatasetSchemaConnection `json:"datasetSchemas"`
-	TagIds            []ID                     `json:"tagIds"`
-	Tags              []Tag                    `json:"tags"`
-	Clusters          []*Cluster               `json:"clusters"`
+	ID                  ID                       `json:"id"`
+	ProjectID           ID                       `json:"projectId"`
+	TeamID              ID                       `json:"teamId"`
+	PropertyID          ID                       `json:"propertyId"`
+	CreatedAt           time.Time                `json:"createdAt"`
+	UpdatedAt           time.Time                `json:"updatedAt"`
+	RootLayerID         ID                       `json:"rootLayerId"`
+	Widgets             []*SceneWidget           `json:"widgets"`
+	Plugins             []*ScenePlugin           `json:"plugins"`
+	WidgetAlignSystem   *WidgetAlignSystem       `json:"widgetAlignSystem,omitempty"`
+	Project             *Project                 `json:"project,omitempty"`
+	Team                *Team                    `json:"team,omitempty"`
+	Property            *Property                `json:"property,omitempty"`
+	RootLayer           *LayerGroup              `json:"rootLayer,omitempty"`
+	NewLayers           []NLSLayer               `json:"newLayers"`
+	Stories             []*Story                 `json:"stories"`
+	Styles              []*Style                 `json:"styles"`
+	DatasetSchemas      *DatasetSchemaConnection `json:"datasetSchemas"`
+	TagIds              []ID                     `json:"tagIds"`
+	Tags                []Tag                    `json:"tags"`
+	Clusters            []*Cluster               `json:"clusters"`
+	UnapprovedPluginIds []ID                     `json:"unapprovedPluginIds"`
+	Version             string                   `json:"version"`
+	IsTemplate          bool                     `json:"isTemplate"`
 }
 
 func (Scene) IsNode()        {}
 func (this Scene) GetID() ID { return this.ID }
 
+type SceneChange struct {
+	Type    SceneHistoryEntityType `json:"type"`
+	ID      ID                     `json:"id"`
+	Kind    SceneChangeKind        `json:"kind"`
+	Version string                 `json:"version"`
+}
+
+type SceneChangeEvent struct {
+	SceneID   ID             `json:"sceneId"`
+	HistoryID *ID            `json:"historyId,omitempty"`
+	UserID    *ID            `json:"userId,omitempty"`
+	Operation string         `json:"operation"`
+	Changes   []*SceneChange `json:"changes"`
+	Scene     *Scene         `json:"scene,omitempty"`
+	User      *User          `json:"user,omitempty"`
+}
+
+type SceneHistory struct {
+	ID        ID                    `json:"id"`
+	SceneID   ID                    `json:"sceneId"`
+	UserID    *ID                   `json:"userId,omitempty"`
+	Operation string                `json:"operation"`
+	Changes   []*SceneHistoryChange `json:"changes"`
+	CreatedAt time.Time             `json:"createdAt"`
+	Undone    bool                  `json:"undone"`
+	Scene     *Scene                `json:"scene,omitempty"`
+	User      *User                 `json:"user,omitempty"`
+}
+
+func (SceneHistory) IsNode()        {}
+func (this SceneHistory) GetID() ID { return this.ID }
+
+type SceneHistoryChange struct {
+	Type   SceneHistoryEntityType `json:"type"`
+	ID     ID                     `json:"id"`
+	Before JSON                   `json:"before,omitempty"`
+	After  JSON                   `json:"after,omitempty"`
+}
+
+type SceneHistoryConnection struct {
+	Edges      []*SceneHistoryEdge `json:"edges"`
+	Nodes      []*SceneHistory     `json:"nodes"`
+	PageInfo   *PageInfo           `json:"pageInfo"`
+	TotalCount int                 `json:"totalCount"`
+}
+
+type SceneHistoryEdge struct {
+	Cursor usecasex.Cursor `json:"cursor"`
+	Node   *SceneHistory   `json:"node,omitempty"`
+}
+
 type ScenePlugin struct {
 	PluginID   ID        `json:"pluginId"`
 	PropertyID *ID       `json:"propertyId,omitempty"`
@@ -1507,6 +1790,16 @@ type ScenePlugin struct {
 	Property   *Property `json:"property,omitempty"`
 }
 
+type ScenePresence struct {
+	SceneID     ID        `json:"sceneId"`
+	UserID      ID        `json:"userId"`
+	LayerID     *ID       `json:"layerId,omitempty"`
+	StoryPageID *ID       `json:"storyPageId,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	User        *User     `json:"user,omitempty"`
+	Layer       NLSLayer  `json:"layer,omitempty"`
+}
+
 type SceneWidget struct {
 	ID          ID               `json:"id"`
 	PluginID    ID               `json:"pluginId"`
@@ -1548,6 +1841,7 @@ type Story struct {
 	ID                ID                `json:"id"`
 	Title             string            `json:"title"`
 	Alias             string            `json:"alias"`
+	Index             int               `json:"index"`
 	PropertyID        ID                `json:"propertyId"`
 	Property          *Property         `json:"property,omitempty"`
 	Pages             []*StoryPage      `json:"pages"`
@@ -1555,6 +1849,8 @@ type Story struct {
 	CreatedAt         time.Time         `json:"createdAt"`
 	UpdatedAt         time.Time         `json:"updatedAt"`
 	PublishedAt       *time.Time        `json:"publishedAt,omitempty"`
+	PublishAt         *time.Time        `json:"publishAt,omitempty"`
+	UnpublishAt       *time.Time        `json:"unpublishAt,omitempty"`
 	SceneID           ID                `json:"sceneId"`
 	Scene             *Scene            `json:"scene,omitempty"`
 	PanelPosition     Position          `json:"panelPosition"`
@@ -1566,6 +1862,7 @@ type Story struct {
 	PublicDescription string            `json:"publicDescription"`
 	PublicImage       string            `json:"publicImage"`
 	PublicNoIndex     bool              `json:"publicNoIndex"`
+	Version           string            `json:"version"`
 }
 
 func (Story) IsNode()        {}
@@ -1613,12 +1910,35 @@ type StoryPayload struct {
 	Story *Story `json:"story"`
 }
 
+type StoryViewTokenPayload struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
 type Style struct {
-	ID      ID     `json:"id"`
-	Name    string `json:"name"`
-	Value   JSON   `json:"value"`
-	SceneID ID     `json:"sceneId"`
-	Scene   *Scene `json:"scene,omitempty"`
+	ID      ID                `json:"id"`
+	Name    string            `json:"name"`
+	Value   JSON              `json:"value"`
+	SceneID ID                `json:"sceneId"`
+	Scene   *Scene            `json:"scene,omitempty"`
+	Library *StyleLibraryLink `json:"library,omitempty"`
+	Version string            `json:"version"`
+}
+
+type StyleLibraryLink struct {
+	LibraryStyleID  ID            `json:"libraryStyleId"`
+	Version         int           `json:"version"`
+	LibraryStyle    *LibraryStyle `json:"libraryStyle,omitempty"`
+	UpdateAvailable bool          `json:"updateAvailable"`
+}
+
+type StyleValidationError struct {
+	Path     string `json:"path"`
+	Position *int   `json:"position,omitempty"`
+	Message  string `json:"message"`
+}
+
+type Subscription struct {
 }
 
 type SyncDatasetInput struct {
@@ -1633,6 +1953,15 @@ type SyncDatasetPayload struct {
 	Dataset       []*Dataset       `json:"dataset"`
 }
 
+type SyncStyleWithLibraryInput struct {
+	StyleID ID   `json:"styleId"`
+	Version *int `json:"version,omitempty"`
+}
+
+type SyncStyleWithLibraryPayload struct {
+	Style *Style `json:"style"`
+}
+
 type TagGroup struct {
 	ID      ID         `json:"id"`
 	SceneID ID         `json:"sceneId"`
@@ -1725,6 +2054,15 @@ type Typography struct {
 	Underline  *bool      `json:"underline,omitempty"`
 }
 
+type UndoInput struct {
+	SceneID ID `json:"sceneId"`
+}
+
+type UndoPayload struct {
+	SceneID ID            `json:"sceneId"`
+	History *SceneHistory `json:"history"`
+}
+
 type UninstallPluginInput struct {
 	SceneID  ID `json:"sceneId"`
 	PluginID ID `json:"pluginId"`
@@ -1742,6 +2080,17 @@ type UnlinkPropertyValueInput struct {
 	FieldID       ID  `json:"fieldId"`
 }
 
+type UpdateAssetInput struct {
+	AssetID ID       `json:"assetId"`
+	Name    *string  `json:"name,omitempty"`
+	Folder  *string  `json:"folder,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+type UpdateAssetPayload struct {
+	Asset *Asset `json:"asset"`
+}
+
 type UpdateClusterInput struct {
 	ClusterID  ID      `json:"clusterId"`
 	SceneID    ID      `json:"sceneId"`
@@ -1804,16 +2153,38 @@ type UpdateMemberOfTeamPayload struct {
 }
 
 type UpdateNLSLayerInput struct {
-	LayerID ID      `json:"layerId"`
-	Name    *string `json:"name,omitempty"`
-	Visible *bool   `json:"visible,omitempty"`
-	Config  JSON    `json:"config,omitempty"`
+	LayerID         ID      `json:"layerId"`
+	Name            *string `json:"name,omitempty"`
+	Visible         *bool   `json:"visible,omitempty"`
+	Config          JSON    `json:"config,omitempty"`
+	ClusterID       *ID     `json:"clusterId,omitempty"`
+	DeleteClusterID *bool   `json:"deleteClusterId,omitempty"`
+	Version         *string `json:"version,omitempty"`
 }
 
 type UpdateNLSLayerPayload struct {
 	Layer NLSLayer `json:"layer"`
 }
 
+type UpdateNLSLayersVisibilityInput struct {
+	LayerIds []ID `json:"layerIds"`
+	Visible  bool `json:"visible"`
+}
+
+type UpdateNLSLayersVisibilityPayload struct {
+	Layers []NLSLayer `json:"layers"`
+}
+
+type UpdatePresenceInput struct {
+	SceneID     ID  `json:"sceneId"`
+	LayerID     *ID `json:"layerId,omitempty"`
+	StoryPageID *ID `json:"storyPageId,omitempty"`
+}
+
+type UpdatePresencePayload struct {
+	Presence []*ScenePresence `json:"presence"`
+}
+
 type UpdateProjectInput struct {
 	ProjectID         ID       `json:"projectId"`
 	Name              *string  `json:"name,omitempty"`
@@ -1856,6 +2227,16 @@ type UpdatePropertyValueInput struct {
 	FieldID       ID          `json:"fieldId"`
 	Value         interface{} `json:"value,omitempty"`
 	Type          ValueType   `json:"type"`
+	Version       *string     `json:"version,omitempty"`
+}
+
+type UpdateSceneTemplateInput struct {
+	SceneID    ID   `json:"sceneId"`
+	IsTemplate bool `json:"isTemplate"`
+}
+
+type UpdateSceneTemplatePayload struct {
+	Scene *Scene `json:"scene"`
 }
 
 type UpdateStoryInput struct {
@@ -1874,6 +2255,7 @@ type UpdateStoryInput struct {
 	PublicImage       *string   `json:"publicImage,omitempty"`
 	PublicNoIndex     *bool     `json:"publicNoIndex,omitempty"`
 	DeletePublicImage *bool     `json:"deletePublicImage,omitempty"`
+	Version           *string   `json:"version,omitempty"`
 }
 
 type UpdateStoryPageInput struct {
@@ -1885,12 +2267,14 @@ type UpdateStoryPageInput struct {
 	Layers          []ID    `json:"layers,omitempty"`
 	SwipeableLayers []ID    `json:"swipeableLayers,omitempty"`
 	Index           *int    `json:"index,omitempty"`
+	Version         *string `json:"version,omitempty"`
 }
 
 type UpdateStyleInput struct {
 	StyleID ID      `json:"styleId"`
 	Name    *string `json:"name,omitempty"`
 	Value   JSON    `json:"value,omitempty"`
+	Version *string `json:"version,omitempty"`
 }
 
 type UpdateStylePayload struct {
@@ -1937,6 +2321,7 @@ type UpdateWidgetInput struct {
 	Location *WidgetLocationInput `json:"location,omitempty"`
 	Extended *bool                `json:"extended,omitempty"`
 	Index    *int                 `json:"index,omitempty"`
+	Version  *string              `json:"version,omitempty"`
 }
 
 type UpdateWidgetPayload struct {
@@ -1985,6 +2370,11 @@ type User struct {
 func (User) IsNode()        {}
 func (this User) GetID() ID { return this.ID }
 
+type ValidateStylePayload struct {
+	Valid  bool                    `json:"valid"`
+	Errors []*StyleValidationError `json:"errors"`
+}
+
 type WidgetAlignSystem struct {
 	Inner *WidgetZone `json:"inner,omitempty"`
 	Outer *WidgetZone `json:"outer,omitempty"`
@@ -2049,6 +2439,21 @@ type WidgetZone struct {
 	Right  *WidgetSection `json:"right,omitempty"`
 }
 
+type WorkspaceUsage struct {
+	WorkspaceID           ID              `json:"workspaceId"`
+	AssetStorageSize      int64           `json:"assetStorageSize"`
+	ProjectCount          int             `json:"projectCount"`
+	PublishedProjectCount int             `json:"publishedProjectCount"`
+	SceneCount            int             `json:"sceneCount"`
+	LayerCount            int             `json:"layerCount"`
+	NlsLayerCount         int             `json:"nlsLayerCount"`
+	StoryCount            int             `json:"storyCount"`
+	PublishedStoryCount   int             `json:"publishedStoryCount"`
+	PublishedSiteCount    int             `json:"publishedSiteCount"`
+	Projects              []*ProjectUsage `json:"projects"`
+	UpdatedAt             time.Time       `json:"updatedAt"`
+}
+
 type AssetSortType string
 
 const (
@@ -2092,6 +2497,51 @@ func (e AssetSortType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type AssetUsageType string
+
+const (
+	AssetUsageTypeScene    AssetUsageType = "SCENE"
+	AssetUsageTypeLayer    AssetUsageType = "LAYER"
+	AssetUsageTypeNlsLayer AssetUsageType = "NLS_LAYER"
+	AssetUsageTypeStory    AssetUsageType = "STORY"
+)
+
+var AllAssetUsageType = []AssetUsageType{
+	AssetUsageTypeScene,
+	AssetUsageTypeLayer,
+	AssetUsageTypeNlsLayer,
+	AssetUsageTypeStory,
+}
+
+func (e AssetUsageType) IsValid() bool {
+	switch e {
+	case AssetUsageTypeScene, AssetUsageTypeLayer, AssetUsageTypeNlsLayer, AssetUsageTypeStory:
+		return true
+	}
+	return false
+}
+
+func (e AssetUsageType) String() string {
+	return string(e)
+}
+
+func (e *AssetUsageType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AssetUsageType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AssetUsageType", str)
+	}
+	return nil
+}
+
+func (e AssetUsageType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type LayerEncodingFormat string
 
 const (
@@ -2100,6 +2550,7 @@ const (
 	LayerEncodingFormatGeojson LayerEncodingFormat = "GEOJSON"
 	LayerEncodingFormatShape   LayerEncodingFormat = "SHAPE"
 	LayerEncodingFormatReearth LayerEncodingFormat = "REEARTH"
+	LayerEncodingFormatCSV     LayerEncodingFormat = "CSV"
 )
 
 var AllLayerEncodingFormat = []LayerEncodingFormat{
@@ -2108,11 +2559,12 @@ var AllLayerEncodingFormat = []LayerEncodingFormat{
 	LayerEncodingFormatGeojson,
 	LayerEncodingFormatShape,
 	LayerEncodingFormatReearth,
+	LayerEncodingFormatCSV,
 }
 
 func (e LayerEncodingFormat) IsValid() bool {
 	switch e {
-	case LayerEncodingFormatKml, LayerEncodingFormatCzml, LayerEncodingFormatGeojson, LayerEncodingFormatShape, LayerEncodingFormatReearth:
+	case LayerEncodingFormatKml, LayerEncodingFormatCzml, LayerEncodingFormatGeojson, LayerEncodingFormatShape, LayerEncodingFormatReearth, LayerEncodingFormatCSV:
 		return true
 	}
 	return false
@@ -2300,6 +2752,47 @@ func (e PluginExtensionType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type PluginLayerAccess string
+
+const (
+	PluginLayerAccessRead  PluginLayerAccess = "READ"
+	PluginLayerAccessWrite PluginLayerAccess = "WRITE"
+)
+
+var AllPluginLayerAccess = []PluginLayerAccess{
+	PluginLayerAccessRead,
+	PluginLayerAccessWrite,
+}
+
+func (e PluginLayerAccess) IsValid() bool {
+	switch e {
+	case PluginLayerAccessRead, PluginLayerAccessWrite:
+		return true
+	}
+	return false
+}
+
+func (e PluginLayerAccess) String() string {
+	return string(e)
+}
+
+func (e *PluginLayerAccess) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PluginLayerAccess(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PluginLayerAccess", str)
+	}
+	return nil
+}
+
+func (e PluginLayerAccess) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type Position string
 
 const (
@@ -2492,6 +2985,96 @@ func (e Role) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type SceneChangeKind string
+
+const (
+	SceneChangeKindCreated SceneChangeKind = "CREATED"
+	SceneChangeKindUpdated SceneChangeKind = "UPDATED"
+	SceneChangeKindRemoved SceneChangeKind = "REMOVED"
+)
+
+var AllSceneChangeKind = []SceneChangeKind{
+	SceneChangeKindCreated,
+	SceneChangeKindUpdated,
+	SceneChangeKindRemoved,
+}
+
+func (e SceneChangeKind) IsValid() bool {
+	switch e {
+	case SceneChangeKindCreated, SceneChangeKindUpdated, SceneChangeKindRemoved:
+		return true
+	}
+	return false
+}
+
+func (e SceneChangeKind) String() string {
+	return string(e)
+}
+
+func (e *SceneChangeKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SceneChangeKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SceneChangeKind", str)
+	}
+	return nil
+}
+
+func (e SceneChangeKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SceneHistoryEntityType string
+
+const (
+	SceneHistoryEntityTypeScene    SceneHistoryEntityType = "SCENE"
+	SceneHistoryEntityTypeNlsLayer SceneHistoryEntityType = "NLS_LAYER"
+	SceneHistoryEntityTypeStyle    SceneHistoryEntityType = "STYLE"
+	SceneHistoryEntityTypeStory    SceneHistoryEntityType = "STORY"
+	SceneHistoryEntityTypeProperty SceneHistoryEntityType = "PROPERTY"
+)
+
+var AllSceneHistoryEntityType = []SceneHistoryEntityType{
+	SceneHistoryEntityTypeScene,
+	SceneHistoryEntityTypeNlsLayer,
+	SceneHistoryEntityTypeStyle,
+	SceneHistoryEntityTypeStory,
+	SceneHistoryEntityTypeProperty,
+}
+
+func (e SceneHistoryEntityType) IsValid() bool {
+	switch e {
+	case SceneHistoryEntityTypeScene, SceneHistoryEntityTypeNlsLayer, SceneHistoryEntityTypeStyle, SceneHistoryEntityTypeStory, SceneHistoryEntityTypeProperty:
+		return true
+	}
+	return false
+}
+
+func (e SceneHistoryEntityType) String() string {
+	return string(e)
+}
+
+func (e *SceneHistoryEntityType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SceneHistoryEntityType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SceneHistoryEntityType", str)
+	}
+	return nil
+}
+
+func (e SceneHistoryEntityType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type TextAlign string
 
 const (
@@ -13,18 +13,20 @@ const (
 )
 
 type Loaders struct {
-	usecases  interfaces.Container
-	Asset     *AssetLoader
-	Dataset   *DatasetLoader
-	Layer     *LayerLoader
-	Plugin    *PluginLoader
-	Policy    *PolicyLoader
-	Project   *ProjectLoader
-	Property  *PropertyLoader
-	Scene     *SceneLoader
-	Workspace *WorkspaceLoader
-	User      *UserLoader
-	Tag       *TagLoader
+	usecases     interfaces.Container
+	Asset        *AssetLoader
+	Dataset      *DatasetLoader
+	Layer        *LayerLoader
+	Plugin       *PluginLoader
+	Policy       *PolicyLoader
+	Project      *ProjectLoader
+	Property     *PropertyLoader
+	Scene        *SceneLoader
+	SceneHistory *SceneHistoryLoader
+	StyleLibrary *StyleLibraryLoader
+	Workspace    *WorkspaceLoader
+	User         *UserLoader
+	Tag          *TagLoader
 }
 
 type DataLoaders struct {
@@ -40,6 +42,7 @@ type DataLoaders struct {
 	Property       PropertyDataLoader
 	PropertySchema PropertySchemaDataLoader
 	Scene          SceneDataLoader
+	LibraryStyle   LibraryStyleDataLoader
 	Workspace      WorkspaceDataLoader
 	User           UserDataLoader
 	Tag            TagDataLoader
@@ -52,18 +55,20 @@ func NewLoaders(usecases *interfaces.Container) *Loaders {
 		return nil
 	}
 	return &Loaders{
-		usecases:  *usecases,
-		Asset:     NewAssetLoader(usecases.Asset),
-		Dataset:   NewDatasetLoader(usecases.Dataset),
-		Layer:     NewLayerLoader(usecases.Layer),
-		Plugin:    NewPluginLoader(usecases.Plugin),
-		Policy:    NewPolicyLoader(usecases.Policy),
-		Project:   NewProjectLoader(usecases.Project),
-		Property:  NewPropertyLoader(usecases.Property),
-		Scene:     NewSceneLoader(usecases.Scene),
-		Workspace: NewWorkspaceLoader(usecases.Workspace),
-		User:      NewUserLoader(usecases.User),
-		Tag:       NewTagLoader(usecases.Tag),
+		usecases:     *usecases,
+		Asset:        NewAssetLoader(usecases.Asset),
+		Dataset:      NewDatasetLoader(usecases.Dataset),
+		Layer:        NewLayerLoader(usecases.Layer),
+		Plugin:       NewPluginLoader(usecases.Plugin),
+		Policy:       NewPolicyLoader(usecases.Policy),
+		Project:      NewProjectLoader(usecases.Project),
+		Property:     NewPropertyLoader(usecases.Property),
+		Scene:        NewSceneLoader(usecases.Scene),
+		SceneHistory: NewSceneHistoryLoader(usecases.SceneHistory),
+		StyleLibrary: NewStyleLibraryLoader(usecases.StyleLibrary),
+		Workspace:    NewWorkspaceLoader(usecases.Workspace),
+		User:         NewUserLoader(usecases.User),
+		Tag:          NewTagLoader(usecases.Tag),
 	}
 }
 
@@ -87,6 +92,7 @@ func (l Loaders) DataLoaders(ctx context.Context) *DataLoaders {
 		Property:       l.Property.DataLoader(ctx),
 		PropertySchema: l.Property.SchemaDataLoader(ctx),
 		Scene:          l.Scene.DataLoader(ctx),
+		LibraryStyle:   l.StyleLibrary.DataLoader(ctx),
 		Workspace:      l.Workspace.DataLoader(ctx),
 		User:           l.User.DataLoader(ctx),
 		Tag:            l.Tag.DataLoader(ctx),
@@ -108,6 +114,7 @@ func (l Loaders) OrdinaryDataLoaders(ctx context.Context) *DataLoaders {
 		Property:       l.Property.OrdinaryDataLoader(ctx),
 		PropertySchema: l.Property.SchemaOrdinaryDataLoader(ctx),
 		Scene:          l.Scene.OrdinaryDataLoader(ctx),
+		LibraryStyle:   l.StyleLibrary.OrdinaryDataLoader(ctx),
 		Workspace:      l.Workspace.OrdinaryDataLoader(ctx),
 		User:           l.User.OrdinaryDataLoader(ctx),
 		Tag:            l.Tag.OrdinaryDataLoader(ctx),
@@ -35,13 +35,19 @@ func (c *AssetLoader) Fetch(ctx context.Context, ids []gqlmodel.ID) ([]*gqlmodel
 	return util.Map(res, gqlmodel.ToAsset), nil
 }
 
-func (c *AssetLoader) FindByWorkspace(ctx context.Context, wsID gqlmodel.ID, keyword *string, sort *asset.SortType, pagination *gqlmodel.Pagination) (*gqlmodel.AssetConnection, error) {
+func (c *AssetLoader) FindByWorkspace(ctx context.Context, wsID gqlmodel.ID, keyword, folder, tag *string, sort *asset.SortType, pagination *gqlmodel.Pagination) (*gqlmodel.AssetConnection, error) {
 	tid, err := gqlmodel.ToID[accountdomain.Workspace](wsID)
 	if err != nil {
 		return nil, err
 	}
 
-	assets, pi, err := c.usecase.FindByWorkspace(ctx, tid, keyword, sort, gqlmodel.ToPagination(pagination), getOperator(ctx))
+	assets, pi, err := c.usecase.FindByWorkspace(ctx, tid, interfaces.FindAssetsParam{
+		Keyword:    keyword,
+		Folder:     folder,
+		Tag:        tag,
+		Sort:       sort,
+		Pagination: gqlmodel.ToPagination(pagination),
+	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +71,37 @@ func (c *AssetLoader) FindByWorkspace(ctx context.Context, wsID gqlmodel.ID, key
 	}, nil
 }
 
+func (c *AssetLoader) FindFolders(ctx context.Context, wsID gqlmodel.ID) ([]string, error) {
+	tid, err := gqlmodel.ToID[accountdomain.Workspace](wsID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.usecase.FindFolders(ctx, tid, getOperator(ctx))
+}
+
+func (c *AssetLoader) FindTags(ctx context.Context, wsID gqlmodel.ID) ([]string, error) {
+	tid, err := gqlmodel.ToID[accountdomain.Workspace](wsID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.usecase.FindTags(ctx, tid, getOperator(ctx))
+}
+
+func (c *AssetLoader) Usages(ctx context.Context, aid gqlmodel.ID) ([]*gqlmodel.AssetUsage, error) {
+	assetID, err := gqlmodel.ToID[id.Asset](aid)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.usecase.Usages(ctx, assetID, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return util.Map(res, gqlmodel.ToAssetUsage), nil
+}
+
 // data loader
 
 type AssetDataLoader interface {
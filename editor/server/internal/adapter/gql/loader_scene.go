@@ -7,6 +7,7 @@ import (
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/util"
 )
 
@@ -50,6 +51,20 @@ func (c *SceneLoader) FindByProject(ctx context.Context, projectID gqlmodel.ID)
 	return gqlmodel.ToScene(res), nil
 }
 
+func (c *SceneLoader) FindTemplates(ctx context.Context, teamID gqlmodel.ID) ([]*gqlmodel.Scene, error) {
+	wid, err := gqlmodel.ToID[accountdomain.Workspace](teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.usecase.FindTemplates(ctx, wid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]*gqlmodel.Scene{}, util.Map(res, gqlmodel.ToScene)...), nil
+}
+
 // data loader
 
 type SceneDataLoader interface {
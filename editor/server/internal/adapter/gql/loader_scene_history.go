@@ -0,0 +1,53 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type SceneHistoryLoader struct {
+	usecase interfaces.SceneHistory
+}
+
+func NewSceneHistoryLoader(usecase interfaces.SceneHistory) *SceneHistoryLoader {
+	return &SceneHistoryLoader{usecase: usecase}
+}
+
+func (c *SceneHistoryLoader) FindByScene(ctx context.Context, sceneID gqlmodel.ID, first *int, last *int, before *usecasex.Cursor, after *usecasex.Cursor) (*gqlmodel.SceneHistoryConnection, error) {
+	sid, err := gqlmodel.ToID[id.Scene](sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, pi, err := c.usecase.Fetch(ctx, sid, usecasex.CursorPagination{
+		First:  intToInt64(first),
+		Last:   intToInt64(last),
+		Before: before,
+		After:  after,
+	}.Wrap(), getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*gqlmodel.SceneHistoryEdge, 0, len(res))
+	nodes := make([]*gqlmodel.SceneHistory, 0, len(res))
+	for _, h := range res {
+		h2 := gqlmodel.ToSceneHistory(h)
+		edges = append(edges, &gqlmodel.SceneHistoryEdge{
+			Node:   h2,
+			Cursor: usecasex.Cursor(h2.ID),
+		})
+		nodes = append(nodes, h2)
+	}
+
+	return &gqlmodel.SceneHistoryConnection{
+		Edges:      edges,
+		Nodes:      nodes,
+		PageInfo:   gqlmodel.ToPageInfo(pi),
+		TotalCount: int(pi.TotalCount),
+	}, nil
+}
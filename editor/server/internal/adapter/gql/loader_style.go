@@ -0,0 +1,77 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqldataloader"
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/util"
+)
+
+type StyleLibraryLoader struct {
+	usecase interfaces.StyleLibrary
+}
+
+func NewStyleLibraryLoader(usecase interfaces.StyleLibrary) *StyleLibraryLoader {
+	return &StyleLibraryLoader{usecase: usecase}
+}
+
+func (c *StyleLibraryLoader) Fetch(ctx context.Context, ids []gqlmodel.ID) ([]*gqlmodel.LibraryStyle, []error) {
+	ids2, err := util.TryMap(ids, gqlmodel.ToID[id.LibraryStyle])
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	res, err := c.usecase.Fetch(ctx, ids2, getOperator(ctx))
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	return util.Map(res, gqlmodel.ToLibraryStyle), nil
+}
+
+// data loader
+
+type LibraryStyleDataLoader interface {
+	Load(gqlmodel.ID) (*gqlmodel.LibraryStyle, error)
+	LoadAll([]gqlmodel.ID) ([]*gqlmodel.LibraryStyle, []error)
+}
+
+func (c *StyleLibraryLoader) DataLoader(ctx context.Context) LibraryStyleDataLoader {
+	return gqldataloader.NewLibraryStyleLoader(gqldataloader.LibraryStyleLoaderConfig{
+		Wait:     dataLoaderWait,
+		MaxBatch: dataLoaderMaxBatch,
+		Fetch: func(keys []gqlmodel.ID) ([]*gqlmodel.LibraryStyle, []error) {
+			return c.Fetch(ctx, keys)
+		},
+	})
+}
+
+func (c *StyleLibraryLoader) OrdinaryDataLoader(ctx context.Context) LibraryStyleDataLoader {
+	return &ordinaryLibraryStyleLoader{
+		fetch: func(keys []gqlmodel.ID) ([]*gqlmodel.LibraryStyle, []error) {
+			return c.Fetch(ctx, keys)
+		},
+	}
+}
+
+type ordinaryLibraryStyleLoader struct {
+	fetch func(keys []gqlmodel.ID) ([]*gqlmodel.LibraryStyle, []error)
+}
+
+func (l *ordinaryLibraryStyleLoader) Load(key gqlmodel.ID) (*gqlmodel.LibraryStyle, error) {
+	res, errs := l.fetch([]gqlmodel.ID{key})
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	if len(res) > 0 {
+		return res[0], nil
+	}
+	return nil, nil
+}
+
+func (l *ordinaryLibraryStyleLoader) LoadAll(keys []gqlmodel.ID) ([]*gqlmodel.LibraryStyle, []error) {
+	return l.fetch(keys)
+}
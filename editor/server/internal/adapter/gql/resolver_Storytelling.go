@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/pkg/scene/history"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/samber/lo"
 )
@@ -22,6 +23,10 @@ func (r *storyResolver) Property(ctx context.Context, obj *gqlmodel.Story) (*gql
 	return dataloaders(ctx).Property.Load(obj.PropertyID)
 }
 
+func (r *storyResolver) Version(ctx context.Context, obj *gqlmodel.Story) (string, error) {
+	return entityVersion(ctx, history.EntityTypeStory, obj.ID)
+}
+
 func (r *Resolver) StoryPage() StoryPageResolver {
 	return &storyPageResolver{r}
 }
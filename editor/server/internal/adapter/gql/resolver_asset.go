@@ -15,3 +15,7 @@ type assetResolver struct{ *Resolver }
 func (r *assetResolver) Team(ctx context.Context, obj *gqlmodel.Asset) (*gqlmodel.Team, error) {
 	return dataloaders(ctx).Workspace.Load(obj.TeamID)
 }
+
+func (r *assetResolver) Usages(ctx context.Context, obj *gqlmodel.Asset) ([]*gqlmodel.AssetUsage, error) {
+	return loaders(ctx).Asset.Usages(ctx, obj.ID)
+}
@@ -0,0 +1,156 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/history"
+)
+
+func (r *Resolver) Subscription() SubscriptionResolver {
+	return &subscriptionResolver{r}
+}
+
+func (r *Resolver) SceneChangeEvent() SceneChangeEventResolver {
+	return &sceneChangeEventResolver{r}
+}
+
+func (r *Resolver) ScenePresence() ScenePresenceResolver {
+	return &scenePresenceResolver{r}
+}
+
+type subscriptionResolver struct{ *Resolver }
+
+func (r *subscriptionResolver) SceneChanged(ctx context.Context, sceneID gqlmodel.ID) (<-chan *gqlmodel.SceneChangeEvent, error) {
+	sid, err := gqlmodel.ToID[id.Scene](sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := usecases(ctx).Collaboration.SubscribeChanges(ctx, sid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(chan *gqlmodel.SceneChangeEvent)
+	go func() {
+		defer close(res)
+		for e := range events {
+			select {
+			case res <- gqlmodel.ToSceneChangeEvent(e):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return res, nil
+}
+
+func (r *subscriptionResolver) ScenePresence(ctx context.Context, sceneID gqlmodel.ID) (<-chan []*gqlmodel.ScenePresence, error) {
+	sid, err := gqlmodel.ToID[id.Scene](sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := usecases(ctx).Collaboration.SubscribePresence(ctx, sid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(chan []*gqlmodel.ScenePresence)
+	go func() {
+		defer close(res)
+		for l := range lists {
+			select {
+			case res <- gqlmodel.ToScenePresenceList(l):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return res, nil
+}
+
+func (r *queryResolver) ScenePresence(ctx context.Context, sceneID gqlmodel.ID) ([]*gqlmodel.ScenePresence, error) {
+	sid, err := gqlmodel.ToID[id.Scene](sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).Collaboration.FindPresence(ctx, sid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return gqlmodel.ToScenePresenceList(res), nil
+}
+
+func (r *mutationResolver) UpdatePresence(ctx context.Context, input gqlmodel.UpdatePresenceInput) (*gqlmodel.UpdatePresencePayload, error) {
+	sid, err := gqlmodel.ToID[id.Scene](input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+	res, err := usecases(ctx).Collaboration.UpdatePresence(ctx, interfaces.UpdatePresenceParam{
+		SceneID:   sid,
+		Layer:     gqlmodel.ToIDRef[id.NLSLayer](input.LayerID),
+		StoryPage: gqlmodel.ToIDRef[id.Page](input.StoryPageID),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.UpdatePresencePayload{
+		Presence: gqlmodel.ToScenePresenceList(res),
+	}, nil
+}
+
+type sceneChangeEventResolver struct{ *Resolver }
+
+func (r *sceneChangeEventResolver) Scene(ctx context.Context, obj *gqlmodel.SceneChangeEvent) (*gqlmodel.Scene, error) {
+	return dataloaders(ctx).Scene.Load(obj.SceneID)
+}
+
+func (r *sceneChangeEventResolver) User(ctx context.Context, obj *gqlmodel.SceneChangeEvent) (*gqlmodel.User, error) {
+	if obj.UserID == nil {
+		return nil, nil
+	}
+	return dataloaders(ctx).User.Load(*obj.UserID)
+}
+
+type scenePresenceResolver struct{ *Resolver }
+
+func (r *scenePresenceResolver) User(ctx context.Context, obj *gqlmodel.ScenePresence) (*gqlmodel.User, error) {
+	return dataloaders(ctx).User.Load(obj.UserID)
+}
+
+func (r *scenePresenceResolver) Layer(ctx context.Context, obj *gqlmodel.ScenePresence) (gqlmodel.NLSLayer, error) {
+	if obj.LayerID == nil {
+		return nil, nil
+	}
+
+	lid, err := gqlmodel.ToID[id.NLSLayer](*obj.LayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).NLSLayer.Fetch(ctx, id.NLSLayerIDList{lid}, getOperator(ctx))
+	if err != nil || len(res) == 0 || res[0] == nil {
+		return nil, err
+	}
+	return gqlmodel.ToNLSLayer(*res[0], nil), nil
+}
+
+// entityVersion returns the version of the entity which clients send back with mutations
+// to make sure that they do not overwrite changes made by others.
+func entityVersion(ctx context.Context, typ history.EntityType, eid gqlmodel.ID) (string, error) {
+	return usecases(ctx).SceneHistory.Version(ctx, typ, string(eid))
+}
+
+// expectVersion makes the mutation fail if the entity is no longer at the version given by the client.
+func expectVersion(ctx context.Context, typ history.EntityType, eid gqlmodel.ID, version *string) {
+	if version == nil {
+		return
+	}
+	usecases(ctx).SceneHistory.ExpectVersion(ctx, typ, string(eid), *version)
+}
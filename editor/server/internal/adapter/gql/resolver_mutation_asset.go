@@ -7,6 +7,7 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/samber/lo"
 )
 
 func (r *mutationResolver) CreateAsset(ctx context.Context, input gqlmodel.CreateAssetInput) (*gqlmodel.CreateAssetPayload, error) {
@@ -18,6 +19,8 @@ func (r *mutationResolver) CreateAsset(ctx context.Context, input gqlmodel.Creat
 	res, err := usecases(ctx).Asset.Create(ctx, interfaces.CreateAssetParam{
 		WorkspaceID: tid,
 		File:        gqlmodel.FromFile(&input.File),
+		Folder:      input.Folder,
+		Tags:        input.Tags,
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -26,13 +29,40 @@ func (r *mutationResolver) CreateAsset(ctx context.Context, input gqlmodel.Creat
 	return &gqlmodel.CreateAssetPayload{Asset: gqlmodel.ToAsset(res)}, nil
 }
 
+func (r *mutationResolver) UpdateAsset(ctx context.Context, input gqlmodel.UpdateAssetInput) (*gqlmodel.UpdateAssetPayload, error) {
+	aid, err := gqlmodel.ToID[id.Asset](input.AssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags *[]string
+	if input.Tags != nil {
+		tags = &input.Tags
+	}
+
+	res, err := usecases(ctx).Asset.Update(ctx, interfaces.UpdateAssetParam{
+		AssetID: aid,
+		Name:    input.Name,
+		Folder:  input.Folder,
+		Tags:    tags,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.UpdateAssetPayload{Asset: gqlmodel.ToAsset(res)}, nil
+}
+
 func (r *mutationResolver) RemoveAsset(ctx context.Context, input gqlmodel.RemoveAssetInput) (*gqlmodel.RemoveAssetPayload, error) {
 	aid, err := gqlmodel.ToID[id.Asset](input.AssetID)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err2 := usecases(ctx).Asset.Remove(ctx, aid, getOperator(ctx))
+	res, err2 := usecases(ctx).Asset.Remove(ctx, interfaces.RemoveAssetParam{
+		AssetID: aid,
+		Force:   lo.FromPtr(input.Force),
+	}, getOperator(ctx))
 	if err2 != nil {
 		return nil, err2
 	}
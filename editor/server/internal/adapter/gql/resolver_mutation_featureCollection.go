@@ -8,6 +8,7 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearthx/util"
 )
 
 func (r *mutationResolver) AddGeoJSONFeature(ctx context.Context, input gqlmodel.AddGeoJSONFeatureInput) (*gqlmodel.Feature, error) {
@@ -97,6 +98,27 @@ func (r *mutationResolver) DeleteGeoJSONFeature(ctx context.Context, input gqlmo
 	}, nil
 }
 
+func (r *mutationResolver) ImportGeoJSONFeatures(ctx context.Context, input gqlmodel.ImportGeoJSONFeaturesInput) (*gqlmodel.ImportGeoJSONFeaturesPayload, error) {
+	lid, err := gqlmodel.ToID[id.NLSLayer](input.LayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	layer, features, err := usecases(ctx).NLSLayer.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID: lid,
+		File:    gqlmodel.FromFile(&input.File),
+		Format:  gqlmodel.FromLayerEncodingFormat(input.Format),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ImportGeoJSONFeaturesPayload{
+		Layer:    gqlmodel.ToNLSLayer(layer, nil),
+		Features: util.Map(features, gqlmodel.ToFeature),
+	}, nil
+}
+
 func convertGeometry(nlslayerGeom nlslayer.Geometry) (gqlmodel.Geometry, error) {
 	switch geom := nlslayerGeom.(type) {
 	case *nlslayer.Point:
@@ -6,6 +6,7 @@ import (
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/history"
 )
 
 func (r *mutationResolver) AddNLSLayerSimple(ctx context.Context, input gqlmodel.AddNLSLayerSimpleInput) (*gqlmodel.AddNLSLayerSimplePayload, error) {
@@ -35,6 +36,80 @@ func (r *mutationResolver) AddNLSLayerSimple(ctx context.Context, input gqlmodel
 	}, nil
 }
 
+func (r *mutationResolver) AddNLSLayerGroup(ctx context.Context, input gqlmodel.AddNLSLayerGroupInput) (*gqlmodel.AddNLSLayerGroupPayload, error) {
+	sId, err := gqlmodel.ToID[id.Scene](input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	parentLayerID := gqlmodel.ToIDRef[id.NLSLayer](input.ParentLayerID)
+
+	layer, parentLayer, err := usecases(ctx).NLSLayer.AddLayerGroup(ctx, interfaces.AddNLSLayerGroupInput{
+		ParentLayerID: parentLayerID,
+		SceneID:       sId,
+		Title:         input.Title,
+		Index:         input.Index,
+		Config:        gqlmodel.ToNLSConfig(input.Config),
+		Visible:       input.Visible,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	var index *int
+	if parentLayer != nil {
+		i := parentLayer.Children().FindLayerIndex(layer.ID())
+		index = &i
+	}
+
+	return &gqlmodel.AddNLSLayerGroupPayload{
+		Layer:       gqlmodel.ToNLSLayerGroup(layer, parentLayerID),
+		ParentLayer: gqlmodel.ToNLSLayerGroup(parentLayer, nil),
+		Index:       index,
+	}, nil
+}
+
+func (r *mutationResolver) MoveNLSLayer(ctx context.Context, input gqlmodel.MoveNLSLayerInput) (*gqlmodel.MoveNLSLayerPayload, error) {
+	lid, err := gqlmodel.ToID[id.NLSLayer](input.LayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	destLayerID := gqlmodel.ToIDRef[id.NLSLayer](input.DestLayerID)
+
+	targetLayerID, fromParentLayer, toParentLayer, index, err := usecases(ctx).NLSLayer.Move(ctx, interfaces.MoveNLSLayerInput{
+		LayerID:     lid,
+		DestLayerID: destLayerID,
+		Index:       gqlmodel.RefToIndex(input.Index),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.MoveNLSLayerPayload{
+		LayerID:         gqlmodel.IDFrom(targetLayerID),
+		FromParentLayer: gqlmodel.ToNLSLayerGroup(fromParentLayer, nil),
+		ToParentLayer:   gqlmodel.ToNLSLayerGroup(toParentLayer, nil),
+		Index:           index,
+	}, nil
+}
+
+func (r *mutationResolver) UpdateNLSLayersVisibility(ctx context.Context, input gqlmodel.UpdateNLSLayersVisibilityInput) (*gqlmodel.UpdateNLSLayersVisibilityPayload, error) {
+	lids, err := gqlmodel.ToIDs[id.NLSLayer](input.LayerIds)
+	if err != nil {
+		return nil, err
+	}
+
+	layers, err := usecases(ctx).NLSLayer.UpdateVisibility(ctx, *lids, input.Visible, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.UpdateNLSLayersVisibilityPayload{
+		Layers: gqlmodel.ToNLSLayers(layers, nil),
+	}, nil
+}
+
 func (r *mutationResolver) RemoveNLSLayer(ctx context.Context, input gqlmodel.RemoveNLSLayerInput) (*gqlmodel.RemoveNLSLayerPayload, error) {
 	lid, err := gqlmodel.ToID[id.NLSLayer](input.LayerID)
 	if err != nil {
@@ -57,11 +132,28 @@ func (r *mutationResolver) UpdateNLSLayer(ctx context.Context, input gqlmodel.Up
 		return nil, err
 	}
 
+	var cid *id.ClusterID
+	if input.ClusterID != nil {
+		c, err := gqlmodel.ToID[id.Cluster](*input.ClusterID)
+		if err != nil {
+			return nil, err
+		}
+		cid = &c
+	}
+
+	deleteCluster := false
+	if input.DeleteClusterID != nil {
+		deleteCluster = *input.DeleteClusterID
+	}
+
+	expectVersion(ctx, history.EntityTypeNLSLayer, input.LayerID, input.Version)
 	layer, err := usecases(ctx).NLSLayer.Update(ctx, interfaces.UpdateNLSLayerInput{
-		LayerID: lid,
-		Name:    input.Name,
-		Visible: input.Visible,
-		Config:  gqlmodel.ToNLSConfig(input.Config),
+		LayerID:       lid,
+		Name:          input.Name,
+		Visible:       input.Visible,
+		Config:        gqlmodel.ToNLSConfig(input.Config),
+		Cluster:       cid,
+		DeleteCluster: deleteCluster,
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
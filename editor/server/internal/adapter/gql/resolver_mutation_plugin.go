@@ -0,0 +1,46 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+func (r *mutationResolver) AddPluginKey(ctx context.Context, input gqlmodel.AddPluginKeyInput) (*gqlmodel.AddPluginKeyPayload, error) {
+	wid, err := gqlmodel.ToID[accountdomain.Workspace](input.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := usecases(ctx).PluginKey.Add(ctx, interfaces.AddPluginKeyInput{
+		WorkspaceID: wid,
+		Name:        input.Name,
+		PublicKey:   input.PublicKey,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.AddPluginKeyPayload{
+		PluginKey: gqlmodel.ToPluginKey(k),
+	}, nil
+}
+
+func (r *mutationResolver) RemovePluginKey(ctx context.Context, input gqlmodel.RemovePluginKeyInput) (*gqlmodel.RemovePluginKeyPayload, error) {
+	kid, err := gqlmodel.ToID[id.PluginKey](input.PluginKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).PluginKey.Remove(ctx, kid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.RemovePluginKeyPayload{
+		PluginKeyID: gqlmodel.IDFrom(res),
+	}, nil
+}
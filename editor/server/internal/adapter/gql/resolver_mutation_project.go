@@ -2,6 +2,7 @@ package gql
 
 import (
 	"context"
+	"errors"
 
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
@@ -10,6 +11,8 @@ import (
 	"github.com/reearth/reearthx/account/accountdomain"
 )
 
+var ErrPublishTargetRequired = errors.New("either projectId or storyId is required")
+
 func (r *mutationResolver) CreateProject(ctx context.Context, input gqlmodel.CreateProjectInput) (*gqlmodel.ProjectPayload, error) {
 	tid, err := gqlmodel.ToID[accountdomain.Workspace](input.TeamID)
 	if err != nil {
@@ -25,6 +28,7 @@ func (r *mutationResolver) CreateProject(ctx context.Context, input gqlmodel.Cre
 		Alias:       input.Alias,
 		Archived:    input.Archived,
 		CoreSupport: input.CoreSupport,
+		TemplateID:  gqlmodel.ToIDRef[id.Scene](input.TemplateID),
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -83,9 +87,11 @@ func (r *mutationResolver) PublishProject(ctx context.Context, input gqlmodel.Pu
 	}
 
 	res, err := usecases(ctx).Project.Publish(ctx, interfaces.PublishProjectParam{
-		ID:     pid,
-		Alias:  input.Alias,
-		Status: gqlmodel.FromPublishmentStatus(input.Status),
+		ID:          pid,
+		Alias:       input.Alias,
+		Status:      gqlmodel.FromPublishmentStatus(input.Status),
+		PublishAt:   input.PublishAt,
+		UnpublishAt: input.UnpublishAt,
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -106,3 +112,72 @@ func (r *mutationResolver) DeleteProject(ctx context.Context, input gqlmodel.Del
 
 	return &gqlmodel.DeleteProjectPayload{ProjectID: input.ProjectID}, nil
 }
+
+func (r *mutationResolver) ExportProject(ctx context.Context, input gqlmodel.ExportProjectInput) (*gqlmodel.ExportProjectPayload, error) {
+	pid, err := gqlmodel.ToID[id.Project](input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := usecases(ctx).Project.ExportProject(ctx, pid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ExportProjectPayload{ProjectDataPath: "/export/" + name}, nil
+}
+
+func (r *mutationResolver) ImportProject(ctx context.Context, input gqlmodel.ImportProjectInput) (*gqlmodel.ProjectPayload, error) {
+	tid, err := gqlmodel.ToID[accountdomain.Workspace](input.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).Project.ImportProject(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: tid,
+		File:        gqlmodel.FromFile(&input.File),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ProjectPayload{Project: gqlmodel.ToProject(res)}, nil
+}
+
+func (r *mutationResolver) RollbackPublish(ctx context.Context, input gqlmodel.RollbackPublishInput) (*gqlmodel.RollbackPublishPayload, error) {
+	if (input.ProjectID == nil) == (input.StoryID == nil) {
+		return nil, ErrPublishTargetRequired
+	}
+
+	if input.StoryID != nil {
+		sid, err := gqlmodel.ToID[id.Story](*input.StoryID)
+		if err != nil {
+			return nil, err
+		}
+
+		story, err := usecases(ctx).StoryTelling.RollbackPublish(ctx, sid, input.Version, getOperator(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		return &gqlmodel.RollbackPublishPayload{
+			Story:   gqlmodel.ToStory(story),
+			Version: input.Version,
+		}, nil
+	}
+
+	pid, err := gqlmodel.ToID[id.Project](*input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	prj, err := usecases(ctx).Project.RollbackPublish(ctx, pid, input.Version, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.RollbackPublishPayload{
+		Project: gqlmodel.ToProject(prj),
+		Version: input.Version,
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene/history"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/util"
 	"github.com/samber/lo"
@@ -27,6 +28,7 @@ func (r *mutationResolver) UpdatePropertyValue(ctx context.Context, input gqlmod
 		return nil, err
 	}
 
+	expectVersion(ctx, history.EntityTypeProperty, input.PropertyID, input.Version)
 	pp, pgl, pg, pf, err := usecases(ctx).Property.UpdateValue(ctx, interfaces.UpdatePropertyValueParam{
 		PropertyID: pid,
 		Pointer: gqlmodel.FromPointer(
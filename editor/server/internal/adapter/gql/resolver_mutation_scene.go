@@ -9,6 +9,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/plugin"
 	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/scene/history"
 )
 
 func (r *mutationResolver) CreateScene(ctx context.Context, input gqlmodel.CreateSceneInput) (*gqlmodel.CreateScenePayload, error) {
@@ -34,6 +35,22 @@ func (r *mutationResolver) CreateScene(ctx context.Context, input gqlmodel.Creat
 	}, nil
 }
 
+func (r *mutationResolver) UpdateSceneTemplate(ctx context.Context, input gqlmodel.UpdateSceneTemplateInput) (*gqlmodel.UpdateSceneTemplatePayload, error) {
+	sid, err := gqlmodel.ToID[id.Scene](input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).Scene.SetTemplate(ctx, sid, input.IsTemplate, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.UpdateSceneTemplatePayload{
+		Scene: gqlmodel.ToScene(res),
+	}, nil
+}
+
 func (r *mutationResolver) AddWidget(ctx context.Context, input gqlmodel.AddWidgetInput) (*gqlmodel.AddWidgetPayload, error) {
 	sid, err := gqlmodel.ToID[id.Scene](input.SceneID)
 	if err != nil {
@@ -68,6 +85,8 @@ func (r *mutationResolver) UpdateWidget(ctx context.Context, input gqlmodel.Upda
 		return nil, err
 	}
 
+	// widgets are versioned as a part of the scene
+	expectVersion(ctx, history.EntityTypeScene, input.SceneID, input.Version)
 	scene, widget, err := usecases(ctx).Scene.UpdateWidget(ctx, interfaces.UpdateWidgetParam{
 		SceneID:  sid,
 		WidgetID: wid,
@@ -0,0 +1,42 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+func (r *mutationResolver) Undo(ctx context.Context, input gqlmodel.UndoInput) (*gqlmodel.UndoPayload, error) {
+	sid, err := gqlmodel.ToID[id.Scene](input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := usecases(ctx).SceneHistory.Undo(ctx, sid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.UndoPayload{
+		SceneID: input.SceneID,
+		History: gqlmodel.ToSceneHistory(h),
+	}, nil
+}
+
+func (r *mutationResolver) Redo(ctx context.Context, input gqlmodel.RedoInput) (*gqlmodel.RedoPayload, error) {
+	sid, err := gqlmodel.ToID[id.Scene](input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := usecases(ctx).SceneHistory.Redo(ctx, sid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.RedoPayload{
+		SceneID: input.SceneID,
+		History: gqlmodel.ToSceneHistory(h),
+	}, nil
+}
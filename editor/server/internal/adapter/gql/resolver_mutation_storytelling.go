@@ -7,6 +7,7 @@ import (
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/history"
 	"github.com/samber/lo"
 )
 
@@ -38,6 +39,8 @@ func (r *mutationResolver) UpdateStory(ctx context.Context, input gqlmodel.Updat
 		return nil, err
 	}
 
+	expectVersion(ctx, history.EntityTypeStory, input.StoryID, input.Version)
+
 	inp := interfaces.UpdateStoryInput{
 		SceneID:       sceneId,
 		StoryID:       storyId,
@@ -94,9 +97,11 @@ func (r *mutationResolver) PublishStory(ctx context.Context, input gqlmodel.Publ
 	}
 
 	res, err := usecases(ctx).StoryTelling.Publish(ctx, interfaces.PublishStoryInput{
-		ID:     sID,
-		Alias:  input.Alias,
-		Status: gqlmodel.FromStoryPublishmentStatus(input.Status),
+		ID:          sID,
+		Alias:       input.Alias,
+		Status:      gqlmodel.FromStoryPublishmentStatus(input.Status),
+		PublishAt:   input.PublishAt,
+		UnpublishAt: input.UnpublishAt,
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -105,6 +110,23 @@ func (r *mutationResolver) PublishStory(ctx context.Context, input gqlmodel.Publ
 	return &gqlmodel.StoryPayload{Story: gqlmodel.ToStory(res)}, nil
 }
 
+func (r *mutationResolver) CreateStoryViewToken(ctx context.Context, input gqlmodel.CreateStoryViewTokenInput) (*gqlmodel.StoryViewTokenPayload, error) {
+	sID, err := gqlmodel.ToID[id.Story](input.StoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := usecases(ctx).StoryTelling.CreateViewToken(ctx, interfaces.CreateStoryViewTokenInput{
+		StoryID:   sID,
+		ExpiresAt: input.ExpiresAt,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.StoryViewTokenPayload{Token: token, ExpiresAt: input.ExpiresAt}, nil
+}
+
 func (r *mutationResolver) MoveStory(ctx context.Context, input gqlmodel.MoveStoryInput) (*gqlmodel.MoveStoryPayload, error) {
 	scId, sId, err := gqlmodel.ToID2[id.Scene, id.Story](input.SceneID, input.StoryID)
 	if err != nil {
@@ -112,6 +134,7 @@ func (r *mutationResolver) MoveStory(ctx context.Context, input gqlmodel.MoveSto
 	}
 
 	inp := interfaces.MoveStoryInput{
+		SceneID: scId,
 		StoryID: sId,
 		Index:   input.Index,
 	}
@@ -133,6 +156,27 @@ func (r *mutationResolver) MoveStory(ctx context.Context, input gqlmodel.MoveSto
 	}, nil
 }
 
+func (r *mutationResolver) DuplicateStory(ctx context.Context, input gqlmodel.DuplicateStoryInput) (*gqlmodel.StoryPayload, error) {
+	sceneId, storyId, err := gqlmodel.ToID2[id.Scene, id.Story](input.SceneID, input.StoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	inp := interfaces.DuplicateStoryInput{
+		SceneID: sceneId,
+		StoryID: storyId,
+	}
+
+	res, err := usecases(ctx).StoryTelling.Duplicate(ctx, inp, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.StoryPayload{
+		Story: gqlmodel.ToStory(res),
+	}, nil
+}
+
 func (r *mutationResolver) CreateStoryPage(ctx context.Context, input gqlmodel.CreateStoryPageInput) (*gqlmodel.StoryPagePayload, error) {
 	sceneId, storyId, err := gqlmodel.ToID2[id.Scene, id.Story](input.SceneID, input.StoryID)
 	if err != nil {
@@ -176,6 +220,9 @@ func (r *mutationResolver) UpdateStoryPage(ctx context.Context, input gqlmodel.U
 		return nil, err
 	}
 
+	// pages are versioned as a part of the story
+	expectVersion(ctx, history.EntityTypeStory, input.StoryID, input.Version)
+
 	layersId, err := gqlmodel.ToIDs[id.NLSLayer](input.Layers)
 	if err != nil {
 		return nil, err
@@ -6,6 +6,8 @@ import (
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/history"
+	"github.com/reearth/reearthx/account/accountdomain"
 )
 
 func (r *mutationResolver) AddStyle(ctx context.Context, input gqlmodel.AddStyleInput) (*gqlmodel.AddStylePayload, error) {
@@ -34,6 +36,8 @@ func (r *mutationResolver) UpdateStyle(ctx context.Context, input gqlmodel.Updat
 		return nil, err
 	}
 
+	expectVersion(ctx, history.EntityTypeStyle, input.StyleID, input.Version)
+
 	s, err := usecases(ctx).Style.UpdateStyle(ctx, interfaces.UpdateStyleInput{
 		StyleID: sid,
 		Name:    input.Name,
@@ -79,3 +83,94 @@ func (r *mutationResolver) DuplicateStyle(ctx context.Context, input gqlmodel.Du
 		Style: gqlmodel.ToStyle(s),
 	}, nil
 }
+
+func (r *mutationResolver) PublishStyleToWorkspace(ctx context.Context, input gqlmodel.PublishStyleToWorkspaceInput) (*gqlmodel.PublishStyleToWorkspacePayload, error) {
+	sid, err := gqlmodel.ToID[id.Style](input.StyleID)
+	if err != nil {
+		return nil, err
+	}
+
+	param := interfaces.PublishStyleInput{
+		StyleID: sid,
+		Name:    input.Name,
+	}
+	if input.LibraryStyleID != nil {
+		lid, err := gqlmodel.ToID[id.LibraryStyle](*input.LibraryStyleID)
+		if err != nil {
+			return nil, err
+		}
+		param.LibraryStyleID = &lid
+	}
+	if input.TeamID != nil {
+		wid, err := gqlmodel.ToID[accountdomain.Workspace](*input.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		param.WorkspaceID = &wid
+	}
+
+	lib, s, err := usecases(ctx).StyleLibrary.Publish(ctx, param, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.PublishStyleToWorkspacePayload{
+		LibraryStyle: gqlmodel.ToLibraryStyle(lib),
+		Style:        gqlmodel.ToStyle(s),
+	}, nil
+}
+
+func (r *mutationResolver) ImportStyle(ctx context.Context, input gqlmodel.ImportStyleInput) (*gqlmodel.ImportStylePayload, error) {
+	lid, sid, err := gqlmodel.ToID2[id.LibraryStyle, id.Scene](input.LibraryStyleID, input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := usecases(ctx).StyleLibrary.Import(ctx, interfaces.ImportStyleInput{
+		LibraryStyleID: lid,
+		SceneID:        sid,
+		Version:        input.Version,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ImportStylePayload{
+		Style: gqlmodel.ToStyle(s),
+	}, nil
+}
+
+func (r *mutationResolver) SyncStyleWithLibrary(ctx context.Context, input gqlmodel.SyncStyleWithLibraryInput) (*gqlmodel.SyncStyleWithLibraryPayload, error) {
+	sid, err := gqlmodel.ToID[id.Style](input.StyleID)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := usecases(ctx).StyleLibrary.Sync(ctx, interfaces.SyncStyleInput{
+		StyleID: sid,
+		Version: input.Version,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.SyncStyleWithLibraryPayload{
+		Style: gqlmodel.ToStyle(s),
+	}, nil
+}
+
+func (r *mutationResolver) RemoveLibraryStyle(ctx context.Context, input gqlmodel.RemoveLibraryStyleInput) (*gqlmodel.RemoveLibraryStylePayload, error) {
+	lid, err := gqlmodel.ToID[id.LibraryStyle](input.LibraryStyleID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).StyleLibrary.Remove(ctx, lid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.RemoveLibraryStylePayload{
+		LibraryStyleID: gqlmodel.IDFrom(res),
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/pkg/scene/history"
 )
 
 func (r *Resolver) NLSLayerSimple() NLSLayerSimpleResolver {
@@ -24,6 +25,10 @@ func (r *nlsLayerSimpleResolver) Scene(ctx context.Context, obj *gqlmodel.NLSLay
 	return dataloaders(ctx).Scene.Load(obj.SceneID)
 }
 
+func (r *nlsLayerSimpleResolver) Version(ctx context.Context, obj *gqlmodel.NLSLayerSimple) (string, error) {
+	return entityVersion(ctx, history.EntityTypeNLSLayer, obj.ID)
+}
+
 func (r *Resolver) NLSLayerGroup() NLSLayerGroupResolver {
 	return &nlsLayerGroupResolver{r}
 }
@@ -34,6 +39,10 @@ func (r *nlsLayerGroupResolver) Scene(ctx context.Context, obj *gqlmodel.NLSLaye
 	return dataloaders(ctx).Scene.Load(obj.SceneID)
 }
 
+func (r *nlsLayerGroupResolver) Version(ctx context.Context, obj *gqlmodel.NLSLayerGroup) (string, error) {
+	return entityVersion(ctx, history.EntityTypeNLSLayer, obj.ID)
+}
+
 type nlsInfoboxResolver struct{ *Resolver }
 
 func (r *nlsInfoboxResolver) Scene(ctx context.Context, obj *gqlmodel.NLSInfobox) (*gqlmodel.Scene, error) {
@@ -84,3 +84,13 @@ func (r *pluginExtensionResolver) TranslatedDescription(ctx context.Context, obj
 	}
 	return obj.Description, nil
 }
+
+func (r *Resolver) PluginKey() PluginKeyResolver {
+	return &pluginKeyResolver{r}
+}
+
+type pluginKeyResolver struct{ *Resolver }
+
+func (r *pluginKeyResolver) Team(ctx context.Context, obj *gqlmodel.PluginKey) (*gqlmodel.Team, error) {
+	return dataloaders(ctx).Workspace.Load(obj.TeamID)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/pkg/scene/history"
 	"github.com/reearth/reearthx/rerror"
 )
 
@@ -81,6 +82,10 @@ func (r *propertyResolver) Merged(ctx context.Context, obj *gqlmodel.Property) (
 	return nil, nil
 }
 
+func (r *propertyResolver) Version(ctx context.Context, obj *gqlmodel.Property) (string, error) {
+	return entityVersion(ctx, history.EntityTypeProperty, obj.ID)
+}
+
 type propertyFieldResolver struct{ *Resolver }
 
 func (r *propertyFieldResolver) Parent(ctx context.Context, obj *gqlmodel.PropertyField) (*gqlmodel.Property, error) {
@@ -4,7 +4,14 @@ import (
 	"context"
 
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/pluginkey"
+	"github.com/reearth/reearth/server/pkg/stylelib"
+	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/usecasex"
+	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
 )
 
 func (r *Resolver) Query() QueryResolver {
@@ -13,8 +20,16 @@ func (r *Resolver) Query() QueryResolver {
 
 type queryResolver struct{ *Resolver }
 
-func (r *queryResolver) Assets(ctx context.Context, teamID gqlmodel.ID, keyword *string, sortType *gqlmodel.AssetSortType, pagination *gqlmodel.Pagination) (*gqlmodel.AssetConnection, error) {
-	return loaders(ctx).Asset.FindByWorkspace(ctx, teamID, keyword, gqlmodel.AssetSortTypeFrom(sortType), pagination)
+func (r *queryResolver) Assets(ctx context.Context, teamID gqlmodel.ID, keyword *string, folder *string, tag *string, sortType *gqlmodel.AssetSortType, pagination *gqlmodel.Pagination) (*gqlmodel.AssetConnection, error) {
+	return loaders(ctx).Asset.FindByWorkspace(ctx, teamID, keyword, folder, tag, gqlmodel.AssetSortTypeFrom(sortType), pagination)
+}
+
+func (r *queryResolver) AssetFolders(ctx context.Context, teamID gqlmodel.ID) ([]string, error) {
+	return loaders(ctx).Asset.FindFolders(ctx, teamID)
+}
+
+func (r *queryResolver) AssetTags(ctx context.Context, teamID gqlmodel.ID) ([]string, error) {
+	return loaders(ctx).Asset.FindTags(ctx, teamID)
 }
 
 func (r *queryResolver) Me(ctx context.Context) (*gqlmodel.Me, error) {
@@ -236,10 +251,45 @@ func (r *queryResolver) Scene(ctx context.Context, projectID gqlmodel.ID) (*gqlm
 	return loaders(ctx).Scene.FindByProject(ctx, projectID)
 }
 
+func (r *queryResolver) SceneTemplates(ctx context.Context, teamID gqlmodel.ID) ([]*gqlmodel.Scene, error) {
+	return loaders(ctx).Scene.FindTemplates(ctx, teamID)
+}
+
 func (r *queryResolver) Projects(ctx context.Context, teamID gqlmodel.ID, includeArchived *bool, first *int, last *int, after *usecasex.Cursor, before *usecasex.Cursor) (*gqlmodel.ProjectConnection, error) {
 	return loaders(ctx).Project.FindByWorkspace(ctx, teamID, first, last, before, after)
 }
 
+func (r *queryResolver) SceneHistory(ctx context.Context, sceneID gqlmodel.ID, first *int, last *int, after *usecasex.Cursor, before *usecasex.Cursor) (*gqlmodel.SceneHistoryConnection, error) {
+	return loaders(ctx).SceneHistory.FindByScene(ctx, sceneID, first, last, before, after)
+}
+
+func (r *queryResolver) PublishedVersions(ctx context.Context, projectID *gqlmodel.ID, storyID *gqlmodel.ID) ([]*gqlmodel.PublishedVersion, error) {
+	if (projectID == nil) == (storyID == nil) {
+		return nil, ErrPublishTargetRequired
+	}
+
+	var versions []interfaces.PublishedVersion
+	if storyID != nil {
+		sid, err := gqlmodel.ToID[id.Story](*storyID)
+		if err != nil {
+			return nil, err
+		}
+		if versions, err = usecases(ctx).StoryTelling.PublishedVersions(ctx, sid, getOperator(ctx)); err != nil {
+			return nil, err
+		}
+	} else {
+		pid, err := gqlmodel.ToID[id.Project](*projectID)
+		if err != nil {
+			return nil, err
+		}
+		if versions, err = usecases(ctx).Project.PublishedVersions(ctx, pid, getOperator(ctx)); err != nil {
+			return nil, err
+		}
+	}
+
+	return util.Map(versions, gqlmodel.ToPublishedVersion), nil
+}
+
 func (r *queryResolver) DatasetSchemas(ctx context.Context, sceneID gqlmodel.ID, first *int, last *int, after *usecasex.Cursor, before *usecasex.Cursor) (*gqlmodel.DatasetSchemaConnection, error) {
 	return loaders(ctx).Dataset.FindSchemaByScene(ctx, sceneID, first, last, before, after)
 }
@@ -255,3 +305,70 @@ func (r *queryResolver) SearchUser(ctx context.Context, nameOrEmail string) (*gq
 func (r *queryResolver) CheckProjectAlias(ctx context.Context, alias string) (*gqlmodel.ProjectAliasAvailability, error) {
 	return loaders(ctx).Project.CheckAlias(ctx, alias)
 }
+
+func (r *queryResolver) ValidateStyle(ctx context.Context, value gqlmodel.JSON, layerID *gqlmodel.ID) (*gqlmodel.ValidateStylePayload, error) {
+	var lid *id.NLSLayerID
+	if layerID != nil {
+		l, err := gqlmodel.ToID[id.NLSLayer](*layerID)
+		if err != nil {
+			return nil, err
+		}
+		lid = &l
+	}
+
+	errs, err := usecases(ctx).Style.ValidateStyle(ctx, interfaces.ValidateStyleInput{
+		Value:   gqlmodel.ToStyleValue(value),
+		LayerID: lid,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return gqlmodel.ToValidateStylePayload(errs), nil
+}
+
+func (r *queryResolver) LibraryStyles(ctx context.Context, teamID gqlmodel.ID) ([]*gqlmodel.LibraryStyle, error) {
+	wid, err := gqlmodel.ToID[accountdomain.Workspace](teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).StyleLibrary.FindByWorkspace(ctx, wid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(res, func(s *stylelib.Style, _ int) *gqlmodel.LibraryStyle {
+		return gqlmodel.ToLibraryStyle(s)
+	}), nil
+}
+
+func (r *queryResolver) WorkspaceUsage(ctx context.Context, workspaceID gqlmodel.ID) (*gqlmodel.WorkspaceUsage, error) {
+	wid, err := gqlmodel.ToID[accountdomain.Workspace](workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).WorkspaceUsage.Find(ctx, wid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return gqlmodel.ToWorkspaceUsage(res), nil
+}
+
+func (r *queryResolver) PluginKeys(ctx context.Context, teamID gqlmodel.ID) ([]*gqlmodel.PluginKey, error) {
+	wid, err := gqlmodel.ToID[accountdomain.Workspace](teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).PluginKey.FindByWorkspace(ctx, wid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(res, func(k *pluginkey.Key, _ int) *gqlmodel.PluginKey {
+		return gqlmodel.ToPluginKey(k)
+	}), nil
+}
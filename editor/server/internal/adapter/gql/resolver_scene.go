@@ -5,7 +5,9 @@ import (
 
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/history"
 	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
 )
 
 func (r *Resolver) Scene() SceneResolver {
@@ -68,6 +70,26 @@ func (r *sceneResolver) NewLayers(ctx context.Context, obj *gqlmodel.Scene) ([]g
 	return res, nil
 }
 
+func (r *sceneResolver) UnapprovedPluginIds(ctx context.Context, obj *gqlmodel.Scene) ([]gqlmodel.ID, error) {
+	sid, err := gqlmodel.ToID[id.Scene](obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).PluginKey.UnapprovedPlugins(ctx, sid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(res, func(pid id.PluginID, _ int) gqlmodel.ID {
+		return gqlmodel.IDFromPluginID(pid)
+	}), nil
+}
+
+func (r *sceneResolver) Version(ctx context.Context, obj *gqlmodel.Scene) (string, error) {
+	return entityVersion(ctx, history.EntityTypeScene, obj.ID)
+}
+
 func (r *sceneResolver) DatasetSchemas(ctx context.Context, obj *gqlmodel.Scene, first *int, last *int, after *usecasex.Cursor, before *usecasex.Cursor) (*gqlmodel.DatasetSchemaConnection, error) {
 	return loaders(ctx).Dataset.FindSchemaByScene(ctx, obj.ID, first, last, before, after)
 }
@@ -0,0 +1,24 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+)
+
+func (r *Resolver) SceneHistory() SceneHistoryResolver {
+	return &sceneHistoryResolver{r}
+}
+
+type sceneHistoryResolver struct{ *Resolver }
+
+func (r *sceneHistoryResolver) Scene(ctx context.Context, obj *gqlmodel.SceneHistory) (*gqlmodel.Scene, error) {
+	return dataloaders(ctx).Scene.Load(obj.SceneID)
+}
+
+func (r *sceneHistoryResolver) User(ctx context.Context, obj *gqlmodel.SceneHistory) (*gqlmodel.User, error) {
+	if obj.UserID == nil {
+		return nil, nil
+	}
+	return dataloaders(ctx).User.Load(*obj.UserID)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/pkg/scene/history"
 )
 
 func (r *Resolver) Style() StyleResolver {
@@ -15,3 +16,37 @@ type styleResolver struct{ *Resolver }
 func (r *styleResolver) Scene(ctx context.Context, obj *gqlmodel.Style) (*gqlmodel.Scene, error) {
 	return dataloaders(ctx).Scene.Load(obj.SceneID)
 }
+
+func (r *styleResolver) Version(ctx context.Context, obj *gqlmodel.Style) (string, error) {
+	return entityVersion(ctx, history.EntityTypeStyle, obj.ID)
+}
+
+func (r *Resolver) StyleLibraryLink() StyleLibraryLinkResolver {
+	return &styleLibraryLinkResolver{r}
+}
+
+type styleLibraryLinkResolver struct{ *Resolver }
+
+func (r *styleLibraryLinkResolver) LibraryStyle(ctx context.Context, obj *gqlmodel.StyleLibraryLink) (*gqlmodel.LibraryStyle, error) {
+	return dataloaders(ctx).LibraryStyle.Load(obj.LibraryStyleID)
+}
+
+// UpdateAvailable reports whether the library style has a newer version than the one the style is linked to.
+// It is false when the library style has been removed or cannot be read.
+func (r *styleLibraryLinkResolver) UpdateAvailable(ctx context.Context, obj *gqlmodel.StyleLibraryLink) (bool, error) {
+	lib, err := r.LibraryStyle(ctx, obj)
+	if err != nil || lib == nil {
+		return false, err
+	}
+	return lib.LatestVersion > obj.Version, nil
+}
+
+func (r *Resolver) LibraryStyle() LibraryStyleResolver {
+	return &libraryStyleResolver{r}
+}
+
+type libraryStyleResolver struct{ *Resolver }
+
+func (r *libraryStyleResolver) Team(ctx context.Context, obj *gqlmodel.LibraryStyle) (*gqlmodel.Team, error) {
+	return dataloaders(ctx).Workspace.Load(obj.TeamID)
+}
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth/server/internal/adapter"
+)
+
+func ExportProject() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		u := adapter.Usecases(ctx)
+
+		name := c.Param("name")
+		r, err := u.Project.ReadExportProject(ctx, name, adapter.Operator(ctx))
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = r.Close()
+		}()
+
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, "application/zip")
+		res.Header().Set("Content-Disposition", "attachment;filename="+name)
+		res.WriteHeader(http.StatusOK)
+		if _, err := io.Copy(res, r); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	}
+}
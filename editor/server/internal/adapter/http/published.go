@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/url"
 
+	"github.com/reearth/reearth/server/internal/usecase"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 )
 
@@ -20,6 +21,14 @@ func (c *PublishedController) Metadata(ctx context.Context, name string) (interf
 	return c.usecase.Metadata(ctx, name)
 }
 
+func (c *PublishedController) Authorize(ctx context.Context, name, token string) (bool, error) {
+	return c.usecase.Authorize(ctx, name, token)
+}
+
+func (c *PublishedController) Export(ctx context.Context, name string, w io.Writer, op *usecase.Operator) error {
+	return c.usecase.Export(ctx, name, w, op)
+}
+
 func (c *PublishedController) Data(ctx context.Context, name string) (io.Reader, error) {
 	return c.usecase.Data(ctx, name)
 }
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth/server/internal/adapter"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/rerror"
+)
+
+// ExportWorkspaceUsage responds with the usage of the workspace as CSV.
+func ExportWorkspaceUsage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		u := adapter.Usecases(ctx)
+
+		wid, err := accountdomain.WorkspaceIDFrom(strings.TrimSuffix(c.Param("param"), ".csv"))
+		if err != nil {
+			return rerror.ErrNotFound
+		}
+
+		// the usage is written at once after it is found, so errors can still be responded
+		buf := &bytes.Buffer{}
+		if err := u.WorkspaceUsage.ExportCSV(ctx, wid, buf, adapter.Operator(ctx)); err != nil {
+			return err
+		}
+
+		res := c.Response()
+		res.Header().Set("Content-Disposition", "attachment;filename=usage_"+wid.String()+".csv")
+		return c.Blob(http.StatusOK, "text/csv", buf.Bytes())
+	}
+}
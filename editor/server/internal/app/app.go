@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"net/http"
 	"net/http/pprof"
+	"net/url"
 	"os"
 
 	"github.com/99designs/gqlgen/graphql/playground"
@@ -60,6 +61,7 @@ func initEcho(ctx context.Context, cfg *ServerConfig) *echo.Echo {
 	authConfig := cfg.Config.JWTProviders()
 	log.Infof("auth: config: %#v", authConfig)
 	e.Use(
+		websocketAuthMiddleware,
 		echo.WrapMiddleware(lo.Must(appx.AuthMiddleware(authConfig, adapter.ContextAuthInfo, true))),
 		attachOpMiddleware(cfg),
 	)
@@ -95,11 +97,22 @@ func initEcho(ctx context.Context, cfg *ServerConfig) *echo.Echo {
 		}
 	}
 
+	// base URLs of the files served by serveFiles to find them in the published data
+	assetBaseURL, _ := url.Parse(cfg.Config.AssetBaseURL)
+	var pluginBaseURL *url.URL
+	if u, err := url.Parse(cfg.Config.Host); err == nil {
+		pluginBaseURL = u.JoinPath("plugins")
+	}
+
 	e.Use(UsecaseMiddleware(cfg.Repos, cfg.Gateways, cfg.AccountRepos, cfg.AccountGateways, interactor.ContainerConfig{
 		SignupSecret:       cfg.Config.SignupSecret,
 		PublishedIndexHTML: publishedIndexHTML,
 		PublishedIndexURL:  cfg.Config.Published.IndexURL,
 		AuthSrvUIDomain:    cfg.Config.Host_Web,
+
+		PublishedTokenSecret: cfg.Config.Published.TokenSecret,
+		AssetBaseURL:         assetBaseURL,
+		PluginBaseURL:        pluginBaseURL,
 	}))
 
 	// auth srv
@@ -109,12 +122,19 @@ func initEcho(ctx context.Context, cfg *ServerConfig) *echo.Echo {
 	api := e.Group("/api")
 	api.GET("/ping", Ping(), privateCache)
 	api.GET("/published/:name", PublishedMetadata())
-	api.GET("/published_data/:name", PublishedData("", true))
+	api.GET("/published_data/:name", PublishedData("", true), PublishedViewTokenMiddleware())
 
 	apiPrivate := api.Group("", privateCache)
-	apiPrivate.POST("/graphql", GraphqlAPI(cfg.Config.GraphQL, gqldev))
+	gqlapi := GraphqlAPI(cfg.Config.GraphQL, origins, gqldev)
+	apiPrivate.POST("/graphql", gqlapi)
+	// subscriptions over WebSocket
+	apiPrivate.GET("/graphql", gqlapi)
 	apiPrivate.GET("/layers/:param", ExportLayer(), AuthRequiredMiddleware())
+	apiPrivate.GET("/nlslayers/:param", ExportNLSLayer(), AuthRequiredMiddleware())
 	apiPrivate.GET("/datasets/:datasetSchemaId", http2.ExportDataset(), AuthRequiredMiddleware())
+	apiPrivate.GET("/export/:name", http2.ExportProject(), AuthRequiredMiddleware())
+	apiPrivate.GET("/usage/:param", http2.ExportWorkspaceUsage(), AuthRequiredMiddleware())
+	apiPrivate.GET("/published_export/:name", ExportPublished(), AuthRequiredMiddleware())
 	apiPrivate.POST("/signup", Signup())
 
 	if !cfg.Config.AuthSrv.Disabled {
@@ -163,6 +183,20 @@ func errorHandler(next func(error, echo.Context)) func(error, echo.Context) {
 	}
 }
 
+// websocketAuthMiddleware takes the access token of a WebSocket handshake from the query
+// because browsers cannot set the Authorization header to WebSocket connections.
+func websocketAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		if c.IsWebSocket() && req.Header.Get("Authorization") == "" {
+			if token := c.QueryParam("access_token"); token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		return next(c)
+	}
+}
+
 func allowedOrigins(cfg *ServerConfig) []string {
 	if cfg == nil {
 		return nil
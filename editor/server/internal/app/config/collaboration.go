@@ -0,0 +1,7 @@
+package config
+
+type CollaborationConfig struct {
+	// enables realtime collaboration. Changes and presences are delivered only between clients connected to the same server process,
+	// so it works correctly only when the server runs as a single instance.
+	Enabled bool `pp:",omitempty"`
+}
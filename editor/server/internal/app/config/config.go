@@ -23,33 +23,36 @@ func init() {
 type Mailer mailer.Mailer
 type Config struct {
 	mailer.Config
-	Port             string            `default:"8080" envconfig:"PORT"`
-	ServerHost       string            `pp:",omitempty"`
-	Host             string            `default:"http://localhost:8080"`
-	Host_Web         string            `pp:",omitempty"`
-	Dev              bool              `pp:",omitempty"`
-	DB               string            `default:"mongodb://localhost"`
-	DB_Account       string            `pp:",omitempty"`
-	DB_Users         []appx.NamedURI   `pp:",omitempty"`
-	GraphQL          GraphQLConfig     `pp:",omitempty"`
-	Published        PublishedConfig   `pp:",omitempty"`
-	GCPProject       string            `envconfig:"GOOGLE_CLOUD_PROJECT" pp:",omitempty"`
-	Profiler         string            `pp:",omitempty"`
-	Tracer           string            `pp:",omitempty"`
-	TracerSample     float64           `pp:",omitempty"`
-	Marketplace      MarketplaceConfig `pp:",omitempty"`
-	AssetBaseURL     string            `default:"http://localhost:8080/assets"`
-	Origins          []string          `pp:",omitempty"`
-	Policy           PolicyConfig      `pp:",omitempty"`
-	Web_Disabled     bool              `pp:",omitempty"`
-	Web_App_Disabled bool              `pp:",omitempty"`
-	Web              map[string]string `pp:",omitempty"`
-	Web_Config       JSON              `pp:",omitempty"`
-	Web_Title        string            `pp:",omitempty"`
-	Web_FaviconURL   string            `pp:",omitempty"`
-	SignupSecret     string            `pp:",omitempty"`
-	SignupDisabled   bool              `pp:",omitempty"`
-	HTTPSREDIRECT    bool              `pp:",omitempty"`
+	Port             string              `default:"8080" envconfig:"PORT"`
+	ServerHost       string              `pp:",omitempty"`
+	Host             string              `default:"http://localhost:8080"`
+	Host_Web         string              `pp:",omitempty"`
+	Dev              bool                `pp:",omitempty"`
+	DB               string              `default:"mongodb://localhost"`
+	DB_Account       string              `pp:",omitempty"`
+	DB_Users         []appx.NamedURI     `pp:",omitempty"`
+	GraphQL          GraphQLConfig       `pp:",omitempty"`
+	Published        PublishedConfig     `pp:",omitempty"`
+	Usage            UsageConfig         `pp:",omitempty"`
+	Collaboration    CollaborationConfig `pp:",omitempty"`
+	GCPProject       string              `envconfig:"GOOGLE_CLOUD_PROJECT" pp:",omitempty"`
+	Profiler         string              `pp:",omitempty"`
+	Tracer           string              `pp:",omitempty"`
+	TracerSample     float64             `pp:",omitempty"`
+	Marketplace      MarketplaceConfig   `pp:",omitempty"`
+	PluginSource     PluginSourceConfig  `pp:",omitempty"`
+	AssetBaseURL     string              `default:"http://localhost:8080/assets"`
+	Origins          []string            `pp:",omitempty"`
+	Policy           PolicyConfig        `pp:",omitempty"`
+	Web_Disabled     bool                `pp:",omitempty"`
+	Web_App_Disabled bool                `pp:",omitempty"`
+	Web              map[string]string   `pp:",omitempty"`
+	Web_Config       JSON                `pp:",omitempty"`
+	Web_Title        string              `pp:",omitempty"`
+	Web_FaviconURL   string              `pp:",omitempty"`
+	SignupSecret     string              `pp:",omitempty"`
+	SignupDisabled   bool                `pp:",omitempty"`
+	HTTPSREDIRECT    bool                `pp:",omitempty"`
 
 	// storage
 	GCS GCSConfig `pp:",omitempty"`
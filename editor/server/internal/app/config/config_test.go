@@ -61,3 +61,18 @@ func Test_AddHTTPScheme(t *testing.T) {
 	assert.Equal(t, "http://a", addHTTPScheme("http://a"))
 	assert.Equal(t, "https://a", addHTTPScheme("https://a"))
 }
+
+func TestPluginSourceConfig_GitLabHosts(t *testing.T) {
+	assert.Equal(t, []string{"gitlab.com"}, PluginSourceConfig{}.GitLabHosts())
+	assert.Equal(t, []string{"gitlab.com", "git.example.com"}, PluginSourceConfig{
+		GitLab_Hosts: []string{"git.example.com", "GitLab.com", ""},
+	}.GitLabHosts())
+
+	// gitlab.com is not added when tokens are configured
+	c := PluginSourceConfig{
+		GitLab_Hosts:  []string{"git.example.com"},
+		GitLab_Tokens: map[string]string{"Git.Example.com": "token"},
+	}
+	assert.Equal(t, []string{"git.example.com"}, c.GitLabHosts())
+	assert.Equal(t, map[string]string{"git.example.com": "token"}, c.GitLabTokens())
+}
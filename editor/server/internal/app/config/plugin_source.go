@@ -0,0 +1,44 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+const defaultGitLabHost = "gitlab.com"
+
+// PluginSourceConfig configures where plugins can be installed from in addition to GitHub and the marketplace.
+type PluginSourceConfig struct {
+	GitLab_Hosts []string `pp:",omitempty"`
+	// GitLab_Tokens maps GitLab hosts to the tokens used to read private repositories on them, e.g. "git.example.com:token"
+	GitLab_Tokens map[string]string `pp:",omitempty"`
+	Local_Dir     string            `pp:",omitempty"`
+}
+
+// GitLabHosts returns the configured GitLab hosts and the hosts which have a token.
+// gitlab.com is included by default unless tokens are configured.
+func (c PluginSourceConfig) GitLabHosts() []string {
+	var hosts []string
+	if len(c.GitLab_Tokens) == 0 {
+		hosts = append(hosts, defaultGitLabHost)
+	}
+	hosts = append(hosts, c.GitLab_Hosts...)
+	hosts = append(hosts, lo.Keys(c.GitLab_Tokens)...)
+
+	res := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		h = strings.ToLower(h)
+		if h != "" && !lo.Contains(res, h) {
+			res = append(res, h)
+		}
+	}
+	return res
+}
+
+// GitLabTokens returns the tokens keyed by the lower-cased host.
+func (c PluginSourceConfig) GitLabTokens() map[string]string {
+	return lo.MapKeys(c.GitLab_Tokens, func(_ string, h string) string {
+		return strings.ToLower(h)
+	})
+}
@@ -1,8 +1,15 @@
 package config
 
-import "net/url"
+import (
+	"net/url"
+	"time"
+)
 
 type PublishedConfig struct {
 	IndexURL *url.URL `pp:",omitempty"`
 	Host     string   `pp:",omitempty"`
+	// interval to run publish schedules. zero disables the scheduler.
+	ScheduleInterval time.Duration `default:"1m" pp:",omitempty"`
+	// secret to sign the view tokens of published stories. empty disables the view tokens.
+	TokenSecret string `pp:",omitempty"`
 }
@@ -0,0 +1,8 @@
+package config
+
+import "time"
+
+type UsageConfig struct {
+	// interval to aggregate the cached usages of workspaces again. zero disables the aggregator.
+	Interval time.Duration `default:"1h" pp:",omitempty"`
+}
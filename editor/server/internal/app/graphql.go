@@ -2,6 +2,8 @@ package app
 
 import (
 	"context"
+	"net/http"
+	"slices"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql"
@@ -9,11 +11,14 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
 	"github.com/ravilushqa/otelgqlgen"
 	"github.com/reearth/reearth/server/internal/adapter"
 	"github.com/reearth/reearth/server/internal/adapter/gql"
 	"github.com/reearth/reearth/server/internal/app/config"
+	"github.com/reearth/reearthx/log"
+	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
@@ -23,7 +28,7 @@ const (
 	maxMemorySize     = 100 * 1024 * 1024       // 100MB
 )
 
-func GraphqlAPI(conf config.GraphQLConfig, dev bool) echo.HandlerFunc {
+func GraphqlAPI(conf config.GraphQLConfig, origins []string, dev bool) echo.HandlerFunc {
 	schema := gql.NewExecutableSchema(gql.Config{
 		Resolvers: gql.NewResolver(),
 	})
@@ -32,6 +37,9 @@ func GraphqlAPI(conf config.GraphQLConfig, dev bool) echo.HandlerFunc {
 
 	srv.AddTransport(transport.Websocket{
 		KeepAlivePingInterval: 10 * time.Second,
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: websocketOriginChecker(origins),
+		},
 	})
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
@@ -62,6 +70,9 @@ func GraphqlAPI(conf config.GraphQLConfig, dev bool) echo.HandlerFunc {
 		Cache: lru.New(30),
 	})
 
+	srv.AroundRootFields(recordSceneHistory)
+	srv.AroundResponses(refreshSubscriptionLoaders)
+
 	srv.SetErrorPresenter(
 		// show more detailed error messgage in debug mode
 		func(ctx context.Context, e error) *gqlerror.Error {
@@ -84,3 +95,55 @@ func GraphqlAPI(conf config.GraphQLConfig, dev bool) echo.HandlerFunc {
 		return nil
 	}
 }
+
+// refreshSubscriptionLoaders resolves each event of a subscription with new data loaders
+// because the cache of the loaders attached to the connection would get stale.
+func refreshSubscriptionLoaders(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	oc := graphql.GetOperationContext(ctx)
+	if oc.Operation == nil || oc.Operation.Operation != ast.Subscription {
+		return next(ctx)
+	}
+
+	if usecases := adapter.Usecases(ctx); usecases != nil {
+		ctx = gql.AttachUsecases(ctx, usecases, enableDataLoaders)
+	}
+	return next(ctx)
+}
+
+// websocketOriginChecker allows the allowed origins of CORS to connect to subscriptions.
+// Only the same origin is allowed when no origin is configured.
+func websocketOriginChecker(origins []string) func(r *http.Request) bool {
+	if len(origins) == 0 {
+		return nil
+	}
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		return origin == "" || slices.Contains(origins, origin) || slices.Contains(origins, "*")
+	}
+}
+
+// recordSceneHistory records changes made by each mutation as an operation of the scene history
+// so that it can be undone later.
+func recordSceneHistory(ctx context.Context, next graphql.RootResolver) graphql.Marshaler {
+	oc := graphql.GetOperationContext(ctx)
+	if oc.Operation == nil || oc.Operation.Operation != ast.Mutation {
+		return next(ctx)
+	}
+
+	usecases := adapter.Usecases(ctx)
+	if usecases == nil || usecases.SceneHistory == nil {
+		return next(ctx)
+	}
+
+	errs := len(graphql.GetErrors(ctx))
+	ctx = usecases.SceneHistory.Begin(ctx, graphql.GetRootFieldContext(ctx).Field.Name, adapter.Operator(ctx))
+	res := next(ctx)
+
+	if len(graphql.GetErrors(ctx)) == errs {
+		if err := usecases.SceneHistory.Commit(ctx); err != nil {
+			log.Errorfc(ctx, "gql: failed to save scene history: %v", err)
+			graphql.AddError(ctx, err)
+		}
+	}
+	return res
+}
@@ -45,15 +45,23 @@ func Start(debug bool, version string) {
 	// Init repositories
 	repos, gateways, acRepos, acGateways := initReposAndGateways(ctx, conf, debug)
 
-	// Start web server
-	NewServer(ctx, &ServerConfig{
+	serverConfig := &ServerConfig{
 		Config:          conf,
 		Debug:           debug,
 		Repos:           repos,
 		AccountRepos:    acRepos,
 		Gateways:        gateways,
 		AccountGateways: acGateways,
-	}).Run()
+	}
+
+	// Start publish scheduler
+	go runPublishScheduler(ctx, serverConfig)
+
+	// Start usage aggregator
+	go runUsageAggregator(ctx, serverConfig)
+
+	// Start web server
+	NewServer(ctx, serverConfig).Run()
 }
 
 type WebServer struct {
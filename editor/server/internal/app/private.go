@@ -1,12 +1,14 @@
 package app
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearth/server/internal/adapter"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer/nlslayerio"
 	"github.com/reearth/reearthx/rerror"
 )
 
@@ -34,3 +36,60 @@ func ExportLayer() echo.HandlerFunc {
 		return c.Stream(http.StatusOK, mime, reader)
 	}
 }
+
+func ExportNLSLayer() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		u := adapter.Usecases(ctx)
+
+		param := c.Param("param")
+		params := strings.Split(param, ".")
+		if len(params) != 2 {
+			return rerror.ErrNotFound
+		}
+
+		lid, err := id.NLSLayerIDFrom(params[0])
+		if err != nil {
+			return rerror.ErrNotFound
+		}
+
+		reader, mime, err := u.NLSLayer.Export(ctx, lid, params[1], adapter.Operator(ctx))
+		if errors.Is(err, nlslayerio.ErrNoExportableFeatures) {
+			return &echo.HTTPError{Code: http.StatusUnprocessableEntity, Message: err}
+		}
+		if err != nil {
+			return err
+		}
+
+		return c.Stream(http.StatusOK, mime, reader)
+	}
+}
+
+// ExportPublished responds with a zip of the standalone site of the published project or story.
+func ExportPublished() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		name := c.Param("name")
+		if name == "" {
+			return rerror.ErrNotFound
+		}
+
+		contr, err := publishedController(c)
+		if err != nil {
+			return err
+		}
+
+		// the response is committed on the first write, so errors before writing the zip can still be responded
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, "application/zip")
+		res.Header().Set(echo.HeaderContentDisposition, "attachment;filename="+name+".zip")
+		if err := contr.Export(ctx, name, res, adapter.Operator(ctx)); err != nil {
+			if !res.Committed {
+				res.Header().Del(echo.HeaderContentDisposition)
+			}
+			return err
+		}
+		return nil
+	}
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"crypto/subtle"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -192,9 +193,10 @@ func PublishedIndexMiddleware(pattern string, useParam, errorIfNotFound bool) ec
 	}
 }
 
+// PublishedAuthMiddleware authorizes requests to published projects and stories with a view token or basic auth.
 func PublishedAuthMiddleware() echo.MiddlewareFunc {
 	key := struct{}{}
-	return middleware.BasicAuthWithConfig(middleware.BasicAuthConfig{
+	basicAuth := middleware.BasicAuthWithConfig(middleware.BasicAuthConfig{
 		Validator: func(user string, password string, c echo.Context) (bool, error) {
 			md, ok := c.Request().Context().Value(key).(interfaces.ProjectPublishedMetadata)
 			if !ok {
@@ -222,6 +224,62 @@ func PublishedAuthMiddleware() echo.MiddlewareFunc {
 			return !md.IsBasicAuthActive
 		},
 	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withBasicAuth := basicAuth(next)
+		return func(c echo.Context) error {
+			ok, err := authorizeViewToken(c)
+			if err != nil {
+				return err
+			}
+			if ok {
+				return next(c)
+			}
+			return withBasicAuth(c)
+		}
+	}
+}
+
+// PublishedViewTokenMiddleware authorizes requests to published stories with a view token only.
+func PublishedViewTokenMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, err := authorizeViewToken(c); err != nil {
+				return err
+			}
+			return next(c)
+		}
+	}
+}
+
+// authorizeViewToken reports whether the request has a valid view token to the published story.
+func authorizeViewToken(c echo.Context) (bool, error) {
+	name := c.Param("name")
+	if name == "" {
+		return false, nil
+	}
+
+	contr, err := publishedController(c)
+	if err != nil {
+		return false, nil
+	}
+
+	ok, err := contr.Authorize(c.Request().Context(), name, viewToken(c))
+	if errors.Is(err, interfaces.ErrViewTokenRequired) || errors.Is(err, interfaces.ErrInvalidViewToken) {
+		return false, echo.ErrUnauthorized
+	}
+	return ok, err
+}
+
+// viewToken returns the view token given with the "token" query parameter or the bearer authorization header.
+func viewToken(c echo.Context) string {
+	if t := c.QueryParam("token"); t != "" {
+		return t
+	}
+	if t, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer "); ok {
+		return t
+	}
+	return ""
 }
 
 func publishedController(c echo.Context) (*http1.PublishedController, error) {
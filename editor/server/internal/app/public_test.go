@@ -23,6 +23,8 @@ func TestPublishedAuthMiddleware(t *testing.T) {
 		PublishedName     string
 		BasicAuthUsername string
 		BasicAuthPassword string
+		Token             string
+		BearerToken       string
 		Error             error
 	}{
 		{
@@ -54,6 +56,32 @@ func TestPublishedAuthMiddleware(t *testing.T) {
 			BasicAuthUsername: "fooo",
 			BasicAuthPassword: "baar",
 		},
+		{
+			Name:          "auth with valid token",
+			PublishedName: "active",
+			Token:         "valid",
+		},
+		{
+			Name:          "limited without token",
+			PublishedName: "limited",
+			Error:         echo.ErrUnauthorized,
+		},
+		{
+			Name:          "limited with invalid token",
+			PublishedName: "limited",
+			Token:         "invalid",
+			Error:         echo.ErrUnauthorized,
+		},
+		{
+			Name:          "limited with valid token",
+			PublishedName: "limited",
+			Token:         "valid",
+		},
+		{
+			Name:          "limited with valid bearer token",
+			PublishedName: "limited",
+			BearerToken:   "valid",
+		},
 	}
 
 	for _, tc := range tests {
@@ -62,10 +90,13 @@ func TestPublishedAuthMiddleware(t *testing.T) {
 			t.Parallel()
 
 			assert := assert.New(t)
-			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req := httptest.NewRequest(http.MethodGet, "/?token="+tc.Token, nil)
 			if tc.BasicAuthUsername != "" {
 				req.Header.Set(echo.HeaderAuthorization, "basic "+base64.StdEncoding.EncodeToString([]byte(tc.BasicAuthUsername+":"+tc.BasicAuthPassword)))
 			}
+			if tc.BearerToken != "" {
+				req.Header.Set(echo.HeaderAuthorization, "Bearer "+tc.BearerToken)
+			}
 			res := httptest.NewRecorder()
 			e := echo.New()
 			c := e.NewContext(req, res)
@@ -220,6 +251,19 @@ func (p *mockPublished) Metadata(ctx context.Context, name string) (interfaces.P
 	return interfaces.ProjectPublishedMetadata{}, rerror.ErrNotFound
 }
 
+func (p *mockPublished) Authorize(ctx context.Context, name, token string) (bool, error) {
+	if token == "valid" {
+		return true, nil
+	}
+	if name == "limited" {
+		if token == "" {
+			return false, interfaces.ErrViewTokenRequired
+		}
+		return false, interfaces.ErrInvalidViewToken
+	}
+	return false, nil
+}
+
 func (p *mockPublished) Data(ctx context.Context, name string) (io.Reader, error) {
 	if name == "prj" {
 		return strings.NewReader("aaa"), nil
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/interactor"
+	"github.com/reearth/reearthx/log"
+)
+
+// runPublishScheduler publishes and unpublishes projects and stories on schedule until ctx is done.
+func runPublishScheduler(ctx context.Context, cfg *ServerConfig) {
+	interval := cfg.Config.Published.ScheduleInterval
+	if interval <= 0 {
+		return
+	}
+
+	scheduler := interactor.NewPublishScheduler(cfg.Repos, cfg.Gateways, cfg.Config.Policy.Default)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Infof("publish scheduler: started with interval %s", interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := scheduler.Run(ctx, now); err != nil {
+				log.Errorfc(ctx, "publish scheduler: %v", err)
+			}
+		}
+	}
+}
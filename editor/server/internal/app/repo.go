@@ -10,6 +10,8 @@ import (
 	"github.com/reearth/reearth/server/internal/infrastructure/google"
 	"github.com/reearth/reearth/server/internal/infrastructure/marketplace"
 	mongorepo "github.com/reearth/reearth/server/internal/infrastructure/mongo"
+	"github.com/reearth/reearth/server/internal/infrastructure/pluginsource"
+	"github.com/reearth/reearth/server/internal/infrastructure/realtime"
 	"github.com/reearth/reearth/server/internal/infrastructure/s3"
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
@@ -91,6 +93,17 @@ func initReposAndGateways(ctx context.Context, conf *config.Config, debug bool)
 		gateways.PluginRegistry = marketplace.New(conf.Marketplace.Endpoint, conf.Marketplace.Secret, conf.Marketplace.OAuth.Config())
 	}
 
+	// Plugin sources
+	gateways.PluginSources = initPluginSources(conf)
+
+	// Realtime collaboration
+	// the pubsub and the presence are in-process, so they are not shared among multiple instances of the server
+	if conf.Collaboration.Enabled {
+		log.Infof("collaboration: realtime collaboration is enabled for a single server instance")
+		gateways.PubSub = realtime.NewPubSub()
+		gateways.Presence = realtime.NewPresence(realtime.DefaultPresenceTTL)
+	}
+
 	// release lock of all scenes
 	if err := repos.SceneLock.ReleaseAllLock(context.Background()); err != nil {
 		log.Fatalf("repo initialization error: %v", err)
@@ -99,6 +112,20 @@ func initReposAndGateways(ctx context.Context, conf *config.Config, debug bool)
 	return repos, gateways, accountRepos, acGateways
 }
 
+func initPluginSources(conf *config.Config) []gateway.PluginSource {
+	var sources []gateway.PluginSource
+	if conf.PluginSource.Local_Dir != "" {
+		log.Infof("plugin source: local registry is used: %s\n", conf.PluginSource.Local_Dir)
+		sources = append(sources, pluginsource.NewLocal(conf.PluginSource.Local_Dir))
+	}
+	return append(
+		sources,
+		pluginsource.NewGitHub(nil),
+		pluginsource.NewGitLab(nil, conf.PluginSource.GitLabHosts(), conf.PluginSource.GitLabTokens()),
+		pluginsource.NewHTTPS(nil),
+	)
+}
+
 func initFile(ctx context.Context, conf *config.Config) (fileRepo gateway.File) {
 	var err error
 	if conf.GCS.IsConfigured() {
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/interactor"
+	"github.com/reearth/reearthx/log"
+)
+
+// runUsageAggregator aggregates the cached usages of workspaces again until ctx is done.
+func runUsageAggregator(ctx context.Context, cfg *ServerConfig) {
+	interval := cfg.Config.Usage.Interval
+	if interval <= 0 {
+		return
+	}
+
+	aggregator := interactor.NewUsageAggregator(cfg.Repos)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Infof("usage aggregator: started with interval %s", interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := aggregator.Run(ctx, now.Add(-interval)); err != nil {
+				log.Errorfc(ctx, "usage aggregator: %v", err)
+			}
+		}
+	}
+}
@@ -178,7 +178,7 @@ func TestWeb(t *testing.T) {
 
 			e.Use(ContextMiddleware(func(ctx context.Context) context.Context {
 				return adapter.AttachUsecases(ctx, &interfaces.Container{
-					Published: interactor.NewPublished(prjRepo, storyRepo, fileg, publishedHTML),
+					Published: interactor.NewPublished(prjRepo, storyRepo, fileg, publishedHTML, ""),
 				})
 			}))
 
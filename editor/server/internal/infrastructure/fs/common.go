@@ -5,5 +5,7 @@ const (
 	pluginDir        = "plugins"
 	publishedDir     = "published"
 	storyDir         = "stories"
+	snapshotDir      = "snapshots"
+	exportDir        = "export"
 	manifestFilePath = "reearth.yml"
 )
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/kennygrant/sanitize"
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
@@ -100,6 +101,30 @@ func (f *fileRepo) RemoveBuiltScene(ctx context.Context, name string) error {
 	return f.delete(ctx, filepath.Join(publishedDir, sanitize.Path(name+".json")))
 }
 
+func (f *fileRepo) UploadBuiltSceneSnapshot(ctx context.Context, reader io.Reader, name, version string) error {
+	_, err := f.upload(ctx, snapshotPath(publishedDir, name, version), reader)
+	return err
+}
+
+func (f *fileRepo) ReadBuiltSceneSnapshot(ctx context.Context, name, version string) (io.ReadCloser, error) {
+	return f.read(ctx, snapshotPath(publishedDir, name, version))
+}
+
+func (f *fileRepo) ListBuiltSceneSnapshots(ctx context.Context, name string) ([]string, error) {
+	return f.listSnapshots(ctx, publishedDir, name)
+}
+
+func (f *fileRepo) RemoveBuiltSceneSnapshot(ctx context.Context, name, version string) error {
+	if version == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, snapshotPath(publishedDir, name, version))
+}
+
+func (f *fileRepo) RemoveBuiltSceneSnapshots(ctx context.Context, name string) error {
+	return f.delete(ctx, snapshotPath(publishedDir, name, ""))
+}
+
 // Stories
 
 func (f *fileRepo) ReadStoryFile(ctx context.Context, name string) (io.ReadCloser, error) {
@@ -123,6 +148,45 @@ func (f *fileRepo) RemoveStory(ctx context.Context, name string) error {
 	return f.delete(ctx, filepath.Join(storyDir, sanitize.Path(name+".json")))
 }
 
+func (f *fileRepo) UploadStorySnapshot(ctx context.Context, reader io.Reader, name, version string) error {
+	_, err := f.upload(ctx, snapshotPath(storyDir, name, version), reader)
+	return err
+}
+
+func (f *fileRepo) ReadStorySnapshot(ctx context.Context, name, version string) (io.ReadCloser, error) {
+	return f.read(ctx, snapshotPath(storyDir, name, version))
+}
+
+func (f *fileRepo) ListStorySnapshots(ctx context.Context, name string) ([]string, error) {
+	return f.listSnapshots(ctx, storyDir, name)
+}
+
+func (f *fileRepo) RemoveStorySnapshot(ctx context.Context, name, version string) error {
+	if version == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, snapshotPath(storyDir, name, version))
+}
+
+func (f *fileRepo) RemoveStorySnapshots(ctx context.Context, name string) error {
+	return f.delete(ctx, snapshotPath(storyDir, name, ""))
+}
+
+// Exported projects
+
+func (f *fileRepo) UploadExportProjectZip(ctx context.Context, reader io.Reader, name string) error {
+	_, err := f.upload(ctx, filepath.Join(exportDir, sanitize.Path(name)), reader)
+	return err
+}
+
+func (f *fileRepo) ReadExportProjectZip(ctx context.Context, name string) (io.ReadCloser, error) {
+	return f.read(ctx, filepath.Join(exportDir, sanitize.Path(name)))
+}
+
+func (f *fileRepo) RemoveExportProjectZip(ctx context.Context, name string) error {
+	return f.delete(ctx, filepath.Join(exportDir, sanitize.Path(name)))
+}
+
 // helpers
 
 func (f *fileRepo) read(ctx context.Context, filename string) (io.ReadCloser, error) {
@@ -202,6 +266,45 @@ func (f *fileRepo) delete(ctx context.Context, filename string) error {
 	return nil
 }
 
+func (f *fileRepo) listSnapshots(ctx context.Context, dir, name string) ([]string, error) {
+	p := snapshotPath(dir, name, "")
+	if p == "" {
+		return nil, nil
+	}
+
+	files, err := afero.ReadDir(f.fs, p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+
+	res := make([]string, 0, len(files))
+	for _, fi := range files {
+		if !fi.IsDir() && filepath.Ext(fi.Name()) == ".json" {
+			res = append(res, strings.TrimSuffix(fi.Name(), ".json"))
+		}
+	}
+	return res, nil
+}
+
+// snapshotPath returns the path of the snapshot, or the directory of the snapshots when the version is empty.
+func snapshotPath(dir, name, version string) string {
+	sn := sanitize.Path(name)
+	if sn == "" {
+		return ""
+	}
+	if version == "" {
+		return filepath.Join(dir, snapshotDir, sn)
+	}
+	sv := sanitize.Path(version + ".json")
+	if sv == "" {
+		return ""
+	}
+	return filepath.Join(dir, snapshotDir, sn, sv)
+}
+
 func getAssetFileURL(base *url.URL, filename string) *url.URL {
 	if base == nil {
 		return nil
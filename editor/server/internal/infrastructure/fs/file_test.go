@@ -240,6 +240,46 @@ func TestFile_RemoveBuiltScene(t *testing.T) {
 	assert.ErrorIs(t, err, os.ErrNotExist)
 }
 
+func TestFile_BuiltSceneSnapshot(t *testing.T) {
+	ctx := context.Background()
+	fs := mockFs()
+	f, _ := NewFile(fs, "")
+
+	versions, err := f.ListBuiltSceneSnapshots(ctx, "p")
+	assert.NoError(t, err)
+	assert.Empty(t, versions)
+
+	assert.NoError(t, f.UploadBuiltSceneSnapshot(ctx, strings.NewReader("{\"v\":1}"), "p", "1000"))
+	assert.NoError(t, f.UploadBuiltSceneSnapshot(ctx, strings.NewReader("{\"v\":2}"), "p", "2000"))
+
+	versions, err = f.ListBuiltSceneSnapshots(ctx, "p")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"1000", "2000"}, versions)
+
+	r, err := f.ReadBuiltSceneSnapshot(ctx, "p", "1000")
+	assert.NoError(t, err)
+	c, _ := io.ReadAll(r)
+	assert.Equal(t, "{\"v\":1}", string(c))
+	assert.NoError(t, r.Close())
+
+	_, err = f.ReadBuiltSceneSnapshot(ctx, "p", "3000")
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	assert.Equal(t, gateway.ErrInvalidFile, f.RemoveBuiltSceneSnapshot(ctx, "p", ""))
+	assert.NoError(t, f.RemoveBuiltSceneSnapshot(ctx, "p", "1000"))
+	versions, err = f.ListBuiltSceneSnapshots(ctx, "p")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2000"}, versions)
+
+	assert.NoError(t, f.RemoveBuiltSceneSnapshots(ctx, "p"))
+	versions, err = f.ListBuiltSceneSnapshots(ctx, "p")
+	assert.NoError(t, err)
+	assert.Empty(t, versions)
+
+	_, err = fs.Stat(filepath.Join("published", "s.json"))
+	assert.NoError(t, err)
+}
+
 func TestGetAssetFileURL(t *testing.T) {
 	e, err := url.Parse("http://hoge.com/assets/xxx.yyy")
 	assert.NoError(t, err)
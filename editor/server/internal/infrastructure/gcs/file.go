@@ -24,6 +24,8 @@ const (
 	gcsPluginBasePath string = "plugins"
 	gcsMapBasePath    string = "maps"
 	gcsStoryBasePath  string = "stories"
+	snapshotBasePath  string = "snapshots"
+	gcsExportBasePath string = "export"
 	fileSizeLimit     int64  = 1024 * 1024 * 100 // about 100MB
 )
 
@@ -162,6 +164,49 @@ func (f *fileRepo) RemoveBuiltScene(ctx context.Context, name string) error {
 	return f.delete(ctx, path.Join(gcsMapBasePath, sn))
 }
 
+func (f *fileRepo) UploadBuiltSceneSnapshot(ctx context.Context, content io.Reader, name, version string) error {
+	sn := snapshotName(gcsMapBasePath, name, version)
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, sn, content)
+	return err
+}
+
+func (f *fileRepo) ReadBuiltSceneSnapshot(ctx context.Context, name, version string) (io.ReadCloser, error) {
+	sn := snapshotName(gcsMapBasePath, name, version)
+	if sn == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, sn)
+}
+
+func (f *fileRepo) ListBuiltSceneSnapshots(ctx context.Context, name string) ([]string, error) {
+	sn := snapshotName(gcsMapBasePath, name, "")
+	if sn == "" {
+		return nil, nil
+	}
+	return f.listSnapshots(ctx, sn)
+}
+
+func (f *fileRepo) RemoveBuiltSceneSnapshot(ctx context.Context, name, version string) error {
+	sn := snapshotName(gcsMapBasePath, name, version)
+	if sn == "" || version == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, sn)
+}
+
+func (f *fileRepo) RemoveBuiltSceneSnapshots(ctx context.Context, name string) error {
+	log.Infofc(ctx, "gcs: built scene snapshots deleted: %s", name)
+
+	sn := snapshotName(gcsMapBasePath, name, "")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.deleteAll(ctx, sn)
+}
+
 // Stories
 
 func (f *fileRepo) ReadStoryFile(ctx context.Context, name string) (io.ReadCloser, error) {
@@ -199,6 +244,77 @@ func (f *fileRepo) RemoveStory(ctx context.Context, name string) error {
 	return f.delete(ctx, path.Join(gcsStoryBasePath, sn))
 }
 
+func (f *fileRepo) UploadStorySnapshot(ctx context.Context, content io.Reader, name, version string) error {
+	sn := snapshotName(gcsStoryBasePath, name, version)
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, sn, content)
+	return err
+}
+
+func (f *fileRepo) ReadStorySnapshot(ctx context.Context, name, version string) (io.ReadCloser, error) {
+	sn := snapshotName(gcsStoryBasePath, name, version)
+	if sn == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, sn)
+}
+
+func (f *fileRepo) ListStorySnapshots(ctx context.Context, name string) ([]string, error) {
+	sn := snapshotName(gcsStoryBasePath, name, "")
+	if sn == "" {
+		return nil, nil
+	}
+	return f.listSnapshots(ctx, sn)
+}
+
+func (f *fileRepo) RemoveStorySnapshot(ctx context.Context, name, version string) error {
+	sn := snapshotName(gcsStoryBasePath, name, version)
+	if sn == "" || version == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, sn)
+}
+
+func (f *fileRepo) RemoveStorySnapshots(ctx context.Context, name string) error {
+	log.Infofc(ctx, "gcs: story snapshots deleted: %s", name)
+
+	sn := snapshotName(gcsStoryBasePath, name, "")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.deleteAll(ctx, sn)
+}
+
+// exported projects
+
+func (f *fileRepo) UploadExportProjectZip(ctx context.Context, content io.Reader, name string) error {
+	sn := sanitize.Path(name)
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, path.Join(gcsExportBasePath, sn), content)
+	return err
+}
+
+func (f *fileRepo) ReadExportProjectZip(ctx context.Context, name string) (io.ReadCloser, error) {
+	if name == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, path.Join(gcsExportBasePath, sanitize.Path(name)))
+}
+
+func (f *fileRepo) RemoveExportProjectZip(ctx context.Context, name string) error {
+	log.Infofc(ctx, "gcs: exported project deleted: %s", name)
+
+	sn := sanitize.Path(name)
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, path.Join(gcsExportBasePath, sn))
+}
+
 // helpers
 
 func (f *fileRepo) bucket(ctx context.Context) (*storage.BucketHandle, error) {
@@ -351,6 +467,50 @@ func (f *fileRepo) deleteAll(ctx context.Context, path string) error {
 	return nil
 }
 
+func (f *fileRepo) listSnapshots(ctx context.Context, prefix string) ([]string, error) {
+	bucket, err := f.bucket(ctx)
+	if err != nil {
+		log.Errorfc(ctx, "gcs: listSnapshots bucket err: %+v\n", err)
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+
+	it := bucket.Objects(ctx, &storage.Query{
+		Prefix: prefix,
+	})
+
+	var res []string
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			log.Errorfc(ctx, "gcs: listSnapshots next err: %+v\n", err)
+			return nil, rerror.ErrInternalByWithContext(ctx, err)
+		}
+		if v := strings.TrimPrefix(attrs.Name, prefix); path.Ext(v) == ".json" && !strings.Contains(v, "/") {
+			res = append(res, strings.TrimSuffix(v, ".json"))
+		}
+	}
+	return res, nil
+}
+
+// snapshotName returns the object name of the snapshot, or the prefix of the snapshots when the version is empty.
+func snapshotName(base, name, version string) string {
+	sn := sanitize.Path(name)
+	if sn == "" {
+		return ""
+	}
+	if version == "" {
+		return path.Join(base, snapshotBasePath, sn) + "/"
+	}
+	sv := sanitize.Path(version + ".json")
+	if sv == "" {
+		return ""
+	}
+	return path.Join(base, snapshotBasePath, sn, sv)
+}
+
 func getGCSObjectURL(base *url.URL, objectName string) *url.URL {
 	if base == nil {
 		return nil
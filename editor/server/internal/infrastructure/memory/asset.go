@@ -12,6 +12,8 @@ import (
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/usecasex"
 	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
 )
 
 type Asset struct {
@@ -52,7 +54,10 @@ func (r *Asset) FindByWorkspace(_ context.Context, wid accountdomain.WorkspaceID
 	}
 
 	result := r.data.FindAll(func(k id.AssetID, v *asset.Asset) bool {
-		return v.Workspace() == wid && (filter.Keyword == nil || strings.Contains(v.Name(), *filter.Keyword))
+		return v.Workspace() == wid &&
+			(filter.Keyword == nil || strings.Contains(v.Name(), *filter.Keyword)) &&
+			(filter.Folder == nil || v.Folder() == *filter.Folder) &&
+			(filter.Tag == nil || slices.Contains(v.Tags(), *filter.Tag))
 	})
 
 	if filter.Sort != nil {
@@ -102,6 +107,36 @@ func (r *Asset) TotalSizeByWorkspace(_ context.Context, wid accountdomain.Worksp
 	return
 }
 
+func (r *Asset) FindFolders(_ context.Context, wid accountdomain.WorkspaceID) ([]string, error) {
+	return r.distinct(wid, func(a *asset.Asset) []string {
+		return []string{a.Folder()}
+	}), nil
+}
+
+func (r *Asset) FindTags(_ context.Context, wid accountdomain.WorkspaceID) ([]string, error) {
+	return r.distinct(wid, func(a *asset.Asset) []string {
+		return a.Tags()
+	}), nil
+}
+
+func (r *Asset) distinct(wid accountdomain.WorkspaceID, f func(*asset.Asset) []string) []string {
+	if !r.f.CanRead(wid) {
+		return nil
+	}
+
+	var res []string
+	r.data.Range(func(k id.AssetID, v *asset.Asset) bool {
+		if v.Workspace() == wid {
+			res = append(res, f(v)...)
+		}
+		return true
+	})
+
+	res = lo.Without(lo.Uniq(res), "")
+	sort.Strings(res)
+	return res
+}
+
 func (r *Asset) Save(_ context.Context, a *asset.Asset) error {
 	if !r.f.CanWrite(a.Workspace()) {
 		return repo.ErrOperationDenied
@@ -16,11 +16,14 @@ func New() *repo.Container {
 		Layer:          NewLayer(),
 		NLSLayer:       NewNLSLayer(),
 		Style:          NewStyle(),
+		StyleLibrary:   NewStyleLibrary(),
+		PluginKey:      NewPluginKey(),
 		Plugin:         NewPlugin(),
 		Project:        NewProject(),
 		PropertySchema: NewPropertySchema(),
 		Property:       NewProperty(),
 		Scene:          NewScene(),
+		SceneHistory:   NewSceneHistory(),
 		Tag:            NewTag(),
 		Workspace:      accountmemory.NewWorkspace(),
 		User:           accountmemory.NewUser(),
@@ -28,6 +31,7 @@ func New() *repo.Container {
 		AuthRequest:    authserver.NewMemory(),
 		Policy:         NewPolicy(),
 		Storytelling:   NewStorytelling(),
+		WorkspaceUsage: NewWorkspaceUsage(),
 		Lock:           NewLock(),
 		Transaction:    &usecasex.NopTransaction{},
 	}
@@ -88,7 +88,7 @@ func (r *NLSLayer) FindNLSLayerGroupByID(ctx context.Context, id id.NLSLayerID)
 
 	d, ok := r.data[id]
 	if !ok {
-		return &nlslayer.NLSLayerGroup{}, nil
+		return nil, rerror.ErrNotFound
 	}
 	if lg := nlslayer.NLSLayerGroupFromLayer(d); lg != nil && r.f.CanRead(lg.Scene()) {
 		return lg, nil
@@ -14,13 +14,14 @@ import (
 
 type Plugin struct {
 	lock sync.Mutex
-	data []*plugin.Plugin
+	// data is a pointer to share it with the filtered repos
+	data *[]*plugin.Plugin
 	f    repo.SceneFilter
 }
 
 func NewPlugin() *Plugin {
 	return &Plugin{
-		data: []*plugin.Plugin{},
+		data: &[]*plugin.Plugin{},
 	}
 }
 
@@ -48,7 +49,7 @@ func (r *Plugin) FindByID(ctx context.Context, id id.PluginID) (*plugin.Plugin,
 	if p := builtin.GetPlugin(id); p != nil {
 		return p, nil
 	}
-	for _, p := range r.data {
+	for _, p := range *r.data {
 		if p.ID().Equal(id) {
 			if s := p.ID().Scene(); s == nil || r.f.CanRead(*s) {
 				return p.Clone(), nil
@@ -68,7 +69,7 @@ func (r *Plugin) FindByIDs(ctx context.Context, ids []id.PluginID) ([]*plugin.Pl
 			result = append(result, p)
 			continue
 		}
-		for _, p := range r.data {
+		for _, p := range *r.data {
 			if p.ID().Equal(id) {
 				if s := p.ID().Scene(); s == nil || r.f.CanRead(*s) {
 					result = append(result, p.Clone())
@@ -92,13 +93,13 @@ func (r *Plugin) Save(ctx context.Context, p *plugin.Plugin) error {
 		return repo.ErrOperationDenied
 	}
 
-	for i, q := range r.data {
+	for i, q := range *r.data {
 		if q.ID().Equal(p.ID()) {
-			r.data = append(r.data[:i], r.data[i+1:]...)
+			*r.data = append((*r.data)[:i], (*r.data)[i+1:]...)
 			break
 		}
 	}
-	r.data = append(r.data, p.Clone())
+	*r.data = append(*r.data, p.Clone())
 	return nil
 }
 
@@ -106,10 +107,10 @@ func (r *Plugin) Remove(ctx context.Context, id id.PluginID) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	for i := 0; i < len(r.data); i++ {
-		if p := r.data[i]; p.ID().Equal(id) {
+	for i := 0; i < len(*r.data); i++ {
+		if p := (*r.data)[i]; p.ID().Equal(id) {
 			if s := p.ID().Scene(); s == nil || r.f.CanWrite(*s) {
-				r.data = append(r.data[:i], r.data[i+1:]...)
+				*r.data = append((*r.data)[:i], (*r.data)[i+1:]...)
 				i--
 			}
 		}
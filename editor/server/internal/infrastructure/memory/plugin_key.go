@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/pluginkey"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+type PluginKey struct {
+	data *util.SyncMap[id.PluginKeyID, *pluginkey.Key]
+	f    repo.WorkspaceFilter
+}
+
+func NewPluginKey() *PluginKey {
+	return &PluginKey{
+		data: util.SyncMapFrom[id.PluginKeyID, *pluginkey.Key](nil),
+	}
+}
+
+func (r *PluginKey) Filtered(f repo.WorkspaceFilter) repo.PluginKey {
+	return &PluginKey{
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *PluginKey) FindByID(_ context.Context, id id.PluginKeyID) (*pluginkey.Key, error) {
+	k, ok := r.data.Load(id)
+	if ok && r.f.CanRead(k.Workspace()) {
+		return k, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *PluginKey) FindByWorkspace(_ context.Context, wid accountdomain.WorkspaceID) (pluginkey.List, error) {
+	if !r.f.CanRead(wid) {
+		return nil, nil
+	}
+
+	res := r.data.FindAll(func(_ id.PluginKeyID, v *pluginkey.Key) bool {
+		return v.Workspace() == wid
+	})
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID().Compare(res[j].ID()) < 0
+	})
+	return res, nil
+}
+
+func (r *PluginKey) Save(_ context.Context, k *pluginkey.Key) error {
+	if !r.f.CanWrite(k.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+	r.data.Store(k.ID(), k)
+	return nil
+}
+
+func (r *PluginKey) Remove(_ context.Context, id id.PluginKeyID) error {
+	if k, ok := r.data.Load(id); ok && r.f.CanWrite(k.Workspace()) {
+		r.data.Delete(id)
+	}
+	return nil
+}
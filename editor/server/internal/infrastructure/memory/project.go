@@ -60,8 +60,8 @@ func (r *Project) FindByWorkspace(ctx context.Context, id accountdomain.Workspac
 		int64(len(r.data)),
 		startCursor,
 		endCursor,
-		true,
-		true,
+		false,
+		false,
 	), nil
 }
 
@@ -133,6 +133,19 @@ func (r *Project) FindByPublicName(ctx context.Context, name string) (*project.P
 	return nil, rerror.ErrNotFound
 }
 
+func (r *Project) FindDuePublishSchedules(_ context.Context, now time.Time) ([]*project.Project, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var res []*project.Project
+	for _, p := range r.data {
+		if s := p.PublishSchedule(); s.IsPublishDue(now) || s.IsUnpublishDue(now) {
+			res = append(res, p)
+		}
+	}
+	return res, nil
+}
+
 func (r *Project) CountByWorkspace(_ context.Context, ws accountdomain.WorkspaceID) (n int, _ error) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/history"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+)
+
+type SceneHistory struct {
+	data *util.SyncMap[id.HistoryID, *history.History]
+	f    repo.SceneFilter
+}
+
+func NewSceneHistory() *SceneHistory {
+	return &SceneHistory{
+		data: util.SyncMapFrom[id.HistoryID, *history.History](nil),
+	}
+}
+
+func (r *SceneHistory) Filtered(f repo.SceneFilter) repo.SceneHistory {
+	return &SceneHistory{
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *SceneHistory) FindByID(_ context.Context, id id.HistoryID) (*history.History, error) {
+	h, ok := r.data.Load(id)
+	if ok && r.f.CanRead(h.Scene()) {
+		return h, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *SceneHistory) FindByScene(_ context.Context, sid id.SceneID, pagination *usecasex.Pagination) ([]*history.History, *usecasex.PageInfo, error) {
+	if !r.f.CanRead(sid) {
+		return nil, usecasex.EmptyPageInfo(), nil
+	}
+
+	result := r.findByScene(sid, nil)
+	total := int64(len(result))
+
+	if pagination != nil && pagination.Cursor != nil {
+		c := pagination.Cursor
+		if c.After != nil {
+			result = lo.Filter(result, func(h *history.History, _ int) bool { return h.ID().String() > string(*c.After) })
+		}
+		if c.Before != nil {
+			result = lo.Filter(result, func(h *history.History, _ int) bool { return h.ID().String() < string(*c.Before) })
+		}
+		if c.First != nil && int64(len(result)) > *c.First {
+			result = result[:*c.First]
+		}
+		if c.Last != nil && int64(len(result)) > *c.Last {
+			result = result[int64(len(result))-*c.Last:]
+		}
+	} else if pagination != nil && pagination.Offset != nil {
+		o := pagination.Offset
+		result = result[lo.Min([]int64{o.Offset, int64(len(result))}):]
+		if o.Limit > 0 && int64(len(result)) > o.Limit {
+			result = result[:o.Limit]
+		}
+	}
+
+	var startCursor, endCursor *usecasex.Cursor
+	if len(result) > 0 {
+		startCursor = usecasex.Cursor(result[0].ID().String()).Ref()
+		endCursor = usecasex.Cursor(result[len(result)-1].ID().String()).Ref()
+	}
+
+	return result, usecasex.NewPageInfo(
+		total,
+		startCursor,
+		endCursor,
+		len(result) > 0 && endCursor != nil && r.hasAfter(sid, *endCursor),
+		len(result) > 0 && startCursor != nil && r.hasBefore(sid, *startCursor),
+	), nil
+}
+
+func (r *SceneHistory) FindLatestApplied(_ context.Context, sid id.SceneID) (*history.History, error) {
+	if !r.f.CanRead(sid) {
+		return nil, rerror.ErrNotFound
+	}
+	res := r.findByScene(sid, lo.ToPtr(false))
+	if len(res) == 0 {
+		return nil, rerror.ErrNotFound
+	}
+	return res[len(res)-1], nil
+}
+
+func (r *SceneHistory) FindEarliestUndone(_ context.Context, sid id.SceneID) (*history.History, error) {
+	if !r.f.CanRead(sid) {
+		return nil, rerror.ErrNotFound
+	}
+	res := r.findByScene(sid, lo.ToPtr(true))
+	if len(res) == 0 {
+		return nil, rerror.ErrNotFound
+	}
+	return res[0], nil
+}
+
+func (r *SceneHistory) Save(_ context.Context, h *history.History) error {
+	if !r.f.CanWrite(h.Scene()) {
+		return repo.ErrOperationDenied
+	}
+	r.data.Store(h.ID(), h)
+	return nil
+}
+
+func (r *SceneHistory) RemoveUndone(_ context.Context, sid id.SceneID) error {
+	if !r.f.CanWrite(sid) {
+		return nil
+	}
+	r.data.DeleteAll(lo.Map(r.findByScene(sid, lo.ToPtr(true)), func(h *history.History, _ int) id.HistoryID {
+		return h.ID()
+	})...)
+	return nil
+}
+
+func (r *SceneHistory) RemoveByScene(_ context.Context, sid id.SceneID) error {
+	if !r.f.CanWrite(sid) {
+		return nil
+	}
+	r.data.DeleteAll(lo.Map(r.findByScene(sid, nil), func(h *history.History, _ int) id.HistoryID {
+		return h.ID()
+	})...)
+	return nil
+}
+
+func (r *SceneHistory) findByScene(sid id.SceneID, undone *bool) []*history.History {
+	res := r.data.FindAll(func(_ id.HistoryID, h *history.History) bool {
+		return h.Scene() == sid && (undone == nil || h.Undone() == *undone)
+	})
+	slices.SortFunc(res, func(a, b *history.History) int {
+		return a.ID().Compare(b.ID())
+	})
+	return res
+}
+
+func (r *SceneHistory) hasAfter(sid id.SceneID, c usecasex.Cursor) bool {
+	_, ok := lo.Find(r.findByScene(sid, nil), func(h *history.History) bool { return h.ID().String() > string(c) })
+	return ok
+}
+
+func (r *SceneHistory) hasBefore(sid id.SceneID, c usecasex.Cursor) bool {
+	_, ok := lo.Find(r.findByScene(sid, nil), func(h *history.History) bool { return h.ID().String() < string(c) })
+	return ok
+}
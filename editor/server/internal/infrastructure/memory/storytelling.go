@@ -68,10 +68,9 @@ func (r *Storytelling) FindByScene(_ context.Context, sId id.SceneID) (*storytel
 	for _, s := range r.data {
 		if s.Scene() == sId {
 			result = append(result, s)
-			continue
 		}
-		result = append(result, nil)
 	}
+	result.Sort()
 	return &result, nil
 }
 
@@ -90,6 +89,31 @@ func (r *Storytelling) FindByPublicName(ctx context.Context, name string) (*stor
 	return nil, rerror.ErrNotFound
 }
 
+func (r *Storytelling) FindDuePublishSchedules(_ context.Context, now time.Time) (*storytelling.StoryList, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var res storytelling.StoryList
+	for _, s := range r.data {
+		if ps := s.PublishSchedule(); ps.IsPublishDue(now) || ps.IsUnpublishDue(now) {
+			res = append(res, s)
+		}
+	}
+	return &res, nil
+}
+
+func (r *Storytelling) CountPublicByScenes(_ context.Context, ids id.SceneIDList) (n int, _ error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, s := range r.data {
+		if ids.Has(s.Scene()) && r.f.CanRead(s.Scene()) && (s.PublishmentStatus() == storytelling.PublishmentStatusPublic || s.PublishmentStatus() == storytelling.PublishmentStatusLimited) {
+			n++
+		}
+	}
+	return
+}
+
 func (r *Storytelling) Save(_ context.Context, p storytelling.Story) error {
 	if !r.f.CanWrite(p.Scene()) {
 		return repo.ErrOperationDenied
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/stylelib"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+type StyleLibrary struct {
+	data *util.SyncMap[id.LibraryStyleID, *stylelib.Style]
+	f    repo.WorkspaceFilter
+}
+
+func NewStyleLibrary() *StyleLibrary {
+	return &StyleLibrary{
+		data: util.SyncMapFrom[id.LibraryStyleID, *stylelib.Style](nil),
+	}
+}
+
+func (r *StyleLibrary) Filtered(f repo.WorkspaceFilter) repo.StyleLibrary {
+	return &StyleLibrary{
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *StyleLibrary) FindByID(_ context.Context, id id.LibraryStyleID) (*stylelib.Style, error) {
+	s, ok := r.data.Load(id)
+	if ok && r.f.CanRead(s.Workspace()) {
+		return s, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *StyleLibrary) FindByIDs(_ context.Context, ids id.LibraryStyleIDList) ([]*stylelib.Style, error) {
+	res := make([]*stylelib.Style, 0, len(ids))
+	for _, id := range ids {
+		s, ok := r.data.Load(id)
+		if !ok || !r.f.CanRead(s.Workspace()) {
+			s = nil
+		}
+		res = append(res, s)
+	}
+	return res, nil
+}
+
+func (r *StyleLibrary) FindByWorkspace(_ context.Context, wid accountdomain.WorkspaceID) ([]*stylelib.Style, error) {
+	if !r.f.CanRead(wid) {
+		return nil, nil
+	}
+
+	res := r.data.FindAll(func(_ id.LibraryStyleID, v *stylelib.Style) bool {
+		return v.Workspace() == wid
+	})
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID().Compare(res[j].ID()) < 0
+	})
+	return res, nil
+}
+
+func (r *StyleLibrary) Save(_ context.Context, s *stylelib.Style) error {
+	if !r.f.CanWrite(s.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+	r.data.Store(s.ID(), s)
+	return nil
+}
+
+func (r *StyleLibrary) Remove(_ context.Context, id id.LibraryStyleID) error {
+	if s, ok := r.data.Load(id); ok && r.f.CanWrite(s.Workspace()) {
+		r.data.Delete(id)
+	}
+	return nil
+}
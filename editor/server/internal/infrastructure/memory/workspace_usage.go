@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/usage"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+type WorkspaceUsage struct {
+	data *util.SyncMap[accountdomain.WorkspaceID, *usage.Usage]
+	f    repo.WorkspaceFilter
+}
+
+func NewWorkspaceUsage() *WorkspaceUsage {
+	return &WorkspaceUsage{
+		data: util.SyncMapFrom[accountdomain.WorkspaceID, *usage.Usage](nil),
+	}
+}
+
+func (r *WorkspaceUsage) Filtered(f repo.WorkspaceFilter) repo.WorkspaceUsage {
+	return &WorkspaceUsage{
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *WorkspaceUsage) FindByWorkspace(_ context.Context, wid accountdomain.WorkspaceID) (*usage.Usage, error) {
+	u, ok := r.data.Load(wid)
+	if ok && r.f.CanRead(wid) {
+		return u, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *WorkspaceUsage) FindUpdatedBefore(_ context.Context, t time.Time) ([]*usage.Usage, error) {
+	return r.data.FindAll(func(wid accountdomain.WorkspaceID, u *usage.Usage) bool {
+		return r.f.CanRead(wid) && u.UpdatedAt().Before(t)
+	}), nil
+}
+
+func (r *WorkspaceUsage) Save(_ context.Context, u *usage.Usage) error {
+	if !r.f.CanWrite(u.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+	r.data.Store(u.Workspace(), u)
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
 
 	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
@@ -13,12 +14,13 @@ import (
 	"github.com/reearth/reearthx/mongox"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 var (
-	assetIndexes       = []string{"team"}
+	assetIndexes       = []string{"team", "folder", "tags"}
 	assetUniqueIndexes = []string{"id"}
 )
 
@@ -77,9 +79,48 @@ func (r *Asset) FindByWorkspace(ctx context.Context, id accountdomain.WorkspaceI
 		})
 	}
 
+	if uFilter.Folder != nil {
+		var folder any = *uFilter.Folder
+		if *uFilter.Folder == "" {
+			// the root folder is not stored
+			folder = bson.M{"$in": []any{"", nil}}
+		}
+		filter = mongox.And(filter, "folder", folder)
+	}
+
+	if uFilter.Tag != nil {
+		filter = mongox.And(filter, "tags", *uFilter.Tag)
+	}
+
 	return r.paginate(ctx, filter, uFilter.Sort, uFilter.Pagination)
 }
 
+func (r *Asset) FindFolders(ctx context.Context, wid accountdomain.WorkspaceID) ([]string, error) {
+	return r.distinct(ctx, wid, "folder")
+}
+
+func (r *Asset) FindTags(ctx context.Context, wid accountdomain.WorkspaceID) ([]string, error) {
+	return r.distinct(ctx, wid, "tags")
+}
+
+func (r *Asset) distinct(ctx context.Context, wid accountdomain.WorkspaceID, field string) ([]string, error) {
+	if !r.f.CanRead(wid) {
+		return nil, nil
+	}
+
+	values, err := r.client.Client().Distinct(ctx, field, bson.M{"team": wid.String()})
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+
+	res := lo.FilterMap(values, func(v any, _ int) (string, bool) {
+		s, ok := v.(string)
+		return s, ok && s != ""
+	})
+	sort.Strings(res)
+	return res, nil
+}
+
 func (r *Asset) TotalSizeByWorkspace(ctx context.Context, wid accountdomain.WorkspaceID) (int64, error) {
 	if !r.f.CanRead(wid) {
 		return 0, repo.ErrOperationDenied
@@ -10,6 +10,7 @@ import (
 	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/mongox"
 	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -91,3 +92,38 @@ func TestAsset_TotalSizeByWorkspace(t *testing.T) {
 	assert.Equal(t, repo.ErrOperationDenied, err)
 	assert.Zero(t, got)
 }
+
+func TestAsset_FindByWorkspace_FolderAndTag(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+	wid := accountdomain.NewWorkspaceID()
+	newAsset := func(folder string, tags ...string) *asset.Asset {
+		return asset.New().NewID().Workspace(wid).URL("https://example.com/a").Size(1).Folder(folder).Tags(tags).MustBuild()
+	}
+	a1 := newAsset("", "x")
+	a2 := newAsset("maps", "x", "y")
+	a3 := newAsset("maps/2024")
+
+	r := NewAsset(mongox.NewClientWithDatabase(c))
+	for _, a := range []*asset.Asset{a1, a2, a3} {
+		assert.NoError(t, r.Save(ctx, a))
+	}
+
+	ids := func(folder, tag *string) []asset.ID {
+		res, _, err := r.FindByWorkspace(ctx, wid, repo.AssetFilter{Folder: folder, Tag: tag})
+		assert.NoError(t, err)
+		return lo.Map(res, func(a *asset.Asset, _ int) asset.ID { return a.ID() })
+	}
+
+	assert.ElementsMatch(t, []asset.ID{a1.ID()}, ids(lo.ToPtr(""), nil))
+	assert.ElementsMatch(t, []asset.ID{a2.ID()}, ids(lo.ToPtr("maps"), nil))
+	assert.ElementsMatch(t, []asset.ID{a1.ID(), a2.ID()}, ids(nil, lo.ToPtr("x")))
+
+	folders, err := r.FindFolders(ctx, wid)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"maps", "maps/2024"}, folders)
+
+	tags, err := r.FindTags(ctx, wid)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"x", "y"}, tags)
+}
@@ -44,15 +44,19 @@ func New(ctx context.Context, db *mongo.Database, account *accountrepo.Container
 		Layer:          NewLayer(client),
 		NLSLayer:       NewNLSLayer(client),
 		Style:          NewStyle(client),
+		StyleLibrary:   NewStyleLibrary(client),
+		PluginKey:      NewPluginKey(client),
 		Plugin:         NewPlugin(client),
 		Project:        NewProject(client),
 		PropertySchema: NewPropertySchema(client),
 		Property:       NewProperty(client),
 		Scene:          NewScene(client),
+		SceneHistory:   NewSceneHistory(client),
 		Tag:            NewTag(client),
 		SceneLock:      NewSceneLock(client),
 		Policy:         NewPolicy(client),
 		Storytelling:   NewStorytelling(client),
+		WorkspaceUsage: NewWorkspaceUsage(client),
 		Lock:           lock,
 		Transaction:    client.Transaction(),
 		Workspace:      account.Workspace,
@@ -128,9 +132,13 @@ func Init(r *repo.Container) error {
 		func() error { return r.Property.(*Property).Init(ctx) },
 		func() error { return r.PropertySchema.(*PropertySchema).Init(ctx) },
 		func() error { return r.Scene.(*Scene).Init(ctx) },
+		func() error { return r.SceneHistory.(*SceneHistory).Init(ctx) },
+		func() error { return r.StyleLibrary.(*StyleLibrary).Init(ctx) },
+		func() error { return r.PluginKey.(*PluginKey).Init(ctx) },
 		func() error { return r.Tag.(*Tag).Init(ctx) },
 		func() error { return r.User.(*accountmongo.User).Init() },
 		func() error { return r.Workspace.(*accountmongo.Workspace).Init() },
+		func() error { return r.WorkspaceUsage.(*WorkspaceUsage).Init(ctx) },
 	)
 }
 
@@ -17,6 +17,16 @@ type AssetDocument struct {
 	Size        int64
 	URL         string
 	ContentType string
+	Folder      string                 `bson:",omitempty"`
+	Tags        []string               `bson:",omitempty"`
+	Metadata    *AssetMetadataDocument `bson:",omitempty"`
+}
+
+type AssetMetadataDocument struct {
+	Width        int       `bson:",omitempty"`
+	Height       int       `bson:",omitempty"`
+	FeatureCount int       `bson:",omitempty"`
+	BBox         []float64 `bson:",omitempty"`
 }
 
 type AssetConsumer = Consumer[*AssetDocument, *asset.Asset]
@@ -37,9 +47,42 @@ func NewAsset(asset *asset.Asset) (*AssetDocument, string) {
 		Size:        asset.Size(),
 		URL:         asset.URL(),
 		ContentType: asset.ContentType(),
+		Folder:      asset.Folder(),
+		Tags:        asset.Tags(),
+		Metadata:    newAssetMetadata(asset.Metadata()),
 	}, aid
 }
 
+func newAssetMetadata(m *asset.Metadata) *AssetMetadataDocument {
+	if m == nil {
+		return nil
+	}
+	doc := &AssetMetadataDocument{
+		Width:        m.Width,
+		Height:       m.Height,
+		FeatureCount: m.FeatureCount,
+	}
+	if m.BBox != nil {
+		doc.BBox = m.BBox[:]
+	}
+	return doc
+}
+
+func (d *AssetMetadataDocument) Model() *asset.Metadata {
+	if d == nil {
+		return nil
+	}
+	m := &asset.Metadata{
+		Width:        d.Width,
+		Height:       d.Height,
+		FeatureCount: d.FeatureCount,
+	}
+	if len(d.BBox) == 4 {
+		m.BBox = &asset.BBox{d.BBox[0], d.BBox[1], d.BBox[2], d.BBox[3]}
+	}
+	return m
+}
+
 func (d *AssetDocument) Model() (*asset.Asset, error) {
 	aid, err := id.AssetIDFrom(d.ID)
 	if err != nil {
@@ -58,5 +101,8 @@ func (d *AssetDocument) Model() (*asset.Asset, error) {
 		Size(d.Size).
 		URL(d.URL).
 		ContentType(d.ContentType).
+		Folder(d.Folder).
+		Tags(d.Tags).
+		Metadata(d.Metadata.Model()).
 		Build()
 }
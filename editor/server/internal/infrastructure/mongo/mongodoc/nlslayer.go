@@ -21,6 +21,7 @@ type NLSLayerDocument struct {
 	Group     *NLSLayerGroupDocument
 	IsSketch  bool
 	Sketch    *NLSLayerSketchInfoDocument
+	Cluster   *string
 }
 
 type NLSLayerSimpleDocument struct {
@@ -105,13 +106,13 @@ func NewNLSLayer(l nlslayer.NLSLayer) (*NLSLayerDocument, string) {
 		group = &NLSLayerGroupDocument{
 			Children: lg.Children().Strings(),
 			Root:     lg.IsRoot(),
-			Config:   *lg.Config(),
+			Config:   newNLSLayerConfigDocument(lg.Config()),
 		}
 	}
 
 	if ls := nlslayer.NLSLayerSimpleFromLayer(l); ls != nil {
 		simple = &NLSLayerSimpleDocument{
-			Config: *ls.Config(),
+			Config: newNLSLayerConfigDocument(ls.Config()),
 		}
 	}
 
@@ -127,6 +128,7 @@ func NewNLSLayer(l nlslayer.NLSLayer) (*NLSLayerDocument, string) {
 		Simple:    simple,
 		IsSketch:  l.IsSketch(),
 		Sketch:    NewNLSLayerSketchInfo(l.Sketch()),
+		Cluster:   l.Cluster().StringRef(),
 	}, id
 }
 
@@ -195,6 +197,7 @@ func (d *NLSLayerDocument) ModelSimple() (*nlslayer.NLSLayerSimple, error) {
 		Config(NewNLSLayerConfig(d.Simple.Config)).
 		IsSketch(d.IsSketch).
 		Sketch(sketchInfo).
+		Cluster(id.ClusterIDFromRef(d.Cluster)).
 		Build()
 }
 
@@ -235,9 +238,10 @@ func (d *NLSLayerDocument) ModelGroup() (*nlslayer.NLSLayerGroup, error) {
 		// group
 		Root(d.Group != nil && d.Group.Root).
 		Layers(nlslayer.NewIDList(ids)).
-		Config(NewNLSLayerConfig(d.Simple.Config)).
+		Config(NewNLSLayerConfig(d.Group.Config)).
 		IsSketch(d.IsSketch).
 		Sketch(sketchInfo).
+		Cluster(id.ClusterIDFromRef(d.Cluster)).
 		Build()
 }
 
@@ -249,6 +253,13 @@ func NewNLSLayerType(p string) nlslayer.LayerType {
 	return lt
 }
 
+func newNLSLayerConfigDocument(c *nlslayer.Config) map[string]any {
+	if c == nil {
+		return nil
+	}
+	return *c
+}
+
 func NewNLSLayerConfig(c map[string]any) *nlslayer.Config {
 	config := nlslayer.Config(c)
 	return &config
@@ -3,6 +3,7 @@ package mongodoc
 import (
 	"testing"
 
+	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/nlslayer"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -416,3 +417,29 @@ func TestNewNLSLayerGeometry(t *testing.T) {
 		})
 	}
 }
+
+func TestNLSLayerDocument_ModelGroup(t *testing.T) {
+	sid := id.NewSceneID()
+	children := []id.NLSLayerID{id.NewNLSLayerID(), id.NewNLSLayerID()}
+	lg := nlslayer.NewNLSLayerGroup().NewID().Scene(sid).Title("group").LayerType(nlslayer.Group).
+		IsVisible(true).
+		Layers(nlslayer.NewIDList(children)).
+		Config(&nlslayer.Config{"key": "value"}).
+		MustBuild()
+
+	doc, _ := NewNLSLayer(lg)
+	assert.Nil(t, doc.Simple)
+	got, err := doc.Model()
+	assert.NoError(t, err)
+
+	g := nlslayer.NLSLayerGroupFromLayer(got)
+	assert.NotNil(t, g)
+	assert.Equal(t, lg.ID(), g.ID())
+	assert.Equal(t, children, g.Children().Layers())
+	assert.Equal(t, &nlslayer.Config{"key": "value"}, g.Config())
+
+	// a group without config
+	doc, _ = NewNLSLayer(nlslayer.NewNLSLayerGroup().NewID().Scene(sid).MustBuild())
+	_, err = doc.Model()
+	assert.NoError(t, err)
+}
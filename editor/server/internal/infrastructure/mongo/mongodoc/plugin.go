@@ -14,7 +14,19 @@ type PluginDocument struct {
 	RepositoryURL string
 	Extensions    []PluginExtensionDocument
 	Schema        *string
-	Scene         *string `bson:",omitempty"`
+	Scene         *string                    `bson:",omitempty"`
+	Permissions   *PluginPermissionsDocument `bson:",omitempty"`
+	Signature     *PluginSignatureDocument   `bson:",omitempty"`
+}
+
+type PluginPermissionsDocument struct {
+	Network []string
+	Layers  string
+}
+
+type PluginSignatureDocument struct {
+	Digest []byte
+	Value  []byte
 }
 
 type PluginExtensionDocument struct {
@@ -87,6 +99,8 @@ func NewPlugin(plugin *plugin.Plugin) (*PluginDocument, string) {
 		Extensions:    extensionsDoc,
 		Schema:        plugin.Schema().StringRef(),
 		Scene:         plugin.ID().Scene().StringRef(),
+		Permissions:   NewPluginPermissions(plugin.Permissions()),
+		Signature:     NewPluginSignature(plugin.Signature()),
 	}, pid
 }
 
@@ -130,9 +144,45 @@ func (d *PluginDocument) Model() (*plugin.Plugin, error) {
 		RepositoryURL(d.RepositoryURL).
 		Extensions(extensions).
 		Schema(id.PropertySchemaIDFromRef(d.Schema)).
+		Permissions(d.Permissions.Model()).
+		Signature(d.Signature.Model()).
 		Build()
 }
 
+func NewPluginPermissions(p *plugin.Permissions) *PluginPermissionsDocument {
+	if p == nil {
+		return nil
+	}
+	return &PluginPermissionsDocument{
+		Network: p.Network(),
+		Layers:  string(p.Layers()),
+	}
+}
+
+func (d *PluginPermissionsDocument) Model() *plugin.Permissions {
+	if d == nil {
+		return nil
+	}
+	return plugin.NewPermissions(d.Network, plugin.LayerAccess(d.Layers))
+}
+
+func NewPluginSignature(s *plugin.Signature) *PluginSignatureDocument {
+	if s == nil {
+		return nil
+	}
+	return &PluginSignatureDocument{
+		Digest: s.Digest(),
+		Value:  s.Value(),
+	}
+}
+
+func (d *PluginSignatureDocument) Model() *plugin.Signature {
+	if d == nil {
+		return nil
+	}
+	return plugin.NewSignature(d.Digest, d.Value)
+}
+
 func NewWidgetLayout(l *plugin.WidgetLayout) *WidgetLayoutDocument {
 	if l == nil {
 		return nil
@@ -0,0 +1,51 @@
+package mongodoc
+
+import (
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/pluginkey"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"golang.org/x/exp/slices"
+)
+
+type PluginKeyDocument struct {
+	ID        string
+	Workspace string
+	Name      string
+	PublicKey []byte
+}
+
+type PluginKeyConsumer = Consumer[*PluginKeyDocument, *pluginkey.Key]
+
+func NewPluginKeyConsumer(workspaces []accountdomain.WorkspaceID) *PluginKeyConsumer {
+	return NewConsumer[*PluginKeyDocument, *pluginkey.Key](func(a *pluginkey.Key) bool {
+		return workspaces == nil || slices.Contains(workspaces, a.Workspace())
+	})
+}
+
+func NewPluginKey(k *pluginkey.Key) (*PluginKeyDocument, string) {
+	kid := k.ID().String()
+	return &PluginKeyDocument{
+		ID:        kid,
+		Workspace: k.Workspace().String(),
+		Name:      k.Name(),
+		PublicKey: k.PublicKey(),
+	}, kid
+}
+
+func (d *PluginKeyDocument) Model() (*pluginkey.Key, error) {
+	kid, err := id.PluginKeyIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	wid, err := accountdomain.WorkspaceIDFrom(d.Workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	return pluginkey.New().
+		ID(kid).
+		Workspace(wid).
+		Name(d.Name).
+		PublicKey(d.PublicKey).
+		Build()
+}
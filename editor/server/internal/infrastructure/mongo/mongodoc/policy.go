@@ -15,6 +15,11 @@ type PolicyDocument struct {
 	DatasetCount          *int
 	DatasetSchemaCount    *int
 	AssetStorageSize      *int64
+	NLSLayerCount         *int
+	SketchFeatureCount    *int
+	StoryCount            *int
+	StoryPageCount        *int
+	PublishedStoryCount   *int
 }
 
 func (d PolicyDocument) Model() *policy.Policy {
@@ -28,6 +33,11 @@ func (d PolicyDocument) Model() *policy.Policy {
 		DatasetCount:          d.DatasetCount,
 		DatasetSchemaCount:    d.DatasetSchemaCount,
 		AssetStorageSize:      d.AssetStorageSize,
+		NLSLayerCount:         d.NLSLayerCount,
+		SketchFeatureCount:    d.SketchFeatureCount,
+		StoryCount:            d.StoryCount,
+		StoryPageCount:        d.StoryPageCount,
+		PublishedStoryCount:   d.PublishedStoryCount,
 	})
 }
 
@@ -33,6 +33,10 @@ type ProjectDocument struct {
 	CoreSupport       bool
 	EnableGA          bool
 	TrackingID        string
+	ScheduledAlias    string     `bson:",omitempty"`
+	ScheduledStatus   string     `bson:",omitempty"`
+	PublishAt         *time.Time `bson:",omitempty"`
+	UnpublishAt       *time.Time `bson:",omitempty"`
 	// Scene             string
 }
 
@@ -74,6 +78,10 @@ func NewProject(project *project.Project) (*ProjectDocument, string) {
 		CoreSupport:       project.CoreSupport(),
 		EnableGA:          project.EnableGA(),
 		TrackingID:        project.TrackingID(),
+		ScheduledAlias:    project.PublishSchedule().Alias(),
+		ScheduledStatus:   string(project.PublishSchedule().Status()),
+		PublishAt:         project.PublishSchedule().PublishAt(),
+		UnpublishAt:       project.PublishSchedule().UnpublishAt(),
 		// Scene:             project.Scene().String(),
 	}, pid
 }
@@ -100,6 +108,11 @@ func (d *ProjectDocument) Model() (*project.Project, error) {
 		}
 	}
 
+	schedule, err := project.NewPublishSchedule(d.ScheduledAlias, project.PublishmentStatus(d.ScheduledStatus), d.PublishAt, d.UnpublishAt)
+	if err != nil {
+		return nil, err
+	}
+
 	return project.New().
 		ID(pid).
 		IsArchived(d.Archived).
@@ -122,6 +135,7 @@ func (d *ProjectDocument) Model() (*project.Project, error) {
 		CoreSupport(d.CoreSupport).
 		EnableGA(d.EnableGA).
 		TrackingID(d.TrackingID).
+		PublishSchedule(schedule).
 		// Scene(scene).
 		Build()
 }
@@ -23,6 +23,7 @@ type SceneDocument struct {
 	UpdateAt    time.Time
 	Property    string
 	Clusters    []SceneClusterDocument
+	Template    bool
 }
 
 type SceneWidgetDocument struct {
@@ -125,6 +126,7 @@ func NewScene(scene *scene.Scene) (*SceneDocument, string) {
 		UpdateAt:    scene.UpdatedAt(),
 		Property:    scene.Property().String(),
 		Clusters:    clsuterDoc,
+		Template:    scene.Template(),
 	}, id
 }
 
@@ -217,6 +219,7 @@ func (d *SceneDocument) Model() (*scene.Scene, error) {
 		Plugins(scene.NewPlugins(ps)).
 		UpdatedAt(d.UpdateAt).
 		Property(prid).
+		Template(d.Template).
 		Build()
 }
 
@@ -0,0 +1,84 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/history"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+)
+
+type SceneHistoryDocument struct {
+	ID        string
+	Scene     string
+	User      *string
+	Operation string
+	Changes   []SceneHistoryChangeDocument
+	CreatedAt time.Time
+	Undone    bool
+}
+
+type SceneHistoryChangeDocument struct {
+	Type   string
+	ID     string
+	Before *string
+	After  *string
+}
+
+type SceneHistoryConsumer = Consumer[*SceneHistoryDocument, *history.History]
+
+func NewSceneHistoryConsumer(scenes []id.SceneID) *SceneHistoryConsumer {
+	return NewConsumer[*SceneHistoryDocument, *history.History](func(a *history.History) bool {
+		return scenes == nil || slices.Contains(scenes, a.Scene())
+	})
+}
+
+func NewSceneHistory(h *history.History) (*SceneHistoryDocument, string) {
+	hid := h.ID().String()
+	return &SceneHistoryDocument{
+		ID:        hid,
+		Scene:     h.Scene().String(),
+		User:      h.User().StringRef(),
+		Operation: h.Operation(),
+		Changes: lo.Map(h.Changes(), func(c history.Change, _ int) SceneHistoryChangeDocument {
+			return SceneHistoryChangeDocument{
+				Type:   string(c.Type),
+				ID:     c.ID,
+				Before: c.Before,
+				After:  c.After,
+			}
+		}),
+		CreatedAt: h.CreatedAt(),
+		Undone:    h.Undone(),
+	}, hid
+}
+
+func (d *SceneHistoryDocument) Model() (*history.History, error) {
+	hid, err := id.HistoryIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	sid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+
+	return history.New().
+		ID(hid).
+		Scene(sid).
+		User(accountdomain.UserIDFromRef(d.User)).
+		Operation(d.Operation).
+		Changes(lo.Map(d.Changes, func(c SceneHistoryChangeDocument, _ int) history.Change {
+			return history.Change{
+				Type:   history.EntityType(c.Type),
+				ID:     c.ID,
+				Before: c.Before,
+				After:  c.After,
+			}
+		})).
+		CreatedAt(d.CreatedAt).
+		Undone(d.Undone).
+		Build()
+}
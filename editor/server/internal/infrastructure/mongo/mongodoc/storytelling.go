@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
 	"github.com/reearth/reearth/server/pkg/storytelling"
 	"github.com/samber/lo"
 	"golang.org/x/exp/slices"
@@ -31,6 +32,11 @@ type StorytellingDocument struct {
 	PublicDescription string
 	PublicImage       string
 	PublicNoIndex     bool
+
+	ScheduledAlias  string     `bson:",omitempty"`
+	ScheduledStatus string     `bson:",omitempty"`
+	PublishAt       *time.Time `bson:",omitempty"`
+	UnpublishAt     *time.Time `bson:",omitempty"`
 }
 
 type PageDocument struct {
@@ -71,7 +77,7 @@ func NewStorytelling(s *storytelling.Story) (*StorytellingDocument, string) {
 		Status:        string(s.Status()),
 		PublishedAt:   s.PublishedAt(),
 		UpdatedAt:     s.UpdatedAt(),
-		Index:         1,
+		Index:         s.Index(),
 		PanelPosition: string(s.PanelPosition()),
 		BgColor:       s.BgColor(),
 
@@ -82,6 +88,11 @@ func NewStorytelling(s *storytelling.Story) (*StorytellingDocument, string) {
 		PublicDescription: s.PublicDescription(),
 		PublicImage:       s.PublicImage(),
 		PublicNoIndex:     s.PublicNoIndex(),
+
+		ScheduledAlias:  s.PublishSchedule().Alias(),
+		ScheduledStatus: string(s.PublishSchedule().Status()),
+		PublishAt:       s.PublishSchedule().PublishAt(),
+		UnpublishAt:     s.PublishSchedule().UnpublishAt(),
 	}, sId
 }
 
@@ -173,6 +184,11 @@ func (d *StorytellingDocument) Model() (*storytelling.Story, error) {
 		return nil, err
 	}
 
+	schedule, err := project.NewPublishSchedule(d.ScheduledAlias, storytelling.PublishmentStatus(d.ScheduledStatus), d.PublishAt, d.UnpublishAt)
+	if err != nil {
+		return nil, err
+	}
+
 	s, err := storytelling.NewStory().
 		ID(sid).
 		Property(property).
@@ -184,12 +200,14 @@ func (d *StorytellingDocument) Model() (*storytelling.Story, error) {
 		BgColor(d.BgColor).
 		PublishedAt(d.PublishedAt).
 		UpdatedAt(d.UpdatedAt).
+		Index(d.Index).
 		Pages(storytelling.NewPageList(pages)).
 		PublicBasicAuth(d.IsBasicAuthActive, d.BasicAuthUsername, d.BasicAuthPassword).
 		PublicTitle(d.PublicTitle).
 		PublicDescription(d.PublicDescription).
 		PublicImage(d.PublicImage).
 		PublicNoIndex(d.PublicNoIndex).
+		PublishSchedule(schedule).
 		Build()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,109 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/stylelib"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+)
+
+type StyleLibraryDocument struct {
+	ID        string
+	Workspace string
+	Name      string
+	Versions  []StyleLibraryVersionDocument
+}
+
+type StyleLibraryVersionDocument struct {
+	Version   int
+	Value     map[string]any
+	User      *string `bson:",omitempty"`
+	CreatedAt time.Time
+}
+
+type StyleLibraryConsumer = Consumer[*StyleLibraryDocument, *stylelib.Style]
+
+func NewStyleLibraryConsumer(workspaces []accountdomain.WorkspaceID) *StyleLibraryConsumer {
+	return NewConsumer[*StyleLibraryDocument, *stylelib.Style](func(a *stylelib.Style) bool {
+		return workspaces == nil || slices.Contains(workspaces, a.Workspace())
+	})
+}
+
+func NewStyleLibrary(s *stylelib.Style) (*StyleLibraryDocument, string) {
+	sid := s.ID().String()
+	return &StyleLibraryDocument{
+		ID:        sid,
+		Workspace: s.Workspace().String(),
+		Name:      s.Name(),
+		Versions: lo.Map(s.Versions(), func(v *stylelib.Version, _ int) StyleLibraryVersionDocument {
+			var value map[string]any
+			if sv := v.Value(); sv != nil {
+				value = *sv
+			}
+			return StyleLibraryVersionDocument{
+				Version:   v.Version(),
+				Value:     value,
+				User:      v.User().StringRef(),
+				CreatedAt: v.CreatedAt(),
+			}
+		}),
+	}, sid
+}
+
+func (d *StyleLibraryDocument) Model() (*stylelib.Style, error) {
+	sid, err := id.LibraryStyleIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	wid, err := accountdomain.WorkspaceIDFrom(d.Workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*stylelib.Version, 0, len(d.Versions))
+	for _, v := range d.Versions {
+		versions = append(versions, stylelib.NewVersion(
+			v.Version,
+			NewStyleValue(v.Value),
+			accountdomain.UserIDFromRef(v.User),
+			v.CreatedAt,
+		))
+	}
+
+	return stylelib.New().
+		ID(sid).
+		Workspace(wid).
+		Name(d.Name).
+		Versions(versions).
+		Build()
+}
+
+type StyleLinkDocument struct {
+	Library string
+	Version int
+}
+
+func NewStyleLink(l *scene.StyleLink) *StyleLinkDocument {
+	if l == nil {
+		return nil
+	}
+	return &StyleLinkDocument{
+		Library: l.Library().String(),
+		Version: l.Version(),
+	}
+}
+
+func (d *StyleLinkDocument) Model() *scene.StyleLink {
+	if d == nil {
+		return nil
+	}
+	lid, err := id.LibraryStyleIDFrom(d.Library)
+	if err != nil {
+		return nil
+	}
+	return scene.NewStyleLink(lid, d.Version)
+}
@@ -0,0 +1,89 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/usage"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"golang.org/x/exp/slices"
+)
+
+// WorkspaceUsageDocument is identified by the ID of the workspace.
+type WorkspaceUsageDocument struct {
+	ID               string
+	AssetStorageSize int64
+	Projects         []WorkspaceUsageProjectDocument
+	UpdatedAt        time.Time
+}
+
+type WorkspaceUsageProjectDocument struct {
+	ID                  string
+	Name                string
+	Scene               *string
+	Published           bool
+	LayerCount          int
+	NLSLayerCount       int
+	StoryCount          int
+	PublishedStoryCount int
+}
+
+type WorkspaceUsageConsumer = Consumer[*WorkspaceUsageDocument, *usage.Usage]
+
+func NewWorkspaceUsageConsumer(workspaces []accountdomain.WorkspaceID) *WorkspaceUsageConsumer {
+	return NewConsumer[*WorkspaceUsageDocument, *usage.Usage](func(u *usage.Usage) bool {
+		return workspaces == nil || slices.Contains(workspaces, u.Workspace())
+	})
+}
+
+func NewWorkspaceUsage(u *usage.Usage) (*WorkspaceUsageDocument, string) {
+	wid := u.Workspace().String()
+	projects := u.Projects()
+	docs := make([]WorkspaceUsageProjectDocument, 0, len(projects))
+	for _, p := range projects {
+		docs = append(docs, WorkspaceUsageProjectDocument{
+			ID:                  p.ID.String(),
+			Name:                p.Name,
+			Scene:               p.Scene.StringRef(),
+			Published:           p.Published,
+			LayerCount:          p.LayerCount,
+			NLSLayerCount:       p.NLSLayerCount,
+			StoryCount:          p.StoryCount,
+			PublishedStoryCount: p.PublishedStoryCount,
+		})
+	}
+
+	return &WorkspaceUsageDocument{
+		ID:               wid,
+		AssetStorageSize: u.AssetStorageSize(),
+		Projects:         docs,
+		UpdatedAt:        u.UpdatedAt(),
+	}, wid
+}
+
+func (d *WorkspaceUsageDocument) Model() (*usage.Usage, error) {
+	wid, err := accountdomain.WorkspaceIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]usage.Project, 0, len(d.Projects))
+	for _, p := range d.Projects {
+		pid, err := id.ProjectIDFrom(p.ID)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, usage.Project{
+			ID:                  pid,
+			Name:                p.Name,
+			Scene:               id.SceneIDFromRef(p.Scene),
+			Published:           p.Published,
+			LayerCount:          p.LayerCount,
+			NLSLayerCount:       p.NLSLayerCount,
+			StoryCount:          p.StoryCount,
+			PublishedStoryCount: p.PublishedStoryCount,
+		})
+	}
+
+	return usage.New(wid, d.AssetStorageSize, projects, d.UpdatedAt), nil
+}
@@ -235,7 +235,11 @@ func (r *NLSLayer) findNLSLayerGroupOne(ctx context.Context, filter interface{})
 	if len(c.Result) == 0 {
 		return nil, rerror.ErrNotFound
 	}
-	return nlslayer.ToNLSLayerGroup(c.Result[0]), nil
+	lg := nlslayer.ToNLSLayerGroup(c.Result[0])
+	if lg == nil {
+		return nil, rerror.ErrNotFound
+	}
+	return lg, nil
 }
 
 func (r *NLSLayer) findNLSLayerSimples(ctx context.Context, dst nlslayer.NLSLayerSimpleList, filter interface{}) (nlslayer.NLSLayerSimpleList, error) {
@@ -0,0 +1,75 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/pluginkey"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	pluginKeyIndexes       = []string{"workspace"}
+	pluginKeyUniqueIndexes = []string{"id"}
+)
+
+type PluginKey struct {
+	client *mongox.ClientCollection
+	f      repo.WorkspaceFilter
+}
+
+func NewPluginKey(client *mongox.Client) *PluginKey {
+	return &PluginKey{client: client.WithCollection("pluginKey")}
+}
+
+func (r *PluginKey) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, pluginKeyIndexes, pluginKeyUniqueIndexes)
+}
+
+func (r *PluginKey) Filtered(f repo.WorkspaceFilter) repo.PluginKey {
+	return &PluginKey{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *PluginKey) FindByID(ctx context.Context, id id.PluginKeyID) (*pluginkey.Key, error) {
+	c := mongodoc.NewPluginKeyConsumer(r.f.Readable)
+	if err := r.client.FindOne(ctx, bson.M{"id": id.String()}, c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *PluginKey) FindByWorkspace(ctx context.Context, wid accountdomain.WorkspaceID) (pluginkey.List, error) {
+	if !r.f.CanRead(wid) {
+		return nil, nil
+	}
+
+	c := mongodoc.NewPluginKeyConsumer(r.f.Readable)
+	if err := r.client.Find(ctx, bson.M{"workspace": wid.String()}, c); err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, nil
+}
+
+func (r *PluginKey) Save(ctx context.Context, k *pluginkey.Key) error {
+	if !r.f.CanWrite(k.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+	doc, kid := mongodoc.NewPluginKey(k)
+	return r.client.SaveOne(ctx, kid, doc)
+}
+
+func (r *PluginKey) Remove(ctx context.Context, id id.PluginKeyID) error {
+	filter := any(bson.M{"id": id.String()})
+	if r.f.Writable != nil {
+		filter = mongox.And(filter, "workspace", bson.M{"$in": r.f.Writable.Strings()})
+	}
+	return r.client.RemoveOne(ctx, filter)
+}
@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 
@@ -16,7 +17,7 @@ import (
 )
 
 var (
-	projectIndexes       = []string{"alias", "alias,publishmentstatus", "team"}
+	projectIndexes       = []string{"alias", "alias,publishmentstatus", "team", "publishat", "unpublishat"}
 	projectUniqueIndexes = []string{"id"}
 )
 
@@ -102,6 +103,15 @@ func (r *Project) FindByPublicName(ctx context.Context, name string) (*project.P
 	return r.findOne(ctx, f, false)
 }
 
+func (r *Project) FindDuePublishSchedules(ctx context.Context, now time.Time) ([]*project.Project, error) {
+	return r.find(ctx, bson.M{
+		"$or": []bson.M{
+			{"publishat": bson.M{"$lte": now}},
+			{"unpublishat": bson.M{"$lte": now}},
+		},
+	})
+}
+
 func (r *Project) CountByWorkspace(ctx context.Context, ws accountdomain.WorkspaceID) (int, error) {
 	if !r.f.CanRead(ws) {
 		return 0, repo.ErrOperationDenied
@@ -0,0 +1,93 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/history"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	sceneHistoryIndexes       = []string{"scene", "scene,undone"}
+	sceneHistoryUniqueIndexes = []string{"id"}
+)
+
+type SceneHistory struct {
+	client *mongox.ClientCollection
+	f      repo.SceneFilter
+}
+
+func NewSceneHistory(client *mongox.Client) *SceneHistory {
+	return &SceneHistory{client: client.WithCollection("sceneHistory")}
+}
+
+func (r *SceneHistory) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, sceneHistoryIndexes, sceneHistoryUniqueIndexes)
+}
+
+func (r *SceneHistory) Filtered(f repo.SceneFilter) repo.SceneHistory {
+	return &SceneHistory{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *SceneHistory) FindByID(ctx context.Context, id id.HistoryID) (*history.History, error) {
+	return r.findOne(ctx, bson.M{"id": id.String()})
+}
+
+func (r *SceneHistory) FindByScene(ctx context.Context, id id.SceneID, pagination *usecasex.Pagination) ([]*history.History, *usecasex.PageInfo, error) {
+	if !r.f.CanRead(id) {
+		return nil, usecasex.EmptyPageInfo(), nil
+	}
+
+	c := mongodoc.NewSceneHistoryConsumer(r.f.Readable)
+	pageInfo, err := r.client.Paginate(ctx, bson.M{"scene": id.String()}, nil, pagination, c)
+	if err != nil {
+		return nil, nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, pageInfo, nil
+}
+
+func (r *SceneHistory) FindLatestApplied(ctx context.Context, id id.SceneID) (*history.History, error) {
+	return r.findOne(ctx, bson.M{"scene": id.String(), "undone": false}, options.FindOne().SetSort(bson.D{{Key: "id", Value: -1}}))
+}
+
+func (r *SceneHistory) FindEarliestUndone(ctx context.Context, id id.SceneID) (*history.History, error) {
+	return r.findOne(ctx, bson.M{"scene": id.String(), "undone": true}, options.FindOne().SetSort(bson.D{{Key: "id", Value: 1}}))
+}
+
+func (r *SceneHistory) Save(ctx context.Context, h *history.History) error {
+	if !r.f.CanWrite(h.Scene()) {
+		return repo.ErrOperationDenied
+	}
+	doc, id := mongodoc.NewSceneHistory(h)
+	return r.client.SaveOne(ctx, id, doc)
+}
+
+func (r *SceneHistory) RemoveUndone(ctx context.Context, id id.SceneID) error {
+	return r.client.RemoveAll(ctx, r.writeFilter(bson.M{"scene": id.String(), "undone": true}))
+}
+
+func (r *SceneHistory) RemoveByScene(ctx context.Context, id id.SceneID) error {
+	return r.client.RemoveAll(ctx, r.writeFilter(bson.M{"scene": id.String()}))
+}
+
+func (r *SceneHistory) findOne(ctx context.Context, filter any, options ...*options.FindOneOptions) (*history.History, error) {
+	c := mongodoc.NewSceneHistoryConsumer(r.f.Readable)
+	if err := r.client.FindOne(ctx, filter, c, options...); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *SceneHistory) writeFilter(filter interface{}) interface{} {
+	return applySceneFilter(filter, r.f.Writable)
+}
@@ -2,19 +2,22 @@ package mongo
 
 import (
 	"context"
+	"time"
 
 	"github.com/reearth/reearth/server/pkg/storytelling"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearthx/mongox"
 	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
 )
 
 var (
-	storytellingIndexes       = []string{"alias", "alias,status", "scene"}
+	storytellingIndexes       = []string{"alias", "alias,status", "scene", "publishat", "unpublishat"}
 	storytellingUniqueIndexes = []string{"id"}
 )
 
@@ -69,6 +72,15 @@ func (r *Storytelling) FindByScene(ctx context.Context, id id.SceneID) (*storyte
 	}
 	return r.find(ctx, bson.M{
 		"scene": id.String(),
+	}, options.Find().SetSort(bson.D{{Key: "index", Value: 1}, {Key: "id", Value: 1}}))
+}
+
+func (r *Storytelling) FindDuePublishSchedules(ctx context.Context, now time.Time) (*storytelling.StoryList, error) {
+	return r.find(ctx, bson.M{
+		"$or": []bson.M{
+			{"publishat": bson.M{"$lte": now}},
+			{"unpublishat": bson.M{"$lte": now}},
+		},
 	})
 }
 
@@ -85,6 +97,23 @@ func (r *Storytelling) FindByPublicName(ctx context.Context, name string) (*stor
 	return r.findOne(ctx, f, false)
 }
 
+func (r *Storytelling) CountPublicByScenes(ctx context.Context, ids id.SceneIDList) (int, error) {
+	ids = lo.Filter(ids, func(s id.SceneID, _ int) bool { return r.f.CanRead(s) })
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	count, err := r.client.Count(ctx, bson.M{
+		"scene": bson.M{
+			"$in": ids.Strings(),
+		},
+		"status": bson.M{
+			"$in": []string{"public", "limited"},
+		},
+	})
+	return int(count), err
+}
+
 func (r *Storytelling) Save(ctx context.Context, story storytelling.Story) error {
 	if !r.f.CanWrite(story.Scene()) {
 		return repo.ErrOperationDenied
@@ -118,9 +147,9 @@ func (r *Storytelling) RemoveByScene(ctx context.Context, id id.SceneID) error {
 	return r.client.RemoveAll(ctx, r.writeFilter(bson.M{"scene": id.String()}))
 }
 
-func (r *Storytelling) find(ctx context.Context, filter interface{}) (*storytelling.StoryList, error) {
+func (r *Storytelling) find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*storytelling.StoryList, error) {
 	c := mongodoc.NewStorytellingConsumer(r.f.Readable)
-	if err := r.client.Find(ctx, filter, c); err != nil {
+	if err := r.client.Find(ctx, filter, c, opts...); err != nil {
 		return nil, err
 	}
 	return (*storytelling.StoryList)(&c.Result), nil
@@ -0,0 +1,105 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/stylelib"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	styleLibraryIndexes       = []string{"workspace"}
+	styleLibraryUniqueIndexes = []string{"id"}
+)
+
+type StyleLibrary struct {
+	client *mongox.ClientCollection
+	f      repo.WorkspaceFilter
+}
+
+func NewStyleLibrary(client *mongox.Client) *StyleLibrary {
+	return &StyleLibrary{client: client.WithCollection("styleLibrary")}
+}
+
+func (r *StyleLibrary) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, styleLibraryIndexes, styleLibraryUniqueIndexes)
+}
+
+func (r *StyleLibrary) Filtered(f repo.WorkspaceFilter) repo.StyleLibrary {
+	return &StyleLibrary{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *StyleLibrary) FindByID(ctx context.Context, id id.LibraryStyleID) (*stylelib.Style, error) {
+	c := mongodoc.NewStyleLibraryConsumer(r.f.Readable)
+	if err := r.client.FindOne(ctx, bson.M{"id": id.String()}, c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *StyleLibrary) FindByIDs(ctx context.Context, ids id.LibraryStyleIDList) ([]*stylelib.Style, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	res, err := r.find(ctx, bson.M{"id": bson.M{"$in": ids.Strings()}})
+	if err != nil {
+		return nil, err
+	}
+
+	styles := make([]*stylelib.Style, 0, len(ids))
+	for _, id := range ids {
+		var s2 *stylelib.Style
+		for _, s := range res {
+			if s.ID() == id {
+				s2 = s
+				break
+			}
+		}
+		styles = append(styles, s2)
+	}
+	return styles, nil
+}
+
+func (r *StyleLibrary) FindByWorkspace(ctx context.Context, wid accountdomain.WorkspaceID) ([]*stylelib.Style, error) {
+	if !r.f.CanRead(wid) {
+		return nil, nil
+	}
+	return r.find(ctx, bson.M{"workspace": wid.String()})
+}
+
+func (r *StyleLibrary) Save(ctx context.Context, s *stylelib.Style) error {
+	if !r.f.CanWrite(s.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+	doc, sid := mongodoc.NewStyleLibrary(s)
+	return r.client.SaveOne(ctx, sid, doc)
+}
+
+func (r *StyleLibrary) Remove(ctx context.Context, id id.LibraryStyleID) error {
+	return r.client.RemoveOne(ctx, r.writeFilter(bson.M{"id": id.String()}))
+}
+
+func (r *StyleLibrary) find(ctx context.Context, filter any) ([]*stylelib.Style, error) {
+	c := mongodoc.NewStyleLibraryConsumer(r.f.Readable)
+	if err := r.client.Find(ctx, filter, c); err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, nil
+}
+
+func (r *StyleLibrary) writeFilter(filter any) any {
+	if r.f.Writable == nil {
+		return filter
+	}
+	return mongox.And(filter, "workspace", bson.M{"$in": r.f.Writable.Strings()})
+}
@@ -0,0 +1,67 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/usage"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	workspaceUsageIndexes       = []string{"updatedat"}
+	workspaceUsageUniqueIndexes = []string{"id"}
+)
+
+type WorkspaceUsage struct {
+	client *mongox.ClientCollection
+	f      repo.WorkspaceFilter
+}
+
+func NewWorkspaceUsage(client *mongox.Client) *WorkspaceUsage {
+	return &WorkspaceUsage{client: client.WithCollection("workspaceUsage")}
+}
+
+func (r *WorkspaceUsage) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, workspaceUsageIndexes, workspaceUsageUniqueIndexes)
+}
+
+func (r *WorkspaceUsage) Filtered(f repo.WorkspaceFilter) repo.WorkspaceUsage {
+	return &WorkspaceUsage{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *WorkspaceUsage) FindByWorkspace(ctx context.Context, wid accountdomain.WorkspaceID) (*usage.Usage, error) {
+	if !r.f.CanRead(wid) {
+		return nil, rerror.ErrNotFound
+	}
+
+	c := mongodoc.NewWorkspaceUsageConsumer(r.f.Readable)
+	if err := r.client.FindOne(ctx, bson.M{"id": wid.String()}, c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *WorkspaceUsage) FindUpdatedBefore(ctx context.Context, t time.Time) ([]*usage.Usage, error) {
+	c := mongodoc.NewWorkspaceUsageConsumer(r.f.Readable)
+	if err := r.client.Find(ctx, bson.M{"updatedat": bson.M{"$lt": t}}, c); err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, nil
+}
+
+func (r *WorkspaceUsage) Save(ctx context.Context, u *usage.Usage) error {
+	if !r.f.CanWrite(u.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+	doc, wid := mongodoc.NewWorkspaceUsage(u)
+	return r.client.SaveOne(ctx, wid, doc)
+}
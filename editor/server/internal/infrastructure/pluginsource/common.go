@@ -0,0 +1,124 @@
+// Package pluginsource implements gateway.PluginSource for the places plugin packages are downloaded from.
+package pluginsource
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearthx/rerror"
+)
+
+const sizeLimit int64 = 10 * 1024 * 1024 // 10MB
+
+const checksumPrefix = "sha256="
+
+// checksum returns the hex SHA-256 digest given in the URL fragment as "#sha256=<hex>".
+func checksum(u *url.URL) string {
+	if u == nil || !strings.HasPrefix(u.Fragment, checksumPrefix) {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(u.Fragment, checksumPrefix))
+}
+
+// verify compares the package with the checksum in the URL if there is one.
+func verify(b []byte, u *url.URL, required bool) error {
+	c := checksum(u)
+	if c == "" {
+		if required {
+			return gateway.ErrPluginChecksumRequired
+		}
+		return nil
+	}
+
+	sum := sha256.Sum256(b)
+	if hex.EncodeToString(sum[:]) != c {
+		return gateway.ErrPluginChecksumMismatch
+	}
+	return nil
+}
+
+func download(ctx context.Context, client *http.Client, u string, header http.Header) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if len(header) > 0 {
+		client = withoutHeaderOnRedirect(client, header)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, rerror.ErrNotFound
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code is %d", res.StatusCode)
+	}
+
+	return readLimited(res.Body)
+}
+
+// withoutHeaderOnRedirect returns a copy of the client which does not send the header to other hosts when redirected.
+func withoutHeaderOnRedirect(client *http.Client, header http.Header) *http.Client {
+	c := *client
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) > 0 && !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+			for k := range header {
+				req.Header.Del(k)
+			}
+		}
+		if client.CheckRedirect != nil {
+			return client.CheckRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &c
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	b, err := io.ReadAll(io.LimitReader(r, sizeLimit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > sizeLimit {
+		return nil, fmt.Errorf("plugin package exceeds %d bytes", sizeLimit)
+	}
+	return b, nil
+}
+
+func withoutFragment(u *url.URL) *url.URL {
+	u2 := *u
+	u2.Fragment = ""
+	u2.RawFragment = ""
+	return &u2
+}
+
+func readCloser(b []byte) io.ReadCloser {
+	return io.NopCloser(bytes.NewReader(b))
+}
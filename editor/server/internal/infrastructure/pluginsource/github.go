@@ -0,0 +1,40 @@
+package pluginsource
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/reearth/reearth/server/pkg/plugin/repourl"
+)
+
+// GitHub downloads the archive of a GitHub repository.
+type GitHub struct {
+	client *http.Client
+}
+
+func NewGitHub(client *http.Client) *GitHub {
+	return &GitHub{client: client}
+}
+
+func (s *GitHub) Match(u *url.URL) bool {
+	_, err := repourl.New(withoutFragment(u))
+	return err == nil
+}
+
+func (s *GitHub) Fetch(ctx context.Context, u *url.URL) (io.ReadCloser, error) {
+	ru, err := repourl.New(withoutFragment(u))
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := download(ctx, s.client, ru.ArchiveURL().String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := verify(b, u, false); err != nil {
+		return nil, err
+	}
+	return readCloser(b), nil
+}
@@ -0,0 +1,94 @@
+package pluginsource
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const gitlabTokenHeader = "PRIVATE-TOKEN"
+
+// GitLab downloads the archive of a repository on gitlab.com or a self-hosted GitLab instance.
+type GitLab struct {
+	client *http.Client
+	hosts  []string
+	tokens map[string]string
+}
+
+// NewGitLab returns a source for the GitLab hosts. Tokens are keyed by host and are sent only to their own host to read private repositories.
+func NewGitLab(client *http.Client, hosts []string, tokens map[string]string) *GitLab {
+	return &GitLab{client: client, hosts: hosts, tokens: tokens}
+}
+
+func (s *GitLab) Match(u *url.URL) bool {
+	_, _, ok := s.parse(u)
+	return ok
+}
+
+func (s *GitLab) Fetch(ctx context.Context, u *url.URL) (io.ReadCloser, error) {
+	project, ref, ok := s.parse(u)
+	if !ok {
+		return nil, fmt.Errorf("invalid gitlab url: %s", u)
+	}
+
+	archive := fmt.Sprintf("%s://%s/api/v4/projects/%s/repository/archive.zip", u.Scheme, u.Host, url.PathEscape(project))
+	if ref != "" {
+		archive += "?sha=" + url.QueryEscape(ref)
+	}
+
+	var header http.Header
+	if token := s.tokens[strings.ToLower(u.Host)]; token != "" {
+		header = http.Header{gitlabTokenHeader: []string{token}}
+	}
+
+	b, err := download(ctx, s.client, archive, header)
+	if err != nil {
+		return nil, err
+	}
+	if err := verify(b, u, false); err != nil {
+		return nil, err
+	}
+	return readCloser(b), nil
+}
+
+// parse extracts the project path and the ref from URLs such as:
+// https://gitlab.com/group/project, https://gitlab.com/group/subgroup/project/-/tree/main,
+// https://gitlab.com/group/project/-/tags/v1.0.0 and https://gitlab.com/group/project/-/archive/v1.0.0/project-v1.0.0.zip
+func (s *GitLab) parse(u *url.URL) (project, ref string, ok bool) {
+	// the token must not be sent in plaintext
+	if u == nil || u.Scheme != "https" || !s.hasHost(u.Host) {
+		return "", "", false
+	}
+
+	p := strings.Trim(u.Path, "/")
+	if i := strings.Index(p, "/-/"); i >= 0 {
+		rest := strings.Split(p[i+3:], "/")
+		p = p[:i]
+		if len(rest) >= 2 {
+			switch rest[0] {
+			case "tree", "tags", "commit", "archive":
+				ref = rest[1]
+			default:
+				return "", "", false
+			}
+		}
+	}
+
+	p = strings.TrimSuffix(p, ".git")
+	if strings.Count(p, "/") < 1 {
+		return "", "", false
+	}
+	return p, ref, true
+}
+
+func (s *GitLab) hasHost(h string) bool {
+	for _, h2 := range s.hosts {
+		if strings.EqualFold(h, h2) {
+			return true
+		}
+	}
+	return false
+}
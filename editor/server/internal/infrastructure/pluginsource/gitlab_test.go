@@ -0,0 +1,110 @@
+package pluginsource
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ gateway.PluginSource = (*GitLab)(nil)
+
+func TestGitLab_Match(t *testing.T) {
+	s := NewGitLab(nil, []string{"gitlab.com", "git.example.com"}, nil)
+
+	tests := []struct {
+		url     string
+		project string
+		ref     string
+		ok      bool
+	}{
+		{url: "https://gitlab.com/foo/bar", project: "foo/bar", ok: true},
+		{url: "https://gitlab.com/foo/bar.git", project: "foo/bar", ok: true},
+		{url: "https://git.example.com/foo/sub/bar/-/tree/main", project: "foo/sub/bar", ref: "main", ok: true},
+		{url: "https://gitlab.com/foo/bar/-/tags/v1.0.0", project: "foo/bar", ref: "v1.0.0", ok: true},
+		{url: "https://gitlab.com/foo/bar/-/archive/v1.0.0/bar-v1.0.0.zip", project: "foo/bar", ref: "v1.0.0", ok: true},
+		{url: "https://gitlab.com/foo/bar/-/issues/1"},
+		{url: "https://gitlab.com/foo"},
+		{url: "https://github.com/foo/bar"},
+		{url: "ftp://gitlab.com/foo/bar"},
+		{url: "http://gitlab.com/foo/bar"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			u, _ := url.Parse(tt.url)
+			project, ref, ok := s.parse(u)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.ok, s.Match(u))
+			assert.Equal(t, tt.project, project)
+			assert.Equal(t, tt.ref, ref)
+		})
+	}
+}
+
+func TestGitLab_Fetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET", "https://git.example.com/api/v4/projects/foo%2Fbar/repository/archive.zip",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("PRIVATE-TOKEN") != "token" {
+				return httpmock.NewStringResponse(http.StatusUnauthorized, ""), nil
+			}
+			if req.URL.Query().Get("sha") != "v1" {
+				return httpmock.NewStringResponse(http.StatusNotFound, ""), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, "zip"), nil
+		},
+	)
+
+	// the token of another host is not sent
+	httpmock.RegisterResponder(
+		"GET", "https://gitlab.com/api/v4/projects/foo%2Fbar/repository/archive.zip",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("PRIVATE-TOKEN") != "" {
+				return httpmock.NewStringResponse(http.StatusUnauthorized, ""), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, "public"), nil
+		},
+	)
+	// nor is it forwarded when redirected to another host
+	httpmock.RegisterResponder(
+		"GET", "https://git.example.com/api/v4/projects/foo%2Fmoved/repository/archive.zip",
+		func(req *http.Request) (*http.Response, error) {
+			res := httpmock.NewStringResponse(http.StatusFound, "")
+			res.Header.Set("Location", "https://gitlab.com/api/v4/projects/foo%2Fbar/repository/archive.zip")
+			return res, nil
+		},
+	)
+
+	s := NewGitLab(nil, []string{"gitlab.com", "git.example.com"}, map[string]string{"git.example.com": "token"})
+	u, _ := url.Parse("https://git.example.com/foo/bar/-/tree/v1")
+	r, err := s.Fetch(context.Background(), u)
+	assert.NoError(t, err)
+	b, _ := io.ReadAll(r)
+	assert.Equal(t, "zip", string(b))
+
+	u2, _ := url.Parse("https://gitlab.com/foo/bar")
+	r, err = s.Fetch(context.Background(), u2)
+	assert.NoError(t, err)
+	b, _ = io.ReadAll(r)
+	assert.Equal(t, "public", string(b))
+
+	u3, _ := url.Parse("https://git.example.com/foo/moved")
+	r, err = s.Fetch(context.Background(), u3)
+	assert.NoError(t, err)
+	b, _ = io.ReadAll(r)
+	assert.Equal(t, "public", string(b))
+
+	_, err = NewGitLab(nil, []string{"git.example.com"}, nil).Fetch(context.Background(), u)
+	assert.EqualError(t, err, "status code is 401")
+}
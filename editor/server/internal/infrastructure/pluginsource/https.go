@@ -0,0 +1,50 @@
+package pluginsource
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearthx/log"
+)
+
+// errHTTPSFetchFailed hides why the download failed, as the response of an arbitrary server should not be exposed to users.
+var errHTTPSFetchFailed = errors.New("failed to download the plugin package")
+
+// HTTPS downloads a zip archive from any HTTPS URL.
+// As the server is not trusted, the URL must carry the SHA-256 digest of the archive such as "https://example.com/plugin.zip#sha256=<hex>",
+// and only servers on public addresses can be accessed.
+type HTTPS struct {
+	client *http.Client
+}
+
+func NewHTTPS(client *http.Client) *HTTPS {
+	if client == nil {
+		client = newRestrictedClient()
+	}
+	return &HTTPS{client: client}
+}
+
+func (s *HTTPS) Match(u *url.URL) bool {
+	return u != nil && u.Scheme == "https" && u.Host != "" && strings.HasSuffix(strings.ToLower(u.Path), ".zip")
+}
+
+func (s *HTTPS) Fetch(ctx context.Context, u *url.URL) (io.ReadCloser, error) {
+	if checksum(u) == "" {
+		return nil, gateway.ErrPluginChecksumRequired
+	}
+
+	b, err := download(ctx, s.client, withoutFragment(u).String(), nil)
+	if err != nil {
+		log.Warnfc(ctx, "pluginsource: failed to download %s: %v", withoutFragment(u), err)
+		return nil, errHTTPSFetchFailed
+	}
+	if err := verify(b, u, true); err != nil {
+		return nil, err
+	}
+	return readCloser(b), nil
+}
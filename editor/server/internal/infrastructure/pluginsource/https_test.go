@@ -0,0 +1,91 @@
+package pluginsource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ gateway.PluginSource = (*HTTPS)(nil)
+
+func TestHTTPS(t *testing.T) {
+	s := NewHTTPS(nil)
+	httpmock.ActivateNonDefault(s.client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://plugins.example.com/plugin.zip", httpmock.NewStringResponder(200, "zip"))
+	httpmock.RegisterResponder("GET", "https://plugins.example.com/private.zip", httpmock.NewStringResponder(403, "secret"))
+
+	sum := sha256.Sum256([]byte("zip"))
+
+	u, _ := url.Parse("https://plugins.example.com/plugin.zip#sha256=" + hex.EncodeToString(sum[:]))
+	assert.True(t, s.Match(u))
+	r, err := s.Fetch(context.Background(), u)
+	assert.NoError(t, err)
+	b, _ := io.ReadAll(r)
+	assert.Equal(t, "zip", string(b))
+
+	u, _ = url.Parse("https://plugins.example.com/plugin.zip")
+	_, err = s.Fetch(context.Background(), u)
+	assert.Equal(t, gateway.ErrPluginChecksumRequired, err)
+
+	u, _ = url.Parse("https://plugins.example.com/plugin.zip#sha256=0000")
+	_, err = s.Fetch(context.Background(), u)
+	assert.Equal(t, gateway.ErrPluginChecksumMismatch, err)
+
+	// the response of the server is not exposed
+	u, _ = url.Parse("https://plugins.example.com/private.zip#sha256=0000")
+	_, err = s.Fetch(context.Background(), u)
+	assert.Same(t, errHTTPSFetchFailed, err)
+
+	u, _ = url.Parse("http://plugins.example.com/plugin.zip")
+	assert.False(t, s.Match(u))
+	u, _ = url.Parse("https://plugins.example.com/plugin")
+	assert.False(t, s.Match(u))
+}
+
+func TestHTTPS_PrivateNetwork(t *testing.T) {
+	called := false
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	s := NewHTTPS(nil)
+	u, _ := url.Parse(srv.URL + "/plugin.zip#sha256=0000")
+	_, err := s.Fetch(context.Background(), u)
+	assert.Same(t, errHTTPSFetchFailed, err)
+	assert.False(t, called)
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := map[string]bool{
+		"8.8.8.8":         true,
+		"2001:4860::8888": true,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"10.0.0.1":        false,
+		"172.16.0.1":      false,
+		"192.168.0.1":     false,
+		"169.254.169.254": false,
+		"fe80::1":         false,
+		"fd00::1":         false,
+		"0.0.0.0":         false,
+		"::ffff:10.0.0.1": false,
+		"100.64.0.1":      false,
+	}
+
+	for addr, expected := range tests {
+		assert.Equal(t, expected, isPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
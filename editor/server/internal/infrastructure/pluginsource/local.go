@@ -0,0 +1,117 @@
+package pluginsource
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/spf13/afero"
+)
+
+const localScheme = "local"
+
+var errInvalidLocalPath = errors.New("invalid local plugin path")
+
+// Local reads plugins from a registry directory on the server, addressed as "local://<path>".
+// The path is either a zip archive or a directory with the files of a plugin.
+type Local struct {
+	fs afero.Fs
+}
+
+// NewLocal returns a source for the registry directory. The paths of URLs are resolved within the directory.
+func NewLocal(dir string) *Local {
+	return NewLocalWithFs(afero.NewBasePathFs(afero.NewOsFs(), dir))
+}
+
+func NewLocalWithFs(fs afero.Fs) *Local {
+	return &Local{fs: fs}
+}
+
+func (s *Local) Match(u *url.URL) bool {
+	return u != nil && u.Scheme == localScheme
+}
+
+func (s *Local) Fetch(ctx context.Context, u *url.URL) (io.ReadCloser, error) {
+	p, err := localPath(u)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := s.fs.Stat(p)
+	if err != nil {
+		return nil, rerror.ErrNotFound
+	}
+
+	var b []byte
+	if fi.IsDir() {
+		b, err = zipDir(afero.NewBasePathFs(s.fs, p))
+	} else {
+		var f afero.File
+		if f, err = s.fs.Open(p); err == nil {
+			b, err = readLimited(f)
+			_ = f.Close()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verify(b, u, false); err != nil {
+		return nil, err
+	}
+	return readCloser(b), nil
+}
+
+func localPath(u *url.URL) (string, error) {
+	p := path.Join(u.Host, u.Path)
+	if p == "" || p == "." || strings.Contains(u.Host+u.Path, "..") {
+		return "", errInvalidLocalPath
+	}
+	return "/" + p, nil
+}
+
+func zipDir(fs afero.Fs) ([]byte, error) {
+	it, err := file.NewFsIterator(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	var size int64
+	for {
+		f, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if f == nil {
+			break
+		}
+
+		w, err := zw.Create(strings.TrimPrefix(f.Path, "/"))
+		if err != nil {
+			_ = f.Content.Close()
+			return nil, err
+		}
+		n, err := io.Copy(w, io.LimitReader(f.Content, sizeLimit-size+1))
+		_ = f.Content.Close()
+		if err != nil {
+			return nil, err
+		}
+		if size += n; size > sizeLimit {
+			return nil, errors.New("plugin package is too large")
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,57 @@
+package pluginsource
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ gateway.PluginSource = (*Local)(nil)
+
+func TestLocal(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, "/plugins/a.zip", []byte("zip"), 0666)
+	_ = afero.WriteFile(fs, "/plugins/b/reearth.yml", []byte("id: b"), 0666)
+	_ = afero.WriteFile(fs, "/plugins/b/index.js", []byte("console.log()"), 0666)
+	s := NewLocalWithFs(fs)
+	ctx := context.Background()
+
+	u, _ := url.Parse("local://plugins/a.zip")
+	assert.True(t, s.Match(u))
+	r, err := s.Fetch(ctx, u)
+	assert.NoError(t, err)
+	b, _ := io.ReadAll(r)
+	assert.Equal(t, "zip", string(b))
+
+	// directories are zipped
+	u, _ = url.Parse("local://plugins/b")
+	r, err = s.Fetch(ctx, u)
+	assert.NoError(t, err)
+	b, _ = io.ReadAll(r)
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	assert.NoError(t, err)
+	names := []string{}
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	assert.ElementsMatch(t, []string{"reearth.yml", "index.js"}, names)
+
+	u, _ = url.Parse("local://plugins/c.zip")
+	_, err = s.Fetch(ctx, u)
+	assert.Equal(t, rerror.ErrNotFound, err)
+
+	u, _ = url.Parse("local://plugins/../../etc/passwd")
+	_, err = s.Fetch(ctx, u)
+	assert.Equal(t, errInvalidLocalPath, err)
+
+	u, _ = url.Parse("https://example.com/a.zip")
+	assert.False(t, s.Match(u))
+}
@@ -0,0 +1,84 @@
+package realtime
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/presence"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/util"
+)
+
+const DefaultPresenceTTL = time.Minute
+
+// Presence stores presences in the memory of the process.
+// Like PubSub, presences are not shared among server instances.
+type Presence struct {
+	lock   sync.Mutex
+	ttl    time.Duration
+	scenes map[id.SceneID]map[accountdomain.UserID]presence.Presence
+}
+
+func NewPresence(ttl time.Duration) gateway.Presence {
+	if ttl <= 0 {
+		ttl = DefaultPresenceTTL
+	}
+	return &Presence{
+		ttl:    ttl,
+		scenes: map[id.SceneID]map[accountdomain.UserID]presence.Presence{},
+	}
+}
+
+func (p *Presence) TTL() time.Duration {
+	return p.ttl
+}
+
+// FindByScene returns unexpired presences of the scene in a stable order.
+func (p *Presence) FindByScene(_ context.Context, sid id.SceneID) (presence.List, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := util.Now()
+	var res presence.List
+	for u, pr := range p.scenes[sid] {
+		if now.Sub(pr.UpdatedAt) > p.ttl {
+			delete(p.scenes[sid], u)
+			continue
+		}
+		res = append(res, pr)
+	}
+	if len(p.scenes[sid]) == 0 {
+		delete(p.scenes, sid)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].User.Compare(res[j].User) < 0
+	})
+	return res, nil
+}
+
+func (p *Presence) Save(_ context.Context, pr presence.Presence) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.scenes[pr.Scene] == nil {
+		p.scenes[pr.Scene] = map[accountdomain.UserID]presence.Presence{}
+	}
+	p.scenes[pr.Scene][pr.User] = pr
+	return nil
+}
+
+func (p *Presence) Remove(_ context.Context, sid id.SceneID, u accountdomain.UserID) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.scenes[sid], u)
+	if len(p.scenes[sid]) == 0 {
+		delete(p.scenes, sid)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/presence"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresence(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	sid := id.NewSceneID()
+	u1, u2 := accountdomain.NewUserID(), accountdomain.NewUserID()
+	lid := id.NewNLSLayerID()
+	p := NewPresence(time.Minute)
+
+	assert.NoError(t, p.Save(ctx, presence.Presence{Scene: sid, User: u1, Layer: &lid, UpdatedAt: now}))
+	assert.NoError(t, p.Save(ctx, presence.Presence{Scene: sid, User: u2, UpdatedAt: now.Add(-30 * time.Second)}))
+
+	got, err := p.FindByScene(ctx, sid)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, &lid, got.FindByUser(u1).Layer)
+
+	// expired
+	util.MockNow(now.Add(45 * time.Second))
+	got, err = p.FindByScene(ctx, sid)
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.NotNil(t, got.FindByUser(u1))
+
+	assert.NoError(t, p.Remove(ctx, sid, u1))
+	got, err = p.FindByScene(ctx, sid)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,65 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearthx/log"
+)
+
+const subscriptionBufferSize = 64
+
+// PubSub delivers messages to subscribers in the same process.
+// Messages are not shared among server instances, so it is used only when the server runs as a single instance.
+type PubSub struct {
+	lock sync.Mutex
+	subs map[string]map[chan []byte]struct{}
+}
+
+func NewPubSub() gateway.PubSub {
+	return &PubSub{
+		subs: map[string]map[chan []byte]struct{}{},
+	}
+}
+
+// Publish never blocks. A message is dropped for a subscriber which does not keep up with the topic.
+func (p *PubSub) Publish(ctx context.Context, topic string, msg []byte) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for ch := range p.subs[topic] {
+		select {
+		case ch <- msg:
+		default:
+			log.Warnfc(ctx, "pubsub: message dropped for a slow subscriber of %s", topic)
+		}
+	}
+	return nil
+}
+
+func (p *PubSub) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ch := make(chan []byte, subscriptionBufferSize)
+
+	p.lock.Lock()
+	if p.subs[topic] == nil {
+		p.subs[topic] = map[chan []byte]struct{}{}
+	}
+	p.subs[topic][ch] = struct{}{}
+	p.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		delete(p.subs[topic], ch)
+		if len(p.subs[topic]) == 0 {
+			delete(p.subs, topic)
+		}
+		close(ch)
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,41 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPubSub(t *testing.T) {
+	ctx := context.Background()
+	p := NewPubSub()
+
+	ctx1, cancel1 := context.WithCancel(ctx)
+	ch1, err := p.Subscribe(ctx1, "a")
+	assert.NoError(t, err)
+	ch2, err := p.Subscribe(ctx, "b")
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.Publish(ctx, "a", []byte("1")))
+	assert.Equal(t, []byte("1"), <-ch1)
+	assert.Empty(t, ch2)
+
+	cancel1()
+	_, ok := <-ch1
+	assert.False(t, ok)
+	assert.NoError(t, p.Publish(ctx, "a", []byte("2")))
+}
+
+func TestPubSub_SlowSubscriber(t *testing.T) {
+	ctx := context.Background()
+	p := NewPubSub()
+
+	ch, err := p.Subscribe(ctx, "a")
+	assert.NoError(t, err)
+
+	for i := 0; i < subscriptionBufferSize+1; i++ {
+		assert.NoError(t, p.Publish(ctx, "a", []byte("1")))
+	}
+	assert.Len(t, ch, subscriptionBufferSize)
+}
@@ -24,11 +24,13 @@ import (
 )
 
 const (
-	assetBasePath  string = "assets"
-	pluginBasePath string = "plugins"
-	mapBasePath    string = "maps"
-	storyBasePath  string = "stories"
-	fileSizeLimit  int64  = 1024 * 1024 * 100 // about 100MB
+	assetBasePath    string = "assets"
+	pluginBasePath   string = "plugins"
+	mapBasePath      string = "maps"
+	storyBasePath    string = "stories"
+	snapshotBasePath string = "snapshots"
+	exportBasePath   string = "export"
+	fileSizeLimit    int64  = 1024 * 1024 * 100 // about 100MB
 )
 
 type fileRepo struct {
@@ -171,6 +173,49 @@ func (f *fileRepo) RemoveBuiltScene(ctx context.Context, name string) error {
 	return f.delete(ctx, path.Join(mapBasePath, sn))
 }
 
+func (f *fileRepo) UploadBuiltSceneSnapshot(ctx context.Context, content io.Reader, name, version string) error {
+	sn := snapshotName(mapBasePath, name, version)
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, sn, content)
+	return err
+}
+
+func (f *fileRepo) ReadBuiltSceneSnapshot(ctx context.Context, name, version string) (io.ReadCloser, error) {
+	sn := snapshotName(mapBasePath, name, version)
+	if sn == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, sn)
+}
+
+func (f *fileRepo) ListBuiltSceneSnapshots(ctx context.Context, name string) ([]string, error) {
+	sn := snapshotName(mapBasePath, name, "")
+	if sn == "" {
+		return nil, nil
+	}
+	return f.listSnapshots(ctx, sn)
+}
+
+func (f *fileRepo) RemoveBuiltSceneSnapshot(ctx context.Context, name, version string) error {
+	sn := snapshotName(mapBasePath, name, version)
+	if sn == "" || version == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, sn)
+}
+
+func (f *fileRepo) RemoveBuiltSceneSnapshots(ctx context.Context, name string) error {
+	log.Infofc(ctx, "s3: built scene snapshots deleted: %s", name)
+
+	sn := snapshotName(mapBasePath, name, "")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.deleteAll(ctx, sn)
+}
+
 // stories
 
 func (f *fileRepo) ReadStoryFile(ctx context.Context, name string) (io.ReadCloser, error) {
@@ -208,6 +253,77 @@ func (f *fileRepo) RemoveStory(ctx context.Context, name string) error {
 	return f.delete(ctx, path.Join(storyBasePath, sn))
 }
 
+func (f *fileRepo) UploadStorySnapshot(ctx context.Context, content io.Reader, name, version string) error {
+	sn := snapshotName(storyBasePath, name, version)
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, sn, content)
+	return err
+}
+
+func (f *fileRepo) ReadStorySnapshot(ctx context.Context, name, version string) (io.ReadCloser, error) {
+	sn := snapshotName(storyBasePath, name, version)
+	if sn == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, sn)
+}
+
+func (f *fileRepo) ListStorySnapshots(ctx context.Context, name string) ([]string, error) {
+	sn := snapshotName(storyBasePath, name, "")
+	if sn == "" {
+		return nil, nil
+	}
+	return f.listSnapshots(ctx, sn)
+}
+
+func (f *fileRepo) RemoveStorySnapshot(ctx context.Context, name, version string) error {
+	sn := snapshotName(storyBasePath, name, version)
+	if sn == "" || version == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, sn)
+}
+
+func (f *fileRepo) RemoveStorySnapshots(ctx context.Context, name string) error {
+	log.Infofc(ctx, "s3: story snapshots deleted: %s", name)
+
+	sn := snapshotName(storyBasePath, name, "")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.deleteAll(ctx, sn)
+}
+
+// exported projects
+
+func (f *fileRepo) UploadExportProjectZip(ctx context.Context, content io.Reader, name string) error {
+	sn := sanitize.Path(name)
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, path.Join(exportBasePath, sn), content)
+	return err
+}
+
+func (f *fileRepo) ReadExportProjectZip(ctx context.Context, name string) (io.ReadCloser, error) {
+	if name == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, path.Join(exportBasePath, sanitize.Path(name)))
+}
+
+func (f *fileRepo) RemoveExportProjectZip(ctx context.Context, name string) error {
+	log.Infofc(ctx, "s3: exported project deleted: %s", name)
+
+	sn := sanitize.Path(name)
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, path.Join(exportBasePath, sn))
+}
+
 // helpers
 
 func (f *fileRepo) read(ctx context.Context, filename string) (io.ReadCloser, error) {
@@ -335,6 +451,9 @@ func (f *fileRepo) deleteAll(ctx context.Context, path string) error {
 	keys := lo.Map(l.Contents, func(obj types.Object, _ int) types.ObjectIdentifier {
 		return types.ObjectIdentifier{Key: obj.Key}
 	})
+	if len(keys) == 0 {
+		return nil
+	}
 	_, err = f.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 		Bucket: aws.String(f.bucketName),
 		Delete: &types.Delete{
@@ -349,6 +468,43 @@ func (f *fileRepo) deleteAll(ctx context.Context, path string) error {
 	return nil
 }
 
+func (f *fileRepo) listSnapshots(ctx context.Context, prefix string) ([]string, error) {
+	var res []string
+	p := s3.NewListObjectsV2Paginator(f.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(f.bucketName),
+		Prefix: aws.String(prefix),
+	})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			log.Errorfc(ctx, "s3: unable to list snapshots: %v", err)
+			return nil, rerror.ErrInternalByWithContext(ctx, err)
+		}
+		for _, obj := range page.Contents {
+			if v := strings.TrimPrefix(lo.FromPtr(obj.Key), prefix); path.Ext(v) == ".json" && !strings.Contains(v, "/") {
+				res = append(res, strings.TrimSuffix(v, ".json"))
+			}
+		}
+	}
+	return res, nil
+}
+
+// snapshotName returns the object name of the snapshot, or the prefix of the snapshots when the version is empty.
+func snapshotName(base, name, version string) string {
+	sn := sanitize.Path(name)
+	if sn == "" {
+		return ""
+	}
+	if version == "" {
+		return path.Join(base, snapshotBasePath, sn) + "/"
+	}
+	sv := sanitize.Path(version + ".json")
+	if sv == "" {
+		return ""
+	}
+	return path.Join(base, snapshotBasePath, sn, sv)
+}
+
 func getObjectURL(base *url.URL, objectName string) *url.URL {
 	if base == nil {
 		return nil
@@ -7,6 +7,9 @@ type Container struct {
 	Mailer         mailer.Mailer
 	DataSource     DataSource
 	PluginRegistry PluginRegistry
+	PluginSources  []PluginSource
 	File           File
+	PubSub         PubSub
+	Presence       Presence
 	Google         Google
 }
@@ -31,8 +31,25 @@ type File interface {
 	MoveBuiltScene(context.Context, string, string) error
 	RemoveBuiltScene(context.Context, string) error
 
+	// Snapshots are immutable copies of published data keyed by the project or story ID and the version.
+	UploadBuiltSceneSnapshot(context.Context, io.Reader, string, string) error
+	ReadBuiltSceneSnapshot(context.Context, string, string) (io.ReadCloser, error)
+	ListBuiltSceneSnapshots(context.Context, string) ([]string, error)
+	RemoveBuiltSceneSnapshot(context.Context, string, string) error
+	RemoveBuiltSceneSnapshots(context.Context, string) error
+
 	UploadStory(context.Context, io.Reader, string) error
 	ReadStoryFile(context.Context, string) (io.ReadCloser, error)
 	MoveStory(context.Context, string, string) error
 	RemoveStory(context.Context, string) error
+
+	UploadStorySnapshot(context.Context, io.Reader, string, string) error
+	ReadStorySnapshot(context.Context, string, string) (io.ReadCloser, error)
+	ListStorySnapshots(context.Context, string) ([]string, error)
+	RemoveStorySnapshot(context.Context, string, string) error
+	RemoveStorySnapshots(context.Context, string) error
+
+	UploadExportProjectZip(context.Context, io.Reader, string) error
+	ReadExportProjectZip(context.Context, string) (io.ReadCloser, error)
+	RemoveExportProjectZip(context.Context, string) error
 }
@@ -0,0 +1,21 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+)
+
+var (
+	ErrUnsupportedPluginSource = errors.New("unsupported plugin source")
+	ErrPluginChecksumRequired  = errors.New("plugin package checksum is required")
+	ErrPluginChecksumMismatch  = errors.New("plugin package checksum mismatch")
+)
+
+// PluginSource downloads plugin packages as zip archives from a location such as a Git hosting service or a plugin registry.
+type PluginSource interface {
+	// Match reports whether the source can download the plugin at the URL.
+	Match(*url.URL) bool
+	Fetch(context.Context, *url.URL) (io.ReadCloser, error)
+}
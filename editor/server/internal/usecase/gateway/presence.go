@@ -0,0 +1,18 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/presence"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+// Presence stores who is editing each scene. A presence expires unless it is saved again within the TTL.
+type Presence interface {
+	TTL() time.Duration
+	FindByScene(context.Context, id.SceneID) (presence.List, error)
+	Save(context.Context, presence.Presence) error
+	Remove(context.Context, id.SceneID, accountdomain.UserID) error
+}
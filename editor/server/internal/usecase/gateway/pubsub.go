@@ -0,0 +1,11 @@
+package gateway
+
+import "context"
+
+// PubSub delivers messages to the subscribers of a topic.
+type PubSub interface {
+	Publish(ctx context.Context, topic string, msg []byte) error
+	// Subscribe returns a channel of messages published to the topic after the call.
+	// The channel is closed when the context is done.
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+}
@@ -1,7 +1,9 @@
 package interactor
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"net/url"
 	"path"
 
@@ -11,10 +13,16 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/asset"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
 )
 
+// metadata is extracted from the head of the file up to the size
+const assetMetadataSizeLimit = 16 * 1024 * 1024 // 16MB
+
 type Asset struct {
 	repos    *repo.Container
 	gateways *gateway.Container
@@ -31,20 +39,66 @@ func (i *Asset) Fetch(ctx context.Context, assets []id.AssetID, operator *usecas
 	return i.repos.Asset.FindByIDs(ctx, assets)
 }
 
-func (i *Asset) FindByWorkspace(ctx context.Context, tid accountdomain.WorkspaceID, keyword *string, sort *asset.SortType, p *usecasex.Pagination, operator *usecase.Operator) ([]*asset.Asset, *usecasex.PageInfo, error) {
+func (i *Asset) FindByWorkspace(ctx context.Context, tid accountdomain.WorkspaceID, param interfaces.FindAssetsParam, operator *usecase.Operator) ([]*asset.Asset, *usecasex.PageInfo, error) {
 	return Run2(
 		ctx, operator, i.repos,
 		Usecase().WithReadableWorkspaces(tid),
 		func(ctx context.Context) ([]*asset.Asset, *usecasex.PageInfo, error) {
+			folder := param.Folder
+			if folder != nil {
+				f, err := asset.NormalizeFolder(*folder)
+				if err != nil {
+					return nil, nil, err
+				}
+				folder = &f
+			}
+
 			return i.repos.Asset.FindByWorkspace(ctx, tid, repo.AssetFilter{
-				Sort:       sort,
-				Keyword:    keyword,
-				Pagination: p,
+				Sort:       param.Sort,
+				Keyword:    param.Keyword,
+				Folder:     folder,
+				Tag:        param.Tag,
+				Pagination: param.Pagination,
 			})
 		},
 	)
 }
 
+func (i *Asset) FindFolders(ctx context.Context, tid accountdomain.WorkspaceID, operator *usecase.Operator) ([]string, error) {
+	return Run1(
+		ctx, operator, i.repos,
+		Usecase().WithReadableWorkspaces(tid),
+		func(ctx context.Context) ([]string, error) {
+			return i.repos.Asset.FindFolders(ctx, tid)
+		},
+	)
+}
+
+func (i *Asset) FindTags(ctx context.Context, tid accountdomain.WorkspaceID, operator *usecase.Operator) ([]string, error) {
+	return Run1(
+		ctx, operator, i.repos,
+		Usecase().WithReadableWorkspaces(tid),
+		func(ctx context.Context) ([]string, error) {
+			return i.repos.Asset.FindTags(ctx, tid)
+		},
+	)
+}
+
+func (i *Asset) Usages(ctx context.Context, aid id.AssetID, operator *usecase.Operator) ([]interfaces.AssetUsage, error) {
+	a, err := i.repos.Asset.FindByID(ctx, aid)
+	if err != nil {
+		return nil, err
+	}
+
+	return Run1(
+		ctx, operator, i.repos,
+		Usecase().WithReadableWorkspaces(a.Workspace()),
+		func(ctx context.Context) ([]interfaces.AssetUsage, error) {
+			return i.usages(ctx, a)
+		},
+	)
+}
+
 func (i *Asset) Create(ctx context.Context, inp interfaces.CreateAssetParam, operator *usecase.Operator) (result *asset.Asset, err error) {
 	if inp.File == nil {
 		return nil, interfaces.ErrFileNotIncluded
@@ -59,11 +113,30 @@ func (i *Asset) Create(ctx context.Context, inp interfaces.CreateAssetParam, ope
 		return nil, interfaces.ErrOperationDenied
 	}
 
-	url, size, err := i.gateways.File.UploadAsset(ctx, inp.File)
+	folder, err := asset.NormalizeFolder(lo.FromPtr(inp.Folder))
 	if err != nil {
 		return nil, err
 	}
 
+	// keep the head of the file to extract the metadata while uploading it
+	head := &headBuffer{limit: assetMetadataSizeLimit}
+	content := inp.File.Content
+	f := *inp.File
+	f.Content = struct {
+		io.Reader
+		io.Closer
+	}{io.TeeReader(content, head), content}
+
+	url, size, err := i.gateways.File.UploadAsset(ctx, &f)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := asset.ExtractMetadata(inp.File.Path, bytes.NewReader(head.Bytes()))
+	if err != nil {
+		log.Warnfc(ctx, "asset: failed to extract metadata of %s: %v", inp.File.Path, err)
+	}
+
 	// enforce policy
 	if policyID := operator.Policy(ws.Policy()); policyID != nil {
 		p, err := i.repos.Policy.FindByID(ctx, *policyID)
@@ -86,6 +159,9 @@ func (i *Asset) Create(ctx context.Context, inp interfaces.CreateAssetParam, ope
 		Name(path.Base(inp.File.Path)).
 		Size(size).
 		URL(url.String()).
+		Folder(folder).
+		Tags(inp.Tags).
+		Metadata(metadata).
 		Build()
 	if err != nil {
 		return nil, err
@@ -98,7 +174,42 @@ func (i *Asset) Create(ctx context.Context, inp interfaces.CreateAssetParam, ope
 	return a, nil
 }
 
-func (i *Asset) Remove(ctx context.Context, aid id.AssetID, operator *usecase.Operator) (result id.AssetID, err error) {
+func (i *Asset) Update(ctx context.Context, inp interfaces.UpdateAssetParam, operator *usecase.Operator) (*asset.Asset, error) {
+	return Run1(
+		ctx, operator, i.repos,
+		Usecase().Transaction(),
+		func(ctx context.Context) (*asset.Asset, error) {
+			a, err := i.repos.Asset.FindByID(ctx, inp.AssetID)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok := operator.IsWritableWorkspace(a.Workspace()); !ok {
+				return nil, interfaces.ErrOperationDenied
+			}
+
+			if inp.Name != nil {
+				a.SetName(*inp.Name)
+			}
+			if inp.Folder != nil {
+				if err := a.SetFolder(*inp.Folder); err != nil {
+					return nil, err
+				}
+			}
+			if inp.Tags != nil {
+				a.SetTags(*inp.Tags)
+			}
+
+			if err := i.repos.Asset.Save(ctx, a); err != nil {
+				return nil, err
+			}
+			return a, nil
+		},
+	)
+}
+
+func (i *Asset) Remove(ctx context.Context, inp interfaces.RemoveAssetParam, operator *usecase.Operator) (result id.AssetID, err error) {
+	aid := inp.AssetID
 	return Run1(
 		ctx, operator, i.repos,
 		Usecase().Transaction(),
@@ -112,6 +223,16 @@ func (i *Asset) Remove(ctx context.Context, aid id.AssetID, operator *usecase.Op
 				return aid, interfaces.ErrOperationDenied
 			}
 
+			if !inp.Force {
+				usages, err := i.usages(ctx, asset)
+				if err != nil {
+					return aid, err
+				}
+				if len(usages) > 0 {
+					return aid, interfaces.ErrAssetInUse
+				}
+			}
+
 			if url, _ := url.Parse(asset.URL()); url != nil {
 				if err := i.gateways.File.RemoveAsset(ctx, url); err != nil {
 					return aid, err
@@ -122,3 +243,133 @@ func (i *Asset) Remove(ctx context.Context, aid id.AssetID, operator *usecase.Op
 		},
 	)
 }
+
+// usages finds the scenes, the layers, the NLS layers and the stories in the workspace of the asset which refer to the asset URL.
+func (i *Asset) usages(ctx context.Context, a *asset.Asset) ([]interfaces.AssetUsage, error) {
+	scenes, err := i.repos.Scene.FindByWorkspace(ctx, a.Workspace())
+	if err != nil {
+		return nil, err
+	}
+
+	var res []interfaces.AssetUsage
+	for _, s := range scenes {
+		var propertyIDs id.PropertyIDList
+		owners := map[id.PropertyID]interfaces.AssetUsage{}
+		addOwner := func(u interfaces.AssetUsage, ids ...id.PropertyID) {
+			for _, p := range ids {
+				if _, ok := owners[p]; !ok {
+					owners[p] = u
+					propertyIDs = append(propertyIDs, p)
+				}
+			}
+		}
+
+		addOwner(interfaces.AssetUsage{
+			Type:    interfaces.AssetUsageTypeScene,
+			SceneID: s.ID(),
+			ID:      s.ID().String(),
+		}, s.Properties()...)
+
+		legacyLayers, err := i.repos.Layer.FindByScene(ctx, s.ID())
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range legacyLayers.Deref() {
+			addOwner(interfaces.AssetUsage{
+				Type:    interfaces.AssetUsageTypeLayer,
+				SceneID: s.ID(),
+				ID:      l.ID().String(),
+			}, l.Properties()...)
+		}
+
+		layers, err := i.repos.NLSLayer.FindByScene(ctx, s.ID())
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range layers.Deref() {
+			u := interfaces.AssetUsage{
+				Type:    interfaces.AssetUsageTypeNLSLayer,
+				SceneID: s.ID(),
+				ID:      l.ID().String(),
+			}
+			if c := l.Config(); c != nil && referencesURL(map[string]any(*c), a.URL()) {
+				res = append(res, u)
+			}
+			if ib := l.Infobox(); ib != nil {
+				addOwner(u, ib.Property())
+				for _, b := range ib.Blocks() {
+					addOwner(u, b.Property())
+				}
+			}
+		}
+
+		stories, err := i.repos.Storytelling.FindByScene(ctx, s.ID())
+		if err != nil {
+			return nil, err
+		}
+		if stories != nil {
+			for _, st := range *stories {
+				addOwner(interfaces.AssetUsage{
+					Type:    interfaces.AssetUsageTypeStory,
+					SceneID: s.ID(),
+					ID:      st.Id().String(),
+				}, st.Properties()...)
+			}
+		}
+
+		properties, err := i.repos.Property.FindByIDs(ctx, propertyIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range properties {
+			if p == nil {
+				continue
+			}
+			if lo.SomeBy(p.Fields(nil), func(f *property.Field) bool {
+				return referencesURL(f.Value().Value(), a.URL())
+			}) {
+				u := owners[p.ID()]
+				u.PropertyID = p.IDRef()
+				res = append(res, u)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// referencesURL reports whether the value includes the URL.
+func referencesURL(v any, u string) bool {
+	switch v := v.(type) {
+	case string:
+		return v == u
+	case *url.URL:
+		return v != nil && v.String() == u
+	case map[string]any:
+		for _, c := range v {
+			if referencesURL(c, u) {
+				return true
+			}
+		}
+	case []any:
+		for _, c := range v {
+			if referencesURL(c, u) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// headBuffer keeps the bytes written up to the limit and discards the rest.
+type headBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *headBuffer) Write(p []byte) (int, error) {
+	if n := b.limit - b.Len(); n > 0 {
+		_, _ = b.Buffer.Write(p[:min(n, len(p))])
+	}
+	return len(p), nil
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"net/url"
+	"path"
 	"testing"
 
 	"github.com/reearth/reearth/server/internal/infrastructure/fs"
@@ -14,10 +16,18 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/asset"
 	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
 	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/account/accountdomain/workspace"
 	"github.com/reearth/reearthx/account/accountinfrastructure/accountmemory"
 	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
@@ -73,3 +83,112 @@ func TestAsset_Create(t *testing.T) {
 	a, _ := uc.repos.Asset.FindByID(ctx, aid)
 	assert.Equal(t, want, a)
 }
+
+func TestAsset_CreateWithMetadata(t *testing.T) {
+	ctx := context.Background()
+	ws := workspace.New().NewID().MustBuild()
+	f, _ := fs.NewFile(afero.NewMemMapFs(), "")
+	uc := &Asset{
+		repos: &repo.Container{
+			Asset:     memory.NewAsset(),
+			Workspace: accountmemory.NewWorkspaceWith(ws),
+		},
+		gateways: &gateway.Container{
+			File: f,
+		},
+	}
+
+	content := `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[139,35]}}]}`
+	res, err := uc.Create(ctx, interfaces.CreateAssetParam{
+		WorkspaceID: ws.ID(),
+		File: &file.File{
+			Content: io.NopCloser(bytes.NewBufferString(content)),
+			Path:    "points.geojson",
+			Size:    int64(len(content)),
+		},
+		Folder: lo.ToPtr("/maps/"),
+		Tags:   []string{"points", " points"},
+	}, &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			WritableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "maps", res.Folder())
+	assert.Equal(t, []string{"points"}, res.Tags())
+	assert.Equal(t, &asset.Metadata{FeatureCount: 1, BBox: &asset.BBox{139, 35, 139, 35}}, res.Metadata())
+
+	// the uploaded file is not affected by the metadata extraction
+	r, err := f.ReadAsset(ctx, path.Base(res.URL()))
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(lo.Must(io.ReadAll(r))))
+}
+
+func TestAsset_Usages(t *testing.T) {
+	ctx := context.Background()
+	ws := workspace.New().NewID().MustBuild()
+	r := memory.New()
+	r.Workspace = accountmemory.NewWorkspaceWith(ws)
+	uc := &Asset{repos: r, gateways: &gateway.Container{}}
+
+	a := asset.New().NewID().Workspace(ws.ID()).URL("https://example.com/assets/a.png").Size(1).MustBuild()
+	lo.Must0(r.Asset.Save(ctx, a))
+
+	u := lo.Must(url.Parse(a.URL()))
+	newProperty := func(sid id.SceneID, v *url.URL) *property.Property {
+		p := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/default")).Items([]property.Item{
+			property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+				property.NewField("image").Value(property.OptionalValueFrom(property.ValueTypeURL.ValueFrom(v))).MustBuild(),
+			}).MustBuild(),
+		}).MustBuild()
+		lo.Must0(r.Property.Save(ctx, p))
+		return p
+	}
+
+	sid := id.NewSceneID()
+	sceneProperty := newProperty(sid, u)
+	s := scene.New().ID(sid).Workspace(ws.ID()).RootLayer(id.NewLayerID()).Property(sceneProperty.ID()).MustBuild()
+	lo.Must0(r.Scene.Save(ctx, s))
+
+	storyProperty := newProperty(sid, lo.Must(url.Parse("https://example.com/assets/b.png")))
+	story := storytelling.NewStory().NewID().Scene(sid).Property(storyProperty.ID()).MustBuild()
+	lo.Must0(r.Storytelling.Save(ctx, *story))
+
+	nlsLayer := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).LayerType("simple").Config(&nlslayer.Config{
+		"data": map[string]any{"type": "geojson", "url": a.URL()},
+	}).MustBuild()
+	lo.Must0(r.NLSLayer.Save(ctx, nlsLayer))
+
+	// legacy layers refer to assets with their properties and infobox fields
+	otherURL := lo.Must(url.Parse("https://example.com/assets/b.png"))
+	infoboxFieldProperty := newProperty(sid, u)
+	legacyLayer := layer.NewItem().NewID().Scene(sid).Property(newProperty(sid, otherURL).IDRef()).Infobox(layer.NewInfobox([]*layer.InfoboxField{
+		layer.NewInfoboxField().NewID().Plugin(id.OfficialPluginID).Extension("textblock").Property(infoboxFieldProperty.ID()).MustBuild(),
+	}, newProperty(sid, otherURL).ID())).MustBuild()
+	lo.Must0(r.Layer.Save(ctx, legacyLayer))
+
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+			WritableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+		},
+	}
+
+	usages, err := uc.Usages(ctx, a.ID(), op)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []interfaces.AssetUsage{
+		{Type: interfaces.AssetUsageTypeScene, SceneID: sid, ID: sid.String(), PropertyID: sceneProperty.IDRef()},
+		{Type: interfaces.AssetUsageTypeLayer, SceneID: sid, ID: legacyLayer.ID().String(), PropertyID: infoboxFieldProperty.IDRef()},
+		{Type: interfaces.AssetUsageTypeNLSLayer, SceneID: sid, ID: nlsLayer.ID().String()},
+	}, usages)
+
+	// removing the asset in use is blocked unless it is forced
+	_, err = uc.Remove(ctx, interfaces.RemoveAssetParam{AssetID: a.ID()}, op)
+	assert.Equal(t, interfaces.ErrAssetInUse, err)
+
+	uc.gateways.File = lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com/assets"))
+	_, err = uc.Remove(ctx, interfaces.RemoveAssetParam{AssetID: a.ID(), Force: true}, op)
+	assert.NoError(t, err)
+	_, err = r.Asset.FindByID(ctx, a.ID())
+	assert.Equal(t, rerror.ErrNotFound, err)
+}
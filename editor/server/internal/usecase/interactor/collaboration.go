@@ -0,0 +1,318 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/history"
+	"github.com/reearth/reearth/server/pkg/scene/presence"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
+)
+
+type Collaboration struct {
+	common
+	pubsub   gateway.PubSub
+	presence gateway.Presence
+}
+
+func NewCollaboration(g *gateway.Container) interfaces.Collaboration {
+	return &Collaboration{
+		pubsub:   g.PubSub,
+		presence: g.Presence,
+	}
+}
+
+func (i *Collaboration) SubscribeChanges(ctx context.Context, sid id.SceneID, operator *usecase.Operator) (<-chan *interfaces.SceneChangeEvent, error) {
+	if err := i.CanReadScene(sid, operator); err != nil {
+		return nil, err
+	}
+	if i.pubsub == nil {
+		return nil, interfaces.ErrCollaborationNotAvailable
+	}
+
+	msgs, err := i.pubsub.Subscribe(ctx, sceneChangesTopic(sid))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(chan *interfaces.SceneChangeEvent)
+	go func() {
+		defer close(res)
+		for msg := range msgs {
+			e, err := decodeSceneChangeEvent(msg)
+			if err != nil {
+				log.Errorfc(ctx, "collaboration: invalid scene change event: %v", err)
+				continue
+			}
+			select {
+			case res <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return res, nil
+}
+
+// SubscribePresence keeps the presence of the operator alive while subscribing.
+// A user editing a scene in several sessions leaves when any of the sessions is closed,
+// and comes back with the next heartbeat of the remaining sessions.
+func (i *Collaboration) SubscribePresence(ctx context.Context, sid id.SceneID, operator *usecase.Operator) (<-chan presence.List, error) {
+	if err := i.CanReadScene(sid, operator); err != nil {
+		return nil, err
+	}
+	if i.pubsub == nil || i.presence == nil {
+		return nil, interfaces.ErrCollaborationNotAvailable
+	}
+
+	msgs, err := i.pubsub.Subscribe(ctx, presenceTopic(sid))
+	if err != nil {
+		return nil, err
+	}
+
+	user := operatorUser(operator)
+	if user != nil {
+		if err := i.heartbeat(ctx, sid, *user); err != nil {
+			return nil, err
+		}
+		i.notifyPresence(ctx, sid)
+	}
+
+	res := make(chan presence.List)
+	go func() {
+		defer close(res)
+
+		// heartbeats keep the presence from expiring
+		t := time.NewTicker(i.presence.TTL() / 2)
+		defer t.Stop()
+
+		var last presence.List
+		sent := false
+		send := func() bool {
+			l, err := i.presence.FindByScene(ctx, sid)
+			if err != nil {
+				log.Errorfc(ctx, "collaboration: failed to load presence: %v", err)
+				return true
+			}
+			if sent && l.Equal(last) {
+				return true
+			}
+			select {
+			case res <- l:
+				last, sent = l, true
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		if !send() {
+			i.leave(ctx, sid, user)
+			return
+		}
+		for {
+			select {
+			case _, ok := <-msgs:
+				if !ok {
+					i.leave(ctx, sid, user)
+					return
+				}
+			case <-t.C:
+				if user != nil {
+					if err := i.heartbeat(ctx, sid, *user); err != nil {
+						log.Errorfc(ctx, "collaboration: failed to save presence: %v", err)
+					}
+				}
+			}
+			if !send() {
+				i.leave(ctx, sid, user)
+				return
+			}
+		}
+	}()
+	return res, nil
+}
+
+func (i *Collaboration) FindPresence(ctx context.Context, sid id.SceneID, operator *usecase.Operator) (presence.List, error) {
+	if err := i.CanReadScene(sid, operator); err != nil {
+		return nil, err
+	}
+	if i.presence == nil {
+		return nil, interfaces.ErrCollaborationNotAvailable
+	}
+	return i.presence.FindByScene(ctx, sid)
+}
+
+func (i *Collaboration) UpdatePresence(ctx context.Context, param interfaces.UpdatePresenceParam, operator *usecase.Operator) (presence.List, error) {
+	if err := i.CanWriteScene(param.SceneID, operator); err != nil {
+		return nil, err
+	}
+	if i.presence == nil {
+		return nil, interfaces.ErrCollaborationNotAvailable
+	}
+
+	user := operatorUser(operator)
+	if user == nil {
+		return nil, interfaces.ErrOperationDenied
+	}
+
+	if err := i.presence.Save(ctx, presence.Presence{
+		Scene:     param.SceneID,
+		User:      *user,
+		Layer:     param.Layer,
+		StoryPage: param.StoryPage,
+		UpdatedAt: util.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	i.notifyPresence(ctx, param.SceneID)
+	return i.presence.FindByScene(ctx, param.SceneID)
+}
+
+// heartbeat extends the presence of the user keeping what the user is focusing on.
+func (i *Collaboration) heartbeat(ctx context.Context, sid id.SceneID, user accountdomain.UserID) error {
+	l, err := i.presence.FindByScene(ctx, sid)
+	if err != nil {
+		return err
+	}
+
+	p := l.FindByUser(user)
+	if p == nil {
+		p = &presence.Presence{Scene: sid, User: user}
+	}
+	p.UpdatedAt = util.Now()
+	return i.presence.Save(ctx, *p)
+}
+
+func (i *Collaboration) leave(ctx context.Context, sid id.SceneID, user *accountdomain.UserID) {
+	if user == nil {
+		return
+	}
+
+	// the context of the subscription has been already done
+	ctx = context.WithoutCancel(ctx)
+	if err := i.presence.Remove(ctx, sid, *user); err != nil {
+		log.Errorfc(ctx, "collaboration: failed to remove presence: %v", err)
+		return
+	}
+	i.notifyPresence(ctx, sid)
+}
+
+// notifyPresence lets subscribers reload the presences of the scene.
+func (i *Collaboration) notifyPresence(ctx context.Context, sid id.SceneID) {
+	if err := i.pubsub.Publish(ctx, presenceTopic(sid), nil); err != nil {
+		log.Errorfc(ctx, "collaboration: failed to publish presence: %v", err)
+	}
+}
+
+func sceneChangesTopic(sid id.SceneID) string {
+	return "scene/" + sid.String() + "/changes"
+}
+
+func presenceTopic(sid id.SceneID) string {
+	return "scene/" + sid.String() + "/presence"
+}
+
+func newSceneChangeEvent(sid id.SceneID, hid *id.HistoryID, user *accountdomain.UserID, operation string, changes []history.Change) *interfaces.SceneChangeEvent {
+	return &interfaces.SceneChangeEvent{
+		Scene:     sid,
+		History:   hid,
+		User:      user,
+		Operation: operation,
+		Changes: lo.Map(changes, func(c history.Change, _ int) interfaces.SceneChange {
+			return interfaces.SceneChange{
+				Type:    c.Type,
+				ID:      c.ID,
+				Created: c.IsCreated(),
+				Removed: c.IsRemoved(),
+				Version: history.Version(c.After),
+			}
+		}),
+	}
+}
+
+// publishSceneChanges only logs errors because the changes have been already saved.
+func publishSceneChanges(ctx context.Context, pubsub gateway.PubSub, e *interfaces.SceneChangeEvent) {
+	if pubsub == nil || e == nil || len(e.Changes) == 0 {
+		return
+	}
+
+	b, err := json.Marshal(newSceneChangeEventDocument(e))
+	if err == nil {
+		err = pubsub.Publish(ctx, sceneChangesTopic(e.Scene), b)
+	}
+	if err != nil {
+		log.Errorfc(ctx, "collaboration: failed to publish scene changes: %v", err)
+	}
+}
+
+type sceneChangeEventDocument struct {
+	Scene     string
+	History   *string
+	User      *string
+	Operation string
+	Changes   []sceneChangeDocument
+}
+
+type sceneChangeDocument struct {
+	Type    string
+	ID      string
+	Created bool
+	Removed bool
+	Version string
+}
+
+func newSceneChangeEventDocument(e *interfaces.SceneChangeEvent) sceneChangeEventDocument {
+	return sceneChangeEventDocument{
+		Scene:     e.Scene.String(),
+		History:   e.History.StringRef(),
+		User:      e.User.StringRef(),
+		Operation: e.Operation,
+		Changes: lo.Map(e.Changes, func(c interfaces.SceneChange, _ int) sceneChangeDocument {
+			return sceneChangeDocument{
+				Type:    string(c.Type),
+				ID:      c.ID,
+				Created: c.Created,
+				Removed: c.Removed,
+				Version: c.Version,
+			}
+		}),
+	}
+}
+
+func decodeSceneChangeEvent(b []byte) (*interfaces.SceneChangeEvent, error) {
+	var d sceneChangeEventDocument
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, err
+	}
+
+	sid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interfaces.SceneChangeEvent{
+		Scene:     sid,
+		History:   id.HistoryIDFromRef(d.History),
+		User:      accountdomain.UserIDFromRef(d.User),
+		Operation: d.Operation,
+		Changes: lo.Map(d.Changes, func(c sceneChangeDocument, _ int) interfaces.SceneChange {
+			return interfaces.SceneChange{
+				Type:    history.EntityType(c.Type),
+				ID:      c.ID,
+				Created: c.Created,
+				Removed: c.Removed,
+				Version: c.Version,
+			}
+		}),
+	}, nil
+}
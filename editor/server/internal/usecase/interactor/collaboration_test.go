@@ -0,0 +1,182 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/infrastructure/realtime"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/scene/history"
+	"github.com/reearth/reearth/server/pkg/scene/presence"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollaboration_SubscribeChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := memory.New()
+	g := &gateway.Container{PubSub: realtime.NewPubSub()}
+	sid := id.NewSceneID()
+	uid := accountdomain.NewUserID()
+	op := &usecase.Operator{
+		AcOperator:     &accountusecase.Operator{User: &uid},
+		ReadableScenes: []id.SceneID{sid},
+		WritableScenes: []id.SceneID{sid},
+	}
+
+	c := NewCollaboration(g)
+	h := NewSceneHistory(db, g)
+	style := NewStyle(recordSceneHistory(db))
+
+	_, err := c.SubscribeChanges(ctx, id.NewSceneID(), op)
+	assert.Equal(t, interfaces.ErrOperationDenied, err)
+
+	events, err := c.SubscribeChanges(ctx, sid, op)
+	assert.NoError(t, err)
+
+	ctx1 := h.Begin(ctx, "addStyle", op)
+	s, err := style.AddStyle(ctx1, interfaces.AddStyleInput{
+		SceneID: sid,
+		Name:    "style",
+		Value:   &scene.StyleValue{},
+	}, op)
+	assert.NoError(t, err)
+	assert.NoError(t, h.Commit(ctx1))
+
+	e := <-events
+	assert.Equal(t, sid, e.Scene)
+	assert.Equal(t, &uid, e.User)
+	assert.Equal(t, "addStyle", e.Operation)
+	assert.NotNil(t, e.History)
+	version, err := h.Version(ctx, history.EntityTypeStyle, s.ID().String())
+	assert.NoError(t, err)
+	assert.Equal(t, []interfaces.SceneChange{{
+		Type:    history.EntityTypeStyle,
+		ID:      s.ID().String(),
+		Created: true,
+		Version: version,
+	}}, e.Changes)
+
+	// undo is also delivered
+	_, err = h.Undo(ctx, sid, op)
+	assert.NoError(t, err)
+
+	e = <-events
+	assert.Equal(t, "undo", e.Operation)
+	assert.Equal(t, []interfaces.SceneChange{{
+		Type:    history.EntityTypeStyle,
+		ID:      s.ID().String(),
+		Removed: true,
+	}}, e.Changes)
+
+	cancel()
+	_, ok := <-events
+	assert.False(t, ok)
+}
+
+func TestCollaboration_Presence(t *testing.T) {
+	ctx := context.Background()
+	g := &gateway.Container{
+		PubSub:   realtime.NewPubSub(),
+		Presence: realtime.NewPresence(time.Minute),
+	}
+	sid := id.NewSceneID()
+	u1, u2 := accountdomain.NewUserID(), accountdomain.NewUserID()
+	op1 := &usecase.Operator{
+		AcOperator:     &accountusecase.Operator{User: &u1},
+		ReadableScenes: []id.SceneID{sid},
+		WritableScenes: []id.SceneID{sid},
+	}
+	op2 := &usecase.Operator{
+		AcOperator:     &accountusecase.Operator{User: &u2},
+		ReadableScenes: []id.SceneID{sid},
+		WritableScenes: []id.SceneID{sid},
+	}
+	c := NewCollaboration(g)
+
+	// the first user joins
+	ctx1, cancel1 := context.WithCancel(ctx)
+	defer cancel1()
+	lists1, err := c.SubscribePresence(ctx1, sid, op1)
+	assert.NoError(t, err)
+	assert.Equal(t, []accountdomain.UserID{u1}, presenceUsers(<-lists1))
+
+	// the second user joins
+	ctx2, cancel2 := context.WithCancel(ctx)
+	lists2, err := c.SubscribePresence(ctx2, sid, op2)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []accountdomain.UserID{u1, u2}, presenceUsers(<-lists2))
+	assert.ElementsMatch(t, []accountdomain.UserID{u1, u2}, presenceUsers(<-lists1))
+
+	// the second user focuses on a layer
+	lid := id.NewNLSLayerID()
+	res, err := c.UpdatePresence(ctx, interfaces.UpdatePresenceParam{SceneID: sid, Layer: &lid}, op2)
+	assert.NoError(t, err)
+	assert.Equal(t, &lid, res.FindByUser(u2).Layer)
+	assert.Equal(t, &lid, (<-lists1).FindByUser(u2).Layer)
+
+	// the second user leaves
+	cancel2()
+	assert.Equal(t, []accountdomain.UserID{u1}, presenceUsers(<-lists1))
+
+	res, err = c.FindPresence(ctx, sid, op1)
+	assert.NoError(t, err)
+	assert.Equal(t, []accountdomain.UserID{u1}, presenceUsers(res))
+
+	_, err = c.UpdatePresence(ctx, interfaces.UpdatePresenceParam{SceneID: sid}, &usecase.Operator{})
+	assert.Equal(t, interfaces.ErrOperationDenied, err)
+}
+
+func TestSceneHistory_ExpectVersion(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	sid := id.NewSceneID()
+	op := &usecase.Operator{
+		ReadableScenes: []id.SceneID{sid},
+		WritableScenes: []id.SceneID{sid},
+	}
+
+	h := NewSceneHistory(db, &gateway.Container{})
+	style := NewStyle(recordSceneHistory(db))
+
+	s, err := style.AddStyle(ctx, interfaces.AddStyleInput{
+		SceneID: sid,
+		Name:    "style",
+		Value:   &scene.StyleValue{},
+	}, op)
+	assert.NoError(t, err)
+
+	version, err := h.Version(ctx, history.EntityTypeStyle, s.ID().String())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, version)
+
+	// the first editor renames the style
+	ctx1 := h.Begin(ctx, "updateStyle", op)
+	h.ExpectVersion(ctx1, history.EntityTypeStyle, s.ID().String(), version)
+	_, err = style.UpdateStyle(ctx1, interfaces.UpdateStyleInput{StyleID: s.ID(), Name: lo.ToPtr("a")}, op)
+	assert.NoError(t, err)
+
+	// the second editor has not seen the rename
+	ctx2 := h.Begin(ctx, "updateStyle", op)
+	h.ExpectVersion(ctx2, history.EntityTypeStyle, s.ID().String(), version)
+	_, err = style.UpdateStyle(ctx2, interfaces.UpdateStyleInput{StyleID: s.ID(), Name: lo.ToPtr("b")}, op)
+	assert.Equal(t, interfaces.ErrVersionConflict, err)
+
+	got, err := db.Style.FindByID(ctx, s.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, "a", got.Name())
+}
+
+func presenceUsers(l presence.List) []accountdomain.UserID {
+	return lo.Map(l, func(p presence.Presence, _ int) accountdomain.UserID { return p.User })
+}
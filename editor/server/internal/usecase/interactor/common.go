@@ -10,6 +10,7 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/policy"
 	"github.com/reearth/reearth/server/pkg/project"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearthx/account/accountdomain"
@@ -24,34 +25,49 @@ type ContainerConfig struct {
 	AuthSrvUIDomain    string
 	PublishedIndexHTML string
 	PublishedIndexURL  *url.URL
+	// secret to sign the view tokens of published stories
+	PublishedTokenSecret string
+	// base URLs of the assets and the plugin files served by the server
+	AssetBaseURL  *url.URL
+	PluginBaseURL *url.URL
 }
 
 func NewContainer(r *repo.Container, g *gateway.Container,
 	ar *accountrepo.Container, ag *accountgateway.Container,
 	config ContainerConfig) interfaces.Container {
-	var published interfaces.Published
+	var published *Published
 	if config.PublishedIndexURL != nil && config.PublishedIndexURL.String() != "" {
-		published = NewPublishedWithURL(r.Project, r.Storytelling, g.File, config.PublishedIndexURL)
+		published = NewPublishedWithURL(r.Project, r.Storytelling, g.File, config.PublishedIndexURL, config.PublishedTokenSecret)
 	} else {
-		published = NewPublished(r.Project, r.Storytelling, g.File, config.PublishedIndexHTML)
+		published = NewPublished(r.Project, r.Storytelling, g.File, config.PublishedIndexHTML, config.PublishedTokenSecret)
 	}
+	published.assetBaseURL = config.AssetBaseURL
+	published.pluginBaseURL = config.PluginBaseURL
+
+	sceneHistory := NewSceneHistory(r, g)
+	r = recordSceneHistory(r)
 
 	return interfaces.Container{
-		Asset:        NewAsset(r, g),
-		Dataset:      NewDataset(r, g),
-		Layer:        NewLayer(r),
-		NLSLayer:     NewNLSLayer(r),
-		Style:        NewStyle(r),
-		Plugin:       NewPlugin(r, g),
-		Policy:       NewPolicy(r),
-		Project:      NewProject(r, g),
-		Property:     NewProperty(r, g),
-		Published:    published,
-		Scene:        NewScene(r, g),
-		Tag:          NewTag(r),
-		StoryTelling: NewStorytelling(r, g),
-		Workspace:    accountinteractor.NewWorkspace(ar, workspaceMemberCountEnforcer(r)),
-		User:         accountinteractor.NewMultiUser(ar, ag, config.SignupSecret, config.AuthSrvUIDomain, ar.Users),
+		Asset:          NewAsset(r, g),
+		Dataset:        NewDataset(r, g),
+		Layer:          NewLayer(r),
+		NLSLayer:       NewNLSLayer(r),
+		Style:          NewStyle(r),
+		StyleLibrary:   NewStyleLibrary(r),
+		PluginKey:      NewPluginKey(r),
+		Plugin:         NewPlugin(r, g),
+		Policy:         NewPolicy(r),
+		Project:        NewProject(r, g),
+		Property:       NewProperty(r, g),
+		Published:      published,
+		Scene:          NewScene(r, g),
+		SceneHistory:   sceneHistory,
+		Collaboration:  NewCollaboration(g),
+		Tag:            NewTag(r),
+		StoryTelling:   NewStorytelling(r, g, config.PublishedTokenSecret),
+		WorkspaceUsage: NewWorkspaceUsage(r),
+		Workspace:      accountinteractor.NewWorkspace(ar, workspaceMemberCountEnforcer(r)),
+		User:           accountinteractor.NewMultiUser(ar, ag, config.SignupSecret, config.AuthSrvUIDomain, ar.Users),
 	}
 }
 
@@ -143,6 +159,45 @@ func (i commonSceneLock) ReleaseSceneLock(ctx context.Context, s id.SceneID) {
 	_ = i.sceneLockRepo.SaveLock(ctx, s, scene.LockModeFree)
 }
 
+// commonPolicy finds the policy applied to the workspace of scenes to enforce its limits.
+type commonPolicy struct {
+	sceneRepo     repo.Scene
+	workspaceRepo accountrepo.Workspace
+	policyRepo    repo.Policy
+}
+
+func newCommonPolicy(r *repo.Container) commonPolicy {
+	return commonPolicy{
+		sceneRepo:     r.Scene,
+		workspaceRepo: r.Workspace,
+		policyRepo:    r.Policy,
+	}
+}
+
+// scenePolicy returns the policy of the workspace of the scene. The policy is nil when no policy is applied.
+func (i commonPolicy) scenePolicy(ctx context.Context, sid id.SceneID, op *usecase.Operator) (*policy.Policy, *scene.Scene, error) {
+	s, err := i.sceneRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ws, err := i.workspaceRepo.FindByID(ctx, s.Workspace())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policyID := op.Policy(ws.Policy())
+	if policyID == nil {
+		return nil, s, nil
+	}
+
+	p, err := i.policyRepo.FindByID(ctx, *policyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, s, nil
+}
+
 type SceneDeleter struct {
 	Scene         repo.Scene
 	SceneLock     repo.SceneLock
@@ -150,6 +205,7 @@ type SceneDeleter struct {
 	Property      repo.Property
 	Dataset       repo.Dataset
 	DatasetSchema repo.DatasetSchema
+	SceneHistory  repo.SceneHistory
 }
 
 func (d SceneDeleter) Delete(ctx context.Context, s *scene.Scene, force bool) error {
@@ -188,6 +244,13 @@ func (d SceneDeleter) Delete(ctx context.Context, s *scene.Scene, force bool) er
 		return err
 	}
 
+	// Delete scene history
+	if d.SceneHistory != nil {
+		if err := d.SceneHistory.RemoveByScene(ctx, s.ID()); err != nil {
+			return err
+		}
+	}
+
 	// Release scene lock
 	if err := d.SceneLock.SaveLock(ctx, s.ID(), scene.LockModeFree); err != nil {
 		return err
@@ -203,8 +266,9 @@ func (d SceneDeleter) Delete(ctx context.Context, s *scene.Scene, force bool) er
 
 type ProjectDeleter struct {
 	SceneDeleter
-	File    gateway.File
-	Project repo.Project
+	File         gateway.File
+	Project      repo.Project
+	Storytelling repo.Storytelling
 }
 
 func (d ProjectDeleter) Delete(ctx context.Context, prj *project.Project, force bool, operator *usecase.Operator) error {
@@ -218,6 +282,21 @@ func (d ProjectDeleter) Delete(ctx context.Context, prj *project.Project, force
 		return err
 	}
 
+	// Delete published data of stories
+	if s != nil && d.Storytelling != nil {
+		stories, err := d.Storytelling.FindByScene(ctx, s.ID())
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return err
+		}
+		if stories != nil {
+			for _, st := range *stories {
+				if err := removePublishedStory(ctx, d.File, st); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
 	// Delete scene
 	if err := d.SceneDeleter.Delete(ctx, s, force); err != nil {
 		return err
@@ -230,6 +309,11 @@ func (d ProjectDeleter) Delete(ctx context.Context, prj *project.Project, force
 		}
 	}
 
+	// Delete snapshots of published data
+	if err := d.File.RemoveBuiltSceneSnapshots(ctx, prj.ID().String()); err != nil {
+		return err
+	}
+
 	// Delete project
 	if err := d.Project.Remove(ctx, prj.ID()); err != nil {
 		return err
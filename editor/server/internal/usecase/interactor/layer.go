@@ -13,6 +13,7 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/builtin"
 	"github.com/reearth/reearth/server/pkg/dataset"
+	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/layer"
 	"github.com/reearth/reearth/server/pkg/layer/decoding"
@@ -999,41 +1000,9 @@ func (i *Layer) ImportLayer(ctx context.Context, inp interfaces.ImportLayerParam
 		return nil, nil, err
 	}
 
-	var decoder decoding.Decoder
-	switch inp.Format {
-	case decoding.LayerEncodingFormatKML:
-		d := xml.NewDecoder(inp.File.Content)
-		decoder = decoding.NewKMLDecoder(d, parent.Scene())
-	case decoding.LayerEncodingFormatGEOJSON:
-		decoder = decoding.NewGeoJSONDecoder(inp.File.Content, parent.Scene())
-	case decoding.LayerEncodingFormatCZML:
-		d := json.NewDecoder(inp.File.Content)
-		decoder = decoding.NewCZMLDecoder(d, parent.Scene())
-	case decoding.LayerEncodingFormatREEARTH:
-		d := json.NewDecoder(inp.File.Content)
-		decoder = decoding.NewReearthDecoder(d, parent.Scene())
-	case decoding.LayerEncodingFormatSHAPE:
-		// limit file size to 2m
-		if inp.File.Size > 2097152 {
-			return nil, nil, errors.New("file is too big")
-		}
-		var reader decoding.ShapeReader
-		if inp.File.ContentType == "application/octet-stream" && strings.HasSuffix(inp.File.Path, ".shp") {
-			reader, err = shp.ReadFrom(inp.File.Content)
-			if err != nil {
-				return nil, nil, err
-			}
-			decoder = decoding.NewShapeDecoder(reader, parent.Scene())
-		} else if inp.File.ContentType == "application/zip" && strings.HasSuffix(inp.File.Path, ".zip") {
-			reader, err = shp.ReadZipFrom(inp.File.Content)
-			if err != nil {
-				return nil, nil, err
-			}
-		}
-		decoder = decoding.NewShapeDecoder(reader, parent.Scene())
-	}
-	if decoder == nil {
-		return nil, nil, errors.New("unsupported format")
+	decoder, err := decoderFrom(inp.File, inp.Format, parent.Scene())
+	if err != nil {
+		return nil, nil, err
 	}
 	result, err := decoder.Decode()
 	if err != nil {
@@ -1068,6 +1037,51 @@ func (i *Layer) ImportLayer(ctx context.Context, inp interfaces.ImportLayerParam
 	return rootLayers, parent, nil
 }
 
+func decoderFrom(f *file.File, format decoding.LayerEncodingFormat, sid id.SceneID) (decoding.Decoder, error) {
+	var decoder decoding.Decoder
+	switch format {
+	case decoding.LayerEncodingFormatKML:
+		d := xml.NewDecoder(f.Content)
+		decoder = decoding.NewKMLDecoder(d, sid)
+	case decoding.LayerEncodingFormatGEOJSON:
+		decoder = decoding.NewGeoJSONDecoder(f.Content, sid)
+	case decoding.LayerEncodingFormatCZML:
+		d := json.NewDecoder(f.Content)
+		decoder = decoding.NewCZMLDecoder(d, sid)
+	case decoding.LayerEncodingFormatREEARTH:
+		d := json.NewDecoder(f.Content)
+		decoder = decoding.NewReearthDecoder(d, sid)
+	case decoding.LayerEncodingFormatCSV:
+		decoder = decoding.NewCSVDecoder(f.Content, sid)
+	case decoding.LayerEncodingFormatSHAPE:
+		// limit file size to 2m
+		if f.Size > 2097152 {
+			return nil, errors.New("file is too big")
+		}
+		var reader decoding.ShapeReader
+		var err error
+		if f.ContentType == "application/octet-stream" && strings.HasSuffix(f.Path, ".shp") {
+			reader, err = shp.ReadFrom(f.Content)
+			if err != nil {
+				return nil, err
+			}
+		} else if f.ContentType == "application/zip" && strings.HasSuffix(f.Path, ".zip") {
+			reader, err = shp.ReadZipFrom(f.Content)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if reader == nil {
+			return nil, errors.New("unsupported format")
+		}
+		decoder = decoding.NewShapeDecoder(reader, sid)
+	}
+	if decoder == nil {
+		return nil, errors.New("unsupported format")
+	}
+	return decoder, nil
+}
+
 func (i *Layer) AttachTag(ctx context.Context, layerID id.LayerID, tagID id.TagID, operator *usecase.Operator) (layer.Layer, error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
@@ -2,12 +2,16 @@ package interactor
 
 import (
 	"context"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/reearth/reearth/server/internal/infrastructure/memory"
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/layer/decoding"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/stretchr/testify/assert"
@@ -36,3 +40,13 @@ func TestCreateInfobox(t *testing.T) {
 	assert.NotNil(t, property)
 	assert.NotNil(t, property.Schema())
 }
+
+func TestDecoderFrom_UnsupportedShape(t *testing.T) {
+	f := &file.File{
+		Content:     io.NopCloser(strings.NewReader("")),
+		Path:        "layer.txt",
+		ContentType: "text/plain",
+	}
+	_, err := decoderFrom(f, decoding.LayerEncodingFormatSHAPE, id.NewSceneID())
+	assert.EqualError(t, err, "unsupported format")
+}
@@ -1,15 +1,21 @@
 package interactor
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
+	"slices"
+	"strings"
 
 	"github.com/reearth/reearth/server/internal/usecase"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/builtin"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer/encoding"
 	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/nlslayer/nlslayerio"
 	"github.com/reearth/reearth/server/pkg/nlslayer/nlslayerops"
 	"github.com/reearth/reearth/server/pkg/plugin"
 	"github.com/reearth/reearth/server/pkg/property"
@@ -20,6 +26,7 @@ import (
 type NLSLayer struct {
 	common
 	commonSceneLock
+	commonPolicy
 	nlslayerRepo  repo.NLSLayer
 	sceneLockRepo repo.SceneLock
 	propertyRepo  repo.Property
@@ -30,6 +37,7 @@ type NLSLayer struct {
 func NewNLSLayer(r *repo.Container) interfaces.NLSLayer {
 	return &NLSLayer{
 		commonSceneLock: commonSceneLock{sceneLockRepo: r.SceneLock},
+		commonPolicy:    newCommonPolicy(r),
 		nlslayerRepo:    r.NLSLayer,
 		sceneLockRepo:   r.SceneLock,
 		propertyRepo:    r.Property,
@@ -71,6 +79,10 @@ func (i *NLSLayer) AddLayerSimple(ctx context.Context, inp interfaces.AddNLSLaye
 		return nil, interfaces.ErrOperationDenied
 	}
 
+	if err := i.enforceNLSLayerCount(ctx, inp.SceneID, 1, operator); err != nil {
+		return nil, err
+	}
+
 	layerSimple, err := nlslayerops.LayerSimple{
 		SceneID:   inp.SceneID,
 		Config:    inp.Config,
@@ -106,6 +118,216 @@ func (i *NLSLayer) AddLayerSimple(ctx context.Context, inp interfaces.AddNLSLaye
 	return layerSimple, nil
 }
 
+func (i *NLSLayer) AddLayerGroup(ctx context.Context, inp interfaces.AddNLSLayerGroupInput, operator *usecase.Operator) (_ *nlslayer.NLSLayerGroup, _ *nlslayer.NLSLayerGroup, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	if err := i.CanWriteScene(inp.SceneID, operator); err != nil {
+		return nil, nil, interfaces.ErrOperationDenied
+	}
+
+	if err := i.CheckSceneLock(ctx, inp.SceneID); err != nil {
+		return nil, nil, err
+	}
+
+	if err := i.enforceNLSLayerCount(ctx, inp.SceneID, 1, operator); err != nil {
+		return nil, nil, err
+	}
+
+	var parentLayer *nlslayer.NLSLayerGroup
+	if inp.ParentLayerID != nil {
+		parentLayer, err = i.nlslayerRepo.FindNLSLayerGroupByID(ctx, *inp.ParentLayerID)
+		if err != nil {
+			if errors.Is(err, rerror.ErrNotFound) {
+				return nil, nil, interfaces.ErrParentLayerNotFound
+			}
+			return nil, nil, err
+		}
+		if parentLayer.Scene() != inp.SceneID {
+			return nil, nil, interfaces.ErrParentLayerNotFound
+		}
+	}
+
+	layerGroup, err := nlslayerops.LayerGroup{
+		SceneID: inp.SceneID,
+		Config:  inp.Config,
+		Title:   inp.Title,
+		Visible: inp.Visible,
+	}.Initialize()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	layers := nlslayer.NLSLayerList{layerGroup.LayerRef()}
+	if parentLayer != nil {
+		index := -1
+		if inp.Index != nil {
+			index = *inp.Index
+		}
+		parentLayer.Children().AddLayer(layerGroup.ID(), index)
+		layers = append(layers, parentLayer.LayerRef())
+	}
+
+	if err := i.nlslayerRepo.SaveAll(ctx, layers); err != nil {
+		return nil, nil, err
+	}
+
+	tx.Commit()
+	return layerGroup, parentLayer, nil
+}
+
+// Move moves a layer into the destination group. If the destination is not specified, the layer is moved to the top level.
+func (i *NLSLayer) Move(ctx context.Context, inp interfaces.MoveNLSLayerInput, operator *usecase.Operator) (_ id.NLSLayerID, _ *nlslayer.NLSLayerGroup, _ *nlslayer.NLSLayerGroup, _ int, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	l, err := i.nlslayerRepo.FindByID(ctx, inp.LayerID)
+	if err != nil {
+		return inp.LayerID, nil, nil, -1, err
+	}
+	if err := i.CanWriteScene(l.Scene(), operator); err != nil {
+		return inp.LayerID, nil, nil, -1, err
+	}
+
+	if err := i.CheckSceneLock(ctx, l.Scene()); err != nil {
+		return inp.LayerID, nil, nil, -1, err
+	}
+
+	parentLayer, err := i.nlslayerRepo.FindParentByID(ctx, inp.LayerID)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return inp.LayerID, nil, nil, -1, err
+	}
+
+	var toParentLayer *nlslayer.NLSLayerGroup
+	if inp.DestLayerID != nil && parentLayer != nil && parentLayer.ID() == *inp.DestLayerID {
+		toParentLayer = parentLayer
+	} else if inp.DestLayerID != nil {
+		if *inp.DestLayerID == inp.LayerID {
+			return inp.LayerID, nil, nil, -1, interfaces.ErrCannotMoveLayerToItsDescendant
+		}
+
+		toParentLayer, err = i.nlslayerRepo.FindNLSLayerGroupByID(ctx, *inp.DestLayerID)
+		if err != nil {
+			return inp.LayerID, nil, nil, -1, err
+		}
+		if toParentLayer.Scene() != l.Scene() {
+			return inp.LayerID, nil, nil, -1, interfaces.ErrCannotMoveLayerToOtherScene
+		}
+
+		descendants, err := i.fetchAllChildren(ctx, l)
+		if err != nil {
+			return inp.LayerID, nil, nil, -1, err
+		}
+		if slices.Contains(descendants, toParentLayer.ID()) {
+			return inp.LayerID, nil, nil, -1, interfaces.ErrCannotMoveLayerToItsDescendant
+		}
+	}
+
+	index := -1
+	layers := nlslayer.NLSLayerList{}
+	if toParentLayer != nil {
+		toParentLayer.MoveLayerFrom(inp.LayerID, inp.Index, parentLayer)
+		index = toParentLayer.Children().FindLayerIndex(inp.LayerID)
+		layers = append(layers, toParentLayer.LayerRef())
+	} else if parentLayer != nil {
+		// move to the top level
+		parentLayer.Children().RemoveLayer(inp.LayerID)
+	}
+	if parentLayer != nil && (toParentLayer == nil || parentLayer.ID() != toParentLayer.ID()) {
+		layers = append(layers, parentLayer.LayerRef())
+	}
+
+	if len(layers) > 0 {
+		if err := i.nlslayerRepo.SaveAll(ctx, layers); err != nil {
+			return inp.LayerID, nil, nil, -1, err
+		}
+	}
+
+	tx.Commit()
+	return inp.LayerID, parentLayer, toParentLayer, index, nil
+}
+
+// UpdateVisibility shows or hides layers at once. The descendants of groups are also updated.
+func (i *NLSLayer) UpdateVisibility(ctx context.Context, ids id.NLSLayerIDList, visible bool, operator *usecase.Operator) (_ nlslayer.NLSLayerList, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	layers, err := i.nlslayerRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var descendants id.NLSLayerIDList
+	for _, l := range layers {
+		if l == nil {
+			return nil, rerror.ErrNotFound
+		}
+		if err := i.CanWriteScene((*l).Scene(), operator); err != nil {
+			return nil, err
+		}
+		if err := i.CheckSceneLock(ctx, (*l).Scene()); err != nil {
+			return nil, err
+		}
+
+		children, err := i.fetchAllChildren(ctx, *l)
+		if err != nil {
+			return nil, err
+		}
+		descendants = append(descendants, children...)
+	}
+
+	descendants = slices.DeleteFunc(descendants, func(lid id.NLSLayerID) bool {
+		return slices.Contains(ids, lid)
+	})
+	if len(descendants) > 0 {
+		children, err := i.nlslayerRepo.FindByIDs(ctx, descendants)
+		if err != nil {
+			return nil, err
+		}
+		layers = layers.AddUnique(children...)
+	}
+
+	for _, l := range layers {
+		if l != nil {
+			(*l).SetVisible(visible)
+		}
+	}
+
+	if err := i.nlslayerRepo.SaveAll(ctx, layers); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return layers, nil
+}
+
 func (i *NLSLayer) fetchAllChildren(ctx context.Context, l nlslayer.NLSLayer) ([]id.NLSLayerID, error) {
 	lidl := nlslayer.ToNLSLayerGroup(l).Children().Layers()
 	layers, err := i.nlslayerRepo.FindByIDs(ctx, lidl)
@@ -161,9 +383,6 @@ func (i *NLSLayer) Remove(ctx context.Context, lid id.NLSLayerID, operator *usec
 		}
 	}
 
-	if parentLayer != nil {
-		return lid, nil, interfaces.ErrCannotRemoveLayerToLinkedLayerGroup
-	}
 	if parentLayer != nil {
 		parentLayer.Children().RemoveLayer(lid)
 		err = i.nlslayerRepo.Save(ctx, parentLayer)
@@ -218,6 +437,19 @@ func (i *NLSLayer) Update(ctx context.Context, inp interfaces.UpdateNLSLayerInpu
 		layer.UpdateConfig(inp.Config)
 	}
 
+	if inp.DeleteCluster {
+		layer.SetCluster(nil)
+	} else if inp.Cluster != nil {
+		s, err := i.sceneRepo.FindByID(ctx, layer.Scene())
+		if err != nil {
+			return nil, err
+		}
+		if !s.Clusters().Has(*inp.Cluster) {
+			return nil, interfaces.ErrClusterNotFound
+		}
+		layer.SetCluster(inp.Cluster)
+	}
+
 	err = i.nlslayerRepo.Save(ctx, layer)
 	if err != nil {
 		return nil, err
@@ -530,6 +762,10 @@ func (i *NLSLayer) Duplicate(ctx context.Context, lid id.NLSLayerID, operator *u
 		return nil, err
 	}
 
+	if err := i.enforceNLSLayerCount(ctx, layer.Scene(), 1, operator); err != nil {
+		return nil, err
+	}
+
 	duplicatedLayer := layer.Duplicate()
 
 	err = i.nlslayerRepo.Save(ctx, duplicatedLayer)
@@ -602,6 +838,10 @@ func (i *NLSLayer) AddGeoJSONFeature(ctx context.Context, inp interfaces.AddNLSL
 		return nlslayer.Feature{}, err
 	}
 
+	if err := i.enforceSketchFeatureCount(ctx, layer, 1, operator); err != nil {
+		return nlslayer.Feature{}, err
+	}
+
 	geometry, err := nlslayer.NewGeometryFromMap(inp.Geometry)
 	if err != nil {
 		return nlslayer.Feature{}, err
@@ -732,3 +972,145 @@ func (i *NLSLayer) DeleteGeoJSONFeature(ctx context.Context, inp interfaces.Dele
 	tx.Commit()
 	return inp.FeatureID, nil
 }
+
+func (i *NLSLayer) ImportGeoJSONFeatures(ctx context.Context, inp interfaces.ImportNLSLayerGeoJSONFeaturesParams, operator *usecase.Operator) (_ nlslayer.NLSLayer, _ []nlslayer.Feature, err error) {
+	if inp.File == nil {
+		return nil, nil, interfaces.ErrFileNotIncluded
+	}
+
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	layer, err := i.nlslayerRepo.FindByID(ctx, inp.LayerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := i.CanWriteScene(layer.Scene(), operator); err != nil {
+		return nil, nil, interfaces.ErrOperationDenied
+	}
+	if err := i.CheckSceneLock(ctx, layer.Scene()); err != nil {
+		return nil, nil, err
+	}
+	// features can be imported only into sketch layers, not into layers which load their data from URLs
+	if !layer.IsSketch() {
+		return nil, nil, interfaces.ErrSketchNotFound
+	}
+
+	decoder, err := decoderFrom(inp.File, inp.Format, layer.Scene())
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := decoder.Decode()
+	if err != nil {
+		return nil, nil, err
+	}
+	features, err := nlslayerio.Features(result)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := i.enforceSketchFeatureCount(ctx, layer, len(features), operator); err != nil {
+		return nil, nil, err
+	}
+
+	for j, f := range features {
+		properties, err := layer.Sketch().ValidateProperties(f.Properties())
+		if err != nil {
+			return nil, nil, err
+		}
+		features[j].UpdateProperties(properties)
+	}
+
+	if layer.Sketch() == nil || layer.Sketch().FeatureCollection() == nil {
+		var schema *map[string]any
+		if layer.Sketch() != nil {
+			schema = layer.Sketch().CustomPropertySchema()
+		}
+		layer.SetSketch(nlslayer.NewSketchInfo(
+			schema,
+			nlslayer.NewFeatureCollection("FeatureCollection", features),
+		))
+	} else {
+		for _, f := range features {
+			layer.Sketch().FeatureCollection().AddFeature(f)
+		}
+	}
+
+	if err := i.nlslayerRepo.Save(ctx, layer); err != nil {
+		return nil, nil, err
+	}
+
+	tx.Commit()
+	return layer, features, nil
+}
+
+// enforceNLSLayerCount checks the policy before n layers are added to the scene.
+func (i *NLSLayer) enforceNLSLayerCount(ctx context.Context, sid id.SceneID, n int, operator *usecase.Operator) error {
+	p, _, err := i.scenePolicy(ctx, sid, operator)
+	if err != nil || p == nil {
+		return err
+	}
+
+	layers, err := i.nlslayerRepo.FindByScene(ctx, sid)
+	if err != nil {
+		return err
+	}
+	return p.EnforceNLSLayerCount(len(layers) + n)
+}
+
+// enforceSketchFeatureCount checks the policy before n features are added to the sketch of the layer.
+func (i *NLSLayer) enforceSketchFeatureCount(ctx context.Context, l nlslayer.NLSLayer, n int, operator *usecase.Operator) error {
+	p, _, err := i.scenePolicy(ctx, l.Scene(), operator)
+	if err != nil || p == nil {
+		return err
+	}
+
+	count := 0
+	if l.Sketch() != nil && l.Sketch().FeatureCollection() != nil {
+		count = len(l.Sketch().FeatureCollection().Features())
+	}
+	return p.EnforceSketchFeatureCount(count + n)
+}
+
+func (i *NLSLayer) Export(ctx context.Context, lid id.NLSLayerID, ext string, operator *usecase.Operator) (io.Reader, string, error) {
+	layer, err := i.nlslayerRepo.FindByID(ctx, lid)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := i.CanReadScene(layer.Scene(), operator); err != nil {
+		return nil, "", err
+	}
+
+	buf := &bytes.Buffer{}
+	e := encoding.EncoderFromExt(strings.ToLower(ext), buf)
+	if e == nil {
+		return nil, "", rerror.ErrNotFound
+	}
+
+	var properties property.Map
+	if ib := layer.Infobox(); ib != nil {
+		pids := make(id.PropertyIDList, 0, len(ib.Blocks()))
+		for _, b := range ib.Blocks() {
+			pids = append(pids, b.Property())
+		}
+		pl, err := i.propertyRepo.FindByIDs(ctx, pids)
+		if err != nil {
+			return nil, "", err
+		}
+		properties = pl.Map()
+	}
+
+	if err := nlslayerio.Encode(e, layer, properties); err != nil {
+		return nil, "", err
+	}
+	return buf, e.MimeType(), nil
+}
@@ -2,15 +2,25 @@ package interactor
 
 import (
 	"context"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/reearth/reearth/server/internal/infrastructure/memory"
 	"github.com/reearth/reearth/server/internal/usecase"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer/decoding"
 	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/nlslayer/nlslayerio"
+	"github.com/reearth/reearth/server/pkg/policy"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,7 +28,9 @@ func TestAddCustomProperties(t *testing.T) {
 	ctx := context.Background()
 
 	db := memory.New()
-	scene, _ := scene.New().NewID().Workspace(accountdomain.NewWorkspaceID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	ws := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	scene, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
 	_ = db.Scene.Save(ctx, scene)
 	il := NewNLSLayer(db)
 
@@ -50,7 +62,9 @@ func TestAddGeoJSONFeature(t *testing.T) {
 	ctx := context.Background()
 
 	db := memory.New()
-	scene, _ := scene.New().NewID().Workspace(accountdomain.NewWorkspaceID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	ws := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	scene, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
 	_ = db.Scene.Save(ctx, scene)
 	il := NewNLSLayer(db)
 
@@ -103,7 +117,9 @@ func TestUpdateGeoJSONFeature(t *testing.T) {
 	ctx := context.Background()
 
 	db := memory.New()
-	scene, _ := scene.New().NewID().Workspace(accountdomain.NewWorkspaceID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	ws := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	scene, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
 	_ = db.Scene.Save(ctx, scene)
 	il := NewNLSLayer(db)
 
@@ -178,7 +194,9 @@ func TestDeleteGeoJSONFeature(t *testing.T) {
 	ctx := context.Background()
 
 	db := memory.New()
-	scene, _ := scene.New().NewID().Workspace(accountdomain.NewWorkspaceID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	ws := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	scene, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
 	_ = db.Scene.Save(ctx, scene)
 	il := NewNLSLayer(db)
 
@@ -231,3 +249,348 @@ func TestDeleteGeoJSONFeature(t *testing.T) {
 	assert.NotNil(t, featureCollection)
 	assert.Equal(t, 0, len(featureCollection.Features()))
 }
+
+func TestNLSLayer_Group(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	ws := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	scene, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	_ = db.Scene.Save(ctx, scene)
+	il := NewNLSLayer(db)
+	op := &usecase.Operator{
+		WritableScenes: []id.SceneID{scene.ID()},
+	}
+
+	l, _ := nlslayer.NewNLSLayerSimple().NewID().Scene(scene.ID()).Build()
+	_ = db.NLSLayer.Save(ctx, l)
+
+	// add groups
+	g1, parent, err := il.AddLayerGroup(ctx, interfaces.AddNLSLayerGroupInput{
+		SceneID: scene.ID(),
+		Title:   "group1",
+	}, op)
+	assert.NoError(t, err)
+	assert.Nil(t, parent)
+	assert.Equal(t, "group1", g1.Title())
+	assert.True(t, g1.IsVisible())
+
+	g2, parent, err := il.AddLayerGroup(ctx, interfaces.AddNLSLayerGroupInput{
+		SceneID:       scene.ID(),
+		Title:         "group2",
+		ParentLayerID: g1.ID().Ref(),
+	}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, g1.ID(), parent.ID())
+	assert.Equal(t, []id.NLSLayerID{g2.ID()}, parent.Children().Layers())
+
+	_, _, err = il.AddLayerGroup(ctx, interfaces.AddNLSLayerGroupInput{
+		SceneID:       scene.ID(),
+		ParentLayerID: l.ID().Ref(),
+	}, op)
+	assert.Equal(t, interfaces.ErrParentLayerNotFound, err)
+
+	// move into a group
+	_, from, to, index, err := il.Move(ctx, interfaces.MoveNLSLayerInput{
+		LayerID:     l.ID(),
+		DestLayerID: g1.ID().Ref(),
+		Index:       0,
+	}, op)
+	assert.NoError(t, err)
+	assert.Nil(t, from)
+	assert.Equal(t, 0, index)
+	assert.Equal(t, []id.NLSLayerID{l.ID(), g2.ID()}, to.Children().Layers())
+
+	// move into another group
+	_, from, to, index, err = il.Move(ctx, interfaces.MoveNLSLayerInput{
+		LayerID:     l.ID(),
+		DestLayerID: g2.ID().Ref(),
+		Index:       -1,
+	}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, index)
+	assert.Equal(t, []id.NLSLayerID{g2.ID()}, from.Children().Layers())
+	assert.Equal(t, []id.NLSLayerID{l.ID()}, to.Children().Layers())
+
+	// a group cannot be moved into its descendant
+	_, _, _, _, err = il.Move(ctx, interfaces.MoveNLSLayerInput{
+		LayerID:     g1.ID(),
+		DestLayerID: g2.ID().Ref(),
+	}, op)
+	assert.Equal(t, interfaces.ErrCannotMoveLayerToItsDescendant, err)
+
+	// bulk visibility applies to descendants
+	layers, err := il.UpdateVisibility(ctx, id.NLSLayerIDList{g1.ID()}, false, op)
+	assert.NoError(t, err)
+	assert.Len(t, layers, 3)
+	res, _ := db.NLSLayer.FindByID(ctx, l.ID())
+	assert.False(t, res.IsVisible())
+
+	// move to the top level
+	_, from, to, index, err = il.Move(ctx, interfaces.MoveNLSLayerInput{
+		LayerID: l.ID(),
+	}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, index)
+	assert.Nil(t, to)
+	assert.Empty(t, from.Children().Layers())
+	g2res, _ := db.NLSLayer.FindNLSLayerGroupByID(ctx, g2.ID())
+	assert.Empty(t, g2res.Children().Layers())
+
+	// layers in groups can be removed
+	_, parent, err = il.Remove(ctx, g2.ID(), op)
+	assert.NoError(t, err)
+	assert.Empty(t, parent.Children().Layers())
+}
+
+func TestNLSLayer_UpdateVisibility_SceneLock(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	s := scene.New().NewID().Workspace(accountdomain.NewWorkspaceID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).MustBuild()
+	_ = db.Scene.Save(ctx, s)
+	l := nlslayer.NewNLSLayerSimple().NewID().Scene(s.ID()).IsVisible(true).MustBuild()
+	_ = db.NLSLayer.Save(ctx, l)
+	_ = db.SceneLock.SaveLock(ctx, s.ID(), scene.LockModePublishing)
+	il := NewNLSLayer(db)
+	op := &usecase.Operator{
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+
+	_, err := il.UpdateVisibility(ctx, id.NLSLayerIDList{l.ID()}, false, op)
+	assert.Same(t, interfaces.ErrSceneIsLocked, err)
+	res, _ := db.NLSLayer.FindByID(ctx, l.ID())
+	assert.True(t, res.IsVisible())
+}
+
+func TestNLSLayer_ImportExportGeoJSONFeatures(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	ws := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	scene, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	_ = db.Scene.Save(ctx, scene)
+	il := NewNLSLayer(db)
+	op := &usecase.Operator{
+		ReadableScenes: []id.SceneID{scene.ID()},
+		WritableScenes: []id.SceneID{scene.ID()},
+	}
+
+	schema := map[string]any{"name": "Text_1", "kind": "Text_2"}
+	l, _ := nlslayer.NewNLSLayerSimple().NewID().Scene(scene.ID()).IsSketch(true).
+		Sketch(nlslayer.NewSketchInfo(&schema, nil)).Build()
+	_ = db.NLSLayer.Save(ctx, l)
+
+	_, _, err := il.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID: l.ID(),
+		Format:  decoding.LayerEncodingFormatCSV,
+	}, op)
+	assert.Equal(t, interfaces.ErrFileNotIncluded, err)
+
+	// layers loading data from URLs are not converted into sketch layers
+	dl, _ := nlslayer.NewNLSLayerSimple().NewID().Scene(scene.ID()).
+		Config(&nlslayer.Config{"data": map[string]any{"type": "geojson", "url": "https://example.com/a.geojson"}}).Build()
+	_ = db.NLSLayer.Save(ctx, dl)
+	_, _, err = il.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID: dl.ID(),
+		File:    &file.File{Content: io.NopCloser(strings.NewReader("name,lat,lng\nTokyo,35.68,139.76\n"))},
+		Format:  decoding.LayerEncodingFormatCSV,
+	}, op)
+	assert.Same(t, interfaces.ErrSketchNotFound, err)
+	res, _ := db.NLSLayer.FindByID(ctx, dl.ID())
+	assert.False(t, res.IsSketch())
+
+	layer, features, err := il.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID: l.ID(),
+		File:    &file.File{Content: io.NopCloser(strings.NewReader("name,lat,lng,kind,memo\nTokyo,35.68,139.76,capital,a\nOsaka,34.69,135.50,city,b\n"))},
+		Format:  decoding.LayerEncodingFormatCSV,
+	}, op)
+	assert.NoError(t, err)
+	assert.Len(t, features, 2)
+	// attributes other than the name are kept as long as the schema defines them
+	assert.Equal(t, &map[string]any{"name": "Tokyo", "kind": "capital"}, features[0].Properties())
+	assert.Len(t, layer.Sketch().FeatureCollection().Features(), 2)
+	assert.Equal(t, &schema, layer.Sketch().CustomPropertySchema())
+
+	res, _ = db.NLSLayer.FindByID(ctx, l.ID())
+	assert.Len(t, res.Sketch().FeatureCollection().Features(), 2)
+
+	r, mime, err := il.Export(ctx, l.ID(), "geojson", op)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", mime)
+	data, _ := io.ReadAll(r)
+	assert.Contains(t, string(data), `"name":"Tokyo"`)
+
+	_, _, err = il.Export(ctx, l.ID(), "txt", op)
+	assert.Equal(t, rerror.ErrNotFound, err)
+	// layers loading data from URLs have nothing to export
+	_, _, err = il.Export(ctx, dl.ID(), "geojson", op)
+	assert.Same(t, nlslayerio.ErrNoExportableFeatures, err)
+}
+
+func TestNLSLayer_ImportGeoJSONFeatures_NumberProperty(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	ws := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	s := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).MustBuild()
+	_ = db.Scene.Save(ctx, s)
+	il := NewNLSLayer(db)
+	op := &usecase.Operator{
+		ReadableScenes: []id.SceneID{s.ID()},
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+
+	schema := map[string]any{"name": "Text_1", "capacity": "Number_2"}
+	newLayer := func() id.NLSLayerID {
+		l := nlslayer.NewNLSLayerSimple().NewID().Scene(s.ID()).IsSketch(true).
+			Sketch(nlslayer.NewSketchInfo(&schema, nil)).MustBuild()
+		_ = db.NLSLayer.Save(ctx, l)
+		return l.ID()
+	}
+
+	// CSV has only text values
+	_, features, err := il.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID: newLayer(),
+		File:    &file.File{Content: io.NopCloser(strings.NewReader("name,lat,lng,capacity\nshelter,35.68,139.76,120\npark,34.69,135.50,\n"))},
+		Format:  decoding.LayerEncodingFormatCSV,
+	}, op)
+	assert.NoError(t, err)
+	assert.Len(t, features, 2)
+	assert.Equal(t, &map[string]any{"name": "shelter", "capacity": 120.0}, features[0].Properties())
+	assert.Equal(t, &map[string]any{"name": "park", "capacity": nil}, features[1].Properties())
+
+	// so does KML
+	_, features, err = il.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID: newLayer(),
+		File: &file.File{Content: io.NopCloser(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Placemark>
+    <name>shelter</name>
+    <ExtendedData><Data name="capacity"><value>120</value></Data></ExtendedData>
+    <Point><coordinates>139,35,0</coordinates></Point>
+  </Placemark>
+</kml>`))},
+		Format: decoding.LayerEncodingFormatKML,
+	}, op)
+	assert.NoError(t, err)
+	assert.Len(t, features, 1)
+	assert.Equal(t, &map[string]any{"name": "shelter", "capacity": 120.0}, features[0].Properties())
+
+	// values which are not numbers are still rejected
+	_, _, err = il.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID: newLayer(),
+		File:    &file.File{Content: io.NopCloser(strings.NewReader("name,lat,lng,capacity\nshelter,35.68,139.76,many\n"))},
+		Format:  decoding.LayerEncodingFormatCSV,
+	}, op)
+	assert.ErrorIs(t, err, nlslayer.ErrInvalidCustomProperty)
+}
+
+func TestNLSLayer_Policy(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	db.Policy = memory.NewPolicyWith(policy.New(policy.Option{
+		ID:                 policy.ID("policy"),
+		NLSLayerCount:      lo.ToPtr(1),
+		SketchFeatureCount: lo.ToPtr(1),
+	}))
+	ws := workspace.New().NewID().Policy(policy.ID("policy").Ref()).MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	scene, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).Build()
+	_ = db.Scene.Save(ctx, scene)
+	il := NewNLSLayer(db)
+	op := &usecase.Operator{
+		WritableScenes: []id.SceneID{scene.ID()},
+	}
+
+	// layers in a scene
+	l, err := il.AddLayerSimple(ctx, interfaces.AddNLSLayerSimpleInput{
+		SceneID:   scene.ID(),
+		Title:     "layer",
+		LayerType: nlslayer.Simple,
+	}, op)
+	assert.NoError(t, err)
+	_, err = il.AddLayerSimple(ctx, interfaces.AddNLSLayerSimpleInput{
+		SceneID:   scene.ID(),
+		LayerType: nlslayer.Simple,
+	}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+	_, _, err = il.AddLayerGroup(ctx, interfaces.AddNLSLayerGroupInput{SceneID: scene.ID()}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+	_, err = il.Duplicate(ctx, l.ID(), op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+
+	// features in a sketch layer
+	param := interfaces.AddNLSLayerGeoJSONFeatureParams{
+		LayerID:  l.ID(),
+		Type:     "Feature",
+		Geometry: map[string]any{"type": "Point", "coordinates": []any{1.0, 2.0}},
+	}
+	_, err = il.AddGeoJSONFeature(ctx, param, op)
+	assert.NoError(t, err)
+	_, err = il.AddGeoJSONFeature(ctx, param, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+}
+
+func TestNLSLayer_UpdateCluster(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	ws := workspace.New().NewID().MustBuild()
+	_ = db.Workspace.Save(ctx, ws)
+	cluster, _ := scene.NewCluster(id.NewClusterID(), "cluster", id.NewPropertyID())
+	s, _ := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).
+		Clusters(scene.NewClusterListFrom([]*scene.Cluster{cluster})).Build()
+	_ = db.Scene.Save(ctx, s)
+	il := NewNLSLayer(db)
+	op := &usecase.Operator{
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+
+	l, _ := nlslayer.NewNLSLayerSimple().NewID().Scene(s.ID()).Build()
+	_ = db.NLSLayer.Save(ctx, l)
+
+	// only clusters in the scene can be referenced
+	_, err := il.Update(ctx, interfaces.UpdateNLSLayerInput{
+		LayerID: l.ID(),
+		Cluster: id.NewClusterID().Ref(),
+	}, op)
+	assert.Same(t, interfaces.ErrClusterNotFound, err)
+
+	res, err := il.Update(ctx, interfaces.UpdateNLSLayerInput{
+		LayerID: l.ID(),
+		Cluster: cluster.ID().Ref(),
+	}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, cluster.ID().Ref(), res.Cluster())
+
+	res, err = il.Update(ctx, interfaces.UpdateNLSLayerInput{
+		LayerID:       l.ID(),
+		DeleteCluster: true,
+	}, op)
+	assert.NoError(t, err)
+	assert.Nil(t, res.Cluster())
+
+	// removing the cluster from the scene unlinks the layers
+	_, _ = il.Update(ctx, interfaces.UpdateNLSLayerInput{
+		LayerID: l.ID(),
+		Cluster: cluster.ID().Ref(),
+	}, op)
+	is := &Scene{
+		sceneRepo:    db.Scene,
+		nlsLayerRepo: db.NLSLayer,
+		transaction:  db.Transaction,
+	}
+	_, err = is.RemoveCluster(ctx, s.ID(), cluster.ID(), &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			WritableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+		},
+	})
+	assert.NoError(t, err)
+	saved, _ := db.NLSLayer.FindByID(ctx, l.ID())
+	assert.Nil(t, saved.Cluster())
+}
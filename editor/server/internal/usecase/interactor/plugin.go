@@ -21,6 +21,8 @@ type Plugin struct {
 	layerRepo          repo.Layer
 	file               gateway.File
 	pluginRegistry     gateway.PluginRegistry
+	pluginSources      []gateway.PluginSource
+	pluginKeyRepo      repo.PluginKey
 	transaction        usecasex.Transaction
 }
 
@@ -32,8 +34,10 @@ func NewPlugin(r *repo.Container, gr *gateway.Container) interfaces.Plugin {
 		propertySchemaRepo: r.PropertySchema,
 		propertyRepo:       r.Property,
 		transaction:        r.Transaction,
+		pluginKeyRepo:      r.PluginKey,
 		file:               gr.File,
 		pluginRegistry:     gr.PluginRegistry,
+		pluginSources:      gr.PluginSources,
 	}
 }
 
@@ -0,0 +1,158 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/pluginkey"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+)
+
+type PluginKey struct {
+	common
+	pluginApproval
+	sceneRepo repo.Scene
+}
+
+func NewPluginKey(r *repo.Container) interfaces.PluginKey {
+	return &PluginKey{
+		pluginApproval: newPluginApproval(r),
+		sceneRepo:      r.Scene,
+	}
+}
+
+func (i *PluginKey) FindByWorkspace(ctx context.Context, wid accountdomain.WorkspaceID, operator *usecase.Operator) (pluginkey.List, error) {
+	if err := i.CanReadWorkspace(wid, operator); err != nil {
+		return nil, err
+	}
+	return i.pluginKeyRepo.FindByWorkspace(ctx, wid)
+}
+
+func (i *PluginKey) Add(ctx context.Context, param interfaces.AddPluginKeyInput, operator *usecase.Operator) (*pluginkey.Key, error) {
+	if err := i.canManageKeys(param.WorkspaceID, operator); err != nil {
+		return nil, err
+	}
+
+	pub, err := pluginkey.ParsePublicKey(param.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := pluginkey.New().
+		NewID().
+		Workspace(param.WorkspaceID).
+		Name(param.Name).
+		PublicKey(pub).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.pluginKeyRepo.Save(ctx, k); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (i *PluginKey) Remove(ctx context.Context, kid id.PluginKeyID, operator *usecase.Operator) (id.PluginKeyID, error) {
+	k, err := i.pluginKeyRepo.FindByID(ctx, kid)
+	if err != nil {
+		return kid, err
+	}
+	if err := i.canManageKeys(k.Workspace(), operator); err != nil {
+		return kid, err
+	}
+	return kid, i.pluginKeyRepo.Remove(ctx, kid)
+}
+
+func (i *PluginKey) UnapprovedPlugins(ctx context.Context, sid id.SceneID, operator *usecase.Operator) ([]id.PluginID, error) {
+	if err := i.CanReadScene(sid, operator); err != nil {
+		return nil, err
+	}
+
+	s, err := i.sceneRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	return i.unapprovedPlugins(ctx, s)
+}
+
+// canManageKeys allows maintainers and owners of the workspace to manage its keys, as the keys decide which plugins can be published.
+func (i *PluginKey) canManageKeys(wid accountdomain.WorkspaceID, operator *usecase.Operator) error {
+	if err := i.OnlyOperator(operator); err != nil {
+		return err
+	}
+	if !operator.IsMaintainingWorkspace(wid) {
+		return interfaces.ErrOperationDenied
+	}
+	return nil
+}
+
+// pluginApproval checks the plugins of scenes against the plugin keys of their workspace before they are published.
+type pluginApproval struct {
+	pluginKeyRepo repo.PluginKey
+	pluginRepo    repo.Plugin
+}
+
+func newPluginApproval(r *repo.Container) pluginApproval {
+	return pluginApproval{
+		pluginKeyRepo: r.PluginKey,
+		pluginRepo:    r.Plugin,
+	}
+}
+
+func (a pluginApproval) unapprovedPlugins(ctx context.Context, s *scene.Scene) ([]id.PluginID, error) {
+	keys, err := a.pluginKeyRepo.FindByWorkspace(ctx, s.Workspace())
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	pids := lo.FilterMap(s.Plugins().Plugins(), func(p *scene.Plugin, _ int) (id.PluginID, bool) {
+		return p.Plugin(), !p.Plugin().System()
+	})
+	if len(pids) == 0 {
+		return nil, nil
+	}
+
+	plugins, err := a.pluginRepo.FindByIDs(ctx, pids)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, err
+	}
+
+	var res []id.PluginID
+	for _, pid := range pids {
+		p, _ := lo.Find(plugins, func(p *plugin.Plugin) bool {
+			return p != nil && p.ID().Equal(pid)
+		})
+		if !keys.Approves(p) {
+			res = append(res, pid)
+		}
+	}
+	return res, nil
+}
+
+// checkPlugins returns interfaces.ErrUnapprovedPlugin if the scene cannot be published with its plugins.
+func (a pluginApproval) checkPlugins(ctx context.Context, s *scene.Scene) error {
+	pids, err := a.unapprovedPlugins(ctx, s)
+	if err != nil {
+		return err
+	}
+	if len(pids) > 0 {
+		names := lo.Map(pids, func(p id.PluginID, _ int) string { return p.String() })
+		return fmt.Errorf("%w: %s", interfaces.ErrUnapprovedPlugin, strings.Join(names, ", "))
+	}
+	return nil
+}
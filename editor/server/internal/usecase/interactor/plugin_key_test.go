@@ -0,0 +1,141 @@
+package interactor
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/plugin/pluginpack"
+	"github.com/reearth/reearth/server/pkg/pluginkey"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginKey(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	i := NewPluginKey(db).(*PluginKey)
+
+	ws := accountdomain.NewWorkspaceID()
+	sid := id.NewSceneID()
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	digest := []byte("digest")
+
+	signed := plugin.New().ID(id.MustPluginID("signed~1.0.0")).Signature(plugin.NewSignature(digest, ed25519.Sign(priv, digest))).MustBuild()
+	unsigned := plugin.New().ID(id.MustPluginID("unsigned~1.0.0")).MustBuild()
+	_ = db.Plugin.Save(ctx, signed)
+	_ = db.Plugin.Save(ctx, unsigned)
+
+	s := scene.New().ID(sid).Workspace(ws).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).MustBuild()
+	s.Plugins().Add(scene.NewPlugin(signed.ID(), nil))
+	s.Plugins().Add(scene.NewPlugin(unsigned.ID(), nil))
+	_ = db.Scene.Save(ctx, s)
+
+	writer := &usecase.Operator{
+		AcOperator:     &accountusecase.Operator{WritableWorkspaces: accountdomain.WorkspaceIDList{ws}},
+		ReadableScenes: id.SceneIDList{sid},
+	}
+	maintainer := &usecase.Operator{
+		AcOperator:     &accountusecase.Operator{MaintainableWorkspaces: accountdomain.WorkspaceIDList{ws}},
+		ReadableScenes: id.SceneIDList{sid},
+	}
+
+	// all plugins are approved until a key is registered
+	pids, err := i.UnapprovedPlugins(ctx, sid, writer)
+	assert.NoError(t, err)
+	assert.Empty(t, pids)
+
+	input := interfaces.AddPluginKeyInput{WorkspaceID: ws, Name: "release", PublicKey: base64.StdEncoding.EncodeToString(pub)}
+	_, err = i.Add(ctx, input, writer)
+	assert.Equal(t, interfaces.ErrOperationDenied, err)
+	_, err = i.Add(ctx, interfaces.AddPluginKeyInput{WorkspaceID: ws, PublicKey: "xxx"}, maintainer)
+	assert.Equal(t, pluginkey.ErrInvalidPublicKey, err)
+
+	k, err := i.Add(ctx, input, maintainer)
+	assert.NoError(t, err)
+	assert.Equal(t, "release", k.Name())
+	assert.Equal(t, ed25519.PublicKey(pub), k.PublicKey())
+
+	keys, err := i.FindByWorkspace(ctx, ws, writer)
+	assert.NoError(t, err)
+	assert.Equal(t, pluginkey.List{k}, keys)
+
+	pids, err = i.UnapprovedPlugins(ctx, sid, writer)
+	assert.NoError(t, err)
+	assert.Equal(t, []id.PluginID{unsigned.ID()}, pids)
+	assert.ErrorIs(t, i.checkPlugins(ctx, s), interfaces.ErrUnapprovedPlugin)
+
+	_, err = i.Remove(ctx, k.ID(), writer)
+	assert.Equal(t, interfaces.ErrOperationDenied, err)
+	_, err = i.Remove(ctx, k.ID(), maintainer)
+	assert.NoError(t, err)
+	assert.NoError(t, i.checkPlugins(ctx, s))
+}
+
+func TestPlugin_Upload_Signature(t *testing.T) {
+	ctx := context.Background()
+	ws := accountdomain.NewWorkspaceID()
+	sid := id.NewSceneID()
+
+	db := memory.New()
+	files, err := fs.NewFile(mockFS(nil), "")
+	assert.NoError(t, err)
+	_ = db.Scene.Save(ctx, scene.New().ID(sid).Workspace(ws).RootLayer(id.NewLayerID()).MustBuild())
+
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	other, _, _ := ed25519.GenerateKey(nil)
+	_ = db.PluginKey.Save(ctx, pluginkey.New().NewID().Workspace(ws).PublicKey(other).MustBuild())
+
+	uc := &Plugin{
+		sceneRepo:          db.Scene,
+		pluginRepo:         db.Plugin,
+		propertySchemaRepo: db.PropertySchema,
+		propertyRepo:       db.Property,
+		layerRepo:          db.Layer,
+		pluginKeyRepo:      db.PluginKey,
+		file:               files,
+		transaction:        db.Transaction,
+	}
+	op := &usecase.Operator{
+		AcOperator:     &accountusecase.Operator{WritableWorkspaces: accountdomain.WorkspaceIDList{ws}},
+		WritableScenes: id.SceneIDList{sid},
+	}
+
+	p, err := pluginpack.PackageFromZip(bytes.NewReader(mockPluginArchiveZip.Bytes()), nil, pluginPackageSizeLimit)
+	assert.NoError(t, err)
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(priv, p.Digest))
+	signed := signedMockPluginArchive(sig)
+
+	// signed with a key which is not registered in the workspace
+	_, _, err = uc.Upload(ctx, bytes.NewReader(signed), sid, op)
+	assert.ErrorIs(t, err, pluginpack.ErrInvalidSignature)
+
+	_ = db.PluginKey.Save(ctx, pluginkey.New().NewID().Workspace(ws).PublicKey(pub).MustBuild())
+	pl, _, err := uc.Upload(ctx, bytes.NewReader(signed), sid, op)
+	assert.NoError(t, err)
+	assert.NotNil(t, pl.Signature())
+}
+
+func signedMockPluginArchive(sig string) []byte {
+	buf := bytes.Buffer{}
+	zw := zip.NewWriter(&buf)
+	for p, f := range mockPluginFiles {
+		w, _ := zw.Create(p)
+		_, _ = w.Write([]byte(f))
+	}
+	w, _ := zw.Create(pluginpack.SignatureFilePath)
+	_, _ = w.Write([]byte(sig))
+	_ = zw.Close()
+	return buf.Bytes()
+}
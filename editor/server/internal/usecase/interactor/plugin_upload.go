@@ -2,20 +2,21 @@ package interactor
 
 import (
 	"context"
+	"errors"
 	"io"
-	"net/http"
 	"net/url"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/plugin"
 	"github.com/reearth/reearth/server/pkg/plugin/manifest"
 	"github.com/reearth/reearth/server/pkg/plugin/pluginpack"
-	"github.com/reearth/reearth/server/pkg/plugin/repourl"
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
 )
 
 var pluginPackageSizeLimit int64 = 10 * 1024 * 1024 // 10MB
@@ -42,30 +43,30 @@ func (i *Plugin) UploadFromRemote(ctx context.Context, u *url.URL, sid id.SceneI
 		return nil, nil, err
 	}
 
-	ru, err := repourl.New(u)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ru.ArchiveURL().String(), nil)
-	if err != nil {
-		return nil, nil, interfaces.ErrInvalidPluginPackage
+	src, ok := lo.Find(i.pluginSources, func(s gateway.PluginSource) bool {
+		return s.Match(u)
+	})
+	if !ok {
+		return nil, nil, gateway.ErrUnsupportedPluginSource
 	}
 
-	res, err := http.DefaultClient.Do(req)
+	r, err := src.Fetch(ctx, u)
 	if err != nil {
-		return nil, nil, interfaces.ErrInvalidPluginPackage
+		if errors.Is(err, gateway.ErrPluginChecksumRequired) || errors.Is(err, gateway.ErrPluginChecksumMismatch) {
+			return nil, nil, err
+		}
+		return nil, nil, &rerror.Error{
+			Label:    interfaces.ErrInvalidPluginPackage,
+			Err:      err,
+			Separate: true,
+		}
 	}
 	defer func() {
-		_ = res.Body.Close()
+		_ = r.Close()
 	}()
-	if res.StatusCode != http.StatusOK {
-		return nil, nil, interfaces.ErrInvalidPluginPackage
-	}
 
-	p, err := pluginpack.PackageFromZip(res.Body, &sid, pluginPackageSizeLimit)
+	p, err := pluginpack.PackageFromZip(r, &sid, pluginPackageSizeLimit)
 	if err != nil {
-		_ = res.Body.Close()
 		return nil, nil, &rerror.Error{
 			Label:    interfaces.ErrInvalidPluginPackage,
 			Err:      err,
@@ -86,6 +87,10 @@ func (i *Plugin) upload(ctx context.Context, p *pluginpack.Package, sid id.Scene
 		return nil, nil, err
 	}
 
+	if err := i.verifySignature(ctx, p, s); err != nil {
+		return nil, nil, err
+	}
+
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return
@@ -354,3 +359,25 @@ func (i *Plugin) pluginManifestFromPlugin(ctx context.Context, p *plugin.Plugin)
 		Schema:          s,
 	}, nil
 }
+
+// verifySignature rejects packages whose signature is not made with any of the plugin keys of the workspace.
+// Unsigned packages are accepted, but scenes using them cannot be published while the workspace has keys.
+func (i *Plugin) verifySignature(ctx context.Context, p *pluginpack.Package, s *scene.Scene) error {
+	sig := p.Manifest.Plugin.Signature()
+	if sig == nil {
+		return nil
+	}
+
+	keys, err := i.pluginKeyRepo.FindByWorkspace(ctx, s.Workspace())
+	if err != nil {
+		return err
+	}
+	if len(keys) > 0 && !sig.Verify(keys.PublicKeys()) {
+		return &rerror.Error{
+			Label:    interfaces.ErrInvalidPluginPackage,
+			Err:      pluginpack.ErrInvalidSignature,
+			Separate: true,
+		}
+	}
+	return nil
+}
@@ -5,12 +5,14 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"net/url"
 	"os"
 	"testing"
 
 	"github.com/reearth/reearth/server/internal/infrastructure/fs"
 	"github.com/reearth/reearth/server/internal/infrastructure/memory"
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/layer"
 	"github.com/reearth/reearth/server/pkg/plugin"
@@ -136,6 +138,65 @@ func TestPlugin_Upload_New(t *testing.T) {
 	assert.Equal(t, "// barfoo", string(npfc))
 }
 
+type mockPluginSource struct {
+	host string
+	data []byte
+}
+
+func (s *mockPluginSource) Match(u *url.URL) bool {
+	return u.Host == s.host
+}
+
+func (s *mockPluginSource) Fetch(_ context.Context, _ *url.URL) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s.data)), nil
+}
+
+func TestPlugin_UploadFromRemote(t *testing.T) {
+	ctx := context.Background()
+	ws := accountdomain.NewWorkspaceID()
+	sid := id.NewSceneID()
+	pid := mockPluginID.WithScene(sid.Ref())
+
+	repos := memory.New()
+	files, err := fs.NewFile(mockFS(nil), "")
+	assert.NoError(t, err)
+	scene := scene.New().ID(sid).Workspace(ws).RootLayer(id.NewLayerID()).MustBuild()
+	_ = repos.Scene.Save(ctx, scene)
+
+	uc := &Plugin{
+		sceneRepo:          repos.Scene,
+		pluginRepo:         repos.Plugin,
+		propertySchemaRepo: repos.PropertySchema,
+		propertyRepo:       repos.Property,
+		layerRepo:          repos.Layer,
+		file:               files,
+		transaction:        repos.Transaction,
+		pluginSources: []gateway.PluginSource{
+			&mockPluginSource{host: "broken.example.com", data: []byte("broken")},
+			&mockPluginSource{host: "git.example.com", data: mockPluginArchiveZip.Bytes()},
+		},
+	}
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			WritableWorkspaces: []accountdomain.WorkspaceID{ws},
+		},
+		WritableScenes: []id.SceneID{sid},
+	}
+
+	u, _ := url.Parse("https://git.example.com/foo/bar")
+	pl, _, err := uc.UploadFromRemote(ctx, u, sid, op)
+	assert.NoError(t, err)
+	assert.Equal(t, pid, pl.ID())
+
+	u, _ = url.Parse("https://broken.example.com/foo/bar")
+	_, _, err = uc.UploadFromRemote(ctx, u, sid, op)
+	assert.EqualError(t, err, "invalid plugin package: zip open error: zip: not a valid zip file")
+
+	u, _ = url.Parse("https://unknown.example.com/foo/bar")
+	_, _, err = uc.UploadFromRemote(ctx, u, sid, op)
+	assert.Equal(t, gateway.ErrUnsupportedPluginSource, err)
+}
+
 // The plugin and its files should be replaced with the new one (old files are deleted)
 // Properties that schema is changed should be migrated
 // Layers, widgets, blocks, properties, and property schemas that extension is deleted should deleted
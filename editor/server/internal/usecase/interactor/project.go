@@ -11,6 +11,7 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/policy"
 	"github.com/reearth/reearth/server/pkg/project"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearth/server/pkg/scene/builder"
@@ -18,46 +19,57 @@ import (
 	"github.com/reearth/reearthx/account/accountusecase/accountrepo"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
 )
 
 type Project struct {
 	common
 	commonSceneLock
-	assetRepo         repo.Asset
-	projectRepo       repo.Project
-	userRepo          accountrepo.User
-	workspaceRepo     accountrepo.Workspace
-	sceneRepo         repo.Scene
-	propertyRepo      repo.Property
-	layerRepo         repo.Layer
-	datasetRepo       repo.Dataset
-	datasetSchemaRepo repo.DatasetSchema
-	tagRepo           repo.Tag
-	transaction       usecasex.Transaction
-	policyRepo        repo.Policy
-	file              gateway.File
-	nlsLayerRepo      repo.NLSLayer
-	layerStyles       repo.Style
+	pluginApproval
+	assetRepo          repo.Asset
+	projectRepo        repo.Project
+	userRepo           accountrepo.User
+	workspaceRepo      accountrepo.Workspace
+	sceneRepo          repo.Scene
+	propertyRepo       repo.Property
+	layerRepo          repo.Layer
+	datasetRepo        repo.Dataset
+	datasetSchemaRepo  repo.DatasetSchema
+	tagRepo            repo.Tag
+	transaction        usecasex.Transaction
+	policyRepo         repo.Policy
+	file               gateway.File
+	nlsLayerRepo       repo.NLSLayer
+	layerStyles        repo.Style
+	pluginRepo         repo.Plugin
+	propertySchemaRepo repo.PropertySchema
+	storytellingRepo   repo.Storytelling
+	sceneHistoryRepo   repo.SceneHistory
 }
 
 func NewProject(r *repo.Container, gr *gateway.Container) interfaces.Project {
 	return &Project{
-		commonSceneLock:   commonSceneLock{sceneLockRepo: r.SceneLock},
-		assetRepo:         r.Asset,
-		projectRepo:       r.Project,
-		userRepo:          r.User,
-		workspaceRepo:     r.Workspace,
-		sceneRepo:         r.Scene,
-		propertyRepo:      r.Property,
-		layerRepo:         r.Layer,
-		datasetRepo:       r.Dataset,
-		datasetSchemaRepo: r.DatasetSchema,
-		tagRepo:           r.Tag,
-		transaction:       r.Transaction,
-		policyRepo:        r.Policy,
-		file:              gr.File,
-		nlsLayerRepo:      r.NLSLayer,
-		layerStyles:       r.Style,
+		commonSceneLock:    commonSceneLock{sceneLockRepo: r.SceneLock},
+		pluginApproval:     newPluginApproval(r),
+		assetRepo:          r.Asset,
+		projectRepo:        r.Project,
+		userRepo:           r.User,
+		workspaceRepo:      r.Workspace,
+		sceneRepo:          r.Scene,
+		propertyRepo:       r.Property,
+		layerRepo:          r.Layer,
+		datasetRepo:        r.Dataset,
+		datasetSchemaRepo:  r.DatasetSchema,
+		tagRepo:            r.Tag,
+		transaction:        r.Transaction,
+		policyRepo:         r.Policy,
+		file:               gr.File,
+		nlsLayerRepo:       r.NLSLayer,
+		layerStyles:        r.Style,
+		pluginRepo:         r.Plugin,
+		propertySchemaRepo: r.PropertySchema,
+		storytellingRepo:   r.Storytelling,
+		sceneHistoryRepo:   r.SceneHistory,
 	}
 }
 
@@ -92,8 +104,9 @@ func (i *Project) Create(ctx context.Context, p interfaces.CreateProjectParam, o
 	}
 
 	// enforce policy
+	var po *policy.Policy
 	if policyID := operator.Policy(ws.Policy()); policyID != nil {
-		p, err := i.policyRepo.FindByID(ctx, *policyID)
+		po, err = i.policyRepo.FindByID(ctx, *policyID)
 		if err != nil {
 			return nil, err
 		}
@@ -103,7 +116,7 @@ func (i *Project) Create(ctx context.Context, p interfaces.CreateProjectParam, o
 			return nil, err
 		}
 
-		if err := p.EnforceProjectCount(projectCount + 1); err != nil {
+		if err := po.EnforceProjectCount(projectCount + 1); err != nil {
 			return nil, err
 		}
 	}
@@ -141,6 +154,14 @@ func (i *Project) Create(ctx context.Context, p interfaces.CreateProjectParam, o
 		return nil, err
 	}
 
+	if p.TemplateID != nil {
+		s, err := i.cloneTemplate(ctx, proj, *p.TemplateID, po, operator)
+		if err != nil {
+			return nil, err
+		}
+		operator.AddNewScene(ws.ID(), s.ID())
+	}
+
 	tx.Commit()
 	return proj, nil
 }
@@ -292,6 +313,40 @@ func (i *Project) Publish(ctx context.Context, params interfaces.PublishProjectP
 		return nil, err
 	}
 
+	if params.UnpublishAt != nil && !params.UnpublishAt.After(time.Now()) {
+		return nil, interfaces.ErrPublishScheduleInPast
+	}
+
+	// publish later by the publish scheduler
+	if params.PublishAt != nil && params.PublishAt.After(time.Now()) {
+		if params.Alias == nil && prj.Alias() == "" {
+			return nil, interfaces.ErrProjectAliasIsNotSet
+		}
+
+		schedule, err := project.NewPublishSchedule(lo.FromPtr(params.Alias), params.Status, params.PublishAt, params.UnpublishAt)
+		if err != nil {
+			return nil, err
+		}
+
+		prj.SetPublishSchedule(schedule)
+		if err := i.projectRepo.Save(ctx, prj); err != nil {
+			return nil, err
+		}
+
+		tx.Commit()
+		return prj, nil
+	}
+
+	// a pending publication is replaced but a scheduled unpublication is kept
+	schedule := prj.PublishSchedule().Published()
+	if params.Status == project.PublishmentStatusPrivate {
+		schedule = nil
+	} else if params.UnpublishAt != nil {
+		if schedule, err = project.NewPublishSchedule("", "", nil, params.UnpublishAt); err != nil {
+			return nil, err
+		}
+	}
+
 	ws, err := i.workspaceRepo.FindByID(ctx, prj.Workspace())
 	if err != nil {
 		return nil, err
@@ -330,6 +385,12 @@ func (i *Project) Publish(ctx context.Context, params interfaces.PublishProjectP
 		return nil, err
 	}
 
+	if params.Status != project.PublishmentStatusPrivate {
+		if err := i.checkPlugins(ctx, s); err != nil {
+			return nil, err
+		}
+	}
+
 	sceneID := s.ID()
 
 	prevAlias := prj.Alias()
@@ -403,10 +464,15 @@ func (i *Project) Publish(ctx context.Context, params interfaces.PublishProjectP
 			).ForScene(s).WithNLSLayers(&nlsLayers).WithLayerStyle(layerStyles).Build(ctx, w, time.Now(), coreSupport, enableGa, trackingId)
 		}()
 
-		// Save
-		if err := i.file.UploadBuiltScene(ctx, r, newPublishedAlias); err != nil {
+		// Save a snapshot and publish it
+		version := newPublishedVersion(time.Now())
+		if err := i.file.UploadBuiltSceneSnapshot(ctx, r, prj.ID().String(), version); err != nil {
+			return nil, err
+		}
+		if err := i.publishBuiltSceneSnapshot(ctx, prj.ID(), version, newPublishedAlias); err != nil {
 			return nil, err
 		}
+		prunePublishedSnapshots(ctx, prj.ID().String(), i.file.ListBuiltSceneSnapshots, i.file.RemoveBuiltSceneSnapshot)
 
 		// If project has been published before and alias is changed,
 		// remove old published data.
@@ -419,6 +485,7 @@ func (i *Project) Publish(ctx context.Context, params interfaces.PublishProjectP
 
 	prj.UpdatePublishmentStatus(params.Status)
 	prj.SetPublishedAt(time.Now())
+	prj.SetPublishSchedule(schedule)
 
 	if err := i.projectRepo.Save(ctx, prj); err != nil {
 		return nil, err
@@ -428,6 +495,64 @@ func (i *Project) Publish(ctx context.Context, params interfaces.PublishProjectP
 	return prj, nil
 }
 
+func (i *Project) PublishedVersions(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) ([]interfaces.PublishedVersion, error) {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanReadWorkspace(prj.Workspace(), operator); err != nil {
+		return nil, err
+	}
+
+	versions, err := i.file.ListBuiltSceneSnapshots(ctx, pid.String())
+	if err != nil {
+		return nil, err
+	}
+	return publishedVersionsFrom(versions), nil
+}
+
+func (i *Project) RollbackPublish(ctx context.Context, pid id.ProjectID, version string, operator *usecase.Operator) (*project.Project, error) {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteWorkspace(prj.Workspace(), operator); err != nil {
+		return nil, err
+	}
+	if prj.PublishmentStatus() == project.PublishmentStatusPrivate || prj.Alias() == "" {
+		return nil, interfaces.ErrNotPublished
+	}
+
+	s, err := i.sceneRepo.FindByProject(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.UpdateSceneLock(ctx, s.ID(), scene.LockModeFree, scene.LockModePublishing); err != nil {
+		return nil, err
+	}
+
+	defer i.ReleaseSceneLock(ctx, s.ID())
+
+	if err := i.publishBuiltSceneSnapshot(ctx, pid, version, prj.Alias()); err != nil {
+		return nil, err
+	}
+	return prj, nil
+}
+
+// publishBuiltSceneSnapshot makes the snapshot public under the alias.
+func (i *Project) publishBuiltSceneSnapshot(ctx context.Context, pid id.ProjectID, version, alias string) error {
+	r, err := i.file.ReadBuiltSceneSnapshot(ctx, pid.String(), version)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	return i.file.UploadBuiltScene(ctx, r, alias)
+}
+
 func (i *Project) Delete(ctx context.Context, projectID id.ProjectID, operator *usecase.Operator) (err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
@@ -457,9 +582,11 @@ func (i *Project) Delete(ctx context.Context, projectID id.ProjectID, operator *
 			Property:      i.propertyRepo,
 			Dataset:       i.datasetRepo,
 			DatasetSchema: i.datasetSchemaRepo,
+			SceneHistory:  i.sceneHistoryRepo,
 		},
-		File:    i.file,
-		Project: i.projectRepo,
+		File:         i.file,
+		Project:      i.projectRepo,
+		Storytelling: i.storytellingRepo,
 	}
 	if err := deleter.Delete(ctx, prj, true, operator); err != nil {
 		return err
@@ -0,0 +1,633 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/project/projectpack"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+)
+
+var projectPackageSizeLimit int64 = 500 * 1024 * 1024 // 500MB
+
+const exportProjectExt = ".zip"
+
+func (i *Project) Export(ctx context.Context, pid id.ProjectID, w io.Writer, operator *usecase.Operator) error {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return err
+	}
+	if err := i.CanReadWorkspace(prj.Workspace(), operator); err != nil {
+		return err
+	}
+
+	m, err := i.exportModels(ctx, prj)
+	if err != nil {
+		return err
+	}
+	d := projectpack.NewDocument(m)
+
+	pw := projectpack.NewWriter(w)
+	if err := pw.WriteDocument(d); err != nil {
+		return err
+	}
+	if err := i.exportAssets(ctx, pw, d, prj); err != nil {
+		return err
+	}
+	if err := i.exportPluginFiles(ctx, pw, m.Plugins); err != nil {
+		return err
+	}
+	return pw.Close()
+}
+
+func (i *Project) ExportProject(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) (string, error) {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return "", err
+	}
+	if err := i.CanReadWorkspace(prj.Workspace(), operator); err != nil {
+		return "", err
+	}
+
+	name := pid.String() + exportProjectExt
+	r, w := io.Pipe()
+	go func() {
+		_ = w.CloseWithError(i.Export(ctx, pid, w, operator))
+	}()
+
+	if err := i.file.UploadExportProjectZip(ctx, r, name); err != nil {
+		_ = r.CloseWithError(err)
+		return "", err
+	}
+	return name, nil
+}
+
+func (i *Project) ReadExportProject(ctx context.Context, name string, operator *usecase.Operator) (io.ReadCloser, error) {
+	pid, err := id.ProjectIDFrom(strings.TrimSuffix(name, exportProjectExt))
+	if err != nil || !strings.HasSuffix(name, exportProjectExt) {
+		return nil, rerror.ErrNotFound
+	}
+
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanReadWorkspace(prj.Workspace(), operator); err != nil {
+		return nil, err
+	}
+
+	return i.file.ReadExportProjectZip(ctx, name)
+}
+
+func (i *Project) ImportProject(ctx context.Context, p interfaces.ImportProjectParam, operator *usecase.Operator) (_ *project.Project, err error) {
+	if err := i.CanWriteWorkspace(p.WorkspaceID, operator); err != nil {
+		return nil, err
+	}
+	if p.File == nil {
+		return nil, interfaces.ErrInvalidProjectPackage
+	}
+
+	pack, err := projectpack.Read(p.File.Content, projectPackageSizeLimit)
+	if err != nil {
+		return nil, &rerror.Error{
+			Label:    interfaces.ErrInvalidProjectPackage,
+			Err:      err,
+			Separate: true,
+		}
+	}
+
+	// files uploaded to the storage are removed when the import fails
+	var uploadedAssets []*url.URL
+	var uploadedPlugins []id.PluginID
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, u := range uploadedAssets {
+			if err := i.file.RemoveAsset(ctx, u); err != nil {
+				log.Warnfc(ctx, "project: failed to remove an imported asset: %v", err)
+			}
+		}
+		for _, pid := range uploadedPlugins {
+			if err := i.file.RemovePlugin(ctx, pid); err != nil {
+				log.Warnfc(ctx, "project: failed to remove an imported plugin: %v", err)
+			}
+		}
+	}()
+
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	ws, err := i.workspaceRepo.FindByID(ctx, p.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// enforce policy
+	var po *policy.Policy
+	if policyID := operator.Policy(ws.Policy()); policyID != nil {
+		po, err = i.policyRepo.FindByID(ctx, *policyID)
+		if err != nil {
+			return nil, err
+		}
+
+		projectCount, err := i.projectRepo.CountByWorkspace(ctx, ws.ID())
+		if err != nil {
+			return nil, err
+		}
+		if err := po.EnforceProjectCount(projectCount + 1); err != nil {
+			return nil, err
+		}
+
+		assetSize, err := i.assetRepo.TotalSizeByWorkspace(ctx, ws.ID())
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range pack.Manifest.Assets {
+			assetSize += a.Size
+		}
+		if err := po.EnforceAssetStorageSize(assetSize); err != nil {
+			return nil, err
+		}
+	}
+
+	mapping := pack.Document.NewIDMapping()
+	mapping[pack.Document.Project.Workspace] = ws.ID().String()
+
+	// validate the package before anything is uploaded to the storage
+	m, err := importModels(pack.Document, mapping, po)
+	if err != nil {
+		return nil, err
+	}
+	pluginFiles, err := importPluginFileIDs(pack.Manifest.PluginFiles, mapping, m)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range pack.Manifest.Assets {
+		u, err := i.importAsset(ctx, pack, a, ws.ID())
+		if u != nil {
+			uploadedAssets = append(uploadedAssets, u)
+		}
+		if err != nil {
+			return nil, err
+		}
+		mapping[a.URL] = u.String()
+	}
+
+	if m, err = importModels(pack.Document, mapping, po); err != nil {
+		return nil, err
+	}
+	if err := i.projectRepo.Save(ctx, m.Project); err != nil {
+		return nil, err
+	}
+	if err := i.saveSceneModels(ctx, m); err != nil {
+		return nil, err
+	}
+
+	uploadedPlugins = lo.Map(m.Plugins, func(p *plugin.Plugin, _ int) id.PluginID { return p.ID() })
+	for k, pf := range pack.Manifest.PluginFiles {
+		if err := i.importPluginFile(ctx, pack, pf, pluginFiles[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	operator.AddNewScene(ws.ID(), m.Scene.ID())
+	tx.Commit()
+	return m.Project, nil
+}
+
+// importModels returns the models of the document whose IDs and URLs are replaced with the mapping.
+// The project and the stories are unpublished and the plugins are unsigned.
+func importModels(d *projectpack.Document, mapping projectpack.Mapping, po *policy.Policy) (*projectpack.Models, error) {
+	d, err := d.Replace(mapping)
+	if err != nil {
+		return nil, interfaces.ErrInvalidProjectPackage
+	}
+	d.Unpublish()
+	d.Unsign()
+
+	m, err := d.Models()
+	if err != nil {
+		return nil, &rerror.Error{
+			Label:    interfaces.ErrInvalidProjectPackage,
+			Err:      err,
+			Separate: true,
+		}
+	}
+	if err := enforceSceneModels(po, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// importPluginFileIDs returns the new plugin IDs of the plugin files. The files must belong to the plugins in the models.
+func importPluginFileIDs(files []projectpack.PluginFile, mapping projectpack.Mapping, m *projectpack.Models) ([]id.PluginID, error) {
+	res := make([]id.PluginID, 0, len(files))
+	for _, pf := range files {
+		pid, err := id.PluginIDFrom(mapping.Replace(pf.Plugin))
+		if err != nil {
+			return nil, interfaces.ErrInvalidProjectPackage
+		}
+		if !lo.ContainsBy(m.Plugins, func(p *plugin.Plugin) bool { return p.ID().Equal(pid) }) ||
+			pf.Name == "" || path.Base(pf.Name) != pf.Name {
+			return nil, interfaces.ErrInvalidProjectPackage
+		}
+		res = append(res, pid)
+	}
+	return res, nil
+}
+
+// cloneTemplate copies the template scene and all of its entities to the project with new IDs.
+// Assets are shared with the template because they are referenced by URLs.
+// The policy is enforced on the copied entities as the scene is new.
+func (i *Project) cloneTemplate(ctx context.Context, prj *project.Project, tid id.SceneID, po *policy.Policy, operator *usecase.Operator) (*scene.Scene, error) {
+	if err := i.CanReadScene(tid, operator); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := i.sceneRepo.FindByID(ctx, tid)
+	if err != nil {
+		return nil, err
+	}
+	if !tmpl.Template() {
+		return nil, interfaces.ErrNotTemplateScene
+	}
+
+	tprj, err := i.projectRepo.FindByID(ctx, tmpl.Project())
+	if err != nil {
+		return nil, err
+	}
+
+	tm, err := i.exportModels(ctx, tprj)
+	if err != nil {
+		return nil, err
+	}
+
+	d := projectpack.NewDocument(tm)
+	mapping := d.NewIDMapping()
+	mapping[d.Project.ID] = prj.ID().String()
+	mapping[d.Project.Workspace] = prj.Workspace().String()
+
+	d, err = d.Replace(mapping)
+	if err != nil {
+		return nil, err
+	}
+	d.Unpublish()
+
+	m, err := d.Models()
+	if err != nil {
+		return nil, err
+	}
+	m.Scene.SetTemplate(false)
+	m.Scene.SetUpdatedAt(m.Scene.CreatedAt())
+	if err := enforceSceneModels(po, m); err != nil {
+		return nil, err
+	}
+
+	for _, p := range tm.Plugins {
+		if err := i.copyPluginFiles(ctx, p, mapping); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := i.saveSceneModels(ctx, m); err != nil {
+		return nil, err
+	}
+	return m.Scene, nil
+}
+
+// enforceSceneModels checks the policy before the entities of the models are saved as a new scene.
+func enforceSceneModels(p *policy.Policy, m *projectpack.Models) error {
+	if p == nil {
+		return nil
+	}
+
+	if err := p.EnforceNLSLayerCount(len(m.NLSLayers)); err != nil {
+		return err
+	}
+	for _, l := range m.NLSLayers.Deref() {
+		if s := l.Sketch(); s != nil && s.FeatureCollection() != nil {
+			if err := p.EnforceSketchFeatureCount(len(s.FeatureCollection().Features())); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := p.EnforceStoryCount(len(m.Stories)); err != nil {
+		return err
+	}
+	for _, s := range m.Stories {
+		if err := p.EnforceStoryPageCount(len(s.Pages().Pages())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveSceneModels saves the scene and its entities except for the project.
+// The repos are filtered by the operator which does not have the new scene yet, so the scene is made writable as Scene.Create does.
+func (i *Project) saveSceneModels(ctx context.Context, m *projectpack.Models) error {
+	rootLayer, err := layer.NewGroup().ID(m.Scene.RootLayer()).Scene(m.Scene.ID()).Root(true).Build()
+	if err != nil {
+		return err
+	}
+
+	filter := repo.SceneFilter{Writable: scene.IDList{m.Scene.ID()}}
+	if err := i.sceneRepo.Save(ctx, m.Scene); err != nil {
+		return err
+	}
+	if err := i.layerRepo.Filtered(filter).Save(ctx, rootLayer); err != nil {
+		return err
+	}
+	if len(m.PropertySchemas) > 0 {
+		if err := i.propertySchemaRepo.Filtered(filter).SaveAll(ctx, m.PropertySchemas); err != nil {
+			return err
+		}
+	}
+	for _, pl := range m.Plugins {
+		if err := i.pluginRepo.Filtered(filter).Save(ctx, pl); err != nil {
+			return err
+		}
+	}
+	if len(m.Properties) > 0 {
+		if err := i.propertyRepo.Filtered(filter).SaveAll(ctx, m.Properties); err != nil {
+			return err
+		}
+	}
+	if len(m.NLSLayers) > 0 {
+		if err := i.nlsLayerRepo.Filtered(filter).SaveAll(ctx, m.NLSLayers); err != nil {
+			return err
+		}
+	}
+	if len(m.Styles) > 0 {
+		if err := i.layerStyles.Filtered(filter).SaveAll(ctx, m.Styles); err != nil {
+			return err
+		}
+	}
+	if len(m.Stories) > 0 {
+		if err := i.storytellingRepo.Filtered(filter).SaveAll(ctx, m.Stories); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Project) exportModels(ctx context.Context, prj *project.Project) (*projectpack.Models, error) {
+	s, err := i.sceneRepo.FindByProject(ctx, prj.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	layers, err := i.nlsLayerRepo.FindByScene(ctx, s.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	var styles scene.StyleList
+	if res, err := i.layerStyles.FindByScene(ctx, s.ID()); err != nil {
+		return nil, err
+	} else if res != nil {
+		styles = *res
+	}
+
+	var stories storytelling.StoryList
+	if res, err := i.storytellingRepo.FindByScene(ctx, s.ID()); err != nil {
+		return nil, err
+	} else if res != nil {
+		stories = *res
+	}
+
+	propertyIDs := id.PropertyIDList(s.Properties())
+	for _, l := range layers.Deref() {
+		if ib := l.Infobox(); ib != nil {
+			propertyIDs = append(propertyIDs, ib.Property())
+			for _, b := range ib.Blocks() {
+				propertyIDs = append(propertyIDs, b.Property())
+			}
+		}
+	}
+	for _, st := range stories {
+		propertyIDs = append(propertyIDs, st.Properties()...)
+	}
+
+	properties, err := i.propertyRepo.FindByIDs(ctx, lo.Uniq(propertyIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	// only private plugins are included because public ones are available in the plugin registry
+	pluginIDs := lo.FilterMap(s.Plugins().Plugins(), func(p *scene.Plugin, _ int) (id.PluginID, bool) {
+		return p.Plugin(), p.Plugin().Scene() != nil
+	})
+
+	var plugins []*plugin.Plugin
+	var schemas property.SchemaList
+	if len(pluginIDs) > 0 {
+		plugins, err = i.pluginRepo.FindByIDs(ctx, pluginIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		schemaIDs := lo.FlatMap(plugins, func(p *plugin.Plugin, _ int) []id.PropertySchemaID {
+			return p.PropertySchemas()
+		})
+		if len(schemaIDs) > 0 {
+			schemas, err = i.propertySchemaRepo.FindByIDs(ctx, schemaIDs)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &projectpack.Models{
+		Project:         prj,
+		Scene:           s,
+		Properties:      properties,
+		NLSLayers:       layers,
+		Styles:          styles,
+		Stories:         stories,
+		Plugins:         plugins,
+		PropertySchemas: schemas,
+	}, nil
+}
+
+// exportAssets writes the assets of the workspace which are referenced from the document.
+func (i *Project) exportAssets(ctx context.Context, w *projectpack.Writer, d *projectpack.Document, prj *project.Project) error {
+	assets, _, err := i.assetRepo.FindByWorkspace(ctx, prj.Workspace(), repo.AssetFilter{})
+	if err != nil {
+		return err
+	}
+
+	referenced, err := d.Referenced(lo.Map(assets, func(a *asset.Asset, _ int) string {
+		return a.URL()
+	}))
+	if err != nil {
+		return err
+	}
+
+	for _, a := range assets {
+		if !lo.Contains(referenced, a.URL()) {
+			continue
+		}
+
+		u, err := url.Parse(a.URL())
+		if err != nil {
+			continue
+		}
+
+		r, err := i.file.ReadAsset(ctx, path.Base(u.Path))
+		if err != nil {
+			return err
+		}
+
+		err = w.WriteAsset(projectpack.Asset{
+			ID:          a.ID().String(),
+			Name:        a.Name(),
+			URL:         a.URL(),
+			ContentType: a.ContentType(),
+			Size:        a.Size(),
+		}, r)
+		_ = r.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportPluginFiles writes the script files of the private plugins. A file of each extension is named after the extension ID.
+func (i *Project) exportPluginFiles(ctx context.Context, w *projectpack.Writer, plugins []*plugin.Plugin) error {
+	for _, p := range plugins {
+		for _, e := range p.Extensions() {
+			name := e.ID().String() + ".js"
+			r, err := i.file.ReadPluginFile(ctx, p.ID(), name)
+			if errors.Is(err, rerror.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			err = w.WritePluginFile(p.ID().String(), name, r)
+			_ = r.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// copyPluginFiles copies the script files of a private plugin to the plugin whose ID is mapped from it.
+func (i *Project) copyPluginFiles(ctx context.Context, p *plugin.Plugin, mapping projectpack.Mapping) error {
+	pid, err := id.PluginIDFrom(mapping.Replace(p.ID().String()))
+	if err != nil {
+		return err
+	}
+
+	for _, e := range p.Extensions() {
+		name := e.ID().String() + ".js"
+		r, err := i.file.ReadPluginFile(ctx, p.ID(), name)
+		if errors.Is(err, rerror.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		err = i.file.UploadPluginFile(ctx, pid, &file.File{
+			Content: r,
+			Path:    name,
+		})
+		_ = r.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importAsset uploads the asset and saves it to the workspace. The URL is returned if it has been uploaded even when an error occurs.
+func (i *Project) importAsset(ctx context.Context, pack *projectpack.Package, a projectpack.Asset, ws accountdomain.WorkspaceID) (*url.URL, error) {
+	r, err := pack.Open(a.Path)
+	if err != nil {
+		return nil, interfaces.ErrInvalidProjectPackage
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	u, size, err := i.file.UploadAsset(ctx, &file.File{
+		Content:     r,
+		Path:        a.Name,
+		Size:        a.Size,
+		ContentType: a.ContentType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	as, err := asset.New().
+		NewID().
+		Workspace(ws).
+		Name(a.Name).
+		Size(size).
+		URL(u.String()).
+		ContentType(a.ContentType).
+		Build()
+	if err != nil {
+		return u, err
+	}
+
+	if err := i.assetRepo.Save(ctx, as); err != nil {
+		return u, err
+	}
+	return u, nil
+}
+
+func (i *Project) importPluginFile(ctx context.Context, pack *projectpack.Package, pf projectpack.PluginFile, pid id.PluginID) error {
+	r, err := pack.Open(pf.Path)
+	if err != nil {
+		return interfaces.ErrInvalidProjectPackage
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	return i.file.UploadPluginFile(ctx, pid, &file.File{
+		Content: r,
+		Path:    pf.Name,
+	})
+}
@@ -0,0 +1,572 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"io"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/i18n"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/pluginkey"
+	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/project/projectpack"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearth/server/pkg/visualizer"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProject_ExportImport(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	ws2 := workspace.New().NewID().MustBuild()
+	ws3 := workspace.New().NewID().Policy(policy.ID("policy").Ref()).MustBuild()
+	f := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com"))
+	r := memory.New()
+	r.Policy = memory.NewPolicyWith(policy.New(policy.Option{ID: policy.ID("policy"), NLSLayerCount: lo.ToPtr(0)}))
+	lo.Must0(r.Workspace.SaveAll(ctx, workspace.List{ws, ws2, ws3}))
+	uc := NewProject(r, &gateway.Container{File: f})
+
+	// asset
+	u, size, err := f.UploadAsset(ctx, &file.File{Content: io.NopCloser(bytes.NewBufferString("png")), Path: "image.png"})
+	require.NoError(t, err)
+	a := asset.New().NewID().Workspace(ws.ID()).Name("image.png").Size(size).URL(u.String()).MustBuild()
+	lo.Must0(r.Asset.Save(ctx, a))
+	unused := asset.New().NewID().Workspace(ws.ID()).Name("unused.png").Size(1).URL("https://example.com/assets/unused.png").MustBuild()
+	lo.Must0(r.Asset.Save(ctx, unused))
+
+	// private plugin
+	sid := id.NewSceneID()
+	pid := lo.Must(id.NewPluginID("test", "1.0.0", &sid))
+	psid := id.NewPropertySchemaID(pid, "widget")
+	schema := property.NewSchema().ID(psid).MustBuild()
+	pl := plugin.New().ID(pid).Name(i18n.StringFrom("test")).Extensions([]*plugin.Extension{
+		plugin.NewExtension().ID("widget").Type(plugin.ExtensionTypeWidget).Schema(psid).MustBuild(),
+	}).MustBuild()
+	lo.Must0(r.Plugin.Save(ctx, pl))
+	lo.Must0(r.PropertySchema.Save(ctx, schema))
+	lo.Must0(f.UploadPluginFile(ctx, pid, &file.File{Content: io.NopCloser(bytes.NewBufferString("js")), Path: "widget.js"}))
+
+	// project
+	prj := project.New().NewID().Workspace(ws.ID()).Name("project").Alias("alias").
+		Visualizer(visualizer.VisualizerCesium).PublishmentStatus(project.PublishmentStatusPublic).
+		ImageURL(u).MustBuild()
+	widgetProperty := property.New().NewID().Scene(sid).Schema(psid).MustBuild()
+	sceneProperty := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+	widget := scene.MustWidget(id.NewWidgetID(), pid, "widget", widgetProperty.ID(), true, false)
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws.ID()).RootLayer(id.NewLayerID()).
+		Property(sceneProperty.ID()).
+		Widgets(scene.NewWidgets([]*scene.Widget{widget}, nil)).
+		Plugins(scene.NewPlugins([]*scene.Plugin{scene.NewPlugin(pid, nil)})).
+		MustBuild()
+	l := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).Title("layer").LayerType(nlslayer.Simple).
+		Config(&nlslayer.Config{"data": map[string]any{"url": u.String()}}).MustBuild()
+	storyProperty := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/story")).MustBuild()
+	story := storytelling.NewStory().NewID().Scene(sid).Property(storyProperty.ID()).Title("story").
+		Alias("story").Status(storytelling.PublishmentStatusPublic).
+		Pages(storytelling.NewPageList(nil)).MustBuild()
+	lo.Must0(r.Project.Save(ctx, prj))
+	lo.Must0(r.Scene.Save(ctx, s))
+	lo.Must0(r.Property.SaveAll(ctx, property.List{widgetProperty, sceneProperty, storyProperty}))
+	lo.Must0(r.NLSLayer.Save(ctx, l))
+	lo.Must0(r.Storytelling.Save(ctx, *story))
+
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+			WritableWorkspaces: accountdomain.WorkspaceIDList{ws2.ID(), ws3.ID()},
+		},
+	}
+
+	// export
+	buf := &bytes.Buffer{}
+	assert.Equal(t, interfaces.ErrOperationDenied, uc.Export(ctx, prj.ID(), buf, &usecase.Operator{AcOperator: &accountusecase.Operator{}}))
+	require.NoError(t, uc.Export(ctx, prj.ID(), buf, op))
+
+	name, err := uc.ExportProject(ctx, prj.ID(), op)
+	require.NoError(t, err)
+	assert.Equal(t, prj.ID().String()+".zip", name)
+	zr, err := uc.ReadExportProject(ctx, name, op)
+	require.NoError(t, err)
+	assert.Equal(t, "PK", string(lo.Must(io.ReadAll(zr))[:2]))
+	_ = zr.Close()
+
+	// import
+	_, err = uc.ImportProject(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: ws.ID(),
+		File:        &file.File{Content: io.NopCloser(bytes.NewReader(buf.Bytes()))},
+	}, op)
+	assert.Equal(t, interfaces.ErrOperationDenied, err)
+
+	_, err = uc.ImportProject(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: ws2.ID(),
+		File:        &file.File{Content: io.NopCloser(bytes.NewBufferString("invalid"))},
+	}, op)
+	assert.ErrorContains(t, err, interfaces.ErrInvalidProjectPackage.Error())
+
+	// the policy of the workspace limits the entities in the package
+	_, err = uc.ImportProject(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: ws3.ID(),
+		File:        &file.File{Content: io.NopCloser(bytes.NewReader(buf.Bytes()))},
+	}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+
+	prj2, err := uc.ImportProject(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: ws2.ID(),
+		File:        &file.File{Content: io.NopCloser(buf)},
+	}, op)
+	require.NoError(t, err)
+	assert.NotEqual(t, prj.ID(), prj2.ID())
+	assert.Equal(t, ws2.ID(), prj2.Workspace())
+	assert.Equal(t, "project", prj2.Name())
+	assert.Equal(t, "", prj2.Alias())
+	assert.Equal(t, project.PublishmentStatusPrivate, prj2.PublishmentStatus())
+	assert.Equal(t, prj2, lo.Must(r.Project.FindByID(ctx, prj2.ID())))
+
+	// only the referenced asset is copied
+	assets, _, _ := r.Asset.FindByWorkspace(ctx, ws2.ID(), repo.AssetFilter{})
+	require.Len(t, assets, 1)
+	assert.Equal(t, "image.png", assets[0].Name())
+	assert.Equal(t, assets[0].URL(), prj2.ImageURL().String())
+	assert.NotEqual(t, u.String(), assets[0].URL())
+
+	s2, err := r.Scene.FindByProject(ctx, prj2.ID())
+	require.NoError(t, err)
+	assert.NotEqual(t, s.ID(), s2.ID())
+	assert.Equal(t, ws2.ID(), s2.Workspace())
+	assert.Contains(t, op.WritableScenes, s2.ID())
+	_, err = r.Layer.FindByID(ctx, s2.RootLayer())
+	assert.NoError(t, err)
+
+	pid2 := s2.Widgets().Widgets()[0].Plugin()
+	assert.Equal(t, s2.ID().Ref(), pid2.Scene())
+	_, err = r.Plugin.FindByID(ctx, pid2)
+	assert.NoError(t, err)
+	_, err = r.PropertySchema.FindByID(ctx, id.NewPropertySchemaID(pid2, "widget"))
+	assert.NoError(t, err)
+	pf, err := f.ReadPluginFile(ctx, pid2, "widget.js")
+	require.NoError(t, err)
+	assert.Equal(t, "js", string(lo.Must(io.ReadAll(pf))))
+
+	properties, err := r.Property.FindByIDs(ctx, s2.Properties())
+	assert.NoError(t, err)
+	assert.Len(t, properties, 2)
+
+	layers, err := r.NLSLayer.FindByScene(ctx, s2.ID())
+	require.NoError(t, err)
+	require.Len(t, layers, 1)
+	l2 := nlslayer.NLSLayerSimpleFromLayer(*layers[0])
+	assert.NotEqual(t, l.ID(), l2.ID())
+	assert.Equal(t, assets[0].URL(), (*l2.Config())["data"].(map[string]any)["url"])
+
+	stories, err := r.Storytelling.FindByScene(ctx, s2.ID())
+	require.NoError(t, err)
+	stories2 := lo.Compact(*stories)
+	require.Len(t, stories2, 1)
+	assert.Equal(t, "", stories2[0].Alias())
+	assert.Equal(t, storytelling.PublishmentStatusPrivate, stories2[0].Status())
+}
+
+func TestProject_ImportProject_FilteredRepos(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	f := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com"))
+	r := memory.New()
+	lo.Must0(r.Workspace.Save(ctx, ws))
+
+	prj := project.New().NewID().Workspace(ws.ID()).Name("project").Visualizer(visualizer.VisualizerCesium).MustBuild()
+	sceneProperty := property.New().NewID().Scene(id.NewSceneID()).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+	s := scene.New().NewID().Project(prj.ID()).Workspace(ws.ID()).RootLayer(id.NewLayerID()).Property(sceneProperty.ID()).MustBuild()
+	sceneProperty = property.New().ID(sceneProperty.ID()).Scene(s.ID()).Schema(sceneProperty.Schema()).MustBuild()
+	l := nlslayer.NewNLSLayerSimple().NewID().Scene(s.ID()).Title("layer").LayerType(nlslayer.Simple).MustBuild()
+	lo.Must0(r.Project.Save(ctx, prj))
+	lo.Must0(r.Scene.Save(ctx, s))
+	lo.Must0(r.Property.Save(ctx, sceneProperty))
+	lo.Must0(r.NLSLayer.Save(ctx, l))
+
+	// the operator already has a scene, so the repos filtered by the operator cannot write other scenes
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+			WritableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+		},
+		ReadableScenes: id.SceneIDList{s.ID()},
+		WritableScenes: id.SceneIDList{s.ID()},
+	}
+	uc := NewProject(r.Filtered(repo.WorkspaceFilterFromOperator(op), repo.SceneFilterFromOperator(op)), &gateway.Container{File: f})
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, uc.Export(ctx, prj.ID(), buf, op))
+
+	prj2, err := uc.ImportProject(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: ws.ID(),
+		File:        &file.File{Content: io.NopCloser(buf)},
+	}, op)
+	require.NoError(t, err)
+
+	s2, err := r.Scene.FindByProject(ctx, prj2.ID())
+	require.NoError(t, err)
+	_, err = r.Layer.FindByID(ctx, s2.RootLayer())
+	assert.NoError(t, err)
+	properties, err := r.Property.FindByIDs(ctx, s2.Properties())
+	assert.NoError(t, err)
+	assert.Len(t, properties, 1)
+	layers, err := r.NLSLayer.FindByScene(ctx, s2.ID())
+	assert.NoError(t, err)
+	assert.Len(t, layers, 1)
+}
+
+func TestProject_ImportProject_ForeignPluginFile(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	f := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com"))
+	r := memory.New()
+	lo.Must0(r.Workspace.Save(ctx, ws))
+	uc := NewProject(r, &gateway.Container{File: f})
+
+	// a private plugin of another scene
+	other := id.NewSceneID()
+	otherPlugin := lo.Must(id.NewPluginID("other", "1.0.0", &other))
+	lo.Must0(f.UploadPluginFile(ctx, otherPlugin, &file.File{Content: io.NopCloser(bytes.NewBufferString("original")), Path: "widget.js"}))
+
+	prj := project.New().NewID().Workspace(ws.ID()).Name("project").Visualizer(visualizer.VisualizerCesium).MustBuild()
+	s := scene.New().NewID().Project(prj.ID()).Workspace(ws.ID()).RootLayer(id.NewLayerID()).Property(id.NewPropertyID()).MustBuild()
+	lo.Must0(r.Project.Save(ctx, prj))
+	lo.Must0(r.Scene.Save(ctx, s))
+
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+			WritableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, uc.Export(ctx, prj.ID(), buf, op))
+	pack := lo.Must(projectpack.Read(buf, projectPackageSizeLimit))
+
+	// the package tries to overwrite the file of the plugin
+	crafted := &bytes.Buffer{}
+	w := projectpack.NewWriter(crafted)
+	require.NoError(t, w.WriteDocument(pack.Document))
+	require.NoError(t, w.WriteAsset(projectpack.Asset{Name: "a.png", URL: "https://example.com/assets/a.png", Size: 3}, bytes.NewBufferString("png")))
+	require.NoError(t, w.WritePluginFile(otherPlugin.String(), "widget.js", bytes.NewBufferString("crafted")))
+	require.NoError(t, w.Close())
+
+	_, err := uc.ImportProject(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: ws.ID(),
+		File:        &file.File{Content: io.NopCloser(crafted)},
+	}, op)
+	assert.ErrorIs(t, err, interfaces.ErrInvalidProjectPackage)
+
+	pf := lo.Must(f.ReadPluginFile(ctx, otherPlugin, "widget.js"))
+	assert.Equal(t, "original", string(lo.Must(io.ReadAll(pf))))
+
+	// nothing is uploaded
+	assets, _, _ := r.Asset.FindByWorkspace(ctx, ws.ID(), repo.AssetFilter{})
+	assert.Empty(t, assets)
+}
+
+func TestProject_ImportProject_PluginSignature(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	f := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com"))
+	r := memory.New()
+	lo.Must0(r.Workspace.Save(ctx, ws))
+	uc := NewProject(r, &gateway.Container{File: f})
+
+	// a private plugin signed with the key of the workspace
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	key := pluginkey.New().NewID().Workspace(ws.ID()).Name("key").PublicKey(pub).MustBuild()
+	digest := []byte("digest")
+	sid := id.NewSceneID()
+	pid := lo.Must(id.NewPluginID("test", "1.0.0", &sid))
+	psid := id.NewPropertySchemaID(pid, "widget")
+	pl := plugin.New().ID(pid).Name(i18n.StringFrom("test")).Extensions([]*plugin.Extension{
+		plugin.NewExtension().ID("widget").Type(plugin.ExtensionTypeWidget).Schema(psid).MustBuild(),
+	}).Signature(plugin.NewSignature(digest, ed25519.Sign(priv, digest))).MustBuild()
+	require.True(t, pluginkey.List{key}.Approves(pl))
+	lo.Must0(r.Plugin.Save(ctx, pl))
+	lo.Must0(r.PropertySchema.Save(ctx, property.NewSchema().ID(psid).MustBuild()))
+	lo.Must0(f.UploadPluginFile(ctx, pid, &file.File{Content: io.NopCloser(bytes.NewBufferString("js")), Path: "widget.js"}))
+
+	prj := project.New().NewID().Workspace(ws.ID()).Name("project").Visualizer(visualizer.VisualizerCesium).MustBuild()
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws.ID()).RootLayer(id.NewLayerID()).Property(id.NewPropertyID()).
+		Plugins(scene.NewPlugins([]*scene.Plugin{scene.NewPlugin(pid, nil)})).MustBuild()
+	lo.Must0(r.Project.Save(ctx, prj))
+	lo.Must0(r.Scene.Save(ctx, s))
+
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+			WritableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, uc.Export(ctx, prj.ID(), buf, op))
+	pack := lo.Must(projectpack.Read(buf, projectPackageSizeLimit))
+	require.NotNil(t, pack.Document.Plugins[0].Signature)
+
+	// the script is replaced while the signature is kept in the package
+	tampered := &bytes.Buffer{}
+	w := projectpack.NewWriter(tampered)
+	require.NoError(t, w.WriteDocument(pack.Document))
+	require.NoError(t, w.WritePluginFile(pid.String(), "widget.js", bytes.NewBufferString("tampered")))
+	require.NoError(t, w.Close())
+
+	prj2, err := uc.ImportProject(ctx, interfaces.ImportProjectParam{
+		WorkspaceID: ws.ID(),
+		File:        &file.File{Content: io.NopCloser(tampered)},
+	}, op)
+	require.NoError(t, err)
+
+	s2 := lo.Must(r.Scene.FindByProject(ctx, prj2.ID()))
+	pl2, err := r.Plugin.FindByID(ctx, s2.Plugins().Plugins()[0].Plugin())
+	require.NoError(t, err)
+	assert.Nil(t, pl2.Signature())
+	assert.False(t, pluginkey.List{key}.Approves(pl2))
+}
+
+func TestProject_CreateFromTemplate(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	ws2 := workspace.New().NewID().MustBuild()
+	ws3 := workspace.New().NewID().Policy(policy.ID("policy").Ref()).MustBuild()
+	f := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com"))
+	r := memory.New()
+	r.Policy = memory.NewPolicyWith(policy.New(policy.Option{ID: policy.ID("policy"), StoryCount: lo.ToPtr(0)}))
+	lo.Must0(r.Workspace.SaveAll(ctx, workspace.List{ws, ws2, ws3}))
+	uc := NewProject(r, &gateway.Container{File: f})
+
+	// template with a private plugin
+	sid := id.NewSceneID()
+	pid := lo.Must(id.NewPluginID("test", "1.0.0", &sid))
+	psid := id.NewPropertySchemaID(pid, "widget")
+	pl := plugin.New().ID(pid).Name(i18n.StringFrom("test")).Extensions([]*plugin.Extension{
+		plugin.NewExtension().ID("widget").Type(plugin.ExtensionTypeWidget).Schema(psid).MustBuild(),
+	}).MustBuild()
+	lo.Must0(r.Plugin.Save(ctx, pl))
+	lo.Must0(r.PropertySchema.Save(ctx, property.NewSchema().ID(psid).MustBuild()))
+	lo.Must0(f.UploadPluginFile(ctx, pid, &file.File{Content: io.NopCloser(bytes.NewBufferString("js")), Path: "widget.js"}))
+
+	prj := project.New().NewID().Workspace(ws.ID()).Name("template").Visualizer(visualizer.VisualizerCesium).MustBuild()
+	widgetProperty := property.New().NewID().Scene(sid).Schema(psid).MustBuild()
+	sceneProperty := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+	widget := scene.MustWidget(id.NewWidgetID(), pid, "widget", widgetProperty.ID(), true, false)
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws.ID()).RootLayer(id.NewLayerID()).
+		Property(sceneProperty.ID()).
+		Widgets(scene.NewWidgets([]*scene.Widget{widget}, nil)).
+		Plugins(scene.NewPlugins([]*scene.Plugin{scene.NewPlugin(pid, nil)})).
+		Template(true).
+		MustBuild()
+	l := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).Title("layer").LayerType(nlslayer.Simple).MustBuild()
+	st := scene.NewStyle().NewID().Scene(sid).Name("style").Value(&scene.StyleValue{}).MustBuild()
+	storyProperty := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/story")).MustBuild()
+	story := storytelling.NewStory().NewID().Scene(sid).Property(storyProperty.ID()).Title("story").
+		Pages(storytelling.NewPageList(nil)).MustBuild()
+	lo.Must0(r.Project.Save(ctx, prj))
+	lo.Must0(r.Scene.Save(ctx, s))
+	lo.Must0(r.Property.SaveAll(ctx, property.List{widgetProperty, sceneProperty, storyProperty}))
+	lo.Must0(r.NLSLayer.Save(ctx, l))
+	lo.Must0(r.Style.Save(ctx, *st))
+	lo.Must0(r.Storytelling.Save(ctx, *story))
+
+	// a scene which is not a template
+	prj3 := project.New().NewID().Workspace(ws.ID()).MustBuild()
+	s3 := scene.New().NewID().Project(prj3.ID()).Workspace(ws.ID()).RootLayer(id.NewLayerID()).
+		Property(id.NewPropertyID()).MustBuild()
+	lo.Must0(r.Project.Save(ctx, prj3))
+	lo.Must0(r.Scene.Save(ctx, s3))
+
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+			WritableWorkspaces: accountdomain.WorkspaceIDList{ws2.ID(), ws3.ID()},
+		},
+		ReadableScenes: id.SceneIDList{sid, s3.ID()},
+	}
+	param := interfaces.CreateProjectParam{
+		WorkspaceID: ws2.ID(),
+		Visualizer:  visualizer.VisualizerCesium,
+		Name:        lo.ToPtr("project"),
+		TemplateID:  sid.Ref(),
+	}
+
+	_, err := uc.Create(ctx, param, &usecase.Operator{
+		AcOperator: &accountusecase.Operator{WritableWorkspaces: accountdomain.WorkspaceIDList{ws2.ID()}},
+	})
+	assert.Equal(t, interfaces.ErrOperationDenied, err)
+
+	_, err = uc.Create(ctx, interfaces.CreateProjectParam{
+		WorkspaceID: ws2.ID(),
+		Visualizer:  visualizer.VisualizerCesium,
+		TemplateID:  s3.ID().Ref(),
+	}, op)
+	assert.Equal(t, interfaces.ErrNotTemplateScene, err)
+
+	// the policy of the workspace limits the entities copied from the template
+	_, err = uc.Create(ctx, interfaces.CreateProjectParam{
+		WorkspaceID: ws3.ID(),
+		Visualizer:  visualizer.VisualizerCesium,
+		TemplateID:  sid.Ref(),
+	}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+
+	prj2, err := uc.Create(ctx, param, op)
+	require.NoError(t, err)
+	assert.Equal(t, "project", prj2.Name())
+	assert.Equal(t, ws2.ID(), prj2.Workspace())
+
+	s2, err := r.Scene.FindByProject(ctx, prj2.ID())
+	require.NoError(t, err)
+	assert.NotEqual(t, sid, s2.ID())
+	assert.Equal(t, ws2.ID(), s2.Workspace())
+	assert.False(t, s2.Template())
+	assert.Contains(t, op.WritableScenes, s2.ID())
+	_, err = r.Layer.FindByID(ctx, s2.RootLayer())
+	assert.NoError(t, err)
+
+	// the private plugin is copied to the new scene
+	widgets := s2.Widgets().Widgets()
+	require.Len(t, widgets, 1)
+	assert.NotEqual(t, widget.ID(), widgets[0].ID())
+	pid2 := widgets[0].Plugin()
+	assert.Equal(t, s2.ID().Ref(), pid2.Scene())
+	pf, err := f.ReadPluginFile(ctx, pid2, "widget.js")
+	require.NoError(t, err)
+	assert.Equal(t, "js", string(lo.Must(io.ReadAll(pf))))
+
+	layers, err := r.NLSLayer.FindByScene(ctx, s2.ID())
+	require.NoError(t, err)
+	require.Len(t, layers, 1)
+	assert.NotEqual(t, l.ID(), (*layers[0]).ID())
+
+	styles, err := r.Style.FindByScene(ctx, s2.ID())
+	require.NoError(t, err)
+	require.Len(t, *styles, 1)
+	assert.NotEqual(t, st.ID(), (*styles)[0].ID())
+
+	stories, err := r.Storytelling.FindByScene(ctx, s2.ID())
+	require.NoError(t, err)
+	require.Len(t, lo.Compact(*stories), 1)
+
+	// the template is kept as it is
+	tmpl, err := r.Scene.FindByID(ctx, sid)
+	require.NoError(t, err)
+	assert.True(t, tmpl.Template())
+}
+
+func TestProject_CreateFromTemplate_FilteredRepos(t *testing.T) {
+	ctx := context.Background()
+
+	ws := workspace.New().NewID().MustBuild()
+	f := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com"))
+	r := memory.New()
+	lo.Must0(r.Workspace.Save(ctx, ws))
+
+	prj := project.New().NewID().Workspace(ws.ID()).Name("template").Visualizer(visualizer.VisualizerCesium).MustBuild()
+	sid := id.NewSceneID()
+	sceneProperty := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws.ID()).RootLayer(id.NewLayerID()).
+		Property(sceneProperty.ID()).Template(true).MustBuild()
+	l := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).Title("layer").LayerType(nlslayer.Simple).MustBuild()
+	lo.Must0(r.Project.Save(ctx, prj))
+	lo.Must0(r.Scene.Save(ctx, s))
+	lo.Must0(r.Property.Save(ctx, sceneProperty))
+	lo.Must0(r.NLSLayer.Save(ctx, l))
+
+	// the operator already has a scene, so the repos filtered by the operator cannot write other scenes
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+			WritableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+		},
+		ReadableScenes: id.SceneIDList{sid},
+		WritableScenes: id.SceneIDList{sid},
+	}
+	uc := NewProject(r.Filtered(repo.WorkspaceFilterFromOperator(op), repo.SceneFilterFromOperator(op)), &gateway.Container{File: f})
+
+	prj2, err := uc.Create(ctx, interfaces.CreateProjectParam{
+		WorkspaceID: ws.ID(),
+		Visualizer:  visualizer.VisualizerCesium,
+		TemplateID:  sid.Ref(),
+	}, op)
+	require.NoError(t, err)
+
+	s2, err := r.Scene.FindByProject(ctx, prj2.ID())
+	require.NoError(t, err)
+	_, err = r.Layer.FindByID(ctx, s2.RootLayer())
+	assert.NoError(t, err)
+	properties, err := r.Property.FindByIDs(ctx, s2.Properties())
+	assert.NoError(t, err)
+	assert.Len(t, properties, 1)
+	layers, err := r.NLSLayer.FindByScene(ctx, s2.ID())
+	assert.NoError(t, err)
+	assert.Len(t, layers, 1)
+}
+
+func TestEnforceSceneModels(t *testing.T) {
+	sid := id.NewSceneID()
+	sketch := nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", []nlslayer.Feature{
+		*lo.Must(nlslayer.NewFeatureWithNewId("Feature", nlslayer.NewPoint("Point", []float64{1, 2}))),
+		*lo.Must(nlslayer.NewFeatureWithNewId("Feature", nlslayer.NewPoint("Point", []float64{3, 4}))),
+	}))
+	l := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).IsSketch(true).Sketch(sketch).MustBuild()
+	page := storytelling.NewPage().NewID().Property(id.NewPropertyID()).MustBuild()
+	story := storytelling.NewStory().NewID().Scene(sid).Property(id.NewPropertyID()).
+		Pages(storytelling.NewPageList([]*storytelling.Page{page})).MustBuild()
+	m := &projectpack.Models{
+		NLSLayers: nlslayer.NLSLayerList{lo.ToPtr[nlslayer.NLSLayer](l)},
+		Stories:   storytelling.StoryList{story},
+	}
+
+	tests := []struct {
+		name string
+		opts policy.Option
+		err  error
+	}{
+		{name: "no limits"},
+		{name: "enough limits", opts: policy.Option{NLSLayerCount: lo.ToPtr(1), SketchFeatureCount: lo.ToPtr(2), StoryCount: lo.ToPtr(1), StoryPageCount: lo.ToPtr(1)}},
+		{name: "layers", opts: policy.Option{NLSLayerCount: lo.ToPtr(0)}, err: policy.ErrPolicyViolation},
+		{name: "sketch features", opts: policy.Option{SketchFeatureCount: lo.ToPtr(1)}, err: policy.ErrPolicyViolation},
+		{name: "stories", opts: policy.Option{StoryCount: lo.ToPtr(0)}, err: policy.ErrPolicyViolation},
+		{name: "story pages", opts: policy.Option{StoryPageCount: lo.ToPtr(0)}, err: policy.ErrPolicyViolation},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.opts.ID = policy.ID("policy")
+			assert.Equal(t, tt.err, enforceSceneModels(policy.New(tt.opts), m))
+		})
+	}
+
+	assert.NoError(t, enforceSceneModels(nil, m))
+}
@@ -2,14 +2,22 @@ package interactor
 
 import (
 	"context"
+	"io"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
 	"github.com/reearth/reearth/server/internal/infrastructure/memory"
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/policy"
 	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
 	"github.com/reearth/reearth/server/pkg/visualizer"
 	"github.com/reearth/reearthx/account/accountdomain/workspace"
 	"github.com/reearth/reearthx/account/accountinfrastructure/accountmemory"
@@ -17,6 +25,7 @@ import (
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/usecasex"
 	"github.com/samber/lo"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -137,3 +146,90 @@ func TestProject_Create(t *testing.T) {
 	assert.Same(t, policy.ErrPolicyViolation, err)
 	assert.Nil(t, got)
 }
+
+func TestProject_RollbackPublish(t *testing.T) {
+	ctx := context.Background()
+
+	file := lo.Must(fs.NewFile(afero.NewMemMapFs(), ""))
+	uc := &Project{
+		commonSceneLock: commonSceneLock{sceneLockRepo: memory.NewSceneLock()},
+		projectRepo:     memory.NewProject(),
+		sceneRepo:       memory.NewScene(),
+		file:            file,
+	}
+
+	ws := workspace.NewID()
+	prj := project.New().NewID().Workspace(ws).Alias("aliasalias").PublishmentStatus(project.PublishmentStatusPublic).MustBuild()
+	prj2 := project.New().NewID().Workspace(ws).Alias("privatealias").MustBuild()
+	s := scene.New().NewID().Workspace(ws).Project(prj.ID()).RootLayer(id.NewLayerID()).MustBuild()
+	_ = uc.projectRepo.Save(ctx, prj)
+	_ = uc.projectRepo.Save(ctx, prj2)
+	_ = uc.sceneRepo.Save(ctx, s)
+
+	_ = file.UploadBuiltSceneSnapshot(ctx, strings.NewReader("v1"), prj.ID().String(), "1000")
+	_ = file.UploadBuiltSceneSnapshot(ctx, strings.NewReader("v2"), prj.ID().String(), "2000")
+	_ = file.UploadBuiltScene(ctx, strings.NewReader("v2"), "aliasalias")
+
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: workspace.IDList{ws},
+			WritableWorkspaces: workspace.IDList{ws},
+		},
+	}
+
+	versions, err := uc.PublishedVersions(ctx, prj.ID(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, []interfaces.PublishedVersion{
+		{Version: "2000", PublishedAt: time.UnixMilli(2000)},
+		{Version: "1000", PublishedAt: time.UnixMilli(1000)},
+	}, versions)
+
+	got, err := uc.RollbackPublish(ctx, prj.ID(), "1000", op)
+	assert.NoError(t, err)
+	assert.Equal(t, prj, got)
+	r := lo.Must(file.ReadBuiltSceneFile(ctx, "aliasalias"))
+	assert.Equal(t, "v1", string(lo.Must(io.ReadAll(r))))
+	_ = r.Close()
+
+	_, err = uc.RollbackPublish(ctx, prj.ID(), "3000", op)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	_, err = uc.RollbackPublish(ctx, prj2.ID(), "1000", op)
+	assert.Same(t, interfaces.ErrNotPublished, err)
+
+	_, err = uc.RollbackPublish(ctx, prj.ID(), "1000", &usecase.Operator{AcOperator: &accountusecase.Operator{}})
+	assert.Error(t, err)
+}
+
+func TestProject_Delete_Stories(t *testing.T) {
+	ctx := context.Background()
+
+	r := memory.New()
+	f := lo.Must(fs.NewFile(afero.NewMemMapFs(), ""))
+	uc := NewProject(r, &gateway.Container{File: f})
+
+	ws := workspace.New().NewID().MustBuild()
+	prj := project.New().NewID().Workspace(ws.ID()).MustBuild()
+	s := scene.New().NewID().Workspace(ws.ID()).Project(prj.ID()).RootLayer(id.NewLayerID()).MustBuild()
+	story := storytelling.NewStory().NewID().Scene(s.ID()).Property(id.NewPropertyID()).
+		Alias("story").Status(storytelling.PublishmentStatusPublic).
+		Pages(storytelling.NewPageList(nil)).MustBuild()
+	lo.Must0(r.Workspace.Save(ctx, ws))
+	lo.Must0(r.Project.Save(ctx, prj))
+	lo.Must0(r.Scene.Save(ctx, s))
+	lo.Must0(r.Storytelling.Save(ctx, *story))
+	lo.Must0(f.UploadStory(ctx, strings.NewReader("{}"), "story"))
+	lo.Must0(f.UploadStorySnapshot(ctx, strings.NewReader("{}"), story.Id().String(), "1000"))
+
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			WritableWorkspaces: workspace.IDList{ws.ID()},
+		},
+	}
+	assert.NoError(t, uc.Delete(ctx, prj.ID(), op))
+
+	// the published data of the stories in the project are removed
+	_, err := f.ReadStoryFile(ctx, "story")
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	assert.Empty(t, lo.Must(f.ListStorySnapshots(ctx, story.Id().String())))
+}
@@ -0,0 +1,150 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
+)
+
+const publishSchedulerLock = "publish_scheduler"
+
+// PublishScheduler applies the publish schedules of projects and stories once they are due.
+type PublishScheduler struct {
+	lock             repo.Lock
+	projectRepo      repo.Project
+	storytellingRepo repo.Storytelling
+	sceneRepo        repo.Scene
+	project          interfaces.Project
+	storytelling     interfaces.Storytelling
+	defaultPolicy    *policy.ID
+}
+
+func NewPublishScheduler(r *repo.Container, g *gateway.Container, defaultPolicy *policy.ID) *PublishScheduler {
+	return &PublishScheduler{
+		lock:             r.Lock,
+		projectRepo:      r.Project,
+		storytellingRepo: r.Storytelling,
+		sceneRepo:        r.Scene,
+		project:          NewProject(r, g),
+		storytelling:     NewStorytelling(r, g, ""),
+		defaultPolicy:    util.CloneRef(defaultPolicy),
+	}
+}
+
+// Run publishes and unpublishes the projects and stories whose schedules are due at the time.
+// Only one replica runs the schedules at once. Failed schedules are kept and retried on the next run.
+func (s *PublishScheduler) Run(ctx context.Context, now time.Time) error {
+	if err := s.lock.Lock(ctx, publishSchedulerLock); err != nil {
+		if errors.Is(err, repo.ErrFailedToLock) || errors.Is(err, repo.ErrAlreadyLocked) {
+			// another replica is running the schedules
+			return nil
+		}
+		return err
+	}
+
+	defer func() {
+		if err := s.lock.Unlock(ctx, publishSchedulerLock); err != nil {
+			log.Errorfc(ctx, "publish scheduler: failed to unlock: %v", err)
+		}
+	}()
+
+	projects, err := s.projectRepo.FindDuePublishSchedules(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, prj := range projects {
+		if err := s.runProject(ctx, prj, now); err != nil {
+			log.Errorfc(ctx, "publish scheduler: failed to run the schedule of project %s: %v", prj.ID(), err)
+		}
+	}
+
+	stories, err := s.storytellingRepo.FindDuePublishSchedules(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, story := range *stories {
+		if err := s.runStory(ctx, story, now); err != nil {
+			log.Errorfc(ctx, "publish scheduler: failed to run the schedule of story %s: %v", story.Id(), err)
+		}
+	}
+
+	return nil
+}
+
+func (s *PublishScheduler) runProject(ctx context.Context, prj *project.Project, now time.Time) error {
+	schedule := prj.PublishSchedule()
+	param := interfaces.PublishProjectParam{
+		ID: prj.ID(),
+	}
+
+	if schedule.IsPublishDue(now) {
+		param.Alias = lo.EmptyableToPtr(schedule.Alias())
+		param.Status = schedule.Status()
+	} else if schedule.IsUnpublishDue(now) {
+		param.Status = project.PublishmentStatusPrivate
+	} else {
+		return nil
+	}
+
+	sc, err := s.sceneRepo.FindByProject(ctx, prj.ID())
+	if err != nil {
+		return err
+	}
+
+	_, err = s.project.Publish(ctx, param, s.operator(prj.Workspace(), sc.ID()))
+	return err
+}
+
+func (s *PublishScheduler) runStory(ctx context.Context, story *storytelling.Story, now time.Time) error {
+	schedule := story.PublishSchedule()
+	inp := interfaces.PublishStoryInput{
+		ID: story.Id(),
+	}
+
+	if schedule.IsPublishDue(now) {
+		inp.Alias = lo.EmptyableToPtr(schedule.Alias())
+		inp.Status = schedule.Status()
+	} else if schedule.IsUnpublishDue(now) {
+		inp.Status = storytelling.PublishmentStatusPrivate
+	} else {
+		return nil
+	}
+
+	sc, err := s.sceneRepo.FindByID(ctx, story.Scene())
+	if err != nil {
+		return err
+	}
+
+	_, err = s.storytelling.Publish(ctx, inp, s.operator(sc.Workspace(), sc.ID()))
+	return err
+}
+
+// operator returns the operator which is allowed to publish the scene on behalf of its workspace.
+func (s *PublishScheduler) operator(ws workspace.ID, sid id.SceneID) *usecase.Operator {
+	return &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: workspace.IDList{ws},
+			WritableWorkspaces: workspace.IDList{ws},
+			DefaultPolicy:      s.defaultPolicy,
+		},
+		ReadableScenes: id.SceneIDList{sid},
+		WritableScenes: id.SceneIDList{sid},
+		DefaultPolicy:  s.defaultPolicy,
+	}
+}
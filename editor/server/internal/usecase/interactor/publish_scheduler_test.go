@@ -0,0 +1,80 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishScheduler_Run(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	publishAt, unpublishAt := now.Add(time.Minute), now.Add(time.Hour)
+
+	ws := workspace.New().NewID().MustBuild()
+	f := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com"))
+	r := memory.New()
+	lo.Must0(r.Workspace.Save(ctx, ws))
+
+	prj := project.New().NewID().Workspace(ws.ID()).Alias("aliasalias").
+		PublishSchedule(lo.Must(project.NewPublishSchedule("", project.PublishmentStatusPublic, &publishAt, &unpublishAt))).
+		MustBuild()
+	sceneProperty := property.New().NewID().Scene(id.NewSceneID()).Schema(id.MustPropertySchemaID("reearth/cesium")).MustBuild()
+	s := scene.New().ID(sceneProperty.Scene()).Project(prj.ID()).Workspace(ws.ID()).RootLayer(id.NewLayerID()).
+		Property(sceneProperty.ID()).MustBuild()
+	storyProperty := property.New().NewID().Scene(s.ID()).Schema(id.MustPropertySchemaID("reearth/story")).MustBuild()
+	story := storytelling.NewStory().NewID().Scene(s.ID()).Property(storyProperty.ID()).Alias("storyalias").
+		PublishSchedule(lo.Must(project.NewPublishSchedule("", storytelling.PublishmentStatusLimited, &publishAt, nil))).
+		MustBuild()
+	lo.Must0(r.Project.Save(ctx, prj))
+	lo.Must0(r.Scene.Save(ctx, s))
+	lo.Must0(r.Property.SaveAll(ctx, property.List{sceneProperty, storyProperty}))
+	lo.Must0(r.Storytelling.Save(ctx, *story))
+
+	scheduler := NewPublishScheduler(r, &gateway.Container{File: f}, nil)
+
+	// nothing is due
+	require.NoError(t, scheduler.Run(ctx, now))
+	prj = lo.Must(r.Project.FindByID(ctx, prj.ID()))
+	assert.Equal(t, project.PublishmentStatusPrivate, prj.PublishmentStatus())
+	_, err := f.ReadBuiltSceneFile(ctx, "aliasalias")
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	// publish
+	require.NoError(t, scheduler.Run(ctx, publishAt))
+	prj = lo.Must(r.Project.FindByID(ctx, prj.ID()))
+	assert.Equal(t, project.PublishmentStatusPublic, prj.PublishmentStatus())
+	assert.Nil(t, prj.PublishSchedule().PublishAt())
+	assert.Equal(t, unpublishAt.UnixNano(), prj.PublishSchedule().UnpublishAt().UnixNano())
+	_, err = f.ReadBuiltSceneFile(ctx, "aliasalias")
+	assert.NoError(t, err)
+
+	story = lo.Must(r.Storytelling.FindByID(ctx, story.Id()))
+	assert.Equal(t, storytelling.PublishmentStatusLimited, story.PublishmentStatus())
+	assert.Nil(t, story.PublishSchedule())
+	_, err = f.ReadStoryFile(ctx, "storyalias")
+	assert.NoError(t, err)
+
+	// unpublish
+	require.NoError(t, scheduler.Run(ctx, unpublishAt))
+	prj = lo.Must(r.Project.FindByID(ctx, prj.ID()))
+	assert.Equal(t, project.PublishmentStatusPrivate, prj.PublishmentStatus())
+	assert.Nil(t, prj.PublishSchedule())
+	_, err = f.ReadBuiltSceneFile(ctx, "aliasalias")
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
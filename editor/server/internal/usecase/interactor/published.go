@@ -16,33 +16,42 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/storytelling"
 	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/util"
 )
 
 type Published struct {
+	common
 	project      repo.Project
 	Storytelling repo.Storytelling
 	file         gateway.File
 	indexHTML    *util.Cache[string]
 	indexHTMLStr string
+	// secret to verify the view tokens of published stories
+	viewTokenSecret string
+	// base URLs of the assets and the plugin files to find the files referenced from published data
+	assetBaseURL  *url.URL
+	pluginBaseURL *url.URL
 }
 
-func NewPublished(project repo.Project, storytelling repo.Storytelling, file gateway.File, indexHTML string) interfaces.Published {
+func NewPublished(project repo.Project, storytelling repo.Storytelling, file gateway.File, indexHTML string, viewTokenSecret string) *Published {
 	return &Published{
-		project:      project,
-		Storytelling: storytelling,
-		file:         file,
-		indexHTMLStr: indexHTML,
+		project:         project,
+		Storytelling:    storytelling,
+		file:            file,
+		indexHTMLStr:    indexHTML,
+		viewTokenSecret: viewTokenSecret,
 	}
 }
 
-func NewPublishedWithURL(project repo.Project, storytelling repo.Storytelling, file gateway.File, indexHTMLURL *url.URL) interfaces.Published {
+func NewPublishedWithURL(project repo.Project, storytelling repo.Storytelling, file gateway.File, indexHTMLURL *url.URL, viewTokenSecret string) *Published {
 	return &Published{
-		project:      project,
-		file:         file,
-		Storytelling: storytelling,
+		project:         project,
+		file:            file,
+		Storytelling:    storytelling,
+		viewTokenSecret: viewTokenSecret,
 		indexHTML: util.NewCache(func(c context.Context, i string) (string, error) {
 			req, err := http.NewRequestWithContext(c, http.MethodGet, indexHTMLURL.String(), nil)
 			if err != nil {
@@ -90,6 +99,43 @@ func (i *Published) Metadata(ctx context.Context, name string) (interfaces.Proje
 	return interfaces.PublishedMetadataFrom(prj), nil
 }
 
+// Authorize checks the view token given to the published story.
+// A limited story without basic auth can only be viewed with a valid token while view tokens are enabled.
+func (i *Published) Authorize(ctx context.Context, name, token string) (bool, error) {
+	if i.viewTokenSecret == "" || name == "" {
+		return false, nil
+	}
+
+	// projects take precedence over stories with the same name as in Metadata
+	prj, err := i.project.FindByPublicName(ctx, name)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return false, err
+	}
+	if prj != nil {
+		return false, nil
+	}
+
+	story, err := i.Storytelling.FindByPublicName(ctx, name)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return false, err
+	}
+	if story == nil {
+		return false, nil
+	}
+
+	if token == "" {
+		if story.Status() == storytelling.PublishmentStatusLimited && !story.IsBasicAuthActive() {
+			return false, interfaces.ErrViewTokenRequired
+		}
+		return false, nil
+	}
+
+	if err := verifyViewToken(i.viewTokenSecret, story.Id(), token, time.Now()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (i *Published) Data(ctx context.Context, name string) (io.Reader, error) {
 	r, err := i.file.ReadBuiltSceneFile(ctx, name)
 	if err != nil && err != rerror.ErrNotFound {
@@ -110,13 +156,9 @@ func (i *Published) Data(ctx context.Context, name string) (io.Reader, error) {
 }
 
 func (i *Published) Index(ctx context.Context, name string, u *url.URL) (string, error) {
-	htmlStr := i.indexHTMLStr
-	if i.indexHTML != nil {
-		htmlCachedStr, err := i.indexHTML.Get(ctx)
-		if err != nil {
-			return "", err
-		}
-		htmlStr = htmlCachedStr
+	htmlStr, err := i.index(ctx)
+	if err != nil {
+		return "", err
 	}
 
 	if name == "" {
@@ -144,6 +186,14 @@ func (i *Published) Index(ctx context.Context, name string, u *url.URL) (string,
 	return htmlStr, nil
 }
 
+// index returns the index HTML of the published viewer.
+func (i *Published) index(ctx context.Context) (string, error) {
+	if i.indexHTML != nil {
+		return i.indexHTML.Get(ctx)
+	}
+	return i.indexHTMLStr, nil
+}
+
 const headers = `{{if .title}}  <meta name="twitter:title" content="{{.title}}" />
   <meta property="og:title" content="{{.title}}" />{{end}}{{if .description}}
   <meta name="twitter:description" content="{{.description}}" />
@@ -0,0 +1,367 @@
+package interactor
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+)
+
+const (
+	siteAssetDir  = "assets"
+	sitePluginDir = "plugins"
+	siteStoryDir  = "stories"
+)
+
+// Export writes a zip of the standalone site of the published project or story so that it can be hosted on any static web server.
+// The site consists of index.html, reearth_config.json, data.json, the data of the public stories of the project,
+// and the assets and the private plugin files referenced from the data.
+// URLs of the assets and the plugin files in the data are rewritten to relative paths in the site.
+func (i *Published) Export(ctx context.Context, name string, w io.Writer, op *usecase.Operator) error {
+	md, prj, data, err := i.exportData(ctx, name, op)
+	if err != nil {
+		return err
+	}
+
+	var d any
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+
+	var stories map[string]any
+	if prj != nil {
+		if stories, err = i.exportStories(ctx, d); err != nil {
+			return err
+		}
+	}
+
+	e := &siteExporter{
+		assetBaseURL:  i.assetBaseURL,
+		pluginBaseURL: i.pluginBaseURL,
+		assets:        map[string]bool{},
+		plugins:       map[string]*siteExporterPlugin{},
+	}
+	e.collect(d)
+	for _, s := range stories {
+		e.collect(s)
+	}
+
+	zw := zip.NewWriter(w)
+
+	for _, a := range lo.Keys(e.assets) {
+		found, err := i.exportSiteAsset(ctx, zw, a)
+		if err != nil {
+			return err
+		}
+		e.assets[a] = found
+	}
+
+	pluginPaths := lo.Keys(e.plugins)
+	sort.Strings(pluginPaths)
+	for _, pp := range pluginPaths {
+		p := e.plugins[pp]
+		if p.exported, err = i.exportSitePluginFile(ctx, zw, *p); err != nil {
+			return err
+		}
+	}
+
+	if err := writeZipJSON(zw, "data.json", e.rewrite(d)); err != nil {
+		return err
+	}
+
+	aliases := lo.Keys(stories)
+	sort.Strings(aliases)
+	for _, a := range aliases {
+		if err := writeZipJSON(zw, path.Join(siteStoryDir, a+".json"), e.rewrite(stories[a])); err != nil {
+			return err
+		}
+	}
+
+	// the viewer loads the scripts of plugin extensions from the plugin directory given in the config
+	if err := writeZipJSON(zw, "reearth_config.json", map[string]any{"plugins": sitePluginDir}); err != nil {
+		return err
+	}
+
+	index, err := i.index(ctx)
+	if err != nil {
+		return err
+	}
+	if index != "" {
+		if err := writeZipFile(zw, "index.html", []byte(renderIndex(index, "", md))); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// exportData returns the metadata and the data of the published project or story which the operator can read.
+// The project is nil when the name is of a story.
+func (i *Published) exportData(ctx context.Context, name string, op *usecase.Operator) (interfaces.ProjectPublishedMetadata, *project.Project, []byte, error) {
+	var md interfaces.ProjectPublishedMetadata
+	var r io.ReadCloser
+
+	prj, err := i.project.FindByPublicName(ctx, name)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return md, nil, nil, err
+	}
+
+	if prj != nil {
+		if err := i.CanReadWorkspace(prj.Workspace(), op); err != nil {
+			return md, nil, nil, err
+		}
+		md = interfaces.PublishedMetadataFrom(prj)
+		if r, err = i.file.ReadBuiltSceneFile(ctx, name); err != nil {
+			return md, nil, nil, err
+		}
+	} else {
+		story, err := i.Storytelling.FindByPublicName(ctx, name)
+		if err != nil {
+			return md, nil, nil, err
+		}
+		if err := i.CanReadScene(story.Scene(), op); err != nil {
+			return md, nil, nil, err
+		}
+		md = interfaces.PublishedMetadataFrom(story)
+		if r, err = i.file.ReadStoryFile(ctx, name); err != nil {
+			return md, nil, nil, err
+		}
+	}
+
+	defer func() {
+		_ = r.Close()
+	}()
+
+	data, err := io.ReadAll(r)
+	return md, prj, data, err
+}
+
+// exportStories returns the published data of the public stories in the scene of the project data keyed by their aliases.
+// Limited stories are excluded since the static site cannot restrict viewers.
+func (i *Published) exportStories(ctx context.Context, d any) (map[string]any, error) {
+	m, _ := d.(map[string]any)
+	sid, err := id.SceneIDFrom(fmt.Sprint(m["id"]))
+	if err != nil {
+		return nil, nil
+	}
+
+	stories, err := i.Storytelling.FindByScene(ctx, sid)
+	if err != nil || stories == nil {
+		return nil, err
+	}
+
+	res := map[string]any{}
+	for _, s := range *stories {
+		if s == nil || s.PublishmentStatus() != storytelling.PublishmentStatusPublic || s.Alias() == "" {
+			continue
+		}
+
+		r, err := i.file.ReadStoryFile(ctx, s.Alias())
+		if errors.Is(err, rerror.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var sd any
+		err = json.NewDecoder(r).Decode(&sd)
+		_ = r.Close()
+		if err != nil {
+			return nil, err
+		}
+		res[s.Alias()] = sd
+	}
+	return res, nil
+}
+
+// exportSiteAsset writes the asset file and reports whether it is stored in the file storage.
+func (i *Published) exportSiteAsset(ctx context.Context, zw *zip.Writer, name string) (bool, error) {
+	r, err := i.file.ReadAsset(ctx, name)
+	if errors.Is(err, rerror.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	f, err := zw.Create(path.Join(siteAssetDir, name))
+	if err != nil {
+		return false, err
+	}
+	_, err = io.Copy(f, r)
+	return err == nil, err
+}
+
+// exportSitePluginFile writes the plugin file and reports whether it is stored in the file storage.
+func (i *Published) exportSitePluginFile(ctx context.Context, zw *zip.Writer, p siteExporterPlugin) (bool, error) {
+	r, err := i.file.ReadPluginFile(ctx, p.plugin, p.file)
+	if errors.Is(err, rerror.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	f, err := zw.Create(p.path())
+	if err != nil {
+		return false, err
+	}
+	_, err = io.Copy(f, r)
+	return err == nil, err
+}
+
+func writeZipJSON(zw *zip.Writer, name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeZipFile(zw, name, data)
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// siteExporter finds the assets and the private plugin files referenced from the published data.
+type siteExporter struct {
+	assetBaseURL  *url.URL
+	pluginBaseURL *url.URL
+	// file names of the assets and whether they are exported
+	assets map[string]bool
+	// plugin files keyed by their paths in the site
+	plugins map[string]*siteExporterPlugin
+}
+
+type siteExporterPlugin struct {
+	plugin   id.PluginID
+	file     string
+	exported bool
+}
+
+func (e *siteExporter) addPlugin(p siteExporterPlugin) {
+	if _, ok := e.plugins[p.path()]; !ok {
+		e.plugins[p.path()] = &p
+	}
+}
+
+func (p siteExporterPlugin) path() string {
+	return path.Join(sitePluginDir, p.plugin.String(), p.file)
+}
+
+func (e *siteExporter) collect(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for _, c := range v {
+			e.collect(c)
+		}
+		pid, _ := v["pluginId"].(string)
+		ext, _ := v["extensionId"].(string)
+		// only private plugins are included because public ones are available in the plugin registry
+		if p, err := id.PluginIDFrom(pid); err == nil && p.Scene() != nil && ext != "" {
+			e.addPlugin(siteExporterPlugin{plugin: p, file: ext + ".js"})
+		}
+	case []any:
+		for _, c := range v {
+			e.collect(c)
+		}
+	case string:
+		if name := e.assetFileName(v); name != "" {
+			e.assets[name] = false
+		} else if p, ok := e.pluginFile(v); ok {
+			e.addPlugin(p)
+		}
+	}
+}
+
+func (e *siteExporter) rewrite(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, c := range v {
+			v[k] = e.rewrite(c)
+		}
+	case []any:
+		for k, c := range v {
+			v[k] = e.rewrite(c)
+		}
+	case string:
+		if name := e.assetFileName(v); name != "" && e.assets[name] {
+			return path.Join(siteAssetDir, name)
+		}
+		if p, ok := e.pluginFile(v); ok && e.plugins[p.path()] != nil && e.plugins[p.path()].exported {
+			return p.path()
+		}
+	}
+	return v
+}
+
+// assetFileName returns the file name of the asset if the URL is under the base URL of the assets.
+func (e *siteExporter) assetFileName(s string) string {
+	name, ok := relativeURLPath(e.assetBaseURL, s)
+	if !ok || name == "" || strings.Contains(name, "/") {
+		return ""
+	}
+	return name
+}
+
+// pluginFile returns the plugin file if the URL is under the base URL of the plugin files such as "<base>/<plugin ID>/<file>".
+func (e *siteExporter) pluginFile(s string) (siteExporterPlugin, bool) {
+	p, ok := relativeURLPath(e.pluginBaseURL, s)
+	if !ok {
+		return siteExporterPlugin{}, false
+	}
+
+	pid, file, ok := strings.Cut(p, "/")
+	if !ok || file == "" || strings.Contains(file, "/") {
+		return siteExporterPlugin{}, false
+	}
+	plugin, err := id.PluginIDFrom(pid)
+	// only private plugins are included because public ones are available in the plugin registry
+	if err != nil || plugin.Scene() == nil {
+		return siteExporterPlugin{}, false
+	}
+	return siteExporterPlugin{plugin: plugin, file: file}, true
+}
+
+// relativeURLPath returns the path of the URL relative to the base URL.
+func relativeURLPath(base *url.URL, s string) (string, bool) {
+	if base == nil || base.Host == "" {
+		return "", false
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme != base.Scheme || !strings.EqualFold(u.Host, base.Host) {
+		return "", false
+	}
+
+	prefix := strings.TrimSuffix(base.Path, "/") + "/"
+	if !strings.HasPrefix(u.Path, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(u.Path, prefix), true
+}
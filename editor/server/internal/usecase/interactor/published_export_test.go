@@ -0,0 +1,112 @@
+package interactor
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublished_Export(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	f := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com/assets"))
+
+	ws := accountdomain.NewWorkspaceID()
+	sid := id.NewSceneID()
+	pid := lo.Must(id.NewPluginID("myplugin", "1.0.0", &sid))
+
+	prj := project.New().NewID().Workspace(ws).Alias("myproject").PublishmentStatus(project.PublishmentStatusPublic).MustBuild()
+	require.NoError(t, r.Project.Save(ctx, prj))
+
+	assetURL, _, err := f.UploadAsset(ctx, &file.File{Content: io.NopCloser(strings.NewReader("image")), Path: "a.png"})
+	require.NoError(t, err)
+	require.NoError(t, f.UploadPluginFile(ctx, pid, &file.File{Content: io.NopCloser(strings.NewReader("script")), Path: "widget.js"}))
+	require.NoError(t, f.UploadPluginFile(ctx, pid, &file.File{Content: io.NopCloser(strings.NewReader("icon")), Path: "icon.png"}))
+	pluginURL := "https://example.com/plugins/" + pid.String() + "/icon.png"
+
+	data := `{"id":"` + sid.String() + `","property":{"default":{"image":"` + assetURL.String() + `",` +
+		`"external":"https://example.org/assets/b.png","other":"https://example.com/files/c.png","icon":"` + pluginURL + `"}},` +
+		`"widgets":[{"pluginId":"` + pid.String() + `","extensionId":"widget"},{"pluginId":"reearth","extensionId":"menu"}]}`
+	require.NoError(t, f.UploadBuiltScene(ctx, strings.NewReader(data), "myproject"))
+
+	public := storytelling.NewStory().NewID().Scene(sid).Property(id.NewPropertyID()).Pages(storytelling.NewPageList(nil)).
+		Alias("mystory").Status(storytelling.PublishmentStatusPublic).MustBuild()
+	limited := storytelling.NewStory().NewID().Scene(sid).Property(id.NewPropertyID()).Pages(storytelling.NewPageList(nil)).
+		Alias("limitedstory").Status(storytelling.PublishmentStatusLimited).MustBuild()
+	require.NoError(t, r.Storytelling.Save(ctx, *public))
+	require.NoError(t, r.Storytelling.Save(ctx, *limited))
+	require.NoError(t, f.UploadStory(ctx, strings.NewReader(`{"image":"`+assetURL.String()+`"}`), "mystory"))
+	require.NoError(t, f.UploadStory(ctx, strings.NewReader(`{}`), "limitedstory"))
+
+	uc := NewPublished(r.Project, r.Storytelling, f, "<html><head><title>x</title></head></html>", "")
+	uc.assetBaseURL = lo.Must(url.Parse("https://example.com/assets"))
+	uc.pluginBaseURL = lo.Must(url.Parse("https://example.com/plugins"))
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: workspace.IDList{ws},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, uc.Export(ctx, "myproject", &buf, op))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, zf := range zr.File {
+		rc := lo.Must(zf.Open())
+		files[zf.Name] = string(lo.Must(io.ReadAll(rc)))
+		_ = rc.Close()
+	}
+
+	assetName := strings.TrimPrefix(assetURL.Path, "/assets/")
+	assert.ElementsMatch(t, []string{
+		"assets/" + assetName,
+		"data.json",
+		"index.html",
+		"reearth_config.json",
+		"plugins/" + pid.String() + "/icon.png",
+		"plugins/" + pid.String() + "/widget.js",
+		"stories/mystory.json",
+	}, lo.Keys(files))
+	assert.Equal(t, "image", files["assets/"+assetName])
+	assert.Equal(t, "script", files["plugins/"+pid.String()+"/widget.js"])
+	assert.Equal(t, "icon", files["plugins/"+pid.String()+"/icon.png"])
+	assert.JSONEq(t, `{"plugins":"plugins"}`, files["reearth_config.json"])
+	assert.JSONEq(t, `{"image":"assets/`+assetName+`"}`, files["stories/mystory.json"])
+	assert.Contains(t, files["index.html"], "<meta property=\"og:type\" content=\"website\" />")
+
+	var d map[string]any
+	require.NoError(t, json.Unmarshal([]byte(files["data.json"]), &d))
+	assert.Equal(t, map[string]any{
+		"image":    "assets/" + assetName,
+		"external": "https://example.org/assets/b.png",
+		"other":    "https://example.com/files/c.png",
+		"icon":     "plugins/" + pid.String() + "/icon.png",
+	}, d["property"].(map[string]any)["default"])
+
+	// the operator cannot read the workspace
+	assert.Equal(t, interfaces.ErrOperationDenied, uc.Export(ctx, "myproject", io.Discard, &usecase.Operator{AcOperator: &accountusecase.Operator{}}))
+}
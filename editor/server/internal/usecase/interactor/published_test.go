@@ -1,10 +1,18 @@
 package interactor
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/storytelling"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRenderIndex(t *testing.T) {
@@ -33,3 +41,93 @@ func TestRenderIndex(t *testing.T) {
 		},
 	))
 }
+
+func TestPublished_Authorize(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	sid := id.NewSceneID()
+	op := &usecase.Operator{
+		WritableScenes: id.SceneIDList{sid},
+	}
+
+	newStory := func(alias string, status storytelling.PublishmentStatus, basicAuth bool) *storytelling.Story {
+		s := storytelling.NewStory().NewID().Scene(sid).Property(id.NewPropertyID()).
+			Alias(alias).Status(status).PublicBasicAuth(basicAuth, "user", "pass").MustBuild()
+		require.NoError(t, r.Storytelling.Save(ctx, *s))
+		return s
+	}
+	limited := newStory("limited", storytelling.PublishmentStatusLimited, false)
+	newStory("public", storytelling.PublishmentStatusPublic, false)
+	newStory("basicauth", storytelling.PublishmentStatusLimited, true)
+	newStory("other", storytelling.PublishmentStatusLimited, false)
+
+	uc := NewPublished(r.Project, r.Storytelling, nil, "", "secret")
+	storyUC := NewStorytelling(r, &gateway.Container{}, "secret")
+
+	token, err := storyUC.CreateViewToken(ctx, interfaces.CreateStoryViewTokenInput{
+		StoryID:   limited.Id(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, op)
+	require.NoError(t, err)
+
+	_, err = storyUC.CreateViewToken(ctx, interfaces.CreateStoryViewTokenInput{
+		StoryID:   limited.Id(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, &usecase.Operator{})
+	assert.Equal(t, interfaces.ErrOperationDenied, err)
+
+	_, err = storyUC.CreateViewToken(ctx, interfaces.CreateStoryViewTokenInput{
+		StoryID:   limited.Id(),
+		ExpiresAt: time.Now().Add(-time.Hour),
+	}, op)
+	assert.Equal(t, interfaces.ErrInvalidViewToken, err)
+
+	_, err = NewStorytelling(r, &gateway.Container{}, "").CreateViewToken(ctx, interfaces.CreateStoryViewTokenInput{
+		StoryID:   limited.Id(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, op)
+	assert.Equal(t, interfaces.ErrViewTokenDisabled, err)
+
+	tests := []struct {
+		name    string
+		alias   string
+		token   string
+		want    bool
+		wantErr error
+	}{
+		{name: "limited with token", alias: "limited", token: token, want: true},
+		{name: "limited without token", alias: "limited", wantErr: interfaces.ErrViewTokenRequired},
+		{name: "limited with invalid token", alias: "limited", token: "1.aaa", wantErr: interfaces.ErrInvalidViewToken},
+		{name: "token of another story", alias: "other", token: token, wantErr: interfaces.ErrInvalidViewToken},
+		{name: "public without token", alias: "public"},
+		{name: "public with token", alias: "public", token: token, wantErr: interfaces.ErrInvalidViewToken},
+		{name: "basic auth without token", alias: "basicauth"},
+		{name: "not found", alias: "xxx", token: token},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Authorize(ctx, tt.alias, tt.token)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// tokens are ignored while they are disabled
+	got, err := NewPublished(r.Project, r.Storytelling, nil, "", "").Authorize(ctx, "limited", "")
+	assert.NoError(t, err)
+	assert.False(t, got)
+}
+
+func TestVerifyViewToken(t *testing.T) {
+	sid := id.NewStoryID()
+	now := time.Now()
+	token := signViewToken("secret", sid, now.Add(time.Minute))
+
+	assert.NoError(t, verifyViewToken("secret", sid, token, now))
+	assert.Equal(t, interfaces.ErrInvalidViewToken, verifyViewToken("secret", sid, token, now.Add(time.Minute)))
+	assert.Equal(t, interfaces.ErrInvalidViewToken, verifyViewToken("secret2", sid, token, now))
+	assert.Equal(t, interfaces.ErrInvalidViewToken, verifyViewToken("secret", id.NewStoryID(), token, now))
+	assert.Equal(t, interfaces.ErrInvalidViewToken, verifyViewToken("secret", sid, "x"+token, now))
+	assert.Equal(t, interfaces.ErrInvalidViewToken, verifyViewToken("secret", sid, "aaa", now))
+}
@@ -0,0 +1,59 @@
+package interactor
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearthx/log"
+	"github.com/samber/lo"
+)
+
+// publishedSnapshotLimit is the number of snapshots kept for each project or story. Older ones are removed when a new one is published.
+const publishedSnapshotLimit = 20
+
+// newPublishedVersion returns the version of a snapshot published at the time.
+// Versions are the Unix time in milliseconds so that they can be stored in case-insensitive storages.
+func newPublishedVersion(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
+
+// publishedVersionsFrom returns the versions of snapshots sorted from the newest one.
+func publishedVersionsFrom(versions []string) []interfaces.PublishedVersion {
+	res := lo.FilterMap(versions, func(v string, _ int) (interfaces.PublishedVersion, bool) {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return interfaces.PublishedVersion{}, false
+		}
+		return interfaces.PublishedVersion{
+			Version:     v,
+			PublishedAt: time.UnixMilli(ms),
+		}, true
+	})
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].PublishedAt.After(res[j].PublishedAt)
+	})
+	return res
+}
+
+// prunePublishedSnapshots removes the snapshots except for the newest ones.
+// Errors are only logged because the new snapshot has been already published.
+func prunePublishedSnapshots(ctx context.Context, name string, list func(context.Context, string) ([]string, error), remove func(context.Context, string, string) error) {
+	versions, err := list(ctx, name)
+	if err != nil {
+		log.Errorfc(ctx, "published: failed to list snapshots of %s: %v", name, err)
+		return
+	}
+
+	res := publishedVersionsFrom(versions)
+	if len(res) <= publishedSnapshotLimit {
+		return
+	}
+	for _, v := range res[publishedSnapshotLimit:] {
+		if err := remove(ctx, name, v.Version); err != nil {
+			log.Errorfc(ctx, "published: failed to remove snapshot %s of %s: %v", v.Version, name, err)
+		}
+	}
+}
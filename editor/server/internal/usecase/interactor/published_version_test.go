@@ -0,0 +1,31 @@
+package interactor
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrunePublishedSnapshots(t *testing.T) {
+	ctx := context.Background()
+	f := lo.Must(fs.NewFile(afero.NewMemMapFs(), ""))
+
+	for i := 1; i <= publishedSnapshotLimit+2; i++ {
+		lo.Must0(f.UploadStorySnapshot(ctx, strings.NewReader("{}"), "s", strconv.Itoa(i*1000)))
+	}
+
+	prunePublishedSnapshots(ctx, "s", f.ListStorySnapshots, f.RemoveStorySnapshot)
+
+	// the oldest snapshots are removed
+	versions := lo.Must(f.ListStorySnapshots(ctx, "s"))
+	assert.Len(t, versions, publishedSnapshotLimit)
+	assert.NotContains(t, versions, "1000")
+	assert.NotContains(t, versions, "2000")
+	assert.Contains(t, versions, "3000")
+}
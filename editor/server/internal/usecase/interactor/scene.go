@@ -11,10 +11,12 @@ import (
 	"github.com/reearth/reearth/server/pkg/builtin"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
 	"github.com/reearth/reearth/server/pkg/plugin"
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearth/server/pkg/visualizer"
+	"github.com/reearth/reearthx/account/accountdomain"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/usecasex"
 	"github.com/samber/lo"
@@ -28,6 +30,7 @@ type Scene struct {
 	projectRepo        repo.Project
 	pluginRepo         repo.Plugin
 	layerRepo          repo.Layer
+	nlsLayerRepo       repo.NLSLayer
 	datasetRepo        repo.Dataset
 	transaction        usecasex.Transaction
 	file               gateway.File
@@ -43,6 +46,7 @@ func NewScene(r *repo.Container, g *gateway.Container) interfaces.Scene {
 		projectRepo:        r.Project,
 		pluginRepo:         r.Plugin,
 		layerRepo:          r.Layer,
+		nlsLayerRepo:       r.NLSLayer,
 		datasetRepo:        r.Dataset,
 		transaction:        r.Transaction,
 		file:               g.File,
@@ -82,6 +86,54 @@ func (i *Scene) FindByProject(ctx context.Context, id id.ProjectID, operator *us
 	return s, nil
 }
 
+func (i *Scene) FindTemplates(ctx context.Context, wid accountdomain.WorkspaceID, operator *usecase.Operator) (scene.List, error) {
+	if err := i.CanReadWorkspace(wid, operator); err != nil {
+		return nil, err
+	}
+
+	scenes, err := i.sceneRepo.FindByWorkspace(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Filter(scenes, func(s *scene.Scene, _ int) bool {
+		return s.Template()
+	}), nil
+}
+
+// SetTemplate is allowed only to maintainers because templates are shared by all members of the workspace.
+func (i *Scene) SetTemplate(ctx context.Context, sid id.SceneID, template bool, operator *usecase.Operator) (_ *scene.Scene, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	s, err := i.sceneRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.OnlyOperator(operator); err != nil {
+		return nil, err
+	}
+	if !operator.IsMaintainingWorkspace(s.Workspace()) {
+		return nil, interfaces.ErrOperationDenied
+	}
+
+	s.SetTemplate(template)
+	if err := i.sceneRepo.Save(ctx, s); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return s, nil
+}
+
 func (i *Scene) Create(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) (_ *scene.Scene, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
@@ -446,7 +498,7 @@ func (i *Scene) RemoveWidget(ctx context.Context, id id.SceneID, wid id.WidgetID
 	return scene, nil
 }
 
-func (i *Scene) AddCluster(ctx context.Context, sceneID id.SceneID, name string, operator *usecase.Operator) (*scene.Scene, *scene.Cluster, error) {
+func (i *Scene) AddCluster(ctx context.Context, sceneID id.SceneID, name string, operator *usecase.Operator) (_ *scene.Scene, _ *scene.Cluster, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -492,7 +544,7 @@ func (i *Scene) AddCluster(ctx context.Context, sceneID id.SceneID, name string,
 	return s, cluster, nil
 }
 
-func (i *Scene) UpdateCluster(ctx context.Context, param interfaces.UpdateClusterParam, operator *usecase.Operator) (*scene.Scene, *scene.Cluster, error) {
+func (i *Scene) UpdateCluster(ctx context.Context, param interfaces.UpdateClusterParam, operator *usecase.Operator) (_ *scene.Scene, _ *scene.Cluster, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -532,7 +584,7 @@ func (i *Scene) UpdateCluster(ctx context.Context, param interfaces.UpdateCluste
 	return s, cluster, nil
 }
 
-func (i *Scene) RemoveCluster(ctx context.Context, sceneID id.SceneID, clusterID id.ClusterID, operator *usecase.Operator) (*scene.Scene, error) {
+func (i *Scene) RemoveCluster(ctx context.Context, sceneID id.SceneID, clusterID id.ClusterID, operator *usecase.Operator) (_ *scene.Scene, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -559,6 +611,27 @@ func (i *Scene) RemoveCluster(ctx context.Context, sceneID id.SceneID, clusterID
 		return nil, err
 	}
 
+	// NLS layers referencing the cluster are no longer clustered
+	nlsLayers, err := i.nlsLayerRepo.FindByScene(ctx, sceneID)
+	if err != nil {
+		return nil, err
+	}
+	var unclustered nlslayer.NLSLayerList
+	for _, l := range nlsLayers {
+		if l == nil || *l == nil {
+			continue
+		}
+		if c := (*l).Cluster(); c != nil && *c == clusterID {
+			(*l).SetCluster(nil)
+			unclustered = append(unclustered, l)
+		}
+	}
+	if len(unclustered) > 0 {
+		if err := i.nlsLayerRepo.SaveAll(ctx, unclustered); err != nil {
+			return nil, err
+		}
+	}
+
 	tx.Commit()
 	return s, nil
 }
@@ -0,0 +1,186 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/history"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type SceneHistory struct {
+	common
+	commonSceneLock
+	historyRepo repo.SceneHistory
+	store       *historyStore
+	transaction usecasex.Transaction
+	pubsub      gateway.PubSub
+}
+
+// NewSceneHistory creates the interactor. The repositories must not be wrapped by recordSceneHistory
+// so that undo and redo are not recorded as new operations.
+func NewSceneHistory(r *repo.Container, g *gateway.Container) interfaces.SceneHistory {
+	return &SceneHistory{
+		commonSceneLock: commonSceneLock{sceneLockRepo: r.SceneLock},
+		historyRepo:     r.SceneHistory,
+		store:           newHistoryStore(r),
+		transaction:     r.Transaction,
+		pubsub:          g.PubSub,
+	}
+}
+
+func (i *SceneHistory) Fetch(ctx context.Context, sid id.SceneID, p *usecasex.Pagination, operator *usecase.Operator) ([]*history.History, *usecasex.PageInfo, error) {
+	if err := i.CanReadScene(sid, operator); err != nil {
+		return nil, nil, err
+	}
+	return i.historyRepo.FindByScene(ctx, sid, p)
+}
+
+func (i *SceneHistory) Undo(ctx context.Context, sid id.SceneID, operator *usecase.Operator) (*history.History, error) {
+	return i.revert(ctx, sid, true, operator)
+}
+
+func (i *SceneHistory) Redo(ctx context.Context, sid id.SceneID, operator *usecase.Operator) (*history.History, error) {
+	return i.revert(ctx, sid, false, operator)
+}
+
+func (i *SceneHistory) revert(ctx context.Context, sid id.SceneID, undo bool, operator *usecase.Operator) (_ *history.History, err error) {
+	if err := i.CanWriteScene(sid, operator); err != nil {
+		return nil, err
+	}
+
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	if err := i.CheckSceneLock(ctx, sid); err != nil {
+		return nil, err
+	}
+
+	var h *history.History
+	var changes []history.Change
+	if undo {
+		h, err = i.historyRepo.FindLatestApplied(ctx, sid)
+		if errors.Is(err, rerror.ErrNotFound) {
+			return nil, interfaces.ErrNothingToUndo
+		}
+		if err != nil {
+			return nil, err
+		}
+		changes = h.Inverse()
+	} else {
+		h, err = i.historyRepo.FindEarliestUndone(ctx, sid)
+		if errors.Is(err, rerror.ErrNotFound) {
+			return nil, interfaces.ErrNothingToRedo
+		}
+		if err != nil {
+			return nil, err
+		}
+		changes = h.Changes()
+	}
+
+	for _, c := range changes {
+		current, _, err := i.store.load(ctx, c.Type, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		// refuse to overwrite changes made outside of the history
+		if !sameState(current, c.Before) {
+			return nil, interfaces.ErrHistoryConflict
+		}
+		if err := i.store.apply(ctx, c.Type, c.ID, c.After); err != nil {
+			return nil, err
+		}
+	}
+
+	h.SetUndone(undo)
+	if err := i.historyRepo.Save(ctx, h); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+
+	operation := "redo"
+	if undo {
+		operation = "undo"
+	}
+	publishSceneChanges(ctx, i.pubsub, newSceneChangeEvent(sid, h.ID().Ref(), operatorUser(operator), operation, changes))
+	return h, nil
+}
+
+func (i *SceneHistory) Begin(ctx context.Context, operation string, operator *usecase.Operator) context.Context {
+	return context.WithValue(ctx, historyRecorderKey{}, &historyRecorder{
+		name:  operation,
+		user:  operatorUser(operator),
+		index: map[string]*historyEntry{},
+	})
+}
+
+func (i *SceneHistory) Commit(ctx context.Context) error {
+	r := historyRecorderFrom(ctx)
+	if r == nil {
+		return nil
+	}
+	if err := i.save(ctx, r); err != nil {
+		return err
+	}
+
+	for _, e := range r.takeEvents() {
+		publishSceneChanges(ctx, i.pubsub, e)
+	}
+	return nil
+}
+
+// save saves the changes which were made outside of transactions.
+func (i *SceneHistory) save(ctx context.Context, r *historyRecorder) (err error) {
+	if r.isEmpty() {
+		return nil
+	}
+
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	events, err := r.save(ctx, i.store, i.historyRepo)
+	if err != nil {
+		return err
+	}
+
+	tx.Commit()
+	r.addEvents(events)
+	return nil
+}
+
+func (i *SceneHistory) Version(ctx context.Context, typ history.EntityType, eid string) (string, error) {
+	state, _, err := i.store.load(ctx, typ, eid)
+	if err != nil {
+		return "", err
+	}
+	return history.Version(state), nil
+}
+
+func (i *SceneHistory) ExpectVersion(ctx context.Context, typ history.EntityType, eid string, version string) {
+	historyRecorderFrom(ctx).expect(typ, eid, version)
+}
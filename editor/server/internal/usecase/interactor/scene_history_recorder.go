@@ -0,0 +1,621 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project/projectpack"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/scene/history"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
+)
+
+type historyRecorderKey struct{}
+
+// historyRecorder collects entities written during an operation with their states before the first write.
+type historyRecorder struct {
+	lock    sync.Mutex
+	name    string
+	user    *accountdomain.UserID
+	entries []*historyEntry
+	index   map[string]*historyEntry
+	// versions which entities must be at when they are written first
+	expected map[string]string
+	// changes of the histories already saved, which are published when the operation finishes
+	events []*interfaces.SceneChangeEvent
+}
+
+type historyEntry struct {
+	typ    history.EntityType
+	id     string
+	scene  id.SceneID
+	before *string
+}
+
+func historyRecorderFrom(ctx context.Context) *historyRecorder {
+	r, _ := ctx.Value(historyRecorderKey{}).(*historyRecorder)
+	return r
+}
+
+func (r *historyRecorder) touch(ctx context.Context, s *historyStore, typ history.EntityType, eid string) error {
+	if r == nil {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := historyEntryKey(typ, eid)
+	if _, ok := r.index[key]; ok {
+		return nil
+	}
+
+	state, sid, err := s.load(ctx, typ, eid)
+	if err != nil {
+		return err
+	}
+	if v, ok := r.expected[key]; ok && v != history.Version(state) {
+		return interfaces.ErrVersionConflict
+	}
+
+	e := &historyEntry{typ: typ, id: eid, scene: sid, before: state}
+	r.entries = append(r.entries, e)
+	r.index[key] = e
+	return nil
+}
+
+func (r *historyRecorder) expect(typ history.EntityType, eid string, version string) {
+	if r == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.expected == nil {
+		r.expected = map[string]string{}
+	}
+	r.expected[historyEntryKey(typ, eid)] = version
+}
+
+func historyEntryKey(typ history.EntityType, eid string) string {
+	return string(typ) + ":" + eid
+}
+
+func (r *historyRecorder) isEmpty() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.entries) == 0
+}
+
+// save saves the changes recorded so far as histories of each scene and starts recording again.
+// The events of the changes are returned to be published after the transaction is committed.
+func (r *historyRecorder) save(ctx context.Context, s *historyStore, historyRepo repo.SceneHistory) ([]*interfaces.SceneChangeEvent, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	scenes, changes, err := r.changes(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*interfaces.SceneChangeEvent, 0, len(scenes))
+	for _, sid := range scenes {
+		h, err := history.New().
+			NewID().
+			Scene(sid).
+			User(r.user).
+			Operation(r.name).
+			Changes(changes[sid]).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+
+		// a new operation discards the operations which can be redone
+		if err := historyRepo.RemoveUndone(ctx, sid); err != nil {
+			return nil, err
+		}
+		if err := historyRepo.Save(ctx, h); err != nil {
+			return nil, err
+		}
+		events = append(events, newSceneChangeEvent(sid, h.ID().Ref(), r.user, r.name, changes[sid]))
+	}
+
+	// the saved entities are now at the versions written by this operation
+	for _, e := range r.entries {
+		delete(r.expected, historyEntryKey(e.typ, e.id))
+	}
+	r.entries = nil
+	r.index = map[string]*historyEntry{}
+	return events, nil
+}
+
+func (r *historyRecorder) addEvents(events []*interfaces.SceneChangeEvent) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = append(r.events, events...)
+}
+
+func (r *historyRecorder) takeEvents() []*interfaces.SceneChangeEvent {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	events := r.events
+	r.events = nil
+	return events
+}
+
+// changes returns the recorded changes grouped by scene in the order the scenes were changed.
+// The lock must be held by the caller.
+func (r *historyRecorder) changes(ctx context.Context, s *historyStore) ([]id.SceneID, map[id.SceneID][]history.Change, error) {
+	var scenes []id.SceneID
+	res := map[id.SceneID][]history.Change{}
+	for _, e := range r.entries {
+		after, sid, err := s.load(ctx, e.typ, e.id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if sameState(e.before, after) {
+			continue
+		}
+		if sid.IsNil() {
+			sid = e.scene
+		}
+		if _, ok := res[sid]; !ok {
+			scenes = append(scenes, sid)
+		}
+		res[sid] = append(res[sid], history.Change{
+			Type:   e.typ,
+			ID:     e.id,
+			Before: e.before,
+			After:  after,
+		})
+	}
+	return scenes, res, nil
+}
+
+func sameState(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// historyStore reads and writes serialized states of entities through the repositories without recording.
+type historyStore struct {
+	nlsLayer repo.NLSLayer
+	style    repo.Style
+	story    repo.Storytelling
+	property repo.Property
+	scene    repo.Scene
+}
+
+func newHistoryStore(r *repo.Container) *historyStore {
+	return &historyStore{
+		nlsLayer: r.NLSLayer,
+		style:    r.Style,
+		story:    r.Storytelling,
+		property: r.Property,
+		scene:    r.Scene,
+	}
+}
+
+func (s *historyStore) load(ctx context.Context, typ history.EntityType, eid string) (*string, id.SceneID, error) {
+	var doc any
+	var sid id.SceneID
+	var err error
+
+	switch typ {
+	case history.EntityTypeNLSLayer:
+		var l nlslayer.NLSLayer
+		if l, err = s.nlsLayer.FindByID(ctx, id.MustNLSLayerID(eid)); err == nil && l != nil {
+			doc, sid = projectpack.NewNLSLayer(l), l.Scene()
+		}
+	case history.EntityTypeStyle:
+		var st *scene.Style
+		if st, err = s.style.FindByID(ctx, id.MustStyleID(eid)); err == nil && st != nil {
+			doc, sid = projectpack.NewStyle(st), st.Scene()
+		}
+	case history.EntityTypeStory:
+		var st *storytelling.Story
+		if st, err = s.story.FindByID(ctx, id.MustStoryID(eid)); err == nil && st != nil {
+			doc, sid = projectpack.NewStory(st), st.Scene()
+		}
+	case history.EntityTypeProperty:
+		var p *property.Property
+		if p, err = s.property.FindByID(ctx, id.MustPropertyID(eid)); err == nil && p != nil {
+			doc, sid = projectpack.NewProperty(p), p.Scene()
+		}
+	case history.EntityTypeScene:
+		var sc *scene.Scene
+		if sc, err = s.scene.FindByID(ctx, id.MustSceneID(eid)); err == nil && sc != nil {
+			doc, sid = projectpack.NewScene(sc), sc.ID()
+		}
+	default:
+		return nil, id.SceneID{}, fmt.Errorf("unknown history entity type: %s", typ)
+	}
+
+	if errors.Is(err, rerror.ErrNotFound) || (err == nil && doc == nil) {
+		return nil, id.SceneID{}, nil
+	}
+	if err != nil {
+		return nil, id.SceneID{}, err
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, id.SceneID{}, err
+	}
+	state := string(b)
+	return &state, sid, nil
+}
+
+// apply restores the entity to the state. A nil state removes the entity.
+func (s *historyStore) apply(ctx context.Context, typ history.EntityType, eid string, state *string) error {
+	switch typ {
+	case history.EntityTypeNLSLayer:
+		if state == nil {
+			return s.nlsLayer.Remove(ctx, id.MustNLSLayerID(eid))
+		}
+		var d projectpack.NLSLayerDocument
+		if err := json.Unmarshal([]byte(*state), &d); err != nil {
+			return err
+		}
+		l, err := d.Model()
+		if err != nil {
+			return err
+		}
+		return s.nlsLayer.Save(ctx, l)
+	case history.EntityTypeStyle:
+		if state == nil {
+			return s.style.Remove(ctx, id.MustStyleID(eid))
+		}
+		var d projectpack.StyleDocument
+		if err := json.Unmarshal([]byte(*state), &d); err != nil {
+			return err
+		}
+		st, err := d.Model()
+		if err != nil {
+			return err
+		}
+		return s.style.Save(ctx, *st)
+	case history.EntityTypeStory:
+		if state == nil {
+			return s.story.Remove(ctx, id.MustStoryID(eid))
+		}
+		var d projectpack.StoryDocument
+		if err := json.Unmarshal([]byte(*state), &d); err != nil {
+			return err
+		}
+		st, err := d.Model()
+		if err != nil {
+			return err
+		}
+		// the basic auth password is not in the state, so the current basic auth is kept
+		current, err := s.story.FindByID(ctx, st.Id())
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return err
+		}
+		if current != nil {
+			if err := st.SetBasicAuth(current.IsBasicAuthActive(), lo.ToPtr(current.BasicAuthUsername()), lo.ToPtr(current.BasicAuthPassword())); err != nil {
+				return err
+			}
+		}
+		return s.story.Save(ctx, *st)
+	case history.EntityTypeProperty:
+		if state == nil {
+			return s.property.Remove(ctx, id.MustPropertyID(eid))
+		}
+		var d projectpack.PropertyDocument
+		if err := json.Unmarshal([]byte(*state), &d); err != nil {
+			return err
+		}
+		p, err := d.Model()
+		if err != nil {
+			return err
+		}
+		return s.property.Save(ctx, p)
+	case history.EntityTypeScene:
+		if state == nil {
+			return s.scene.Remove(ctx, id.MustSceneID(eid))
+		}
+		var d projectpack.SceneDocument
+		if err := json.Unmarshal([]byte(*state), &d); err != nil {
+			return err
+		}
+		sc, err := d.Model()
+		if err != nil {
+			return err
+		}
+		return s.scene.Save(ctx, sc)
+	}
+	return fmt.Errorf("unknown history entity type: %s", typ)
+}
+
+// recordSceneHistory returns a container whose repositories record writes to the history recorder in the context.
+// The recorded changes are saved as histories in the transaction in which they are made.
+func recordSceneHistory(r *repo.Container) *repo.Container {
+	if r.SceneHistory == nil || r.NLSLayer == nil || r.Style == nil || r.Storytelling == nil || r.Property == nil || r.Scene == nil {
+		return r
+	}
+
+	s := newHistoryStore(r)
+
+	r2 := *r
+	if r.Transaction != nil {
+		r2.Transaction = &historyTransaction{Transaction: r.Transaction, store: s, historyRepo: r.SceneHistory}
+	}
+	r2.NLSLayer = &historyNLSLayer{NLSLayer: r.NLSLayer, store: s}
+	r2.Style = &historyStyle{Style: r.Style, store: s}
+	r2.Storytelling = &historyStorytelling{Storytelling: r.Storytelling, store: s}
+	r2.Property = &historyProperty{Property: r.Property, store: s}
+	r2.Scene = &historyScene{Scene: r.Scene, store: s}
+	return &r2
+}
+
+// historyTransaction saves the recorded changes before committing a transaction
+// so that an operation is never committed without its history.
+type historyTransaction struct {
+	usecasex.Transaction
+	store       *historyStore
+	historyRepo repo.SceneHistory
+}
+
+func (t *historyTransaction) Begin(ctx context.Context) (usecasex.Tx, error) {
+	tx, err := t.Transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r := historyRecorderFrom(ctx)
+	if r == nil {
+		return tx, nil
+	}
+	return &historyTx{Tx: tx, transaction: t, recorder: r}, nil
+}
+
+type historyTx struct {
+	usecasex.Tx
+	transaction *historyTransaction
+	recorder    *historyRecorder
+	committed   bool
+}
+
+func (tx *historyTx) Commit() {
+	tx.committed = true
+}
+
+func (tx *historyTx) IsCommitted() bool {
+	return tx.committed
+}
+
+func (tx *historyTx) End(ctx context.Context) error {
+	if !tx.committed {
+		return tx.Tx.End(ctx)
+	}
+
+	events, err := tx.recorder.save(ctx, tx.transaction.store, tx.transaction.historyRepo)
+	if err != nil {
+		// roll back the changes which cannot be recorded
+		_ = tx.Tx.End(ctx)
+		return err
+	}
+
+	tx.Tx.Commit()
+	if err := tx.Tx.End(ctx); err != nil {
+		return err
+	}
+	tx.recorder.addEvents(events)
+	return nil
+}
+
+type historyNLSLayer struct {
+	repo.NLSLayer
+	store *historyStore
+}
+
+func (r *historyNLSLayer) Filtered(f repo.SceneFilter) repo.NLSLayer {
+	return &historyNLSLayer{NLSLayer: r.NLSLayer.Filtered(f), store: r.store}
+}
+
+func (r *historyNLSLayer) Save(ctx context.Context, l nlslayer.NLSLayer) error {
+	if err := r.touch(ctx, l.ID()); err != nil {
+		return err
+	}
+	return r.NLSLayer.Save(ctx, l)
+}
+
+func (r *historyNLSLayer) SaveAll(ctx context.Context, ls nlslayer.NLSLayerList) error {
+	for _, l := range ls {
+		if l == nil {
+			continue
+		}
+		if err := r.touch(ctx, (*l).ID()); err != nil {
+			return err
+		}
+	}
+	return r.NLSLayer.SaveAll(ctx, ls)
+}
+
+func (r *historyNLSLayer) Remove(ctx context.Context, lid id.NLSLayerID) error {
+	if err := r.touch(ctx, lid); err != nil {
+		return err
+	}
+	return r.NLSLayer.Remove(ctx, lid)
+}
+
+func (r *historyNLSLayer) RemoveAll(ctx context.Context, ids id.NLSLayerIDList) error {
+	for _, lid := range ids {
+		if err := r.touch(ctx, lid); err != nil {
+			return err
+		}
+	}
+	return r.NLSLayer.RemoveAll(ctx, ids)
+}
+
+func (r *historyNLSLayer) touch(ctx context.Context, lid id.NLSLayerID) error {
+	return historyRecorderFrom(ctx).touch(ctx, r.store, history.EntityTypeNLSLayer, lid.String())
+}
+
+type historyStyle struct {
+	repo.Style
+	store *historyStore
+}
+
+func (r *historyStyle) Filtered(f repo.SceneFilter) repo.Style {
+	return &historyStyle{Style: r.Style.Filtered(f), store: r.store}
+}
+
+func (r *historyStyle) Save(ctx context.Context, s scene.Style) error {
+	if err := r.touch(ctx, s.ID()); err != nil {
+		return err
+	}
+	return r.Style.Save(ctx, s)
+}
+
+func (r *historyStyle) SaveAll(ctx context.Context, ss scene.StyleList) error {
+	for _, s := range ss {
+		if err := r.touch(ctx, s.ID()); err != nil {
+			return err
+		}
+	}
+	return r.Style.SaveAll(ctx, ss)
+}
+
+func (r *historyStyle) Remove(ctx context.Context, sid id.StyleID) error {
+	if err := r.touch(ctx, sid); err != nil {
+		return err
+	}
+	return r.Style.Remove(ctx, sid)
+}
+
+func (r *historyStyle) RemoveAll(ctx context.Context, ids id.StyleIDList) error {
+	for _, sid := range ids {
+		if err := r.touch(ctx, sid); err != nil {
+			return err
+		}
+	}
+	return r.Style.RemoveAll(ctx, ids)
+}
+
+func (r *historyStyle) touch(ctx context.Context, sid id.StyleID) error {
+	return historyRecorderFrom(ctx).touch(ctx, r.store, history.EntityTypeStyle, sid.String())
+}
+
+type historyStorytelling struct {
+	repo.Storytelling
+	store *historyStore
+}
+
+func (r *historyStorytelling) Filtered(f repo.SceneFilter) repo.Storytelling {
+	return &historyStorytelling{Storytelling: r.Storytelling.Filtered(f), store: r.store}
+}
+
+func (r *historyStorytelling) Save(ctx context.Context, s storytelling.Story) error {
+	if err := r.touch(ctx, s.Id()); err != nil {
+		return err
+	}
+	return r.Storytelling.Save(ctx, s)
+}
+
+func (r *historyStorytelling) SaveAll(ctx context.Context, ss storytelling.StoryList) error {
+	for _, s := range ss {
+		if err := r.touch(ctx, s.Id()); err != nil {
+			return err
+		}
+	}
+	return r.Storytelling.SaveAll(ctx, ss)
+}
+
+func (r *historyStorytelling) Remove(ctx context.Context, sid id.StoryID) error {
+	if err := r.touch(ctx, sid); err != nil {
+		return err
+	}
+	return r.Storytelling.Remove(ctx, sid)
+}
+
+func (r *historyStorytelling) RemoveAll(ctx context.Context, ids id.StoryIDList) error {
+	for _, sid := range ids {
+		if err := r.touch(ctx, sid); err != nil {
+			return err
+		}
+	}
+	return r.Storytelling.RemoveAll(ctx, ids)
+}
+
+func (r *historyStorytelling) touch(ctx context.Context, sid id.StoryID) error {
+	return historyRecorderFrom(ctx).touch(ctx, r.store, history.EntityTypeStory, sid.String())
+}
+
+type historyProperty struct {
+	repo.Property
+	store *historyStore
+}
+
+func (r *historyProperty) Filtered(f repo.SceneFilter) repo.Property {
+	return &historyProperty{Property: r.Property.Filtered(f), store: r.store}
+}
+
+func (r *historyProperty) Save(ctx context.Context, p *property.Property) error {
+	if err := r.touch(ctx, p.ID()); err != nil {
+		return err
+	}
+	return r.Property.Save(ctx, p)
+}
+
+func (r *historyProperty) SaveAll(ctx context.Context, ps property.List) error {
+	for _, p := range ps {
+		if err := r.touch(ctx, p.ID()); err != nil {
+			return err
+		}
+	}
+	return r.Property.SaveAll(ctx, ps)
+}
+
+func (r *historyProperty) Remove(ctx context.Context, pid id.PropertyID) error {
+	if err := r.touch(ctx, pid); err != nil {
+		return err
+	}
+	return r.Property.Remove(ctx, pid)
+}
+
+func (r *historyProperty) RemoveAll(ctx context.Context, ids id.PropertyIDList) error {
+	for _, pid := range ids {
+		if err := r.touch(ctx, pid); err != nil {
+			return err
+		}
+	}
+	return r.Property.RemoveAll(ctx, ids)
+}
+
+func (r *historyProperty) touch(ctx context.Context, pid id.PropertyID) error {
+	return historyRecorderFrom(ctx).touch(ctx, r.store, history.EntityTypeProperty, pid.String())
+}
+
+// historyScene records only saves of scenes because removal of a scene removes its history too.
+type historyScene struct {
+	repo.Scene
+	store *historyStore
+}
+
+func (r *historyScene) Filtered(f repo.WorkspaceFilter) repo.Scene {
+	return &historyScene{Scene: r.Scene.Filtered(f), store: r.store}
+}
+
+func (r *historyScene) Save(ctx context.Context, s *scene.Scene) error {
+	if err := historyRecorderFrom(ctx).touch(ctx, r.store, history.EntityTypeScene, s.ID().String()); err != nil {
+		return err
+	}
+	return r.Scene.Save(ctx, s)
+}
@@ -0,0 +1,196 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/scene/history"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneHistory(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	sid := id.NewSceneID()
+	uid := accountdomain.NewUserID()
+	op := &usecase.Operator{
+		AcOperator:     &accountusecase.Operator{User: &uid},
+		ReadableScenes: []id.SceneID{sid},
+		WritableScenes: []id.SceneID{sid},
+	}
+
+	h := NewSceneHistory(db, &gateway.Container{})
+	style := NewStyle(recordSceneHistory(db))
+
+	// add a style
+	ctx1 := h.Begin(ctx, "addStyle", op)
+	s, err := style.AddStyle(ctx1, interfaces.AddStyleInput{
+		SceneID: sid,
+		Name:    "style",
+		Value:   &scene.StyleValue{"marker": map[string]any{"pointColor": "red"}},
+	}, op)
+	assert.NoError(t, err)
+	// the history is saved in the transaction of the operation
+	hs, _, err := h.Fetch(ctx, sid, nil, op)
+	assert.NoError(t, err)
+	assert.Len(t, hs, 1)
+	assert.NoError(t, h.Commit(ctx1))
+
+	// rename the style
+	ctx2 := h.Begin(ctx, "updateStyle", op)
+	_, err = style.UpdateStyle(ctx2, interfaces.UpdateStyleInput{
+		StyleID: s.ID(),
+		Name:    lo.ToPtr("renamed"),
+	}, op)
+	assert.NoError(t, err)
+	assert.NoError(t, h.Commit(ctx2))
+
+	// an operation without changes is not recorded
+	ctx3 := h.Begin(ctx, "duplicateStyle", op)
+	assert.NoError(t, h.Commit(ctx3))
+
+	hs, pi, err := h.Fetch(ctx, sid, usecasex.CursorPagination{First: lo.ToPtr(int64(10))}.Wrap(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), pi.TotalCount)
+	assert.Equal(t, []string{"addStyle", "updateStyle"}, lo.Map(hs, func(h *history.History, _ int) string { return h.Operation() }))
+	assert.Equal(t, &uid, hs[1].User())
+	assert.Equal(t, history.EntityTypeStyle, hs[1].Changes()[0].Type)
+
+	// undo the rename
+	undone, err := h.Undo(ctx, sid, op)
+	assert.NoError(t, err)
+	assert.Equal(t, "updateStyle", undone.Operation())
+	got, err := db.Style.FindByID(ctx, s.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, "style", got.Name())
+
+	// undo the addition
+	_, err = h.Undo(ctx, sid, op)
+	assert.NoError(t, err)
+	_, err = db.Style.FindByID(ctx, s.ID())
+	assert.Equal(t, rerror.ErrNotFound, err)
+
+	_, err = h.Undo(ctx, sid, op)
+	assert.Equal(t, interfaces.ErrNothingToUndo, err)
+
+	// redo the addition
+	redone, err := h.Redo(ctx, sid, op)
+	assert.NoError(t, err)
+	assert.Equal(t, "addStyle", redone.Operation())
+	got, err = db.Style.FindByID(ctx, s.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, "style", got.Name())
+
+	// a new operation discards the rename which can be redone
+	ctx4 := h.Begin(ctx, "removeStyle", op)
+	_, err = style.RemoveStyle(ctx4, s.ID(), op)
+	assert.NoError(t, err)
+	assert.NoError(t, h.Commit(ctx4))
+
+	_, err = h.Redo(ctx, sid, op)
+	assert.Equal(t, interfaces.ErrNothingToRedo, err)
+
+	// changes made outside of the history are not overwritten
+	_ = db.Style.Save(ctx, *scene.NewStyle().ID(s.ID()).Scene(sid).Name("other").Value(&scene.StyleValue{}).MustBuild())
+	_, err = h.Undo(ctx, sid, op)
+	assert.Equal(t, interfaces.ErrHistoryConflict, err)
+
+	// other users cannot undo
+	_, err = h.Undo(ctx, sid, &usecase.Operator{})
+	assert.Equal(t, interfaces.ErrOperationDenied, err)
+}
+
+type failingSceneHistory struct {
+	repo.SceneHistory
+	err error
+}
+
+func (r *failingSceneHistory) FindLatestApplied(context.Context, id.SceneID) (*history.History, error) {
+	return nil, r.err
+}
+
+func (r *failingSceneHistory) FindEarliestUndone(context.Context, id.SceneID) (*history.History, error) {
+	return nil, r.err
+}
+
+func (r *failingSceneHistory) Save(context.Context, *history.History) error {
+	return r.err
+}
+
+func TestSceneHistory_RepoError(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	sid := id.NewSceneID()
+	op := &usecase.Operator{
+		WritableScenes: []id.SceneID{sid},
+	}
+	errTimeout := errors.New("timeout")
+	db.SceneHistory = &failingSceneHistory{SceneHistory: db.SceneHistory, err: errTimeout}
+	h := NewSceneHistory(db, &gateway.Container{})
+
+	_, err := h.Undo(ctx, sid, op)
+	assert.Same(t, errTimeout, err)
+	_, err = h.Redo(ctx, sid, op)
+	assert.Same(t, errTimeout, err)
+}
+
+func TestSceneHistory_SaveError(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	sid := id.NewSceneID()
+	op := &usecase.Operator{
+		ReadableScenes: []id.SceneID{sid},
+		WritableScenes: []id.SceneID{sid},
+	}
+	errTimeout := errors.New("timeout")
+	db.SceneHistory = &failingSceneHistory{SceneHistory: db.SceneHistory, err: errTimeout}
+	h := NewSceneHistory(db, &gateway.Container{})
+	style := NewStyle(recordSceneHistory(db))
+
+	// the operation fails when its history cannot be saved
+	ctx1 := h.Begin(ctx, "addStyle", op)
+	_, err := style.AddStyle(ctx1, interfaces.AddStyleInput{
+		SceneID: sid,
+		Name:    "style",
+		Value:   &scene.StyleValue{},
+	}, op)
+	assert.Same(t, errTimeout, err)
+}
+
+func TestHistoryStore_Story(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	s := newHistoryStore(db)
+
+	st := storytelling.NewStory().NewID().Scene(id.NewSceneID()).Property(id.NewPropertyID()).Title("story").
+		PublicBasicAuth(true, "user", "password").Pages(storytelling.NewPageList(nil)).MustBuild()
+	_ = db.Storytelling.Save(ctx, *st)
+
+	state, _, err := s.load(ctx, history.EntityTypeStory, st.Id().String())
+	assert.NoError(t, err)
+	st.Rename("renamed")
+	_ = db.Storytelling.Save(ctx, *st)
+
+	// restoring a state keeps the basic auth which is not in the state
+	assert.NoError(t, s.apply(ctx, history.EntityTypeStory, st.Id().String(), state))
+	got, err := db.Storytelling.FindByID(ctx, st.Id())
+	assert.NoError(t, err)
+	assert.Equal(t, "story", got.Title())
+	assert.True(t, got.IsBasicAuthActive())
+	assert.Equal(t, "user", got.BasicAuthUsername())
+	assert.Equal(t, "password", got.BasicAuthPassword())
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/builtin"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/project"
 	"github.com/reearth/reearth/server/pkg/property"
 	scene2 "github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearth/server/pkg/scene/builder"
@@ -26,11 +27,12 @@ import (
 type Storytelling struct {
 	common
 	commonSceneLock
+	commonPolicy
+	pluginApproval
 	storytellingRepo repo.Storytelling
 	pluginRepo       repo.Plugin
 	propertyRepo     repo.Property
 	workspaceRepo    accountrepo.Workspace
-	policyRepo       repo.Policy
 	projectRepo      repo.Project
 	sceneRepo        repo.Scene
 	layerRepo        repo.Layer
@@ -40,16 +42,20 @@ type Storytelling struct {
 	transaction      usecasex.Transaction
 	nlsLayerRepo     repo.NLSLayer
 	layerStyles      repo.Style
+	viewTokenSecret  string
 }
 
-func NewStorytelling(r *repo.Container, gr *gateway.Container) interfaces.Storytelling {
+// NewStorytelling creates the interactor. View tokens of published stories are signed with viewTokenSecret,
+// and they are disabled when it is empty.
+func NewStorytelling(r *repo.Container, gr *gateway.Container, viewTokenSecret string) interfaces.Storytelling {
 	return &Storytelling{
 		commonSceneLock:  commonSceneLock{sceneLockRepo: r.SceneLock},
+		commonPolicy:     newCommonPolicy(r),
+		pluginApproval:   newPluginApproval(r),
 		storytellingRepo: r.Storytelling,
 		pluginRepo:       r.Plugin,
 		propertyRepo:     r.Property,
 		workspaceRepo:    r.Workspace,
-		policyRepo:       r.Policy,
 		projectRepo:      r.Project,
 		sceneRepo:        r.Scene,
 		layerRepo:        r.Layer,
@@ -59,6 +65,7 @@ func NewStorytelling(r *repo.Container, gr *gateway.Container) interfaces.Storyt
 		transaction:      r.Transaction,
 		nlsLayerRepo:     r.NLSLayer,
 		layerStyles:      r.Style,
+		viewTokenSecret:  viewTokenSecret,
 	}
 }
 
@@ -70,7 +77,7 @@ func (i *Storytelling) FetchByScene(ctx context.Context, sid id.SceneID, _ *usec
 	return i.storytellingRepo.FindByScene(ctx, sid)
 }
 
-func (i *Storytelling) Create(ctx context.Context, inp interfaces.CreateStoryInput, op *usecase.Operator) (*storytelling.Story, error) {
+func (i *Storytelling) Create(ctx context.Context, inp interfaces.CreateStoryInput, op *usecase.Operator) (_ *storytelling.Story, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -87,6 +94,10 @@ func (i *Storytelling) Create(ctx context.Context, inp interfaces.CreateStoryInp
 		return nil, interfaces.ErrOperationDenied
 	}
 
+	if err := i.enforceStoryCount(ctx, inp.SceneID, op); err != nil {
+		return nil, err
+	}
+
 	schema := builtin.GetPropertySchema(builtin.PropertySchemaIDStory)
 	prop, err := property.New().NewID().Schema(schema.ID()).Scene(inp.SceneID).Build()
 	if err != nil {
@@ -105,13 +116,16 @@ func (i *Storytelling) Create(ctx context.Context, inp interfaces.CreateStoryInp
 		return nil, err
 	}
 
-	// TODO: Handel ordering
+	stories, err := i.placeStory(ctx, story, inp.Index)
+	if err != nil {
+		return nil, err
+	}
 
 	if err = i.propertyRepo.Save(ctx, prop); err != nil {
 		return nil, err
 	}
 
-	if err := i.storytellingRepo.Save(ctx, *story); err != nil {
+	if err := i.storytellingRepo.SaveAll(ctx, stories); err != nil {
 		return nil, err
 	}
 
@@ -119,7 +133,7 @@ func (i *Storytelling) Create(ctx context.Context, inp interfaces.CreateStoryInp
 	return story, nil
 }
 
-func (i *Storytelling) Update(ctx context.Context, inp interfaces.UpdateStoryInput, op *usecase.Operator) (*storytelling.Story, error) {
+func (i *Storytelling) Update(ctx context.Context, inp interfaces.UpdateStoryInput, op *usecase.Operator) (_ *storytelling.Story, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -181,9 +195,14 @@ func (i *Storytelling) Update(ctx context.Context, inp interfaces.UpdateStoryInp
 		}
 	}
 
-	// TODO: Handel ordering
+	stories := storytelling.StoryList{story}
+	if inp.Index != nil {
+		if stories, err = i.placeStory(ctx, story, inp.Index); err != nil {
+			return nil, err
+		}
+	}
 
-	err = i.storytellingRepo.Save(ctx, *story)
+	err = i.storytellingRepo.SaveAll(ctx, stories)
 	if err != nil {
 		return nil, err
 	}
@@ -201,7 +220,7 @@ func (i *Storytelling) Update(ctx context.Context, inp interfaces.UpdateStoryInp
 	return story, nil
 }
 
-func (i *Storytelling) Remove(ctx context.Context, inp interfaces.RemoveStoryInput, op *usecase.Operator) (*id.StoryID, error) {
+func (i *Storytelling) Remove(ctx context.Context, inp interfaces.RemoveStoryInput, op *usecase.Operator) (_ *id.StoryID, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -222,16 +241,29 @@ func (i *Storytelling) Remove(ctx context.Context, inp interfaces.RemoveStoryInp
 		return nil, err
 	}
 
-	// TODO: Handel ordering
+	stories, err := i.storytellingRepo.FindByScene(ctx, story.Scene())
+	if err != nil {
+		return nil, err
+	}
+	stories.Remove(story.Id())
 
 	if err := i.storytellingRepo.Remove(ctx, inp.StoryID); err != nil {
 		return nil, err
 	}
 
+	if err := i.storytellingRepo.SaveAll(ctx, *stories); err != nil {
+		return nil, err
+	}
+
+	if err := removePublishedStory(ctx, i.file, story); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
 	return &inp.StoryID, nil
 }
 
-func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryInput, op *usecase.Operator) (*storytelling.Story, error) {
+func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryInput, op *usecase.Operator) (_ *storytelling.Story, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -255,11 +287,58 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 		return nil, err
 	}
 
+	if inp.UnpublishAt != nil && !inp.UnpublishAt.After(time.Now()) {
+		return nil, interfaces.ErrPublishScheduleInPast
+	}
+
+	// enforce policy also when the story is published later
+	if inp.Status != storytelling.PublishmentStatusPrivate && !isPublicStory(story) {
+		if err := i.enforcePublishedStoryCount(ctx, story.Scene(), op); err != nil {
+			return nil, err
+		}
+	}
+
+	// publish later by the publish scheduler
+	if inp.PublishAt != nil && inp.PublishAt.After(time.Now()) {
+		if inp.Alias == nil && story.Alias() == "" {
+			return nil, interfaces.ErrStoryAliasIsNotSet
+		}
+
+		schedule, err := project.NewPublishSchedule(lo.FromPtr(inp.Alias), inp.Status, inp.PublishAt, inp.UnpublishAt)
+		if err != nil {
+			return nil, err
+		}
+
+		story.SetPublishSchedule(schedule)
+		if err := i.storytellingRepo.Save(ctx, *story); err != nil {
+			return nil, err
+		}
+
+		tx.Commit()
+		return story, nil
+	}
+
+	// a pending publication is replaced but a scheduled unpublication is kept
+	schedule := story.PublishSchedule().Published()
+	if inp.Status == storytelling.PublishmentStatusPrivate {
+		schedule = nil
+	} else if inp.UnpublishAt != nil {
+		if schedule, err = project.NewPublishSchedule("", "", nil, inp.UnpublishAt); err != nil {
+			return nil, err
+		}
+	}
+
 	scene, err := i.sceneRepo.FindByID(ctx, story.Scene())
 	if err != nil {
 		return nil, err
 	}
 
+	if inp.Status != storytelling.PublishmentStatusPrivate {
+		if err := i.checkPlugins(ctx, scene); err != nil {
+			return nil, err
+		}
+	}
+
 	// prj, err := i.projectRepo.FindByScene(ctx, story.Scene())
 	// if err != nil {
 	// 	return nil, err
@@ -268,31 +347,6 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 	// enableGa := prj.EnableGA()
 	// trackingId := prj.TrackingID()
 
-	//
-	// Commenting this out till the point we make a decision on this: @pyshx
-	//
-	// ws, err := i.workspaceRepo.FindByID(ctx, scene.Workspace())
-	// if err != nil {
-	// 	return nil, err
-	// }
-
-	// if story.PublishmentStatus() == storytelling.PublishmentStatusPrivate {
-	// 	// enforce policy
-	// 	if policyID := op.Policy(ws.Policy()); policyID != nil {
-	// 		p, err := i.policyRepo.FindByID(ctx, *policyID)
-	// 		if err != nil {
-	// 			return nil, err
-	// 		}
-	// 		s, err := i.projectRepo.CountPublicByWorkspace(ctx, ws.ID())
-	// 		if err != nil {
-	// 			return nil, err
-	// 		}
-	// 		if err := p.EnforcePublishedProjectCount(s + 1); err != nil {
-	// 			return nil, err
-	// 		}
-	// 	}
-	// }
-
 	nlsLayers, err := i.nlsLayerRepo.FindByScene(ctx, story.Scene())
 	if err != nil {
 		return nil, err
@@ -305,7 +359,7 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 
 	prevAlias := story.Alias()
 	if inp.Alias == nil && prevAlias == "" && inp.Status != storytelling.PublishmentStatusPrivate {
-		return nil, interfaces.ErrProjectAliasIsNotSet
+		return nil, interfaces.ErrStoryAliasIsNotSet
 	}
 
 	var prevPublishedAlias string
@@ -362,10 +416,15 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 			).ForScene(scene).WithNLSLayers(&nlsLayers).WithLayerStyle(layerStyles).WithStory(story).Build(ctx, w, time.Now(), true, false, "")
 		}()
 
-		// Save
-		if err := i.file.UploadStory(ctx, r, newAlias); err != nil {
+		// Save a snapshot and publish it
+		version := newPublishedVersion(time.Now())
+		if err := i.file.UploadStorySnapshot(ctx, r, story.Id().String(), version); err != nil {
+			return nil, err
+		}
+		if err := i.publishStorySnapshot(ctx, story.Id(), version, newAlias); err != nil {
 			return nil, err
 		}
+		prunePublishedSnapshots(ctx, story.Id().String(), i.file.ListStorySnapshots, i.file.RemoveStorySnapshot)
 
 		// If project has been published before and alias is changed,
 		// remove old published data.
@@ -378,6 +437,7 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 
 	story.UpdatePublishmentStatus(inp.Status)
 	story.SetPublishedAt(time.Now())
+	story.SetPublishSchedule(schedule)
 
 	if err := i.storytellingRepo.Save(ctx, *story); err != nil {
 		return nil, err
@@ -387,11 +447,244 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 	return story, nil
 }
 
-func (i *Storytelling) Move(_ context.Context, _ interfaces.MoveStoryInput, _ *usecase.Operator) (*id.StoryID, int, error) {
-	return nil, 0, rerror.ErrNotImplemented
+func (i *Storytelling) PublishedVersions(ctx context.Context, sid id.StoryID, op *usecase.Operator) ([]interfaces.PublishedVersion, error) {
+	story, err := i.storytellingRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanReadScene(story.Scene(), op); err != nil {
+		return nil, err
+	}
+
+	versions, err := i.file.ListStorySnapshots(ctx, sid.String())
+	if err != nil {
+		return nil, err
+	}
+	return publishedVersionsFrom(versions), nil
+}
+
+func (i *Storytelling) CreateViewToken(ctx context.Context, inp interfaces.CreateStoryViewTokenInput, op *usecase.Operator) (string, error) {
+	if i.viewTokenSecret == "" {
+		return "", interfaces.ErrViewTokenDisabled
+	}
+	if !inp.ExpiresAt.After(time.Now()) {
+		return "", interfaces.ErrInvalidViewToken
+	}
+
+	story, err := i.storytellingRepo.FindByID(ctx, inp.StoryID)
+	if err != nil {
+		return "", err
+	}
+	if err := i.CanWriteScene(story.Scene(), op); err != nil {
+		return "", err
+	}
+
+	return signViewToken(i.viewTokenSecret, story.Id(), inp.ExpiresAt), nil
 }
 
-func (i *Storytelling) CreatePage(ctx context.Context, inp interfaces.CreatePageParam, op *usecase.Operator) (*storytelling.Story, *storytelling.Page, error) {
+func (i *Storytelling) RollbackPublish(ctx context.Context, sid id.StoryID, version string, op *usecase.Operator) (*storytelling.Story, error) {
+	story, err := i.storytellingRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteScene(story.Scene(), op); err != nil {
+		return nil, err
+	}
+	if story.PublishmentStatus() == storytelling.PublishmentStatusPrivate || story.Alias() == "" {
+		return nil, interfaces.ErrNotPublished
+	}
+
+	if err := i.UpdateSceneLock(ctx, story.Scene(), scene2.LockModeFree, scene2.LockModePublishing); err != nil {
+		return nil, err
+	}
+
+	defer i.ReleaseSceneLock(ctx, story.Scene())
+
+	if err := i.publishStorySnapshot(ctx, sid, version, story.Alias()); err != nil {
+		return nil, err
+	}
+	return story, nil
+}
+
+// publishStorySnapshot makes the snapshot public under the alias.
+func (i *Storytelling) publishStorySnapshot(ctx context.Context, sid id.StoryID, version, alias string) error {
+	r, err := i.file.ReadStorySnapshot(ctx, sid.String(), version)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	return i.file.UploadStory(ctx, r, alias)
+}
+
+func (i *Storytelling) Move(ctx context.Context, inp interfaces.MoveStoryInput, op *usecase.Operator) (_ *id.StoryID, _ int, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	story, err := i.storytellingRepo.FindByID(ctx, inp.StoryID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := i.CanWriteScene(story.Scene(), op); err != nil {
+		return nil, 0, interfaces.ErrOperationDenied
+	}
+
+	stories, err := i.placeStory(ctx, story, &inp.Index)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := i.storytellingRepo.SaveAll(ctx, stories); err != nil {
+		return nil, 0, err
+	}
+
+	tx.Commit()
+	return story.Id().Ref(), story.Index(), nil
+}
+
+func (i *Storytelling) Duplicate(ctx context.Context, inp interfaces.DuplicateStoryInput, op *usecase.Operator) (_ *storytelling.Story, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	story, err := i.storytellingRepo.FindByID(ctx, inp.StoryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteScene(story.Scene(), op); err != nil {
+		return nil, interfaces.ErrOperationDenied
+	}
+
+	if err := i.enforceStoryCount(ctx, story.Scene(), op); err != nil {
+		return nil, err
+	}
+
+	dup, propertyIDs := story.Duplicate()
+
+	properties, err := i.propertyRepo.FindByIDs(ctx, lo.Keys(propertyIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	dupProperties := make(property.List, 0, len(properties))
+	for _, p := range properties {
+		if p == nil {
+			return nil, rerror.ErrNotFound
+		}
+		dupProperties = append(dupProperties, p.Duplicate(propertyIDs[p.ID()]))
+	}
+
+	// the copy is placed next to the original
+	stories, err := i.storytellingRepo.FindByScene(ctx, story.Scene())
+	if err != nil {
+		return nil, err
+	}
+	stories.AddAt(dup, lo.ToPtr(stories.IndexOf(story.Id())+1))
+
+	if err := i.propertyRepo.SaveAll(ctx, dupProperties); err != nil {
+		return nil, err
+	}
+
+	if err := i.storytellingRepo.SaveAll(ctx, *stories); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return dup, nil
+}
+
+// enforceStoryCount checks the policy before a story is added to the scene.
+func (i *Storytelling) enforceStoryCount(ctx context.Context, sid id.SceneID, op *usecase.Operator) error {
+	p, _, err := i.scenePolicy(ctx, sid, op)
+	if err != nil || p == nil {
+		return err
+	}
+
+	stories, err := i.storytellingRepo.FindByScene(ctx, sid)
+	if err != nil {
+		return err
+	}
+	return p.EnforceStoryCount(len(*stories) + 1)
+}
+
+// enforceStoryPageCount checks the policy before a page is added to the story.
+func (i *Storytelling) enforceStoryPageCount(ctx context.Context, story *storytelling.Story, op *usecase.Operator) error {
+	p, _, err := i.scenePolicy(ctx, story.Scene(), op)
+	if err != nil || p == nil {
+		return err
+	}
+	return p.EnforceStoryPageCount(len(story.Pages().Pages()) + 1)
+}
+
+// enforcePublishedStoryCount checks the policy before a story of the scene is newly published.
+// Published stories are counted among all scenes of the workspace.
+func (i *Storytelling) enforcePublishedStoryCount(ctx context.Context, sid id.SceneID, op *usecase.Operator) error {
+	p, s, err := i.scenePolicy(ctx, sid, op)
+	if err != nil || p == nil {
+		return err
+	}
+
+	scenes, err := i.sceneRepo.FindByWorkspace(ctx, s.Workspace())
+	if err != nil {
+		return err
+	}
+
+	count, err := i.storytellingRepo.CountPublicByScenes(ctx, scenes.IDs())
+	if err != nil {
+		return err
+	}
+	return p.EnforcePublishedStoryCount(count + 1)
+}
+
+// removePublishedStory removes the published data and the snapshots of the story.
+func removePublishedStory(ctx context.Context, f gateway.File, s *storytelling.Story) error {
+	if s == nil {
+		return nil
+	}
+	if s.PublishmentStatus() != storytelling.PublishmentStatusPrivate && s.Alias() != "" {
+		if err := f.RemoveStory(ctx, s.Alias()); err != nil {
+			return err
+		}
+	}
+	return f.RemoveStorySnapshots(ctx, s.Id().String())
+}
+
+func isPublicStory(s *storytelling.Story) bool {
+	return s.PublishmentStatus() == storytelling.PublishmentStatusPublic || s.PublishmentStatus() == storytelling.PublishmentStatusLimited
+}
+
+// placeStory puts the story at the index among the stories of its scene, and returns the stories whose indexes should be saved.
+func (i *Storytelling) placeStory(ctx context.Context, story *storytelling.Story, index *int) (storytelling.StoryList, error) {
+	stories, err := i.storytellingRepo.FindByScene(ctx, story.Scene())
+	if err != nil {
+		return nil, err
+	}
+
+	stories.Remove(story.Id())
+	stories.AddAt(story, index)
+	return *stories, nil
+}
+
+func (i *Storytelling) CreatePage(ctx context.Context, inp interfaces.CreatePageParam, op *usecase.Operator) (_ *storytelling.Story, _ *storytelling.Page, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -444,6 +737,10 @@ func (i *Storytelling) CreatePage(ctx context.Context, inp interfaces.CreatePage
 		return nil, nil, err
 	}
 
+	if err := i.enforceStoryPageCount(ctx, story, op); err != nil {
+		return nil, nil, err
+	}
+
 	story.Pages().AddAt(page, inp.Index)
 
 	if err = i.propertyRepo.Save(ctx, prop); err != nil {
@@ -458,7 +755,7 @@ func (i *Storytelling) CreatePage(ctx context.Context, inp interfaces.CreatePage
 	return story, page, nil
 }
 
-func (i *Storytelling) UpdatePage(ctx context.Context, inp interfaces.UpdatePageParam, op *usecase.Operator) (*storytelling.Story, *storytelling.Page, error) {
+func (i *Storytelling) UpdatePage(ctx context.Context, inp interfaces.UpdatePageParam, op *usecase.Operator) (_ *storytelling.Story, _ *storytelling.Page, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -511,7 +808,7 @@ func (i *Storytelling) UpdatePage(ctx context.Context, inp interfaces.UpdatePage
 	return story, page, nil
 }
 
-func (i *Storytelling) RemovePage(ctx context.Context, inp interfaces.RemovePageParam, op *usecase.Operator) (*storytelling.Story, *id.PageID, error) {
+func (i *Storytelling) RemovePage(ctx context.Context, inp interfaces.RemovePageParam, op *usecase.Operator) (_ *storytelling.Story, _ *id.PageID, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -548,7 +845,7 @@ func (i *Storytelling) RemovePage(ctx context.Context, inp interfaces.RemovePage
 	return story, page.Id().Ref(), nil
 }
 
-func (i *Storytelling) MovePage(ctx context.Context, inp interfaces.MovePageParam, op *usecase.Operator) (*storytelling.Story, *storytelling.Page, int, error) {
+func (i *Storytelling) MovePage(ctx context.Context, inp interfaces.MovePageParam, op *usecase.Operator) (_ *storytelling.Story, _ *storytelling.Page, _ int, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, nil, 0, err
@@ -585,7 +882,7 @@ func (i *Storytelling) MovePage(ctx context.Context, inp interfaces.MovePagePara
 	return story, page, inp.Index, nil
 }
 
-func (i *Storytelling) DuplicatePage(ctx context.Context, inp interfaces.DuplicatePageParam, op *usecase.Operator) (*storytelling.Story, *storytelling.Page, error) {
+func (i *Storytelling) DuplicatePage(ctx context.Context, inp interfaces.DuplicatePageParam, op *usecase.Operator) (_ *storytelling.Story, _ *storytelling.Page, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -612,6 +909,10 @@ func (i *Storytelling) DuplicatePage(ctx context.Context, inp interfaces.Duplica
 		return nil, nil, interfaces.ErrPageNotFound
 	}
 
+	if err := i.enforceStoryPageCount(ctx, story, op); err != nil {
+		return nil, nil, err
+	}
+
 	dupPage := page.Duplicate()
 	story.Pages().AddAt(dupPage, lo.ToPtr(story.Pages().IndexOf(page.Id())+1))
 
@@ -623,7 +924,7 @@ func (i *Storytelling) DuplicatePage(ctx context.Context, inp interfaces.Duplica
 	return story, dupPage, nil
 }
 
-func (i *Storytelling) AddPageLayer(ctx context.Context, inp interfaces.PageLayerParam, op *usecase.Operator) (*storytelling.Story, *storytelling.Page, error) {
+func (i *Storytelling) AddPageLayer(ctx context.Context, inp interfaces.PageLayerParam, op *usecase.Operator) (_ *storytelling.Story, _ *storytelling.Page, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -668,7 +969,7 @@ func (i *Storytelling) AddPageLayer(ctx context.Context, inp interfaces.PageLaye
 	return story, page, nil
 }
 
-func (i *Storytelling) RemovePageLayer(ctx context.Context, inp interfaces.PageLayerParam, op *usecase.Operator) (*storytelling.Story, *storytelling.Page, error) {
+func (i *Storytelling) RemovePageLayer(ctx context.Context, inp interfaces.PageLayerParam, op *usecase.Operator) (_ *storytelling.Story, _ *storytelling.Page, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -713,7 +1014,7 @@ func (i *Storytelling) RemovePageLayer(ctx context.Context, inp interfaces.PageL
 	return story, page, nil
 }
 
-func (i *Storytelling) CreateBlock(ctx context.Context, inp interfaces.CreateBlockParam, op *usecase.Operator) (*storytelling.Story, *storytelling.Page, *storytelling.Block, int, error) {
+func (i *Storytelling) CreateBlock(ctx context.Context, inp interfaces.CreateBlockParam, op *usecase.Operator) (_ *storytelling.Story, _ *storytelling.Page, _ *storytelling.Block, _ int, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, nil, nil, -1, err
@@ -783,7 +1084,7 @@ func (i *Storytelling) CreateBlock(ctx context.Context, inp interfaces.CreateBlo
 	return story, page, block, 1, err
 }
 
-func (i *Storytelling) RemoveBlock(ctx context.Context, inp interfaces.RemoveBlockParam, op *usecase.Operator) (*storytelling.Story, *storytelling.Page, *id.BlockID, error) {
+func (i *Storytelling) RemoveBlock(ctx context.Context, inp interfaces.RemoveBlockParam, op *usecase.Operator) (_ *storytelling.Story, _ *storytelling.Page, _ *id.BlockID, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, nil, nil, err
@@ -828,7 +1129,7 @@ func (i *Storytelling) RemoveBlock(ctx context.Context, inp interfaces.RemoveBlo
 	return story, page, &inp.BlockID, nil
 }
 
-func (i *Storytelling) MoveBlock(ctx context.Context, inp interfaces.MoveBlockParam, op *usecase.Operator) (*storytelling.Story, *storytelling.Page, *id.BlockID, int, error) {
+func (i *Storytelling) MoveBlock(ctx context.Context, inp interfaces.MoveBlockParam, op *usecase.Operator) (_ *storytelling.Story, _ *storytelling.Page, _ *id.BlockID, _ int, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, nil, nil, inp.Index, err
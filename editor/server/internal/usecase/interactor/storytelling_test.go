@@ -0,0 +1,157 @@
+package interactor
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/policy"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorytelling_Ordering(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	f := lo.Must(fs.NewFile(afero.NewMemMapFs(), ""))
+	uc := NewStorytelling(r, &gateway.Container{File: f}, "")
+
+	ws := workspace.New().NewID().MustBuild()
+	s := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).MustBuild()
+	lo.Must0(r.Workspace.Save(ctx, ws))
+	lo.Must0(r.Scene.Save(ctx, s))
+	sid := s.ID()
+	op := &usecase.Operator{
+		WritableScenes: id.SceneIDList{sid},
+	}
+
+	titles := func() []string {
+		stories := lo.Must(uc.FetchByScene(ctx, sid, op))
+		return lo.Map(*stories, func(s *storytelling.Story, i int) string {
+			assert.Equal(t, i, s.Index())
+			return s.Title()
+		})
+	}
+
+	a := lo.Must(uc.Create(ctx, interfaces.CreateStoryInput{SceneID: sid, Title: "a"}, op))
+	b := lo.Must(uc.Create(ctx, interfaces.CreateStoryInput{SceneID: sid, Title: "b"}, op))
+	lo.Must(uc.Create(ctx, interfaces.CreateStoryInput{SceneID: sid, Title: "c", Index: lo.ToPtr(0)}, op))
+	assert.Equal(t, []string{"c", "a", "b"}, titles())
+
+	// move
+	moved, index, err := uc.Move(ctx, interfaces.MoveStoryInput{SceneID: sid, StoryID: b.Id(), Index: 0}, op)
+	require.NoError(t, err)
+	assert.Equal(t, b.Id(), *moved)
+	assert.Equal(t, 0, index)
+	assert.Equal(t, []string{"b", "c", "a"}, titles())
+
+	// update with index
+	_, err = uc.Update(ctx, interfaces.UpdateStoryInput{SceneID: sid, StoryID: a.Id(), Title: lo.ToPtr("A"), Index: lo.ToPtr(1)}, op)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "A", "c"}, titles())
+
+	// duplicate
+	_, _, err = uc.CreatePage(ctx, interfaces.CreatePageParam{SceneID: sid, StoryID: b.Id(), Title: lo.ToPtr("page")}, op)
+	require.NoError(t, err)
+	dup, err := uc.Duplicate(ctx, interfaces.DuplicateStoryInput{SceneID: sid, StoryID: b.Id()}, op)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "b (copy)", "A", "c"}, titles())
+
+	b = lo.Must(r.Storytelling.FindByID(ctx, b.Id()))
+	dupPage := dup.Pages().Pages()[0]
+	assert.Equal(t, "page", dupPage.Title())
+	assert.NotEqual(t, b.Pages().Pages()[0].Id(), dupPage.Id())
+	props := lo.Must(r.Property.FindByIDs(ctx, id.PropertyIDList{dup.Property(), dupPage.Property()}))
+	assert.NotContains(t, props, nil)
+	assert.NotEqual(t, b.Pages().Pages()[0].Property(), dupPage.Property())
+
+	// remove
+	lo.Must0(f.UploadStorySnapshot(ctx, strings.NewReader("{}"), b.Id().String(), "1000"))
+	_, err = uc.Remove(ctx, interfaces.RemoveStoryInput{SceneID: sid, StoryID: b.Id()}, op)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b (copy)", "A", "c"}, titles())
+	assert.Empty(t, lo.Must(f.ListStorySnapshots(ctx, b.Id().String())))
+
+	// denied
+	_, err = uc.Duplicate(ctx, interfaces.DuplicateStoryInput{SceneID: sid, StoryID: dup.Id()}, &usecase.Operator{})
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+}
+
+func TestStorytelling_Policy(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	r.Policy = memory.NewPolicyWith(policy.New(policy.Option{
+		ID:                  policy.ID("policy"),
+		StoryCount:          lo.ToPtr(1),
+		StoryPageCount:      lo.ToPtr(1),
+		PublishedStoryCount: lo.ToPtr(1),
+	}))
+	uc := NewStorytelling(r, &gateway.Container{}, "")
+
+	ws := workspace.New().NewID().Policy(policy.ID("policy").Ref()).MustBuild()
+	s := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).MustBuild()
+	s2 := scene.New().NewID().Workspace(ws.ID()).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).MustBuild()
+	lo.Must0(r.Workspace.Save(ctx, ws))
+	lo.Must0(r.Scene.Save(ctx, s))
+	lo.Must0(r.Scene.Save(ctx, s2))
+	op := &usecase.Operator{
+		WritableScenes: id.SceneIDList{s.ID(), s2.ID()},
+	}
+
+	// stories in a scene
+	story := lo.Must(uc.Create(ctx, interfaces.CreateStoryInput{SceneID: s.ID(), Title: "a"}, op))
+	_, err := uc.Create(ctx, interfaces.CreateStoryInput{SceneID: s.ID(), Title: "b"}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+	_, err = uc.Duplicate(ctx, interfaces.DuplicateStoryInput{SceneID: s.ID(), StoryID: story.Id()}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+
+	// pages in a story
+	_, page, err := uc.CreatePage(ctx, interfaces.CreatePageParam{SceneID: s.ID(), StoryID: story.Id()}, op)
+	require.NoError(t, err)
+	_, _, err = uc.CreatePage(ctx, interfaces.CreatePageParam{SceneID: s.ID(), StoryID: story.Id()}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+	_, _, err = uc.DuplicatePage(ctx, interfaces.DuplicatePageParam{SceneID: s.ID(), StoryID: story.Id(), PageID: page.Id()}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+
+	// published stories are counted in the workspace
+	published := storytelling.NewStory().NewID().Scene(s2.ID()).Property(id.NewPropertyID()).
+		Status(storytelling.PublishmentStatusPublic).Pages(storytelling.NewPageList(nil)).MustBuild()
+	lo.Must0(r.Storytelling.Save(ctx, *published))
+	_, err = uc.Publish(ctx, interfaces.PublishStoryInput{
+		ID:        story.Id(),
+		Alias:     lo.ToPtr("story"),
+		Status:    storytelling.PublishmentStatusPublic,
+		PublishAt: lo.ToPtr(time.Now().Add(time.Hour)),
+	}, op)
+	assert.Same(t, policy.ErrPolicyViolation, err)
+
+	lo.Must0(r.Storytelling.Remove(ctx, published.Id()))
+
+	// the story needs an alias to be published
+	_, err = uc.Publish(ctx, interfaces.PublishStoryInput{
+		ID:        story.Id(),
+		Status:    storytelling.PublishmentStatusPublic,
+		PublishAt: lo.ToPtr(time.Now().Add(time.Hour)),
+	}, op)
+	assert.Same(t, interfaces.ErrStoryAliasIsNotSet, err)
+
+	_, err = uc.Publish(ctx, interfaces.PublishStoryInput{
+		ID:        story.Id(),
+		Alias:     lo.ToPtr("story"),
+		Status:    storytelling.PublishmentStatusPublic,
+		PublishAt: lo.ToPtr(time.Now().Add(time.Hour)),
+	}, op)
+	assert.NoError(t, err)
+}
@@ -16,6 +16,7 @@ type Style struct {
 	common
 	commonSceneLock
 	styleRepo     repo.Style
+	nlslayerRepo  repo.NLSLayer
 	sceneLockRepo repo.SceneLock
 	transaction   usecasex.Transaction
 }
@@ -24,6 +25,7 @@ func NewStyle(r *repo.Container) interfaces.Style {
 	return &Style{
 		commonSceneLock: commonSceneLock{sceneLockRepo: r.SceneLock},
 		styleRepo:       r.Style,
+		nlslayerRepo:    r.NLSLayer,
 		sceneLockRepo:   r.SceneLock,
 		transaction:     r.Transaction,
 	}
@@ -37,7 +39,7 @@ func (i *Style) FetchByScene(ctx context.Context, sid id.SceneID, _ *usecase.Ope
 	return i.styleRepo.FindByScene(ctx, sid)
 }
 
-func (i *Style) AddStyle(ctx context.Context, param interfaces.AddStyleInput, operator *usecase.Operator) (*scene.Style, error) {
+func (i *Style) AddStyle(ctx context.Context, param interfaces.AddStyleInput, operator *usecase.Operator) (_ *scene.Style, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -54,6 +56,10 @@ func (i *Style) AddStyle(ctx context.Context, param interfaces.AddStyleInput, op
 	// 	return nil, interfaces.ErrOperationDenied
 	// }
 
+	if err := param.Value.Validate(); err != nil {
+		return nil, err
+	}
+
 	style, err := sceneops.Style{
 		SceneID: param.SceneID,
 		Value:   param.Value,
@@ -71,7 +77,7 @@ func (i *Style) AddStyle(ctx context.Context, param interfaces.AddStyleInput, op
 	return style, nil
 }
 
-func (i *Style) UpdateStyle(ctx context.Context, param interfaces.UpdateStyleInput, operator *usecase.Operator) (*scene.Style, error) {
+func (i *Style) UpdateStyle(ctx context.Context, param interfaces.UpdateStyleInput, operator *usecase.Operator) (_ *scene.Style, err error) {
 
 	tx, err := i.transaction.Begin(ctx)
 
@@ -97,7 +103,12 @@ func (i *Style) UpdateStyle(ctx context.Context, param interfaces.UpdateStyleInp
 	}
 
 	if param.Value != nil {
+		if err := param.Value.Validate(); err != nil {
+			return nil, err
+		}
 		style.UpdateValue(param.Value)
+		// a style edited in the scene no longer follows its library style
+		style.SetLink(nil)
 	}
 
 	if err := i.styleRepo.Save(ctx, *style); err != nil {
@@ -143,7 +154,7 @@ func (i *Style) RemoveStyle(ctx context.Context, styleID id.StyleID, operator *u
 	return styleID, nil
 }
 
-func (i *Style) DuplicateStyle(ctx context.Context, styleID id.StyleID, operator *usecase.Operator) (*scene.Style, error) {
+func (i *Style) DuplicateStyle(ctx context.Context, styleID id.StyleID, operator *usecase.Operator) (_ *scene.Style, err error) {
 	tx, err := i.transaction.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -174,3 +185,24 @@ func (i *Style) DuplicateStyle(ctx context.Context, styleID id.StyleID, operator
 	tx.Commit()
 	return duplicatedStyle, nil
 }
+
+func (i *Style) ValidateStyle(ctx context.Context, param interfaces.ValidateStyleInput, operator *usecase.Operator) (scene.StyleErrors, error) {
+	var attributes []string
+	if param.LayerID != nil {
+		layer, err := i.nlslayerRepo.FindByID(ctx, *param.LayerID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := i.CanReadScene(layer.Scene(), operator); err != nil {
+			return nil, err
+		}
+
+		// attributes are only known for sketch layers, whose features are stored in the scene
+		if layer.HasSketch() {
+			attributes = layer.Sketch().Attributes()
+		}
+	}
+
+	return param.Value.Check(attributes), nil
+}
@@ -0,0 +1,267 @@
+package interactor
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/stylelib"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type StyleLibrary struct {
+	common
+	libraryRepo repo.StyleLibrary
+	styleRepo   repo.Style
+	sceneRepo   repo.Scene
+	transaction usecasex.Transaction
+	now         func() time.Time
+}
+
+func NewStyleLibrary(r *repo.Container) interfaces.StyleLibrary {
+	return &StyleLibrary{
+		libraryRepo: r.StyleLibrary,
+		styleRepo:   r.Style,
+		sceneRepo:   r.Scene,
+		transaction: r.Transaction,
+		now:         time.Now,
+	}
+}
+
+func (i *StyleLibrary) Fetch(ctx context.Context, ids id.LibraryStyleIDList, _ *usecase.Operator) ([]*stylelib.Style, error) {
+	return i.libraryRepo.FindByIDs(ctx, ids)
+}
+
+func (i *StyleLibrary) FindByWorkspace(ctx context.Context, wid accountdomain.WorkspaceID, operator *usecase.Operator) ([]*stylelib.Style, error) {
+	if err := i.CanReadWorkspace(wid, operator); err != nil {
+		return nil, err
+	}
+	return i.libraryRepo.FindByWorkspace(ctx, wid)
+}
+
+func (i *StyleLibrary) Publish(ctx context.Context, param interfaces.PublishStyleInput, operator *usecase.Operator) (_ *stylelib.Style, _ *scene.Style, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	style, sc, err := i.writableStyle(ctx, param.StyleID, operator)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := style.Value().Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	var lib *stylelib.Style
+	if param.LibraryStyleID != nil {
+		if lib, err = i.libraryRepo.FindByID(ctx, *param.LibraryStyleID); err != nil {
+			return nil, nil, err
+		}
+		if err := i.CanWriteWorkspace(lib.Workspace(), operator); err != nil {
+			return nil, nil, err
+		}
+		if param.Name != nil {
+			lib.Rename(*param.Name)
+		}
+		lib.Publish(style.Value(), operatorUser(operator), i.now())
+	} else {
+		wid := sc.Workspace()
+		if param.WorkspaceID != nil {
+			wid = *param.WorkspaceID
+		}
+		if err := i.CanWriteWorkspace(wid, operator); err != nil {
+			return nil, nil, err
+		}
+
+		name := style.Name()
+		if param.Name != nil {
+			name = *param.Name
+		}
+		if lib, err = stylelib.New().
+			NewID().
+			Workspace(wid).
+			Name(name).
+			Versions([]*stylelib.Version{stylelib.NewVersion(1, style.Value(), operatorUser(operator), i.now())}).
+			Build(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := i.libraryRepo.Save(ctx, lib); err != nil {
+		return nil, nil, err
+	}
+
+	style.SetLink(scene.NewStyleLink(lib.ID(), lib.LatestVersion()))
+	if err := i.styleRepo.Save(ctx, *style); err != nil {
+		return nil, nil, err
+	}
+
+	tx.Commit()
+	return lib, style, nil
+}
+
+func (i *StyleLibrary) Import(ctx context.Context, param interfaces.ImportStyleInput, operator *usecase.Operator) (_ *scene.Style, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	sc, err := i.sceneRepo.FindByID(ctx, param.SceneID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteWorkspace(sc.Workspace(), operator); err != nil {
+		return nil, err
+	}
+
+	lib, v, err := i.libraryVersion(ctx, param.LibraryStyleID, param.Version, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	style, err := scene.NewStyle().
+		NewID().
+		Scene(sc.ID()).
+		Name(lib.Name()).
+		Value(v.Value()).
+		Link(scene.NewStyleLink(lib.ID(), v.Version())).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.styleRepo.Save(ctx, *style); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return style, nil
+}
+
+func (i *StyleLibrary) Sync(ctx context.Context, param interfaces.SyncStyleInput, operator *usecase.Operator) (_ *scene.Style, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	style, _, err := i.writableStyle(ctx, param.StyleID, operator)
+	if err != nil {
+		return nil, err
+	}
+	if style.Link() == nil {
+		return nil, interfaces.ErrStyleNotLinked
+	}
+
+	lib, v, err := i.libraryVersion(ctx, style.Link().Library(), param.Version, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	style.UpdateValue(v.Value())
+	style.SetLink(scene.NewStyleLink(lib.ID(), v.Version()))
+	if err := i.styleRepo.Save(ctx, *style); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return style, nil
+}
+
+func (i *StyleLibrary) Remove(ctx context.Context, lid id.LibraryStyleID, operator *usecase.Operator) (_ id.LibraryStyleID, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	lib, err := i.libraryRepo.FindByID(ctx, lid)
+	if err != nil {
+		return lid, err
+	}
+	if err := i.CanWriteWorkspace(lib.Workspace(), operator); err != nil {
+		return lid, err
+	}
+
+	// scene styles linked to the library style keep their values
+	if err := i.libraryRepo.Remove(ctx, lid); err != nil {
+		return lid, err
+	}
+
+	tx.Commit()
+	return lid, nil
+}
+
+func (i *StyleLibrary) writableStyle(ctx context.Context, sid id.StyleID, operator *usecase.Operator) (*scene.Style, *scene.Scene, error) {
+	style, err := i.styleRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, nil, err
+	}
+	sc, err := i.sceneRepo.FindByID(ctx, style.Scene())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := i.CanWriteWorkspace(sc.Workspace(), operator); err != nil {
+		return nil, nil, err
+	}
+	return style, sc, nil
+}
+
+func (i *StyleLibrary) libraryVersion(ctx context.Context, lid id.LibraryStyleID, version *int, operator *usecase.Operator) (*stylelib.Style, *stylelib.Version, error) {
+	lib, err := i.libraryRepo.FindByID(ctx, lid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := i.CanReadWorkspace(lib.Workspace(), operator); err != nil {
+		return nil, nil, err
+	}
+
+	if version == nil {
+		return lib, lib.Latest(), nil
+	}
+	v, err := lib.Version(*version)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lib, v, nil
+}
+
+func operatorUser(operator *usecase.Operator) *accountdomain.UserID {
+	if operator == nil || operator.AcOperator == nil {
+		return nil
+	}
+	return operator.AcOperator.User
+}
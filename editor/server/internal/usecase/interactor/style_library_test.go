@@ -0,0 +1,109 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyleLibrary(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	i := NewStyleLibrary(db).(*StyleLibrary)
+	i.now = func() time.Time { return now }
+
+	ws1, ws2 := accountdomain.NewWorkspaceID(), accountdomain.NewWorkspaceID()
+	s1 := scene.New().NewID().Workspace(ws1).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).MustBuild()
+	s2 := scene.New().NewID().Workspace(ws2).Project(id.NewProjectID()).RootLayer(id.NewLayerID()).MustBuild()
+	_ = db.Scene.Save(ctx, s1)
+	_ = db.Scene.Save(ctx, s2)
+
+	v1 := &scene.StyleValue{"marker": map[string]any{"pointColor": "red"}}
+	v2 := &scene.StyleValue{"marker": map[string]any{"pointColor": "blue"}}
+	st := scene.NewStyle().NewID().Scene(s1.ID()).Name("height").Value(v1).MustBuild()
+	_ = db.Style.Save(ctx, *st)
+
+	uid := accountdomain.NewUserID()
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			User:               &uid,
+			WritableWorkspaces: accountdomain.WorkspaceIDList{ws1, ws2},
+		},
+	}
+
+	// publish a new library style
+	lib, published, err := i.Publish(ctx, interfaces.PublishStyleInput{StyleID: st.ID()}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, ws1, lib.Workspace())
+	assert.Equal(t, "height", lib.Name())
+	assert.Equal(t, 1, lib.LatestVersion())
+	assert.Equal(t, &uid, lib.Latest().User())
+	assert.Equal(t, scene.NewStyleLink(lib.ID(), 1), published.Link())
+
+	// import into a scene of another workspace
+	imported, err := i.Import(ctx, interfaces.ImportStyleInput{LibraryStyleID: lib.ID(), SceneID: s2.ID()}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, s2.ID(), imported.Scene())
+	assert.Equal(t, "height", imported.Name())
+	assert.Equal(t, v1, imported.Value())
+	assert.Equal(t, scene.NewStyleLink(lib.ID(), 1), imported.Link())
+
+	// publish a new version
+	published.UpdateValue(v2)
+	_ = db.Style.Save(ctx, *published)
+	lib, _, err = i.Publish(ctx, interfaces.PublishStyleInput{StyleID: st.ID(), LibraryStyleID: lo.ToPtr(lib.ID())}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, lib.LatestVersion())
+	assert.Equal(t, 2, len(lib.Versions()))
+
+	// the imported style follows the library
+	synced, err := i.Sync(ctx, interfaces.SyncStyleInput{StyleID: imported.ID()}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, v2, synced.Value())
+	assert.Equal(t, scene.NewStyleLink(lib.ID(), 2), synced.Link())
+
+	synced, err = i.Sync(ctx, interfaces.SyncStyleInput{StyleID: imported.ID(), Version: lo.ToPtr(1)}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, v1, synced.Value())
+
+	// local edits unlink the style
+	_, err = NewStyle(db).UpdateStyle(ctx, interfaces.UpdateStyleInput{StyleID: imported.ID(), Value: v2}, op)
+	assert.NoError(t, err)
+	_, err = i.Sync(ctx, interfaces.SyncStyleInput{StyleID: imported.ID()}, op)
+	assert.Equal(t, interfaces.ErrStyleNotLinked, err)
+
+	// permissions
+	readOnly := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces: accountdomain.WorkspaceIDList{ws1},
+			WritableWorkspaces: accountdomain.WorkspaceIDList{ws2},
+		},
+	}
+	_, err = i.Import(ctx, interfaces.ImportStyleInput{LibraryStyleID: lib.ID(), SceneID: s1.ID()}, readOnly)
+	assert.Equal(t, interfaces.ErrOperationDenied, err)
+	_, err = i.Import(ctx, interfaces.ImportStyleInput{LibraryStyleID: lib.ID(), SceneID: s2.ID()}, readOnly)
+	assert.NoError(t, err)
+	_, err = i.Remove(ctx, lib.ID(), readOnly)
+	assert.Equal(t, interfaces.ErrOperationDenied, err)
+
+	libs, err := i.FindByWorkspace(ctx, ws1, readOnly)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(libs))
+
+	_, err = i.Remove(ctx, lib.ID(), op)
+	assert.NoError(t, err)
+	libs, err = i.FindByWorkspace(ctx, ws1, op)
+	assert.NoError(t, err)
+	assert.Empty(t, libs)
+}
@@ -0,0 +1,80 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyle_AddAndUpdateStyle(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	i := NewStyle(db)
+	sid := id.NewSceneID()
+
+	invalid := &scene.StyleValue{
+		"marker": map[string]any{
+			"pointColor": map[string]any{"expression": `color("red"`},
+		},
+	}
+	valid := &scene.StyleValue{
+		"marker": map[string]any{
+			"pointColor": map[string]any{"expression": `${height} > 10 ? color("red") : color("blue")`},
+		},
+	}
+
+	_, err := i.AddStyle(ctx, interfaces.AddStyleInput{SceneID: sid, Name: "style", Value: invalid}, nil)
+	assert.ErrorIs(t, err, scene.ErrInvalidStyle)
+	assert.Equal(t, `invalid style: marker.pointColor.expression:12: expected "," or ")", found end of expression`, err.Error())
+
+	s, err := i.AddStyle(ctx, interfaces.AddStyleInput{SceneID: sid, Name: "style", Value: valid}, nil)
+	assert.NoError(t, err)
+
+	_, err = i.UpdateStyle(ctx, interfaces.UpdateStyleInput{StyleID: s.ID(), Value: invalid}, nil)
+	assert.ErrorIs(t, err, scene.ErrInvalidStyle)
+
+	saved, err := db.Style.FindByID(ctx, s.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, valid, saved.Value())
+}
+
+func TestStyle_ValidateStyle(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	i := NewStyle(db)
+	sid := id.NewSceneID()
+
+	f, err := nlslayer.NewFeature(nlslayer.NewFeatureID(), "Feature", nlslayer.NewPoint("Point", []float64{1, 2}))
+	assert.NoError(t, err)
+	f.UpdateProperties(&map[string]any{"height": 10})
+	sketch := nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", []nlslayer.Feature{*f}))
+	l := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).IsSketch(true).Sketch(sketch).MustBuild()
+	assert.NoError(t, db.NLSLayer.Save(ctx, l))
+
+	value := &scene.StyleValue{
+		"marker": map[string]any{
+			"pointColor": map[string]any{"expression": `${height} > ${width}`},
+		},
+	}
+	op := &usecase.Operator{ReadableScenes: id.SceneIDList{sid}}
+
+	errs, err := i.ValidateStyle(ctx, interfaces.ValidateStyleInput{Value: value}, op)
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+
+	errs, err = i.ValidateStyle(ctx, interfaces.ValidateStyleInput{Value: value, LayerID: l.IDRef()}, op)
+	assert.NoError(t, err)
+	assert.Equal(t, scene.StyleErrors{
+		{Path: "marker.pointColor.expression", Pos: 13, Message: `unknown attribute "width"`},
+	}, errs)
+
+	_, err = i.ValidateStyle(ctx, interfaces.ValidateStyleInput{Value: value, LayerID: l.IDRef()}, &usecase.Operator{})
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+}
@@ -0,0 +1,44 @@
+package interactor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+// signViewToken issues a token which allows to view the published story until it expires.
+// The token is "<expiration in unix seconds>.<signature>", signed with HMAC-SHA256 over the story ID and the expiration.
+func signViewToken(secret string, sid id.StoryID, expiresAt time.Time) string {
+	exp := strconv.FormatInt(expiresAt.Unix(), 10)
+	return exp + "." + viewTokenSignature(secret, sid, exp)
+}
+
+func verifyViewToken(secret string, sid id.StoryID, token string, now time.Time) error {
+	exp, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return interfaces.ErrInvalidViewToken
+	}
+
+	expected := viewTokenSignature(secret, sid, exp)
+	if !hmac.Equal([]byte(sig), []byte(expected)) {
+		return interfaces.ErrInvalidViewToken
+	}
+
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || !now.Before(time.Unix(unix, 0)) {
+		return interfaces.ErrInvalidViewToken
+	}
+	return nil
+}
+
+func viewTokenSignature(secret string, sid id.StoryID, exp string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(sid.String() + "." + exp))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,203 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/usage"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
+)
+
+// workspaceUsageMaxAge is how long a cached usage is returned without being aggregated again.
+const workspaceUsageMaxAge = time.Hour
+
+const usageAggregatorLock = "usage_aggregator"
+
+type WorkspaceUsage struct {
+	common
+	usageAggregation
+}
+
+func NewWorkspaceUsage(r *repo.Container) interfaces.WorkspaceUsage {
+	return &WorkspaceUsage{
+		usageAggregation: newUsageAggregation(r),
+	}
+}
+
+func (i *WorkspaceUsage) Find(ctx context.Context, wid accountdomain.WorkspaceID, operator *usecase.Operator) (*usage.Usage, error) {
+	if err := i.OnlyOperator(operator); err != nil {
+		return nil, err
+	}
+	if !operator.IsMaintainingWorkspace(wid) {
+		return nil, interfaces.ErrOperationDenied
+	}
+
+	u, err := i.usageRepo.FindByWorkspace(ctx, wid)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, err
+	}
+	if u != nil && util.Now().Sub(u.UpdatedAt()) < workspaceUsageMaxAge {
+		return u, nil
+	}
+
+	return i.refresh(ctx, wid, util.Now())
+}
+
+func (i *WorkspaceUsage) ExportCSV(ctx context.Context, wid accountdomain.WorkspaceID, w io.Writer, operator *usecase.Operator) error {
+	u, err := i.Find(ctx, wid, operator)
+	if err != nil {
+		return err
+	}
+	return u.WriteCSV(w)
+}
+
+// UsageAggregator keeps the cached usages of workspaces up to date in the background,
+// so that administrators rarely wait for the aggregation.
+type UsageAggregator struct {
+	usageAggregation
+	lock repo.Lock
+}
+
+func NewUsageAggregator(r *repo.Container) *UsageAggregator {
+	return &UsageAggregator{
+		usageAggregation: newUsageAggregation(r),
+		lock:             r.Lock,
+	}
+}
+
+// Run aggregates the usages cached before the time again. Only one replica runs the aggregation at once.
+func (a *UsageAggregator) Run(ctx context.Context, before time.Time) error {
+	if err := a.lock.Lock(ctx, usageAggregatorLock); err != nil {
+		if errors.Is(err, repo.ErrFailedToLock) || errors.Is(err, repo.ErrAlreadyLocked) {
+			// another replica is running the aggregation
+			return nil
+		}
+		return err
+	}
+
+	defer func() {
+		if err := a.lock.Unlock(ctx, usageAggregatorLock); err != nil {
+			log.Errorfc(ctx, "usage aggregator: failed to unlock: %v", err)
+		}
+	}()
+
+	usages, err := a.usageRepo.FindUpdatedBefore(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range usages {
+		if _, err := a.refresh(ctx, u.Workspace(), util.Now()); err != nil {
+			log.Errorfc(ctx, "usage aggregator: failed to aggregate the usage of workspace %s: %v", u.Workspace(), err)
+		}
+	}
+	return nil
+}
+
+type usageAggregation struct {
+	usageRepo        repo.WorkspaceUsage
+	assetRepo        repo.Asset
+	projectRepo      repo.Project
+	sceneRepo        repo.Scene
+	layerRepo        repo.Layer
+	nlsLayerRepo     repo.NLSLayer
+	storytellingRepo repo.Storytelling
+}
+
+func newUsageAggregation(r *repo.Container) usageAggregation {
+	return usageAggregation{
+		usageRepo:        r.WorkspaceUsage,
+		assetRepo:        r.Asset,
+		projectRepo:      r.Project,
+		sceneRepo:        r.Scene,
+		layerRepo:        r.Layer,
+		nlsLayerRepo:     r.NLSLayer,
+		storytellingRepo: r.Storytelling,
+	}
+}
+
+// refresh aggregates the usage of the workspace and caches it.
+func (a usageAggregation) refresh(ctx context.Context, wid accountdomain.WorkspaceID, now time.Time) (*usage.Usage, error) {
+	size, err := a.assetRepo.TotalSizeByWorkspace(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+
+	scenes, err := a.sceneRepo.FindByWorkspace(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []usage.Project
+	if err := repo.IterateProjectsByWorkspace(a.projectRepo, ctx, wid, 100, func(prjs []*project.Project) error {
+		for _, prj := range prjs {
+			p, err := a.aggregateProject(ctx, prj, scenes)
+			if err != nil {
+				return err
+			}
+			projects = append(projects, p)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	u := usage.New(wid, size, projects, now)
+	if err := a.usageRepo.Save(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (a usageAggregation) aggregateProject(ctx context.Context, prj *project.Project, scenes scene.List) (usage.Project, error) {
+	res := usage.Project{
+		ID:        prj.ID(),
+		Name:      prj.Name(),
+		Published: prj.PublishmentStatus() == project.PublishmentStatusPublic || prj.PublishmentStatus() == project.PublishmentStatusLimited,
+	}
+
+	s, ok := lo.Find(scenes, func(s *scene.Scene) bool {
+		return s.Project() == prj.ID()
+	})
+	if !ok {
+		return res, nil
+	}
+	res.Scene = s.ID().Ref()
+
+	layers, err := a.layerRepo.CountByScene(ctx, s.ID())
+	if err != nil {
+		return res, err
+	}
+	res.LayerCount = layers
+
+	nlsLayers, err := a.nlsLayerRepo.FindByScene(ctx, s.ID())
+	if err != nil {
+		return res, err
+	}
+	res.NLSLayerCount = len(nlsLayers)
+
+	stories, err := a.storytellingRepo.FindByScene(ctx, s.ID())
+	if err != nil {
+		return res, err
+	}
+	if stories != nil {
+		for _, story := range lo.Compact(*stories) {
+			res.StoryCount++
+			if isPublicStory(story) {
+				res.PublishedStoryCount++
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,116 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearth/server/pkg/usage"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/reearth/reearthx/account/accountdomain/workspace"
+	"github.com/reearth/reearthx/account/accountusecase"
+	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceUsage_Find(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	r := memory.New()
+	uc := NewWorkspaceUsage(r)
+	ws := workspace.New().NewID().MustBuild()
+	lo.Must0(r.Workspace.Save(ctx, ws))
+
+	a := asset.New().NewID().Workspace(ws.ID()).Name("a.png").Size(100).URL("https://example.com/a.png").MustBuild()
+	lo.Must0(r.Asset.Save(ctx, a))
+
+	prj := project.New().NewID().Workspace(ws.ID()).Name("project").PublishmentStatus(project.PublishmentStatusPublic).MustBuild()
+	prj2 := project.New().NewID().Workspace(ws.ID()).Name("empty").MustBuild()
+	s := scene.New().NewID().Project(prj.ID()).Workspace(ws.ID()).RootLayer(id.NewLayerID()).MustBuild()
+	l := nlslayer.NewNLSLayerSimple().NewID().Scene(s.ID()).LayerType(nlslayer.Simple).MustBuild()
+	story := storytelling.NewStory().NewID().Scene(s.ID()).Property(id.NewPropertyID()).
+		Status(storytelling.PublishmentStatusLimited).Pages(storytelling.NewPageList(nil)).MustBuild()
+	lo.Must0(r.Project.Save(ctx, prj))
+	lo.Must0(r.Project.Save(ctx, prj2))
+	lo.Must0(r.Scene.Save(ctx, s))
+	lo.Must0(r.NLSLayer.Save(ctx, l))
+	lo.Must0(r.Storytelling.Save(ctx, *story))
+
+	op := &usecase.Operator{
+		AcOperator: &accountusecase.Operator{
+			ReadableWorkspaces:     accountdomain.WorkspaceIDList{ws.ID()},
+			WritableWorkspaces:     accountdomain.WorkspaceIDList{ws.ID()},
+			MaintainableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()},
+		},
+	}
+
+	// only maintainers can see the usage
+	_, err := uc.Find(ctx, ws.ID(), &usecase.Operator{
+		AcOperator: &accountusecase.Operator{WritableWorkspaces: accountdomain.WorkspaceIDList{ws.ID()}},
+	})
+	assert.Same(t, interfaces.ErrOperationDenied, err)
+
+	u, err := uc.Find(ctx, ws.ID(), op)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), u.AssetStorageSize())
+	assert.Equal(t, 2, u.ProjectCount())
+	assert.Equal(t, 1, u.SceneCount())
+	assert.Equal(t, 1, u.NLSLayerCount())
+	assert.Equal(t, 2, u.PublishedSiteCount())
+	assert.Equal(t, now, u.UpdatedAt())
+	assert.ElementsMatch(t, []usage.Project{
+		{ID: prj.ID(), Name: "project", Scene: s.ID().Ref(), Published: true, NLSLayerCount: 1, StoryCount: 1, PublishedStoryCount: 1},
+		{ID: prj2.ID(), Name: "empty"},
+	}, u.Projects())
+
+	// the cached usage is returned until it gets outdated
+	lo.Must0(r.NLSLayer.Save(ctx, nlslayer.NewNLSLayerSimple().NewID().Scene(s.ID()).LayerType(nlslayer.Simple).MustBuild()))
+	u, err = uc.Find(ctx, ws.ID(), op)
+	require.NoError(t, err)
+	assert.Equal(t, 1, u.NLSLayerCount())
+
+	defer util.MockNow(now.Add(workspaceUsageMaxAge))()
+	u, err = uc.Find(ctx, ws.ID(), op)
+	require.NoError(t, err)
+	assert.Equal(t, 2, u.NLSLayerCount())
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, uc.ExportCSV(ctx, ws.ID(), buf, op))
+	assert.Contains(t, buf.String(), "workspace,"+ws.ID().String()+",")
+}
+
+func TestUsageAggregator_Run(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	r := memory.New()
+	ws1, ws2 := accountdomain.NewWorkspaceID(), accountdomain.NewWorkspaceID()
+	lo.Must0(r.WorkspaceUsage.Save(ctx, usage.New(ws1, 0, nil, now.Add(-2*time.Hour))))
+	lo.Must0(r.WorkspaceUsage.Save(ctx, usage.New(ws2, 0, nil, now)))
+	lo.Must0(r.Project.Save(ctx, project.New().NewID().Workspace(ws1).MustBuild()))
+	lo.Must0(r.Project.Save(ctx, project.New().NewID().Workspace(ws2).MustBuild()))
+
+	require.NoError(t, NewUsageAggregator(r).Run(ctx, now.Add(-time.Hour)))
+
+	// only the outdated usage is aggregated again
+	u1 := lo.Must(r.WorkspaceUsage.FindByWorkspace(ctx, ws1))
+	assert.Equal(t, now, u1.UpdatedAt())
+	assert.Equal(t, 1, u1.ProjectCount())
+	u2 := lo.Must(r.WorkspaceUsage.FindByWorkspace(ctx, ws2))
+	assert.Equal(t, 0, u2.ProjectCount())
+}
@@ -20,18 +20,65 @@ const (
 	AssetFilterName AssetFilterType = "NAME"
 )
 
+type AssetUsageType string
+
+const (
+	AssetUsageTypeScene    AssetUsageType = "scene"
+	AssetUsageTypeLayer    AssetUsageType = "layer"
+	AssetUsageTypeNLSLayer AssetUsageType = "nlsLayer"
+	AssetUsageTypeStory    AssetUsageType = "story"
+)
+
 type CreateAssetParam struct {
 	WorkspaceID accountdomain.WorkspaceID
 	File        *file.File
+	Folder      *string
+	Tags        []string
+}
+
+type UpdateAssetParam struct {
+	AssetID id.AssetID
+	Name    *string
+	Folder  *string
+	Tags    *[]string
+}
+
+type RemoveAssetParam struct {
+	AssetID id.AssetID
+	// removes the asset even if it is still in use
+	Force bool
+}
+
+type FindAssetsParam struct {
+	Keyword    *string
+	Folder     *string
+	Tag        *string
+	Sort       *asset.SortType
+	Pagination *usecasex.Pagination
+}
+
+// AssetUsage is a scene, a layer, an NLS layer or a story which refers to the asset.
+type AssetUsage struct {
+	Type    AssetUsageType
+	SceneID id.SceneID
+	// ID of the scene, the layer, the NLS layer or the story
+	ID string
+	// property which refers to the asset, or nil when the asset is referred from the config of the NLS layer
+	PropertyID *id.PropertyID
 }
 
 var (
 	ErrCreateAssetFailed error = errors.New("failed to create asset")
+	ErrAssetInUse        error = errors.New("asset is in use")
 )
 
 type Asset interface {
 	Fetch(context.Context, []id.AssetID, *usecase.Operator) ([]*asset.Asset, error)
-	FindByWorkspace(context.Context, accountdomain.WorkspaceID, *string, *asset.SortType, *usecasex.Pagination, *usecase.Operator) ([]*asset.Asset, *usecasex.PageInfo, error)
+	FindByWorkspace(context.Context, accountdomain.WorkspaceID, FindAssetsParam, *usecase.Operator) ([]*asset.Asset, *usecasex.PageInfo, error)
+	FindFolders(context.Context, accountdomain.WorkspaceID, *usecase.Operator) ([]string, error)
+	FindTags(context.Context, accountdomain.WorkspaceID, *usecase.Operator) ([]string, error)
+	Usages(context.Context, id.AssetID, *usecase.Operator) ([]AssetUsage, error)
 	Create(context.Context, CreateAssetParam, *usecase.Operator) (*asset.Asset, error)
-	Remove(context.Context, id.AssetID, *usecase.Operator) (id.AssetID, error)
+	Update(context.Context, UpdateAssetParam, *usecase.Operator) (*asset.Asset, error)
+	Remove(context.Context, RemoveAssetParam, *usecase.Operator) (id.AssetID, error)
 }
@@ -0,0 +1,48 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/history"
+	"github.com/reearth/reearth/server/pkg/scene/presence"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+var ErrCollaborationNotAvailable = errors.New("collaboration is not available")
+
+// SceneChangeEvent tells editors of a scene that entities of the scene have been changed by an operation.
+type SceneChangeEvent struct {
+	Scene     id.SceneID
+	History   *id.HistoryID
+	User      *accountdomain.UserID
+	Operation string
+	Changes   []SceneChange
+}
+
+type SceneChange struct {
+	Type    history.EntityType
+	ID      string
+	Created bool
+	Removed bool
+	// Version is the version of the entity after the change. It is empty when the entity is removed.
+	Version string
+}
+
+type UpdatePresenceParam struct {
+	SceneID   id.SceneID
+	Layer     *id.NLSLayerID
+	StoryPage *id.PageID
+}
+
+type Collaboration interface {
+	// SubscribeChanges returns changes of the scene made after the call until the context is done.
+	SubscribeChanges(context.Context, id.SceneID, *usecase.Operator) (<-chan *SceneChangeEvent, error)
+	// SubscribePresence marks the operator as editing the scene until the context is done
+	// and returns the presences of the scene whenever they change.
+	SubscribePresence(context.Context, id.SceneID, *usecase.Operator) (<-chan presence.List, error)
+	FindPresence(context.Context, id.SceneID, *usecase.Operator) (presence.List, error)
+	UpdatePresence(context.Context, UpdatePresenceParam, *usecase.Operator) (presence.List, error)
+}
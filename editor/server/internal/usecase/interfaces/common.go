@@ -22,19 +22,24 @@ var (
 )
 
 type Container struct {
-	Asset        Asset
-	Dataset      Dataset
-	Layer        Layer
-	NLSLayer     NLSLayer
-	Plugin       Plugin
-	Policy       Policy
-	Project      Project
-	Property     Property
-	Published    Published
-	Scene        Scene
-	Tag          Tag
-	StoryTelling Storytelling
-	Style        Style
-	User         accountinterfaces.User
-	Workspace    accountinterfaces.Workspace
+	Asset          Asset
+	Dataset        Dataset
+	Layer          Layer
+	NLSLayer       NLSLayer
+	Plugin         Plugin
+	Policy         Policy
+	Project        Project
+	Property       Property
+	Published      Published
+	Scene          Scene
+	SceneHistory   SceneHistory
+	Collaboration  Collaboration
+	Tag            Tag
+	StoryTelling   Storytelling
+	Style          Style
+	StyleLibrary   StyleLibrary
+	PluginKey      PluginKey
+	WorkspaceUsage WorkspaceUsage
+	User           accountinterfaces.User
+	Workspace      accountinterfaces.Workspace
 }
@@ -77,6 +77,7 @@ var (
 	ErrLinkedLayerItemCannotBeMoved         error = errors.New("linked layer item cannot be moved")
 	ErrLayerCannotBeMovedToLinkedLayerGroup error = errors.New("layer cannot be moved to linked layer group")
 	ErrCannotMoveLayerToOtherScene          error = errors.New("layer cannot layer to other scene")
+	ErrCannotMoveLayerToItsDescendant       error = errors.New("layer cannot be moved to its descendant")
 	ErrExtensionTypeMustBePrimitive         error = errors.New("extension type must be primitive")
 	ErrExtensionTypeMustBeBlock             error = errors.New("extension type must be block")
 	ErrInvalidExtensionType                 error = errors.New("invalid extension type")
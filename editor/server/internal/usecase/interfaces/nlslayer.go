@@ -2,12 +2,18 @@ package interfaces
 
 import (
 	"context"
+	"errors"
+	"io"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer/decoding"
 	"github.com/reearth/reearth/server/pkg/nlslayer"
 )
 
+var ErrClusterNotFound error = errors.New("cluster not found")
+
 type AddNLSLayerSimpleInput struct {
 	ParentLayerID id.NLSLayerID
 	Title         string
@@ -19,11 +25,28 @@ type AddNLSLayerSimpleInput struct {
 	Schema        *map[string]any
 }
 
+type AddNLSLayerGroupInput struct {
+	ParentLayerID *id.NLSLayerID
+	SceneID       id.SceneID
+	Title         string
+	Index         *int
+	Config        *nlslayer.Config
+	Visible       *bool
+}
+
+type MoveNLSLayerInput struct {
+	LayerID     id.NLSLayerID
+	DestLayerID *id.NLSLayerID
+	Index       int
+}
+
 type UpdateNLSLayerInput struct {
-	LayerID id.NLSLayerID
-	Name    *string
-	Visible *bool
-	Config  *nlslayer.Config
+	LayerID       id.NLSLayerID
+	Name          *string
+	Visible       *bool
+	Config        *nlslayer.Config
+	Cluster       *id.ClusterID
+	DeleteCluster bool
 }
 
 type AddNLSInfoboxBlockParam struct {
@@ -68,12 +91,21 @@ type DeleteNLSLayerGeoJSONFeatureParams struct {
 	FeatureID id.FeatureID
 }
 
+type ImportNLSLayerGeoJSONFeaturesParams struct {
+	LayerID id.NLSLayerID
+	File    *file.File
+	Format  decoding.LayerEncodingFormat
+}
+
 type NLSLayer interface {
 	Fetch(context.Context, id.NLSLayerIDList, *usecase.Operator) (nlslayer.NLSLayerList, error)
 	FetchByScene(context.Context, id.SceneID, *usecase.Operator) (nlslayer.NLSLayerList, error)
 	FetchLayerSimple(context.Context, id.NLSLayerIDList, *usecase.Operator) (nlslayer.NLSLayerSimpleList, error)
 	FetchParent(context.Context, id.NLSLayerID, *usecase.Operator) (*nlslayer.NLSLayerGroup, error)
 	AddLayerSimple(context.Context, AddNLSLayerSimpleInput, *usecase.Operator) (*nlslayer.NLSLayerSimple, error)
+	AddLayerGroup(context.Context, AddNLSLayerGroupInput, *usecase.Operator) (*nlslayer.NLSLayerGroup, *nlslayer.NLSLayerGroup, error)
+	Move(context.Context, MoveNLSLayerInput, *usecase.Operator) (id.NLSLayerID, *nlslayer.NLSLayerGroup, *nlslayer.NLSLayerGroup, int, error)
+	UpdateVisibility(context.Context, id.NLSLayerIDList, bool, *usecase.Operator) (nlslayer.NLSLayerList, error)
 	Remove(context.Context, id.NLSLayerID, *usecase.Operator) (id.NLSLayerID, *nlslayer.NLSLayerGroup, error)
 	Update(context.Context, UpdateNLSLayerInput, *usecase.Operator) (nlslayer.NLSLayer, error)
 	CreateNLSInfobox(context.Context, id.NLSLayerID, *usecase.Operator) (nlslayer.NLSLayer, error)
@@ -86,4 +118,6 @@ type NLSLayer interface {
 	AddGeoJSONFeature(context.Context, AddNLSLayerGeoJSONFeatureParams, *usecase.Operator) (nlslayer.Feature, error)
 	UpdateGeoJSONFeature(context.Context, UpdateNLSLayerGeoJSONFeatureParams, *usecase.Operator) (nlslayer.Feature, error)
 	DeleteGeoJSONFeature(context.Context, DeleteNLSLayerGeoJSONFeatureParams, *usecase.Operator) (id.FeatureID, error)
+	ImportGeoJSONFeatures(context.Context, ImportNLSLayerGeoJSONFeaturesParams, *usecase.Operator) (nlslayer.NLSLayer, []nlslayer.Feature, error)
+	Export(context.Context, id.NLSLayerID, string, *usecase.Operator) (io.Reader, string, error)
 }
@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/pluginkey"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+var ErrUnapprovedPlugin = errors.New("scene has plugins that are not signed with a plugin key of the workspace")
+
+type AddPluginKeyInput struct {
+	WorkspaceID accountdomain.WorkspaceID
+	Name        string
+	// PublicKey is an Ed25519 public key encoded in base64 or PEM.
+	PublicKey string
+}
+
+type PluginKey interface {
+	FindByWorkspace(context.Context, accountdomain.WorkspaceID, *usecase.Operator) (pluginkey.List, error)
+	Add(context.Context, AddPluginKeyInput, *usecase.Operator) (*pluginkey.Key, error)
+	Remove(context.Context, id.PluginKeyID, *usecase.Operator) (id.PluginKeyID, error)
+	// UnapprovedPlugins returns the plugins of the scene that prevent it from being published.
+	UnapprovedPlugins(context.Context, id.SceneID, *usecase.Operator) ([]id.PluginID, error)
+}
@@ -3,9 +3,12 @@ package interfaces
 import (
 	"context"
 	"errors"
+	"io"
 	"net/url"
+	"time"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/project"
 	"github.com/reearth/reearth/server/pkg/visualizer"
@@ -22,6 +25,8 @@ type CreateProjectParam struct {
 	Alias       *string
 	Archived    *bool
 	CoreSupport *bool
+	// TemplateID is a template scene which the scene of the project is cloned from.
+	TemplateID *id.SceneID
 }
 
 type UpdateProjectParam struct {
@@ -46,14 +51,22 @@ type UpdateProjectParam struct {
 }
 
 type PublishProjectParam struct {
-	ID     id.ProjectID
-	Alias  *string
-	Status project.PublishmentStatus
+	ID          id.ProjectID
+	Alias       *string
+	Status      project.PublishmentStatus
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+}
+
+type ImportProjectParam struct {
+	WorkspaceID accountdomain.WorkspaceID
+	File        *file.File
 }
 
 var (
 	ErrProjectAliasIsNotSet    error = errors.New("project alias is not set")
 	ErrProjectAliasAlreadyUsed error = errors.New("project alias is already used by another project")
+	ErrInvalidProjectPackage   error = errors.New("invalid project package")
 )
 
 type Project interface {
@@ -62,6 +75,12 @@ type Project interface {
 	Create(context.Context, CreateProjectParam, *usecase.Operator) (*project.Project, error)
 	Update(context.Context, UpdateProjectParam, *usecase.Operator) (*project.Project, error)
 	Publish(context.Context, PublishProjectParam, *usecase.Operator) (*project.Project, error)
+	PublishedVersions(context.Context, id.ProjectID, *usecase.Operator) ([]PublishedVersion, error)
+	RollbackPublish(context.Context, id.ProjectID, string, *usecase.Operator) (*project.Project, error)
 	CheckAlias(context.Context, string) (bool, error)
 	Delete(context.Context, id.ProjectID, *usecase.Operator) error
+	Export(context.Context, id.ProjectID, io.Writer, *usecase.Operator) error
+	ExportProject(context.Context, id.ProjectID, *usecase.Operator) (string, error)
+	ReadExportProject(context.Context, string, *usecase.Operator) (io.ReadCloser, error)
+	ImportProject(context.Context, ImportProjectParam, *usecase.Operator) (*project.Project, error)
 }
@@ -2,10 +2,28 @@ package interfaces
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/url"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+)
+
+var (
+	ErrNotPublished          = errors.New("not published")
+	ErrPublishScheduleInPast = errors.New("publish schedule is in the past")
+	ErrViewTokenRequired     = errors.New("view token is required")
+	ErrInvalidViewToken      = errors.New("invalid view token")
+	ErrViewTokenDisabled     = errors.New("view tokens are disabled")
 )
 
+// PublishedVersion is an immutable snapshot of the published data kept on every publish.
+type PublishedVersion struct {
+	Version     string
+	PublishedAt time.Time
+}
+
 type HasPublicMeta interface {
 	PublicTitle() string
 	PublicDescription() string
@@ -42,4 +60,8 @@ type Published interface {
 	Metadata(context.Context, string) (ProjectPublishedMetadata, error)
 	Data(context.Context, string) (io.Reader, error)
 	Index(context.Context, string, *url.URL) (string, error)
+	// Authorize checks the view token given to the published story.
+	// It reports true when the token is valid so that basic auth can be skipped.
+	Authorize(context.Context, string, string) (bool, error)
+	Export(context.Context, string, io.Writer, *usecase.Operator) error
 }
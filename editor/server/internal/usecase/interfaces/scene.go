@@ -7,6 +7,7 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain"
 )
 
 var (
@@ -14,11 +15,15 @@ var (
 	ErrPluginNotInstalled        error = errors.New("plugin not installed")
 	ErrCannotUpgradeToPlugin     error = errors.New("cannot upgrade to such plugin")
 	ErrExtensionTypeMustBeWidget error = errors.New("extension type must be widget")
+	ErrNotTemplateScene          error = errors.New("scene is not a template")
 )
 
 type Scene interface {
 	Fetch(context.Context, []id.SceneID, *usecase.Operator) ([]*scene.Scene, error)
 	FindByProject(context.Context, id.ProjectID, *usecase.Operator) (*scene.Scene, error)
+	// FindTemplates returns the scenes of the workspace which new projects can be created from.
+	FindTemplates(context.Context, accountdomain.WorkspaceID, *usecase.Operator) (scene.List, error)
+	SetTemplate(context.Context, id.SceneID, bool, *usecase.Operator) (*scene.Scene, error)
 	Create(context.Context, id.ProjectID, *usecase.Operator) (*scene.Scene, error)
 	AddWidget(context.Context, id.SceneID, id.PluginID, id.PluginExtensionID, *usecase.Operator) (*scene.Scene, *scene.Widget, error)
 	UpdateWidget(context.Context, UpdateWidgetParam, *usecase.Operator) (*scene.Scene, *scene.Widget, error)
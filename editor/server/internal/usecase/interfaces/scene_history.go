@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/history"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+var (
+	ErrNothingToUndo   = errors.New("nothing to undo")
+	ErrNothingToRedo   = errors.New("nothing to redo")
+	ErrHistoryConflict = errors.New("the scene has been changed since the operation")
+	ErrVersionConflict = errors.New("the entity has been changed by someone else")
+)
+
+type SceneHistory interface {
+	Fetch(context.Context, id.SceneID, *usecasex.Pagination, *usecase.Operator) ([]*history.History, *usecasex.PageInfo, error)
+	Undo(context.Context, id.SceneID, *usecase.Operator) (*history.History, error)
+	Redo(context.Context, id.SceneID, *usecase.Operator) (*history.History, error)
+	// Begin starts recording changes made through the repositories with the context.
+	// The changes are saved as history of each scene when the transaction they are made in is committed.
+	Begin(ctx context.Context, operation string, operator *usecase.Operator) context.Context
+	// Commit saves the changes made outside of transactions since Begin and publishes the changes of the operation.
+	Commit(context.Context) error
+	// Version returns the current version of the entity, or an empty string if it does not exist.
+	Version(context.Context, history.EntityType, string) (string, error)
+	// ExpectVersion makes the operation started by Begin fail with ErrVersionConflict
+	// if the entity is not at the version when it is written first.
+	ExpectVersion(ctx context.Context, t history.EntityType, id string, version string)
+}
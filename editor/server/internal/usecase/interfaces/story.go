@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/reearth/reearth/server/internal/usecase"
 	"github.com/reearth/reearth/server/pkg/id"
@@ -42,15 +43,27 @@ type MoveStoryInput struct {
 	Index   int
 }
 
+type DuplicateStoryInput struct {
+	SceneID id.SceneID
+	StoryID id.StoryID
+}
+
+type CreateStoryViewTokenInput struct {
+	StoryID   id.StoryID
+	ExpiresAt time.Time
+}
+
 type RemoveStoryInput struct {
 	SceneID id.SceneID
 	StoryID id.StoryID
 }
 
 type PublishStoryInput struct {
-	ID     id.StoryID
-	Alias  *string
-	Status storytelling.PublishmentStatus
+	ID          id.StoryID
+	Alias       *string
+	Status      storytelling.PublishmentStatus
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
 }
 
 type CreatePageParam struct {
@@ -126,6 +139,7 @@ var (
 	ErrBlockNotFound                 error = rerror.NewE(i18n.T("block not found"))
 	ErrPageSwipeableMismatch         error = rerror.NewE(i18n.T("page swipeable mismatch"))
 	ErrExtensionTypeMustBeStoryBlock error = errors.New("extension type must be storyBlock")
+	ErrStoryAliasIsNotSet            error = errors.New("story alias is not set")
 )
 
 type Storytelling interface {
@@ -135,7 +149,11 @@ type Storytelling interface {
 	Update(context.Context, UpdateStoryInput, *usecase.Operator) (*storytelling.Story, error)
 	Remove(context.Context, RemoveStoryInput, *usecase.Operator) (*id.StoryID, error)
 	Move(context.Context, MoveStoryInput, *usecase.Operator) (*id.StoryID, int, error)
+	Duplicate(context.Context, DuplicateStoryInput, *usecase.Operator) (*storytelling.Story, error)
 	Publish(context.Context, PublishStoryInput, *usecase.Operator) (*storytelling.Story, error)
+	PublishedVersions(context.Context, id.StoryID, *usecase.Operator) ([]PublishedVersion, error)
+	RollbackPublish(context.Context, id.StoryID, string, *usecase.Operator) (*storytelling.Story, error)
+	CreateViewToken(context.Context, CreateStoryViewTokenInput, *usecase.Operator) (string, error)
 
 	CreatePage(context.Context, CreatePageParam, *usecase.Operator) (*storytelling.Story, *storytelling.Page, error)
 	UpdatePage(context.Context, UpdatePageParam, *usecase.Operator) (*storytelling.Story, *storytelling.Page, error)
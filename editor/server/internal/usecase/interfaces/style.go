@@ -20,6 +20,11 @@ type UpdateStyleInput struct {
 	Value   *scene.StyleValue
 }
 
+type ValidateStyleInput struct {
+	Value   *scene.StyleValue
+	LayerID *id.NLSLayerID
+}
+
 type Style interface {
 	Fetch(context.Context, id.StyleIDList, *usecase.Operator) (*scene.StyleList, error)
 	FetchByScene(context.Context, id.SceneID, *usecase.Operator) (*scene.StyleList, error)
@@ -27,4 +32,5 @@ type Style interface {
 	UpdateStyle(context.Context, UpdateStyleInput, *usecase.Operator) (*scene.Style, error)
 	RemoveStyle(context.Context, id.StyleID, *usecase.Operator) (id.StyleID, error)
 	DuplicateStyle(context.Context, id.StyleID, *usecase.Operator) (*scene.Style, error)
+	ValidateStyle(context.Context, ValidateStyleInput, *usecase.Operator) (scene.StyleErrors, error)
 }
@@ -0,0 +1,45 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/stylelib"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+var ErrStyleNotLinked = errors.New("style is not linked to a library style")
+
+type PublishStyleInput struct {
+	StyleID id.StyleID
+	// LibraryStyleID publishes the style as a new version of an existing library style.
+	LibraryStyleID *id.LibraryStyleID
+	// WorkspaceID is the workspace of a new library style. It defaults to the workspace of the scene.
+	WorkspaceID *accountdomain.WorkspaceID
+	Name        *string
+}
+
+type ImportStyleInput struct {
+	LibraryStyleID id.LibraryStyleID
+	SceneID        id.SceneID
+	// Version defaults to the latest version.
+	Version *int
+}
+
+type SyncStyleInput struct {
+	StyleID id.StyleID
+	// Version defaults to the latest version.
+	Version *int
+}
+
+type StyleLibrary interface {
+	Fetch(context.Context, id.LibraryStyleIDList, *usecase.Operator) ([]*stylelib.Style, error)
+	FindByWorkspace(context.Context, accountdomain.WorkspaceID, *usecase.Operator) ([]*stylelib.Style, error)
+	Publish(context.Context, PublishStyleInput, *usecase.Operator) (*stylelib.Style, *scene.Style, error)
+	Import(context.Context, ImportStyleInput, *usecase.Operator) (*scene.Style, error)
+	Sync(context.Context, SyncStyleInput, *usecase.Operator) (*scene.Style, error)
+	Remove(context.Context, id.LibraryStyleID, *usecase.Operator) (id.LibraryStyleID, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/usage"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type WorkspaceUsage interface {
+	// Find returns the cached usage of the workspace, and aggregates it again when the cache is outdated.
+	Find(context.Context, accountdomain.WorkspaceID, *usecase.Operator) (*usage.Usage, error)
+	ExportCSV(context.Context, accountdomain.WorkspaceID, io.Writer, *usecase.Operator) error
+}
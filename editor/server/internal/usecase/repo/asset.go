@@ -12,6 +12,8 @@ import (
 type AssetFilter struct {
 	Sort       *asset.SortType
 	Keyword    *string
+	Folder     *string // empty string matches the assets in the root folder
+	Tag        *string
 	Pagination *usecasex.Pagination
 }
 
@@ -21,6 +23,8 @@ type Asset interface {
 	FindByID(context.Context, id.AssetID) (*asset.Asset, error)
 	FindByIDs(context.Context, id.AssetIDList) ([]*asset.Asset, error)
 	TotalSizeByWorkspace(context.Context, accountdomain.WorkspaceID) (int64, error)
+	FindFolders(context.Context, accountdomain.WorkspaceID) ([]string, error)
+	FindTags(context.Context, accountdomain.WorkspaceID) ([]string, error)
 	Save(context.Context, *asset.Asset) error
 	Remove(context.Context, id.AssetID) error
 }
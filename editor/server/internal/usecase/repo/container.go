@@ -25,27 +25,32 @@ type Container struct {
 	Layer          Layer
 	NLSLayer       NLSLayer
 	Style          Style
+	StyleLibrary   StyleLibrary
+	PluginKey      PluginKey
 	Lock           Lock
 	Plugin         Plugin
 	Project        Project
 	PropertySchema PropertySchema
 	Property       Property
 	Scene          Scene
+	SceneHistory   SceneHistory
 	SceneLock      SceneLock
 	Tag            Tag
 	Workspace      accountrepo.Workspace
 	User           accountrepo.User
 	Policy         Policy
 	Storytelling   Storytelling
+	WorkspaceUsage WorkspaceUsage
 	Transaction    usecasex.Transaction
 	Extensions     []plugin.ID
 }
 
+// AccountRepos returns the repos shared with the account package.
+// Policies are not included because they are enforced by the interactors of this package with Container.Policy.
 func (c *Container) AccountRepos() *accountrepo.Container {
 	return &accountrepo.Container{
-		Workspace: c.Workspace,
-		User:      c.User,
-		// TODO: Policy: c.Policy,
+		Workspace:   c.Workspace,
+		User:        c.User,
 		Transaction: c.Transaction,
 	}
 }
@@ -63,6 +68,8 @@ func (c *Container) Filtered(workspace WorkspaceFilter, scene SceneFilter) *Cont
 		Layer:          c.Layer.Filtered(scene),
 		NLSLayer:       c.NLSLayer.Filtered(scene),
 		Style:          c.Style.Filtered(scene),
+		StyleLibrary:   c.StyleLibrary.Filtered(workspace),
+		PluginKey:      c.PluginKey.Filtered(workspace),
 		Lock:           c.Lock,
 		Plugin:         c.Plugin.Filtered(scene),
 		Policy:         c.Policy,
@@ -71,11 +78,13 @@ func (c *Container) Filtered(workspace WorkspaceFilter, scene SceneFilter) *Cont
 		PropertySchema: c.PropertySchema.Filtered(scene),
 		Property:       c.Property.Filtered(scene),
 		Scene:          c.Scene.Filtered(workspace),
+		SceneHistory:   c.SceneHistory.Filtered(scene),
 		SceneLock:      c.SceneLock,
 		Tag:            c.Tag.Filtered(scene),
 		Transaction:    c.Transaction,
 		User:           c.User,
 		Workspace:      c.Workspace,
+		WorkspaceUsage: c.WorkspaceUsage.Filtered(workspace),
 		Extensions:     c.Extensions,
 	}
 }
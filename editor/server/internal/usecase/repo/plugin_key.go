@@ -0,0 +1,17 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/pluginkey"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type PluginKey interface {
+	Filtered(WorkspaceFilter) PluginKey
+	FindByID(context.Context, id.PluginKeyID) (*pluginkey.Key, error)
+	FindByWorkspace(context.Context, accountdomain.WorkspaceID) (pluginkey.List, error)
+	Save(context.Context, *pluginkey.Key) error
+	Remove(context.Context, id.PluginKeyID) error
+}
@@ -0,0 +1,20 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene/history"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type SceneHistory interface {
+	Filtered(SceneFilter) SceneHistory
+	FindByID(context.Context, id.HistoryID) (*history.History, error)
+	FindByScene(context.Context, id.SceneID, *usecasex.Pagination) ([]*history.History, *usecasex.PageInfo, error)
+	FindLatestApplied(context.Context, id.SceneID) (*history.History, error)
+	FindEarliestUndone(context.Context, id.SceneID) (*history.History, error)
+	Save(context.Context, *history.History) error
+	RemoveUndone(context.Context, id.SceneID) error
+	RemoveByScene(context.Context, id.SceneID) error
+}
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/storytelling"
@@ -13,6 +14,8 @@ type Storytelling interface {
 	FindByIDs(context.Context, id.StoryIDList) (*storytelling.StoryList, error)
 	FindByScene(context.Context, id.SceneID) (*storytelling.StoryList, error)
 	FindByPublicName(ctx context.Context, alias string) (*storytelling.Story, error)
+	FindDuePublishSchedules(context.Context, time.Time) (*storytelling.StoryList, error)
+	CountPublicByScenes(context.Context, id.SceneIDList) (int, error)
 	Save(context.Context, storytelling.Story) error
 	SaveAll(context.Context, storytelling.StoryList) error
 	Remove(context.Context, id.StoryID) error
@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/stylelib"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type StyleLibrary interface {
+	Filtered(WorkspaceFilter) StyleLibrary
+	FindByID(context.Context, id.LibraryStyleID) (*stylelib.Style, error)
+	FindByIDs(context.Context, id.LibraryStyleIDList) ([]*stylelib.Style, error)
+	FindByWorkspace(context.Context, accountdomain.WorkspaceID) ([]*stylelib.Style, error)
+	Save(context.Context, *stylelib.Style) error
+	Remove(context.Context, id.LibraryStyleID) error
+}
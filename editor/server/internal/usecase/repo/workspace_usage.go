@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/usage"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type WorkspaceUsage interface {
+	Filtered(WorkspaceFilter) WorkspaceUsage
+	FindByWorkspace(context.Context, accountdomain.WorkspaceID) (*usage.Usage, error)
+	FindUpdatedBefore(context.Context, time.Time) ([]*usage.Usage, error)
+	Save(context.Context, *usage.Usage) error
+}
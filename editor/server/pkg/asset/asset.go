@@ -2,13 +2,17 @@ package asset
 
 import (
 	"errors"
+	"strings"
 	"time"
+
+	"github.com/samber/lo"
 )
 
 var (
 	ErrEmptyWorkspaceID = errors.New("require workspace id")
 	ErrEmptyURL         = errors.New("require valid url")
 	ErrEmptySize        = errors.New("file size cannot be zero")
+	ErrInvalidFolder    = errors.New("invalid folder")
 )
 
 type Asset struct {
@@ -19,6 +23,9 @@ type Asset struct {
 	size        int64  // file size
 	url         string
 	contentType string
+	folder      string // slash-separated path of the folder, or empty for the root
+	tags        []string
+	metadata    *Metadata
 }
 
 func (a *Asset) ID() ID {
@@ -51,3 +58,64 @@ func (a *Asset) CreatedAt() time.Time {
 	}
 	return a.id.Timestamp()
 }
+
+func (a *Asset) Folder() string {
+	return a.folder
+}
+
+func (a *Asset) Tags() []string {
+	return append([]string{}, a.tags...)
+}
+
+func (a *Asset) Metadata() *Metadata {
+	return a.metadata.Clone()
+}
+
+func (a *Asset) SetName(name string) {
+	a.name = name
+}
+
+func (a *Asset) SetFolder(folder string) error {
+	f, err := NormalizeFolder(folder)
+	if err != nil {
+		return err
+	}
+	a.folder = f
+	return nil
+}
+
+func (a *Asset) SetTags(tags []string) {
+	a.tags = NormalizeTags(tags)
+}
+
+func (a *Asset) SetMetadata(m *Metadata) {
+	a.metadata = m.Clone()
+}
+
+// NormalizeFolder trims the slashes and the spaces of each segment of the folder path such as "maps/2024".
+func NormalizeFolder(folder string) (string, error) {
+	var segments []string
+	for _, s := range strings.Split(folder, "/") {
+		s = strings.TrimSpace(s)
+		if s == "" || s == "." {
+			continue
+		}
+		if s == ".." {
+			return "", ErrInvalidFolder
+		}
+		segments = append(segments, s)
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// NormalizeTags returns the trimmed tags without empty and duplicated ones.
+func NormalizeTags(tags []string) []string {
+	res := lo.Uniq(lo.FilterMap(tags, func(t string, _ int) (string, bool) {
+		t = strings.TrimSpace(t)
+		return t, t != ""
+	}))
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
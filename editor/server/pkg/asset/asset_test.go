@@ -63,3 +63,21 @@ func TestAsset(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeFolder(t *testing.T) {
+	f, err := NormalizeFolder(" /maps// 2024 /./")
+	assert.NoError(t, err)
+	assert.Equal(t, "maps/2024", f)
+
+	f, err = NormalizeFolder("")
+	assert.NoError(t, err)
+	assert.Equal(t, "", f)
+
+	_, err = NormalizeFolder("maps/../x")
+	assert.Equal(t, ErrInvalidFolder, err)
+}
+
+func TestNormalizeTags(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, NormalizeTags([]string{" a", "b", "", "a "}))
+	assert.Nil(t, NormalizeTags([]string{" "}))
+}
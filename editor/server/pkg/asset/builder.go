@@ -25,6 +25,12 @@ func (b *Builder) Build() (*Asset, error) {
 	if b.a.size <= 0 {
 		return nil, ErrEmptySize
 	}
+	folder, err := NormalizeFolder(b.a.folder)
+	if err != nil {
+		return nil, err
+	}
+	b.a.folder = folder
+	b.a.tags = NormalizeTags(b.a.tags)
 	if b.a.createdAt.IsZero() {
 		b.a.createdAt = b.a.CreatedAt()
 	}
@@ -78,3 +84,18 @@ func (b *Builder) CreatedAt(createdAt time.Time) *Builder {
 	b.a.createdAt = createdAt
 	return b
 }
+
+func (b *Builder) Folder(folder string) *Builder {
+	b.a.folder = folder
+	return b
+}
+
+func (b *Builder) Tags(tags []string) *Builder {
+	b.a.tags = tags
+	return b
+}
+
+func (b *Builder) Metadata(metadata *Metadata) *Builder {
+	b.a.metadata = metadata.Clone()
+	return b
+}
@@ -0,0 +1,186 @@
+package asset
+
+import (
+	"encoding/json"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"path"
+	"strings"
+)
+
+// Metadata is extracted from the content of the asset file.
+type Metadata struct {
+	// dimensions of images
+	Width  int
+	Height int
+	// number of features of GeoJSON and entities of CZML
+	FeatureCount int
+	BBox         *BBox
+}
+
+// BBox is [west, south, east, north] in degrees.
+type BBox [4]float64
+
+func (m *Metadata) Clone() *Metadata {
+	if m == nil {
+		return nil
+	}
+	res := *m
+	if m.BBox != nil {
+		b := *m.BBox
+		res.BBox = &b
+	}
+	return &res
+}
+
+// ExtractMetadata extracts the metadata from the file content, which is detected by the extension of the file name.
+// It returns nil when the file type is not supported.
+func ExtractMetadata(name string, r io.Reader) (*Metadata, error) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".png", ".jpg", ".jpeg", ".gif":
+		c, _, err := image.DecodeConfig(r)
+		if err != nil {
+			return nil, err
+		}
+		return &Metadata{Width: c.Width, Height: c.Height}, nil
+	case ".geojson", ".json":
+		return extractGeoJSONMetadata(r)
+	case ".czml":
+		return extractCZMLMetadata(r)
+	}
+	return nil, nil
+}
+
+func extractGeoJSONMetadata(r io.Reader) (*Metadata, error) {
+	var d struct {
+		Type     string            `json:"type"`
+		Features []json.RawMessage `json:"features"`
+	}
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &d); err != nil {
+		// not a GeoJSON object such as a JSON array
+		return nil, nil
+	}
+
+	m := &Metadata{}
+	switch d.Type {
+	case "FeatureCollection":
+		m.FeatureCount = len(d.Features)
+	case "Feature":
+		m.FeatureCount = 1
+	default:
+		return nil, nil
+	}
+
+	var geo any
+	if err := json.Unmarshal(raw, &geo); err != nil {
+		return nil, err
+	}
+	var b bboxBuilder
+	b.addCoordinates(geo)
+	m.BBox = b.bbox()
+	return m, nil
+}
+
+func extractCZMLMetadata(r io.Reader) (*Metadata, error) {
+	var packets []struct {
+		ID       string `json:"id"`
+		Position *struct {
+			CartographicDegrees []float64 `json:"cartographicDegrees"`
+		} `json:"position"`
+	}
+	if err := json.NewDecoder(r).Decode(&packets); err != nil {
+		return nil, err
+	}
+
+	m := &Metadata{}
+	var b bboxBuilder
+	for _, p := range packets {
+		if p.ID == "document" {
+			continue
+		}
+		m.FeatureCount++
+		if p.Position == nil {
+			continue
+		}
+
+		d := p.Position.CartographicDegrees
+		// [longitude, latitude, height] or time-tagged [time, longitude, latitude, height, ...]
+		stride, offset := 3, 0
+		if len(d) != 3 && len(d)%4 == 0 {
+			stride, offset = 4, 1
+		}
+		for i := offset; i+1 < len(d); i += stride {
+			b.add(d[i], d[i+1])
+		}
+	}
+	m.BBox = b.bbox()
+	return m, nil
+}
+
+type bboxBuilder struct {
+	b  BBox
+	ok bool
+}
+
+// addCoordinates adds the positions found in the "coordinates" of the GeoJSON objects.
+func (b *bboxBuilder) addCoordinates(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, c := range v {
+			if k == "coordinates" {
+				b.addPositions(c)
+			} else {
+				b.addCoordinates(c)
+			}
+		}
+	case []any:
+		for _, c := range v {
+			b.addCoordinates(c)
+		}
+	}
+}
+
+func (b *bboxBuilder) addPositions(v any) {
+	p, ok := v.([]any)
+	if !ok {
+		return
+	}
+	if len(p) >= 2 {
+		lng, ok1 := p[0].(float64)
+		lat, ok2 := p[1].(float64)
+		if ok1 && ok2 {
+			b.add(lng, lat)
+			return
+		}
+	}
+	for _, c := range p {
+		b.addPositions(c)
+	}
+}
+
+func (b *bboxBuilder) add(lng, lat float64) {
+	if !b.ok {
+		b.b = BBox{lng, lat, lng, lat}
+		b.ok = true
+		return
+	}
+	b.b[0] = min(b.b[0], lng)
+	b.b[1] = min(b.b[1], lat)
+	b.b[2] = max(b.b[2], lng)
+	b.b[3] = max(b.b[3], lat)
+}
+
+func (b *bboxBuilder) bbox() *BBox {
+	if !b.ok {
+		return nil
+	}
+	res := b.b
+	return &res
+}
@@ -0,0 +1,87 @@
+package asset
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractMetadata(t *testing.T) {
+	var img bytes.Buffer
+	assert.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 3, 2))))
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    *Metadata
+		wantErr bool
+	}{
+		{
+			name:    "image",
+			file:    "a.PNG",
+			content: img.String(),
+			want:    &Metadata{Width: 3, Height: 2},
+		},
+		{
+			name:    "broken image",
+			file:    "a.png",
+			content: "aaa",
+			wantErr: true,
+		},
+		{
+			name: "geojson feature collection",
+			file: "a.geojson",
+			content: `{"type":"FeatureCollection","features":[
+				{"type":"Feature","geometry":{"type":"Point","coordinates":[139.1,35.5]}},
+				{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[138,34],[140,34],[140,36.5],[138,34]]]}}
+			]}`,
+			want: &Metadata{FeatureCount: 2, BBox: &BBox{138, 34, 140, 36.5}},
+		},
+		{
+			name:    "geojson feature without geometry",
+			file:    "a.json",
+			content: `{"type":"Feature","geometry":null}`,
+			want:    &Metadata{FeatureCount: 1},
+		},
+		{
+			name:    "json which is not geojson",
+			file:    "a.json",
+			content: `[1,2]`,
+		},
+		{
+			name: "czml",
+			file: "a.czml",
+			content: `[
+				{"id":"document","version":"1.0"},
+				{"id":"a","position":{"cartographicDegrees":[139,35,0]}},
+				{"id":"b","position":{"cartographicDegrees":[0,140,36,0,10,141,37,0]}},
+				{"id":"c"}
+			]`,
+			want: &Metadata{FeatureCount: 3, BBox: &BBox{139, 35, 141, 37}},
+		},
+		{
+			name:    "unsupported",
+			file:    "a.txt",
+			content: "aaa",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ExtractMetadata(tt.file, strings.NewReader(tt.content))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
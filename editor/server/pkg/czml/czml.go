@@ -1,12 +1,14 @@
 package czml
 
 type Feature struct {
-	Id       string    `json:"id"`
-	Name     string    `json:"name"`
-	Polygon  *Polygon  `json:"polygon,omitempty"`
-	Polyline *Polyline `json:"polyline,omitempty"`
-	Position *Position `json:"position,omitempty"`
-	Point    *Point    `json:"point,omitempty"`
+	Id          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Polygon     *Polygon       `json:"polygon,omitempty"`
+	Polyline    *Polyline      `json:"polyline,omitempty"`
+	Position    *Position      `json:"position,omitempty"`
+	Point       *Point         `json:"point,omitempty"`
+	Properties  map[string]any `json:"properties,omitempty"`
 }
 type Polyline struct {
 	Positions Position  `json:"positions"`
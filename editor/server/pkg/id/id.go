@@ -26,6 +26,9 @@ type Style struct{}
 type Infobox struct{}
 type InfoboxBlock struct{}
 type Feature struct{}
+type History struct{}
+type LibraryStyle struct{}
+type PluginKey struct{}
 
 func (Asset) Type() string               { return "asset" }
 func (AuthRequest) Type() string         { return "authRequest" }
@@ -51,6 +54,9 @@ func (Style) Type() string               { return "style" }
 func (Infobox) Type() string             { return "infobox" }
 func (InfoboxBlock) Type() string        { return "infoboxBlock" }
 func (Feature) Type() string             { return "feature" }
+func (History) Type() string             { return "history" }
+func (LibraryStyle) Type() string        { return "libraryStyle" }
+func (PluginKey) Type() string           { return "pluginKey" }
 
 type AssetID = idx.ID[Asset]
 type AuthRequestID = idx.ID[AuthRequest]
@@ -73,6 +79,9 @@ type StyleID = idx.ID[Style]
 type InfoboxID = idx.ID[Infobox]
 type InfoboxBlockID = idx.ID[InfoboxBlock]
 type FeatureID = idx.ID[Feature]
+type HistoryID = idx.ID[History]
+type LibraryStyleID = idx.ID[LibraryStyle]
+type PluginKeyID = idx.ID[PluginKey]
 
 type PluginExtensionID = idx.StringID[PluginExtension]
 type PropertySchemaGroupID = idx.StringID[PropertySchemaGroup]
@@ -97,6 +106,9 @@ var NewStyleID = idx.New[Style]
 var NewInfoboxID = idx.New[Infobox]
 var NewInfoboxBlockID = idx.New[InfoboxBlock]
 var NewFeatureID = idx.New[Feature]
+var NewHistoryID = idx.New[History]
+var NewLibraryStyleID = idx.New[LibraryStyle]
+var NewPluginKeyID = idx.New[PluginKey]
 
 var MustAssetID = idx.Must[Asset]
 var MustAuthRequestID = idx.Must[AuthRequest]
@@ -119,6 +131,9 @@ var MustStyleID = idx.Must[Style]
 var MustInfoboxID = idx.Must[Infobox]
 var MustInfoboxBlockID = idx.Must[InfoboxBlock]
 var MustFeatureID = idx.Must[Feature]
+var MustHistoryID = idx.Must[History]
+var MustLibraryStyleID = idx.Must[LibraryStyle]
+var MustPluginKeyID = idx.Must[PluginKey]
 
 var AssetIDFrom = idx.From[Asset]
 var AuthRequestIDFrom = idx.From[AuthRequest]
@@ -141,6 +156,9 @@ var StyleIDFrom = idx.From[Style]
 var InfoboxIDFrom = idx.From[Infobox]
 var InfoboxBlockIDFrom = idx.From[InfoboxBlock]
 var FeatureIDFrom = idx.From[Feature]
+var HistoryIDFrom = idx.From[History]
+var LibraryStyleIDFrom = idx.From[LibraryStyle]
+var PluginKeyIDFrom = idx.From[PluginKey]
 
 var AssetIDFromRef = idx.FromRef[Asset]
 var AuthRequestIDFromRef = idx.FromRef[AuthRequest]
@@ -163,6 +181,9 @@ var StyleIDFromRef = idx.FromRef[Style]
 var InfoboxIDFromRef = idx.FromRef[Infobox]
 var InfoboxBlockIDFromRef = idx.FromRef[InfoboxBlock]
 var FeatureIDFromRef = idx.FromRef[Feature]
+var HistoryIDFromRef = idx.FromRef[History]
+var LibraryStyleIDFromRef = idx.FromRef[LibraryStyle]
+var PluginKeyIDFromRef = idx.FromRef[PluginKey]
 
 var PluginExtensionIDFromRef = idx.StringIDFromRef[PluginExtension]
 var PropertyFieldIDFromRef = idx.StringIDFromRef[PropertyField]
@@ -189,6 +210,9 @@ type StyleIDList = idx.List[Style]
 type InfoboxIDList = idx.List[Infobox]
 type InfoboxBlockIDList = idx.List[InfoboxBlock]
 type FeatureIDList = idx.List[Feature]
+type HistoryIDList = idx.List[History]
+type LibraryStyleIDList = idx.List[LibraryStyle]
+type PluginKeyIDList = idx.List[PluginKey]
 
 var AssetIDListFrom = idx.ListFrom[Asset]
 var AuthRequestIDListFrom = idx.ListFrom[AuthRequest]
@@ -211,6 +235,9 @@ var StyleIDListFrom = idx.ListFrom[Style]
 var InfoboxIDListFrom = idx.ListFrom[Infobox]
 var InfoboxBlockIDListFrom = idx.ListFrom[InfoboxBlock]
 var FeatureIDListFrom = idx.ListFrom[Feature]
+var HistoryIDListFrom = idx.ListFrom[History]
+var LibraryStyleIDListFrom = idx.ListFrom[LibraryStyle]
+var PluginKeyIDListFrom = idx.ListFrom[PluginKey]
 
 type AssetIDSet = idx.Set[Asset]
 type AuthRequestIDSet = idx.Set[AuthRequest]
@@ -233,6 +260,9 @@ type StyleIDSet = idx.Set[Style]
 type InfoboxIDSet = idx.Set[Infobox]
 type InfoboxBlockIDSet = idx.Set[InfoboxBlock]
 type FeatureIDSet = idx.Set[Feature]
+type HistoryIDSet = idx.Set[History]
+type LibraryStyleIDSet = idx.Set[LibraryStyle]
+type PluginKeyIDSet = idx.Set[PluginKey]
 
 var NewAssetIDSet = idx.NewSet[Asset]
 var NewAuthRequestIDSet = idx.NewSet[AuthRequest]
@@ -255,6 +285,9 @@ var NewStyleIDSet = idx.NewSet[Style]
 var NewInfoboxIDSet = idx.NewSet[InfoboxBlock]
 var NewInfoboxBlockIDSet = idx.NewSet[InfoboxBlock]
 var NewFeatureIDSet = idx.NewSet[Feature]
+var NewHistoryIDSet = idx.NewSet[History]
+var NewLibraryStyleIDSet = idx.NewSet[LibraryStyle]
+var NewPluginKeyIDSet = idx.NewSet[PluginKey]
 
 // Storytelling ids
 
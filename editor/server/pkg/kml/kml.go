@@ -11,11 +11,20 @@ type Collection struct {
 	Name       string       `xml:"name"`
 }
 type Placemark struct {
-	Point    Point      `xml:"Point"`
-	Polygon  Polygon    `xml:"Polygon"`
-	Polyline LineString `xml:"LineString"`
-	Name     string     `xml:"name"`
-	StyleUrl string     `xml:"styleUrl"`
+	Point        Point        `xml:"Point"`
+	Polygon      Polygon      `xml:"Polygon"`
+	Polyline     LineString   `xml:"LineString"`
+	Name         string       `xml:"name"`
+	Description  string       `xml:"description"`
+	ExtendedData ExtendedData `xml:"ExtendedData"`
+	StyleUrl     string       `xml:"styleUrl"`
+}
+type ExtendedData struct {
+	Data []Data `xml:"Data"`
+}
+type Data struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
 }
 type BoundaryIs struct {
 	LinearRing LinearRing `xml:"LinearRing"`
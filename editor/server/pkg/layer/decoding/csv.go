@@ -0,0 +1,164 @@
+package decoding
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/property"
+)
+
+var (
+	ErrCSVNoCoordinates = errors.New("latitude and longitude columns are required")
+
+	csvLatColumns    = []string{"lat", "latitude"}
+	csvLngColumns    = []string{"lng", "lon", "long", "longitude"}
+	csvHeightColumns = []string{"height", "alt", "altitude"}
+	csvNameColumns   = []string{"name", "title"}
+)
+
+// CSVDecoder decodes a CSV file whose rows represent points.
+// The header row must contain latitude and longitude columns.
+type CSVDecoder struct {
+	reader  *csv.Reader
+	sceneId layer.SceneID
+}
+
+func NewCSVDecoder(r io.Reader, s layer.SceneID) *CSVDecoder {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return &CSVDecoder{
+		reader:  reader,
+		sceneId: s,
+	}
+}
+
+func (d *CSVDecoder) Decode() (Result, error) {
+	lg, err := layer.NewGroup().NewID().Scene(d.sceneId).Name("CSV").Build()
+	if err != nil {
+		return Result{}, err
+	}
+
+	header, err := d.reader.Read()
+	if err != nil {
+		return Result{}, errors.New("unable to parse file content")
+	}
+
+	latIndex := csvColumnIndex(header, csvLatColumns)
+	lngIndex := csvColumnIndex(header, csvLngColumns)
+	heightIndex := csvColumnIndex(header, csvHeightColumns)
+	nameIndex := csvColumnIndex(header, csvNameColumns)
+	if latIndex < 0 || lngIndex < 0 {
+		return Result{}, ErrCSVNoCoordinates
+	}
+
+	var layers layer.Map
+	var properties property.Map
+	var attributes map[layer.ID]map[string]any
+	for {
+		record, err := d.reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Result{}, errors.New("unable to parse file content")
+		}
+
+		lat, err := csvFloat(record, latIndex)
+		if err != nil {
+			return Result{}, err
+		}
+		lng, err := csvFloat(record, lngIndex)
+		if err != nil {
+			return Result{}, err
+		}
+		var height float64
+		if heightIndex >= 0 && heightIndex < len(record) && record[heightIndex] != "" {
+			if height, err = csvFloat(record, heightIndex); err != nil {
+				return Result{}, err
+			}
+		}
+
+		p, err := createProperty("Point", property.LatLngHeight{
+			Lat:    lat,
+			Lng:    lng,
+			Height: height,
+		}, d.sceneId, nil, "")
+		if err != nil {
+			return Result{}, err
+		}
+
+		layerName := "Point"
+		if nameIndex >= 0 && nameIndex < len(record) && record[nameIndex] != "" {
+			layerName = record[nameIndex]
+		}
+
+		ex := extensions["Point"]
+		li, err := layer.
+			NewItem().
+			NewID().
+			Name(layerName).
+			Scene(d.sceneId).
+			Property(p.IDRef()).
+			Extension(&ex).
+			Plugin(&layer.OfficialPluginID).
+			Build()
+		if err != nil {
+			return Result{}, err
+		}
+
+		var l layer.Layer = li
+		lg.Layers().AddLayer(l.ID(), -1)
+		layers = layers.Add(&l)
+		properties = properties.Add(p)
+		setAttributes(&attributes, l.ID(), csvAttributes(header, record, latIndex, lngIndex, heightIndex))
+	}
+
+	r, err := resultFrom(lg, layers, properties)
+	r.Attributes = attributes
+	return r, err
+}
+
+// csvAttributes returns the values of the columns other than coordinates.
+func csvAttributes(header, record []string, coordinateIndexes ...int) map[string]any {
+	res := map[string]any{}
+	for i, h := range header {
+		if i >= len(record) || slices.Contains(coordinateIndexes, i) {
+			continue
+		}
+		h = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+		if h == "" {
+			continue
+		}
+		res[h] = record[i]
+	}
+	return res
+}
+
+func csvColumnIndex(header []string, names []string) int {
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		for _, n := range names {
+			if h == n {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func csvFloat(record []string, i int) (float64, error) {
+	if i >= len(record) {
+		return 0, errors.New("unable to parse coordinates")
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(record[i]), 64)
+	if err != nil {
+		return 0, errors.New("unable to parse coordinates")
+	}
+	return f, nil
+}
@@ -0,0 +1,46 @@
+package decoding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Decoder = &CSVDecoder{}
+
+const csvmock = `Name,Latitude,Longitude,Height
+Tokyo,35.68,139.76,10
+Osaka,34.69,135.50,
+`
+
+func TestCSVDecoder_Decode(t *testing.T) {
+	s := layer.NewSceneID()
+	result, err := NewCSVDecoder(strings.NewReader(csvmock), s).Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(result.Layers))
+	assert.Equal(t, 2, len(result.Properties))
+
+	rootLayer := result.RootLayers().ToLayerGroupList()[0]
+	assert.Equal(t, "CSV", rootLayer.Name())
+	assert.Equal(t, 2, rootLayer.Layers().LayerCount())
+
+	l := result.Layers.Layer(rootLayer.Layers().LayerAt(0))
+	assert.Equal(t, "Tokyo", l.Name())
+	prop := result.Properties[*l.Property()]
+	f, _, _ := prop.Field(property.PointFieldBySchemaGroup(propertyItems, propertyFields["Point"]))
+	assert.Equal(t, property.LatLng{Lat: 35.68, Lng: 139.76}, f.Value().Value())
+	h, _, _ := prop.Field(property.PointFieldBySchemaGroup(propertyItems, "height"))
+	assert.Equal(t, 10.0, h.Value().Value())
+
+	l = result.Layers.Layer(rootLayer.Layers().LayerAt(1))
+	assert.Equal(t, "Osaka", l.Name())
+
+	_, err = NewCSVDecoder(strings.NewReader("name,x,y\na,1,2\n"), s).Decode()
+	assert.Equal(t, ErrCSVNoCoordinates, err)
+
+	_, err = NewCSVDecoder(strings.NewReader("lat,lng\na,1\n"), s).Decode()
+	assert.Error(t, err)
+}
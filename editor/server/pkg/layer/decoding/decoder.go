@@ -15,6 +15,9 @@ type Result struct {
 	Root       *layer.IDList
 	Layers     layer.Map
 	Properties property.Map
+	// Attributes are the properties of the source features, such as GeoJSON properties,
+	// CSV columns, KML extended data and DBF fields, keyed by the IDs of the decoded layers.
+	Attributes map[layer.ID]map[string]any
 }
 
 func (r Result) RootLayers() layer.List {
@@ -28,6 +31,7 @@ func (r Result) Merge(r2 Result) Result {
 		Root:       root,
 		Layers:     r.Layers.Merge(r2.Layers),
 		Properties: r.Properties.Merge(r2.Properties),
+		Attributes: mergeAttributes(r.Attributes, r2.Attributes),
 	}
 }
 
@@ -36,6 +40,7 @@ func (r Result) MergeInitializerResult(r2 layer.InitializerResult) Result {
 		Root:       r.Root.Clone().AppendLayers(r2.Root),
 		Layers:     r.Layers.Merge(r2.Layers),
 		Properties: r.Properties.Merge(r2.Properties),
+		Attributes: r.Attributes,
 	}
 }
 
@@ -57,3 +62,28 @@ func resultFrom(lg *layer.Group, layers layer.Map, properties property.Map) (r R
 	err = r.Validate()
 	return
 }
+
+func mergeAttributes(a, b map[layer.ID]map[string]any) map[layer.ID]map[string]any {
+	if a == nil && b == nil {
+		return nil
+	}
+	res := make(map[layer.ID]map[string]any, len(a)+len(b))
+	for k, v := range a {
+		res[k] = v
+	}
+	for k, v := range b {
+		res[k] = v
+	}
+	return res
+}
+
+// setAttributes stores the attributes of the layer in the map, which is allocated on demand.
+func setAttributes(m *map[layer.ID]map[string]any, lid layer.ID, attributes map[string]any) {
+	if len(attributes) == 0 {
+		return
+	}
+	if *m == nil {
+		*m = map[layer.ID]map[string]any{}
+	}
+	(*m)[lid] = attributes
+}
@@ -8,4 +8,5 @@ const (
 	LayerEncodingFormatGEOJSON LayerEncodingFormat = "geojson"
 	LayerEncodingFormatSHAPE   LayerEncodingFormat = "shape"
 	LayerEncodingFormatREEARTH LayerEncodingFormat = "reearth"
+	LayerEncodingFormatCSV     LayerEncodingFormat = "csv"
 )
@@ -16,10 +16,11 @@ type GeoStyle struct {
 	FillColor   string  `json:"fill"`
 }
 type GeoJSONDecoder struct {
-	reader    io.Reader
-	features  []*geojson.Feature
-	sceneId   layer.SceneID
-	groupName string
+	reader     io.Reader
+	features   []*geojson.Feature
+	sceneId    layer.SceneID
+	groupName  string
+	attributes map[layer.ID]map[string]any
 }
 
 func NewGeoJSONDecoder(r io.Reader, s layer.SceneID) *GeoJSONDecoder {
@@ -84,7 +85,7 @@ func (d *GeoJSONDecoder) Decode() (Result, error) {
 	for range d.features {
 		li, p, err := d.decodeLayer()
 		if errors.Is(err, io.EOF) {
-			return resultFrom(lg, layers, properties)
+			break
 		}
 		if err != nil {
 			return Result{}, err
@@ -101,7 +102,9 @@ func (d *GeoJSONDecoder) Decode() (Result, error) {
 		}
 	}
 
-	return resultFrom(lg, layers, properties)
+	r, err := resultFrom(lg, layers, properties)
+	r.Attributes = d.attributes
+	return r, err
 }
 
 func (d *GeoJSONDecoder) decodeLayer() (*layer.Item, *property.Property, error) {
@@ -121,6 +124,10 @@ func (d *GeoJSONDecoder) decodeLayer() (*layer.Item, *property.Property, error)
 		return nil, nil, io.EOF
 	}
 
+	if feat == nil || feat.Geometry == nil {
+		return nil, nil, errors.New("unable to parse file content")
+	}
+
 	switch feat.Geometry.Type {
 	case "Point":
 		var latlng property.LatLng
@@ -151,7 +158,7 @@ func (d *GeoJSONDecoder) decodeLayer() (*layer.Item, *property.Property, error)
 			if len(c) == 2 {
 				height = 0
 			} else if len(c) == 3 {
-				height = c[3]
+				height = c[2]
 			} else {
 				return nil, nil, errors.New("unable to parse coordinates")
 			}
@@ -187,7 +194,7 @@ func (d *GeoJSONDecoder) decodeLayer() (*layer.Item, *property.Property, error)
 				if len(c) == 2 {
 					height = 0
 				} else if len(c) == 3 {
-					height = c[3]
+					height = c[2]
 				} else {
 					return nil, nil, errors.New("unable to parse coordinates")
 				}
@@ -246,5 +253,6 @@ func (d *GeoJSONDecoder) decodeLayer() (*layer.Item, *property.Property, error)
 	if err != nil {
 		return nil, nil, err
 	}
+	setAttributes(&d.attributes, l.ID(), feat.Properties)
 	return l, p, nil
 }
@@ -14,9 +14,10 @@ import (
 )
 
 type KMLDecoder struct {
-	decoder *xml.Decoder
-	sceneId layer.SceneID
-	styles  map[string]kml.Style
+	decoder    *xml.Decoder
+	sceneId    layer.SceneID
+	styles     map[string]kml.Style
+	attributes map[layer.ID]map[string]any
 }
 
 func NewKMLDecoder(d *xml.Decoder, s layer.SceneID) *KMLDecoder {
@@ -235,6 +236,19 @@ func (d *KMLDecoder) decodePlacemark(p kml.Placemark) (*layer.Item, *property.Pr
 		layerName = p.Name
 	}
 
+	attributes := map[string]any{}
+	if p.Name != "" {
+		attributes["name"] = p.Name
+	}
+	if p.Description != "" {
+		attributes["description"] = p.Description
+	}
+	for _, data := range p.ExtendedData.Data {
+		if data.Name != "" {
+			attributes[data.Name] = data.Value
+		}
+	}
+
 	layerItem, err := layer.
 		NewItem().
 		NewID().
@@ -247,6 +261,7 @@ func (d *KMLDecoder) decodePlacemark(p kml.Placemark) (*layer.Item, *property.Pr
 	if err != nil {
 		return nil, nil, err
 	}
+	setAttributes(&d.attributes, layerItem.ID(), attributes)
 
 	return layerItem, prop, nil
 }
@@ -295,5 +310,7 @@ func (d *KMLDecoder) Decode() (Result, error) {
 		}
 	}
 
-	return resultFrom(lg, ll, pl)
+	r, err := resultFrom(lg, ll, pl)
+	r.Attributes = d.attributes
+	return r, err
 }
@@ -750,3 +750,24 @@ func TestKMLdecodePlacemark(t *testing.T) {
 //	}
 //
 //}
+
+func TestKMLDecoder_Attributes(t *testing.T) {
+	const data = `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Placemark>
+    <name>shelter</name>
+    <description>elementary school</description>
+    <ExtendedData>
+      <Data name="capacity"><value>120</value></Data>
+    </ExtendedData>
+    <Point><coordinates>139,35,0</coordinates></Point>
+  </Placemark>
+</kml>`
+
+	r, err := NewKMLDecoder(xml.NewDecoder(strings.NewReader(data)), layer.NewSceneID()).Decode()
+	assert.NoError(t, err)
+	assert.Len(t, r.Attributes, 1)
+	for _, a := range r.Attributes {
+		assert.Equal(t, map[string]any{"name": "shelter", "description": "elementary school", "capacity": "120"}, a)
+	}
+}
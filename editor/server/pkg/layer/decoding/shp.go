@@ -11,6 +11,12 @@ type ShapeReader interface {
 	Shape() (int, shp.Shape)
 	Err() error
 }
+
+// shapeAttributeReader is a ShapeReader which also reads the DBF table.
+type shapeAttributeReader interface {
+	Attribute(int) string
+	Fields() []shp.Field
+}
 type ShapeDecoder struct {
 	reader  ShapeReader
 	sceneId layer.SceneID
@@ -64,6 +70,7 @@ func (shd *ShapeDecoder) Decode() (Result, error) {
 	}
 	var properties property.Map
 	var layers layer.Map
+	var attributes map[layer.ID]map[string]any
 	for shd.reader.Next() {
 		_, shape := shd.reader.Shape()
 		var li *layer.Item
@@ -90,11 +97,29 @@ func (shd *ShapeDecoder) Decode() (Result, error) {
 			var l layer.Layer = li
 			lg.Layers().AddLayer(l.ID(), -1)
 			layers = layers.Add(&l)
+			setAttributes(&attributes, l.ID(), shd.attributes())
 		}
 		if p != nil {
 			properties = properties.Add(p)
 		}
 	}
 
-	return resultFrom(lg, layers, properties)
+	r, err := resultFrom(lg, layers, properties)
+	r.Attributes = attributes
+	return r, err
+}
+
+// attributes returns the DBF row of the shape that was last read, if the reader supports it.
+func (shd *ShapeDecoder) attributes() map[string]any {
+	ar, ok := shd.reader.(shapeAttributeReader)
+	if !ok {
+		return nil
+	}
+	res := map[string]any{}
+	for i, f := range ar.Fields() {
+		if name := f.String(); name != "" {
+			res[name] = ar.Attribute(i)
+		}
+	}
+	return res
 }
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/reearth/reearth/server/pkg/layer"
 	"github.com/reearth/reearth/server/pkg/shp"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
@@ -144,3 +145,18 @@ func getShapesFromFile(filename string, t *testing.T) (shapes []shp.Shape) {
 
 	return shapes
 }
+
+func TestShapeDecoder_Attributes(t *testing.T) {
+	f := lo.Must(os.Open("shapetest/shapes.zip"))
+	defer func() {
+		_ = f.Close()
+	}()
+	zr := lo.Must(shp.ReadZipFrom(f))
+
+	r, err := NewShapeDecoder(zr, layer.NewSceneID()).Decode()
+	assert.NoError(t, err)
+	assert.Len(t, r.Attributes, 3)
+	for _, a := range r.Attributes {
+		assert.Equal(t, map[string]any{"point_ID": ""}, a)
+	}
+}
@@ -38,8 +38,10 @@ func (e *CZMLEncoder) encodeSingleLayer(li *merging.SealedLayerItem) (*czml.Feat
 	}
 
 	feature := czml.Feature{
-		Id:   li.Original.String(),
-		Name: li.Name,
+		Id:          li.Original.String(),
+		Name:        li.Name,
+		Description: li.Description,
+		Properties:  li.Attributes,
 	}
 
 	switch li.ExtensionID.String() {
@@ -151,11 +153,7 @@ func (e *CZMLEncoder) encodeLayerGroup(li *merging.SealedLayerGroup) ([]*czml.Fe
 
 	for _, ch := range li.Children {
 		sl := merging.SealedLayerItem{
-			SealedLayerCommon: merging.SealedLayerCommon{
-				Merged:   ch.Common().Merged,
-				Property: ch.Common().Property,
-				Infobox:  ch.Common().Infobox,
-			},
+			SealedLayerCommon: *ch.Common(),
 		}
 		l, err := e.encodeSingleLayer(&sl)
 		if err != nil {
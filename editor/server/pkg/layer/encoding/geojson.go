@@ -104,6 +104,12 @@ func (e *GeoJSONEncoder) encodeSingleLayer(li *merging.SealedLayerItem) (*geojso
 	}
 
 	if res != nil {
+		for k, v := range li.Attributes {
+			res.SetProperty(k, v)
+		}
+		if li.Description != "" {
+			res.SetProperty("description", li.Description)
+		}
 		res.SetProperty("name", li.Name)
 	}
 	return res, nil
@@ -113,11 +119,7 @@ func (e *GeoJSONEncoder) encodeLayerGroup(li *merging.SealedLayerGroup) (*geojso
 	layers := geojson.NewFeatureCollection()
 	for _, ch := range li.Flatten() {
 		sl := merging.SealedLayerItem{
-			SealedLayerCommon: merging.SealedLayerCommon{
-				Merged:   ch.Common().Merged,
-				Property: ch.Common().Property,
-				Infobox:  ch.Common().Infobox,
-			},
+			SealedLayerCommon: *ch.Common(),
 		}
 		l, err := e.encodeSingleLayer(&sl)
 		if err != nil {
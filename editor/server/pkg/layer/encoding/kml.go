@@ -1,8 +1,11 @@
 package encoding
 
 import (
+	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
+	"sort"
 
 	"github.com/reearth/reearth/server/pkg/layer"
 	"github.com/reearth/reearth/server/pkg/layer/merging"
@@ -223,11 +226,37 @@ func (e *KMLEncoder) encodeLayerTag(li *merging.SealedLayerItem) (*kml.CompoundE
 	if len(li.Name) != 0 {
 		placemark.Add(e.getName(li.Name))
 	}
+	if len(li.Description) != 0 {
+		placemark.Add(kml.Description(li.Description))
+	}
+	if data := e.encodeExtendedData(li.Attributes); data != nil {
+		placemark.Add(data)
+	}
 	placemark = placemark.Add(layerTag)
 
 	return placemark, nil
 }
 
+func (e *KMLEncoder) encodeExtendedData(attributes map[string]any) *kml.CompoundElement {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	data := kml.ExtendedData()
+	for _, k := range keys {
+		d := kml.Data(kml.Value(fmt.Sprint(attributes[k])))
+		d.Attr = append(d.Attr, xml.Attr{Name: xml.Name{Local: "name"}, Value: k})
+		data.Add(d)
+	}
+	return data
+}
+
 func (e *KMLEncoder) encodeLayerGroup(li *merging.SealedLayerGroup, parent *kml.CompoundElement) (*kml.CompoundElement, error) {
 	name := e.getName(li.Name)
 	if len(li.Name) != 0 {
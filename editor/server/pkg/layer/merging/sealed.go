@@ -31,6 +31,9 @@ type SealedLayerCommon struct {
 	Property *property.Sealed
 	Infobox  *SealedInfobox
 	Tags     []SealedTag
+	// Attributes and Description are written out by encoders that support them.
+	Attributes  map[string]any
+	Description string
 }
 
 type SealedInfobox struct {
@@ -2,6 +2,10 @@ package nlslayer
 
 type Config map[string]any
 
+// ConfigKeyCluster is the key of the config which holds inline clustering parameters
+// for layers which do not reference a cluster of the scene.
+const ConfigKeyCluster = "cluster"
+
 func (c Config) Clone() Config {
 	cloned := make(Config)
 	for key, value := range c {
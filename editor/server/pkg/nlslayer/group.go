@@ -74,6 +74,23 @@ func (l *NLSLayerGroup) Children() *IDList {
 	return l.children
 }
 
+// MoveLayerFrom moves a layer from another group to this group. If the layer is a top-level layer, fromLayerGroup is nil.
+func (l *NLSLayerGroup) MoveLayerFrom(id ID, index int, fromLayerGroup *NLSLayerGroup) {
+	if l == nil {
+		return
+	}
+
+	if fromLayerGroup != nil && fromLayerGroup.ID() == l.ID() {
+		l.Children().MoveLayer(id, index)
+		return
+	}
+
+	if fromLayerGroup != nil {
+		fromLayerGroup.Children().RemoveLayer(id)
+	}
+	l.Children().AddLayer(id, index)
+}
+
 func (l *NLSLayerGroup) LayerRef() *NLSLayer {
 	if l == nil {
 		return nil
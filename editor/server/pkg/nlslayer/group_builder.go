@@ -106,3 +106,8 @@ func (b *NLSLayerGroupBuilder) Sketch(sketch *SketchInfo) *NLSLayerGroupBuilder
 	b.l.sketch = sketch
 	return b
 }
+
+func (b *NLSLayerGroupBuilder) Cluster(cluster *ClusterID) *NLSLayerGroupBuilder {
+	b.l.cluster = cluster.CloneRef()
+	return b
+}
@@ -0,0 +1,30 @@
+package nlslayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNLSLayerGroup_MoveLayerFrom(t *testing.T) {
+	l1, l2, l3 := NewID(), NewID(), NewID()
+	g1 := NewNLSLayerGroup().NewID().Layers(NewIDList([]ID{l1, l2})).MustBuild()
+	g2 := NewNLSLayerGroup().NewID().MustBuild()
+
+	// reorder in the same group
+	g1.MoveLayerFrom(l1, 1, g1)
+	assert.Equal(t, []ID{l2, l1}, g1.Children().Layers())
+
+	// move to another group
+	g2.MoveLayerFrom(l1, 0, g1)
+	assert.Equal(t, []ID{l2}, g1.Children().Layers())
+	assert.Equal(t, []ID{l1}, g2.Children().Layers())
+
+	// move a top-level layer
+	g2.MoveLayerFrom(l3, 0, nil)
+	assert.Equal(t, []ID{l3, l1}, g2.Children().Layers())
+
+	var g3 *NLSLayerGroup
+	g3.MoveLayerFrom(l1, 0, g2)
+	assert.Equal(t, []ID{l3, l1}, g2.Children().Layers())
+}
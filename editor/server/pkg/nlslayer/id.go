@@ -15,6 +15,7 @@ type InfoboxBlockID = id.InfoboxBlockID
 type PluginID = id.PluginID
 type PluginExtensionID = id.PluginExtensionID
 type FeatureID = id.FeatureID
+type ClusterID = id.ClusterID
 
 var NewID = id.NewNLSLayerID
 var NewInfoboxID = id.NewInfoboxID
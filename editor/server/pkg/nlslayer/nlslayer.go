@@ -20,6 +20,8 @@ type NLSLayer interface {
 	HasSketch() bool
 	Sketch() *SketchInfo
 	SetSketch(*SketchInfo)
+	Cluster() *ClusterID
+	SetCluster(*ClusterID)
 }
 
 func ToNLSLayerGroup(l NLSLayer) *NLSLayerGroup {
@@ -68,6 +70,7 @@ type layerBase struct {
 	config    *Config
 	isSketch  bool
 	sketch    *SketchInfo
+	cluster   *ClusterID
 }
 
 func (l *layerBase) ID() ID {
@@ -178,6 +181,7 @@ func (l *layerBase) Clone() *layerBase {
 		visible:   l.visible,
 		config:    clonedConfig,
 		isSketch:  l.isSketch,
+		cluster:   l.cluster.CloneRef(),
 	}
 
 	if l.infobox != nil {
@@ -209,6 +213,7 @@ func (l *layerBase) Duplicate() NLSLayer {
 		visible:   l.visible,
 		config:    duplicatedConfig,
 		isSketch:  l.isSketch,
+		cluster:   l.cluster.CloneRef(),
 	}
 
 	if l.infobox != nil {
@@ -256,3 +261,18 @@ func (l *layerBase) SetSketchInfo(sketch *SketchInfo) {
 	}
 	l.sketch = sketch
 }
+
+// Cluster returns the ID of the scene cluster with which the features of the layer are clustered.
+func (l *layerBase) Cluster() *ClusterID {
+	if l == nil {
+		return nil
+	}
+	return l.cluster.CloneRef()
+}
+
+func (l *layerBase) SetCluster(cluster *ClusterID) {
+	if l == nil {
+		return
+	}
+	l.cluster = cluster.CloneRef()
+}
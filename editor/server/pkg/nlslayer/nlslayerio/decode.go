@@ -0,0 +1,118 @@
+package nlslayerio
+
+import (
+	"errors"
+
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/layer/decoding"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/property"
+)
+
+var ErrNoFeatures = errors.New("no features are decoded")
+
+var propertyItem = property.SchemaGroupID("default")
+
+// Features converts layers decoded by pkg/layer/decoding into sketch features.
+// Attributes of the source features are stored as the properties of the features,
+// and names of the decoded layers are stored as the "name" property unless the source has one.
+func Features(r decoding.Result) ([]nlslayer.Feature, error) {
+	var res []nlslayer.Feature
+	for _, l := range r.RootLayers().Deref() {
+		features, err := features(r, l)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, features...)
+	}
+	if len(res) == 0 {
+		return nil, ErrNoFeatures
+	}
+	return res, nil
+}
+
+func features(r decoding.Result, l layer.Layer) ([]nlslayer.Feature, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	if lg := layer.ToLayerGroup(l); lg != nil {
+		var res []nlslayer.Feature
+		for _, c := range r.Layers.Pick(lg.Layers()).Deref() {
+			features, err := features(r, c)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, features...)
+		}
+		return res, nil
+	}
+
+	if l.Property() == nil || l.Extension() == nil {
+		return nil, nil
+	}
+
+	g := geometry(l.Extension().String(), r.Properties[*l.Property()])
+	if g == nil {
+		return nil, nil
+	}
+
+	f, err := nlslayer.NewFeatureWithNewId("Feature", g)
+	if err != nil {
+		return nil, err
+	}
+	properties := map[string]any{}
+	for k, v := range r.Attributes[l.ID()] {
+		properties[k] = v
+	}
+	if _, ok := properties["name"]; !ok && l.Name() != "" {
+		properties["name"] = l.Name()
+	}
+	f.UpdateProperties(&properties)
+	return []nlslayer.Feature{*f}, nil
+}
+
+func geometry(ext string, p *property.Property) nlslayer.Geometry {
+	switch ext {
+	case "marker":
+		location := field(p, "location").ValueLatLng()
+		if location == nil {
+			return nil
+		}
+		coords := []float64{location.Lng, location.Lat}
+		if height := field(p, "height").ValueNumber(); height != nil {
+			coords = append(coords, *height)
+		}
+		return nlslayer.NewPoint("Point", coords)
+	case "polyline":
+		coords := field(p, "coordinates").ValueCoordinates()
+		if coords == nil {
+			return nil
+		}
+		return nlslayer.NewLineString("LineString", fromCoordinates(*coords))
+	case "polygon":
+		polygon := field(p, "polygon").ValuePolygon()
+		if polygon == nil {
+			return nil
+		}
+		rings := make([][][]float64, 0, len(*polygon))
+		for _, c := range *polygon {
+			rings = append(rings, fromCoordinates(c))
+		}
+		return nlslayer.NewPolygon("Polygon", rings)
+	}
+	return nil
+}
+
+func field(p *property.Property, f property.FieldID) *property.Value {
+	pf, _, _ := p.Field(property.PointFieldBySchemaGroup(propertyItem, f))
+	return pf.Value()
+}
+
+func fromCoordinates(c property.Coordinates) [][]float64 {
+	res := make([][]float64, 0, len(c))
+	for _, l := range c {
+		res = append(res, []float64{l.Lng, l.Lat, l.Height})
+	}
+	return res
+}
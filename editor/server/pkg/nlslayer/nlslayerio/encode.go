@@ -0,0 +1,185 @@
+package nlslayerio
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/layer"
+	"github.com/reearth/reearth/server/pkg/layer/encoding"
+	"github.com/reearth/reearth/server/pkg/layer/merging"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/property"
+)
+
+var (
+	ErrUnsupportedLayer     = errors.New("only simple layers can be exported")
+	ErrNoExportableFeatures = errors.New("layer has no exportable features")
+)
+
+var extensions = map[string]layer.PluginExtensionID{
+	"marker":   layer.PluginExtensionID("marker"),
+	"polyline": layer.PluginExtensionID("polyline"),
+	"polygon":  layer.PluginExtensionID("polygon"),
+}
+
+// Encode writes the features of a simple layer with an encoder of pkg/layer/encoding.
+// Properties of features are written as attributes and the text of the infobox is written as their description,
+// as far as the format supports them. Properties of infobox blocks are looked up from the property map.
+func Encode(e encoding.Encoder, l nlslayer.NLSLayer, properties property.Map) error {
+	if l == nil || nlslayer.ToNLSLayerGroup(l) != nil {
+		return ErrUnsupportedLayer
+	}
+	// layers which load their data from URLs have no features stored in the server
+	if !l.IsSketch() || l.Sketch() == nil {
+		return ErrNoExportableFeatures
+	}
+	return e.Encode(Seal(l, properties))
+}
+
+// Seal converts a simple layer into a sealed layer group so that it can be encoded like legacy layers.
+// Multi polygons and geometry collections are split into their members.
+func Seal(l nlslayer.NLSLayer, properties property.Map) *merging.SealedLayerGroup {
+	g := &merging.SealedLayerGroup{
+		SealedLayerCommon: merging.SealedLayerCommon{
+			Merged: layer.Merged{
+				Original:  layer.NewID(),
+				Name:      l.Title(),
+				Scene:     l.Scene(),
+				IsVisible: l.IsVisible(),
+			},
+		},
+	}
+	if !l.IsSketch() || l.Sketch() == nil {
+		return g
+	}
+
+	description := infoboxDescription(l.Infobox(), properties)
+	for _, f := range l.Sketch().FeatureCollection().Features() {
+		var attributes map[string]any
+		name := ""
+		if p := f.Properties(); p != nil {
+			attributes = *p
+			name, _ = attributes["name"].(string)
+		}
+		for _, item := range sealedItems(l, name, f.Geometry()) {
+			item.Attributes = attributes
+			item.Description = description
+			g.Children = append(g.Children, item)
+		}
+	}
+	return g
+}
+
+// infoboxDescription joins the contents of text and image blocks in the infobox.
+func infoboxDescription(ib *nlslayer.Infobox, properties property.Map) string {
+	if ib == nil {
+		return ""
+	}
+
+	var res []string
+	for _, b := range ib.Blocks() {
+		if b == nil {
+			continue
+		}
+		p := properties[b.Property()]
+		var v *string
+		switch b.Extension() {
+		case "textInfoboxBetaBlock":
+			v = field(p, "text").ValueString()
+		case "imageInfoboxBetaBlock":
+			v = field(p, "src").ValueString()
+		}
+		if v != nil && *v != "" {
+			res = append(res, *v)
+		}
+	}
+	return strings.Join(res, "\n\n")
+}
+
+func sealedItems(l nlslayer.NLSLayer, name string, g nlslayer.Geometry) []*merging.SealedLayerItem {
+	var ext string
+	var fields []*property.SealedField
+
+	switch g := g.(type) {
+	case *nlslayer.Point:
+		coords := g.Coordinates()
+		if len(coords) < 2 {
+			return nil
+		}
+		ext = "marker"
+		fields = append(fields, sealedField("location", property.ValueTypeLatLng, property.LatLng{Lng: coords[0], Lat: coords[1]}))
+		if len(coords) > 2 {
+			fields = append(fields, sealedField("height", property.ValueTypeNumber, coords[2]))
+		}
+	case *nlslayer.LineString:
+		ext = "polyline"
+		fields = append(fields, sealedField("coordinates", property.ValueTypeCoordinates, toCoordinates(g.Coordinates())))
+	case *nlslayer.Polygon:
+		ext = "polygon"
+		fields = append(fields, sealedField("polygon", property.ValueTypePolygon, toPolygon(g.Coordinates())))
+	case *nlslayer.MultiPolygon:
+		var res []*merging.SealedLayerItem
+		for _, p := range g.Coordinates() {
+			res = append(res, sealedItems(l, name, nlslayer.NewPolygon("Polygon", p))...)
+		}
+		return res
+	case *nlslayer.GeometryCollection:
+		var res []*merging.SealedLayerItem
+		for _, c := range g.Geometries() {
+			res = append(res, sealedItems(l, name, c)...)
+		}
+		return res
+	default:
+		return nil
+	}
+
+	ex := extensions[ext]
+	return []*merging.SealedLayerItem{{
+		SealedLayerCommon: merging.SealedLayerCommon{
+			Merged: layer.Merged{
+				Original:    layer.NewID(),
+				Name:        name,
+				Scene:       l.Scene(),
+				PluginID:    &layer.OfficialPluginID,
+				ExtensionID: &ex,
+				IsVisible:   l.IsVisible(),
+			},
+			Property: &property.Sealed{
+				Items: []*property.SealedItem{{
+					SchemaGroup: propertyItem,
+					Fields:      fields,
+				}},
+			},
+		},
+	}}
+}
+
+func sealedField(f property.FieldID, t property.ValueType, v any) *property.SealedField {
+	return &property.SealedField{
+		ID:  f,
+		Val: property.NewValueAndDatasetValue(t, nil, t.ValueFrom(v)),
+	}
+}
+
+func toCoordinates(c [][]float64) property.Coordinates {
+	res := make(property.Coordinates, 0, len(c))
+	for _, p := range c {
+		if len(p) < 2 {
+			continue
+		}
+		l := property.LatLngHeight{Lng: p[0], Lat: p[1]}
+		if len(p) > 2 {
+			l.Height = p[2]
+		}
+		res = append(res, l)
+	}
+	return res
+}
+
+func toPolygon(p [][][]float64) property.Polygon {
+	res := make(property.Polygon, 0, len(p))
+	for _, r := range p {
+		res = append(res, toCoordinates(r))
+	}
+	return res
+}
@@ -0,0 +1,112 @@
+package nlslayerio
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/layer/decoding"
+	"github.com/reearth/reearth/server/pkg/layer/encoding"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const geojson = `{
+  "type": "FeatureCollection",
+  "features": [
+    {"type": "Feature", "geometry": {"type": "Point", "coordinates": [139.0, 35.0, 10.0]}, "properties": {"name": "point", "capacity": 120}},
+    {"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[139.0, 35.0], [140.0, 36.0]]}, "properties": {}},
+    {"type": "Feature", "geometry": {"type": "Polygon", "coordinates": [[[139.0, 35.0], [140.0, 35.0], [140.0, 36.0], [139.0, 35.0]]]}, "properties": {}}
+  ]
+}`
+
+func TestFeatures(t *testing.T) {
+	sid := id.NewSceneID()
+	r, err := decoding.NewGeoJSONDecoder(strings.NewReader(geojson), sid).Decode()
+	require.NoError(t, err)
+
+	res, err := Features(r)
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+
+	assert.Equal(t, []float64{139, 35, 10}, res[0].Geometry().(*nlslayer.Point).Coordinates())
+	assert.Equal(t, &map[string]any{"name": "point", "capacity": float64(120)}, res[0].Properties())
+	assert.Equal(t, [][]float64{{139, 35, 0}, {140, 36, 0}}, res[1].Geometry().(*nlslayer.LineString).Coordinates())
+	assert.Equal(t, &map[string]any{"name": "Polyline"}, res[1].Properties())
+	assert.Len(t, res[2].Geometry().(*nlslayer.Polygon).Coordinates()[0], 4)
+
+	// attributes of other formats
+	r, err = decoding.NewCSVDecoder(strings.NewReader("lat,lng,name,kind\n35,139,shelter,school\n"), sid).Decode()
+	require.NoError(t, err)
+	res, err = Features(r)
+	require.NoError(t, err)
+	assert.Equal(t, &map[string]any{"name": "shelter", "kind": "school"}, res[0].Properties())
+
+	r, err = decoding.NewGeoJSONDecoder(strings.NewReader(`{"type": "FeatureCollection", "features": []}`), sid).Decode()
+	if err == nil {
+		_, err = Features(r)
+	}
+	assert.Error(t, err)
+}
+
+func TestEncode(t *testing.T) {
+	sid := id.NewSceneID()
+	p, _ := nlslayer.NewFeatureWithNewId("Feature", nlslayer.NewPoint("Point", []float64{139, 35}))
+	p.UpdateProperties(&map[string]any{"name": "point", "height": 10.0})
+	mp, _ := nlslayer.NewFeatureWithNewId("Feature", nlslayer.NewMultiPolygon("MultiPolygon", [][][][]float64{
+		{{{139, 35}, {140, 35}, {140, 36}, {139, 35}}},
+		{{{141, 35}, {142, 35}, {142, 36}, {141, 35}}},
+	}))
+
+	textProperty := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/textInfoboxBetaBlock")).
+		Items([]property.Item{
+			property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+				property.NewField("text").Value(property.OptionalValueFrom(property.ValueTypeString.ValueFrom("hello"))).MustBuild(),
+			}).MustBuild(),
+		}).MustBuild()
+	block := nlslayer.NewInfoboxBlock().NewID().Property(textProperty.ID()).
+		Plugin(id.OfficialPluginID).Extension("textInfoboxBetaBlock").MustBuild()
+	infobox := nlslayer.NewInfobox([]*nlslayer.InfoboxBlock{block}, id.NewPropertyID())
+
+	l := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).Title("sketch").IsSketch(true).Infobox(infobox).
+		Sketch(nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", []nlslayer.Feature{*p, *mp}))).
+		MustBuild()
+	properties := property.Map{}.Add(textProperty)
+
+	// geojson
+	buf := &bytes.Buffer{}
+	require.NoError(t, Encode(encoding.NewGeoJSONEncoder(buf), l, properties))
+
+	var fc map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &fc))
+	features := fc["features"].([]any)
+	require.Len(t, features, 3)
+	feature := features[0].(map[string]any)
+	assert.Equal(t, "Point", feature["geometry"].(map[string]any)["type"])
+	assert.Equal(t, map[string]any{"name": "point", "height": 10.0, "description": "hello"}, feature["properties"])
+	assert.Equal(t, "Polygon", features[1].(map[string]any)["geometry"].(map[string]any)["type"])
+
+	// kml
+	buf = &bytes.Buffer{}
+	require.NoError(t, Encode(encoding.NewKMLEncoder(buf), l, properties))
+	assert.Contains(t, buf.String(), "<description>hello</description>")
+	assert.Contains(t, buf.String(), `<Data name="height">`)
+
+	// czml
+	buf = &bytes.Buffer{}
+	require.NoError(t, Encode(encoding.NewCZMLEncoder(buf), l, properties))
+	assert.Contains(t, buf.String(), `"properties":{"height":10,"name":"point"}`)
+
+	// a layer without sketch
+	buf = &bytes.Buffer{}
+	l2 := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).MustBuild()
+	assert.Equal(t, ErrNoExportableFeatures, Encode(encoding.NewGeoJSONEncoder(buf), l2, nil))
+	assert.Empty(t, buf.String())
+
+	g := nlslayer.NewNLSLayerGroup().NewID().Scene(sid).MustBuild()
+	assert.Equal(t, ErrUnsupportedLayer, Encode(encoding.NewGeoJSONEncoder(buf), g, nil))
+}
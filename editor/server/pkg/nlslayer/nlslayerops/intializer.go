@@ -48,3 +48,28 @@ func (i LayerSimple) Initialize() (*nlslayer.NLSLayerSimple, error) {
 
 	return layerSimple, nil
 }
+
+type LayerGroup struct {
+	SceneID nlslayer.SceneID
+	Config  *nlslayer.Config
+	Title   string
+	Visible *bool
+}
+
+func (i LayerGroup) Initialize() (*nlslayer.NLSLayerGroup, error) {
+	builder := nlslayer.NewNLSLayerGroup().NewID().Scene(i.SceneID).LayerType(nlslayer.Group).Title(i.Title)
+
+	if i.Config != nil {
+		builder.Config(i.Config)
+	} else {
+		builder.Config(&nlslayer.Config{})
+	}
+
+	if i.Visible != nil {
+		builder.IsVisible(*i.Visible)
+	} else {
+		builder.IsVisible(true)
+	}
+
+	return builder.Build()
+}
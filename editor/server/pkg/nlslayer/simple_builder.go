@@ -96,3 +96,8 @@ func (b *NLSLayerSimpleBuilder) Sketch(sketch *SketchInfo) *NLSLayerSimpleBuilde
 	b.l.sketch = sketch
 	return b
 }
+
+func (b *NLSLayerSimpleBuilder) Cluster(cluster *ClusterID) *NLSLayerSimpleBuilder {
+	b.l.cluster = cluster.CloneRef()
+	return b
+}
@@ -1,5 +1,15 @@
 package nlslayer
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidCustomProperty = errors.New("invalid custom property")
+
 type SketchInfo struct {
 	customPropertySchema *map[string]any
 	featureCollection    *FeatureCollection
@@ -34,3 +44,101 @@ func (s *SketchInfo) Clone() *SketchInfo {
 		featureCollection:    s.featureCollection,
 	}
 }
+
+// ValidateProperties checks feature properties against the custom property schema.
+// The schema maps a property name to its type, such as "Text_1" or "Number_2".
+// Text values of number and boolean properties are converted since some file formats, such as CSV and KML, have only text values.
+// Properties not defined in the schema are dropped. If no schema is set, properties are returned as they are.
+func (s *SketchInfo) ValidateProperties(properties *map[string]any) (*map[string]any, error) {
+	if s == nil || s.customPropertySchema == nil || properties == nil {
+		return properties, nil
+	}
+
+	res := map[string]any{}
+	for k, v := range *properties {
+		t, ok := (*s.customPropertySchema)[k]
+		if !ok {
+			continue
+		}
+		if ts, ok := t.(string); ok && v != nil {
+			if v, ok = customPropertyValue(ts, v); !ok {
+				return nil, fmt.Errorf("%w: %s must be %s", ErrInvalidCustomProperty, k, customPropertyType(ts))
+			}
+		}
+		res[k] = v
+	}
+	return &res, nil
+}
+
+// Attributes returns the sorted names of the feature properties defined in the custom property schema or set on any feature.
+func (s *SketchInfo) Attributes() []string {
+	if s == nil {
+		return nil
+	}
+
+	set := map[string]struct{}{}
+	if s.customPropertySchema != nil {
+		for k := range *s.customPropertySchema {
+			set[k] = struct{}{}
+		}
+	}
+	if s.featureCollection != nil {
+		for _, f := range s.featureCollection.Features() {
+			if p := f.Properties(); p != nil {
+				for k := range *p {
+					set[k] = struct{}{}
+				}
+			}
+		}
+	}
+
+	res := make([]string, 0, len(set))
+	for k := range set {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func customPropertyType(t string) string {
+	if i := strings.LastIndex(t, "_"); i > 0 {
+		return t[:i]
+	}
+	return t
+}
+
+// customPropertyValue returns the value converted to the type of the custom property, or false if it is not of the type.
+// An empty text is regarded as no value for number and boolean properties.
+func customPropertyValue(t string, v any) (any, bool) {
+	switch customPropertyType(t) {
+	case "Text", "TextArea", "URL", "Asset":
+		_, ok := v.(string)
+		return v, ok
+	case "Number":
+		switch v2 := v.(type) {
+		case float64, float32, int, int32, int64:
+			return v, true
+		case string:
+			if v2 = strings.TrimSpace(v2); v2 == "" {
+				return nil, true
+			}
+			f, err := strconv.ParseFloat(v2, 64)
+			return f, err == nil
+		}
+		return nil, false
+	case "Boolean":
+		switch v2 := v.(type) {
+		case bool:
+			return v, true
+		case string:
+			if v2 = strings.TrimSpace(v2); v2 == "" {
+				return nil, true
+			}
+			b, err := strconv.ParseBool(v2)
+			return b, err == nil
+		}
+		return nil, false
+	}
+	// unknown types are not checked
+	return v, true
+}
@@ -55,3 +55,53 @@ func TestSketchInfoClone(t *testing.T) {
 
 	assert.Equal(t, si, si2)
 }
+
+func TestSketchInfo_ValidateProperties(t *testing.T) {
+	properties := map[string]any{"name": "a", "height": 10.0, "visible": true, "other": 1}
+
+	res, err := (*SketchInfo)(nil).ValidateProperties(&properties)
+	assert.NoError(t, err)
+	assert.Equal(t, &properties, res)
+
+	schema := map[string]any{"name": "Text_1", "height": "Number_2", "visible": "Boolean_3", "extra": 1}
+	si := NewSketchInfo(&schema, nil)
+
+	res, err = si.ValidateProperties(&properties)
+	assert.NoError(t, err)
+	assert.Equal(t, &map[string]any{"name": "a", "height": 10.0, "visible": true}, res)
+
+	// text values are converted to the types of the properties
+	res, err = si.ValidateProperties(&map[string]any{"height": " 10.5", "visible": "true"})
+	assert.NoError(t, err)
+	assert.Equal(t, &map[string]any{"height": 10.5, "visible": true}, res)
+
+	res, err = si.ValidateProperties(&map[string]any{"height": "", "visible": ""})
+	assert.NoError(t, err)
+	assert.Equal(t, &map[string]any{"height": nil, "visible": nil}, res)
+
+	res, err = si.ValidateProperties(&map[string]any{"height": "ten"})
+	assert.ErrorIs(t, err, ErrInvalidCustomProperty)
+	assert.Nil(t, res)
+
+	res, err = si.ValidateProperties(&map[string]any{"visible": "yes"})
+	assert.ErrorIs(t, err, ErrInvalidCustomProperty)
+	assert.Nil(t, res)
+
+	res, err = si.ValidateProperties(&map[string]any{"name": 1})
+	assert.ErrorIs(t, err, ErrInvalidCustomProperty)
+	assert.Nil(t, res)
+}
+
+func TestSketchInfo_Attributes(t *testing.T) {
+	f, err := NewFeature(NewFeatureID(), "Feature", NewPoint("Point", []float64{1, 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.UpdateProperties(&map[string]any{"height": 10, "name": "a"})
+	schema := map[string]any{"name": "Text_1", "kind": "Text_2"}
+	si := NewSketchInfo(&schema, NewFeatureCollection("FeatureCollection", []Feature{*f}))
+
+	assert.Equal(t, []string{"height", "kind", "name"}, si.Attributes())
+	assert.Equal(t, []string{}, NewSketchInfo(nil, nil).Attributes())
+	assert.Nil(t, (*SketchInfo)(nil).Attributes())
+}
@@ -72,3 +72,13 @@ func (b *Builder) Schema(schema *PropertySchemaID) *Builder {
 	b.p.schema = schema.CopyRef()
 	return b
 }
+
+func (b *Builder) Permissions(permissions *Permissions) *Builder {
+	b.p.permissions = permissions.Clone()
+	return b
+}
+
+func (b *Builder) Signature(signature *Signature) *Builder {
+	b.p.signature = signature.Clone()
+	return b
+}
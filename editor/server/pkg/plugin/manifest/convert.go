@@ -83,6 +83,7 @@ func (i *Root) manifest(sid *plugin.SceneID, tl *TranslatedRoot) (*Manifest, err
 		RepositoryURL(repository).
 		Schema(pluginSchema.IDRef()).
 		Extensions(extensions).
+		Permissions(i.Permissions.permissions()).
 		Build()
 	if err != nil {
 		return nil, errInvalidManifestWith(rerror.From("build", err))
@@ -95,6 +96,13 @@ func (i *Root) manifest(sid *plugin.SceneID, tl *TranslatedRoot) (*Manifest, err
 	}, nil
 }
 
+func (p *Permissions) permissions() *plugin.Permissions {
+	if p == nil {
+		return nil
+	}
+	return plugin.NewPermissions(p.Network, plugin.LayerAccess(p.Layers))
+}
+
 func (i Extension) extension(pluginID plugin.ID, sys bool, te *TranslatedExtension) (*plugin.Extension, *property.Schema, error) {
 	eid := string(i.ID)
 	var ts *TranslatedPropertySchema
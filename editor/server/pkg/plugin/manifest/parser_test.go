@@ -74,6 +74,16 @@ func TestParse(t *testing.T) {
 			expected: normalExpected,
 			err:      nil,
 		},
+		{
+			name:  "success create manifest with permissions",
+			input: "id: aaa\nversion: 1.1.1\npermissions:\n  network: [api.example.com]\n  layers: write\n",
+			expected: &Manifest{
+				Plugin: plugin.New().ID(plugin.MustID("aaa~1.1.1")).
+					Permissions(plugin.NewPermissions([]string{"api.example.com"}, plugin.LayerAccessWrite)).
+					MustBuild(),
+			},
+			err: nil,
+		},
 		{
 			name:     "fail not valid JSON",
 			input:    "",
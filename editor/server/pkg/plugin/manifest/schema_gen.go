@@ -35,6 +35,11 @@ type Location struct {
 	Zone    string `json:"zone,omitempty"`
 }
 
+type Permissions struct {
+	Layers  string   `json:"layers,omitempty"`
+	Network []string `json:"network,omitempty"`
+}
+
 type PropertyCondition struct {
 	Field string      `json:"field"`
 	Type  Valuetype   `json:"type"`
@@ -90,6 +95,7 @@ type Root struct {
 	ID          ID              `json:"id"`
 	Main        *string         `json:"main,omitempty"`
 	Name        string          `json:"name"`
+	Permissions *Permissions    `json:"permissions,omitempty"`
 	Repository  *string         `json:"repository,omitempty"`
 	Schema      *PropertySchema `json:"schema,omitempty"`
 	System      bool            `json:"system,omitempty"`
@@ -0,0 +1,68 @@
+package plugin
+
+import "strings"
+
+type LayerAccess string
+
+const (
+	LayerAccessRead  LayerAccess = "read"
+	LayerAccessWrite LayerAccess = "write"
+)
+
+// Permissions are what a plugin declares it needs in the permissions section of its manifest.
+type Permissions struct {
+	network []string
+	layers  LayerAccess
+}
+
+func NewPermissions(network []string, layers LayerAccess) *Permissions {
+	if layers != LayerAccessWrite {
+		layers = LayerAccessRead
+	}
+	var n []string
+	if len(network) > 0 {
+		n = append([]string{}, network...)
+	}
+	return &Permissions{network: n, layers: layers}
+}
+
+// Network returns the hosts the plugin connects to. A host can start with "*." to match its subdomains.
+func (p *Permissions) Network() []string {
+	if p == nil || p.network == nil {
+		return nil
+	}
+	return append([]string{}, p.network...)
+}
+
+func (p *Permissions) Layers() LayerAccess {
+	if p == nil {
+		return LayerAccessRead
+	}
+	return p.layers
+}
+
+func (p *Permissions) CanWriteLayers() bool {
+	return p.Layers() == LayerAccessWrite
+}
+
+// AllowsHost reports whether the plugin declares that it connects to the host.
+func (p *Permissions) AllowsHost(host string) bool {
+	host = strings.ToLower(host)
+	for _, h := range p.Network() {
+		h = strings.ToLower(h)
+		if h == "*" || h == host {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(h, "*"); ok && strings.HasPrefix(suffix, ".") && strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Permissions) Clone() *Permissions {
+	if p == nil {
+		return nil
+	}
+	return NewPermissions(p.network, p.layers)
+}
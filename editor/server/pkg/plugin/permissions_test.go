@@ -0,0 +1,26 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissions(t *testing.T) {
+	p := NewPermissions([]string{"api.example.com", "*.tiles.example.com"}, "")
+	assert.Equal(t, LayerAccessRead, p.Layers())
+	assert.False(t, p.CanWriteLayers())
+	assert.True(t, p.AllowsHost("api.example.com"))
+	assert.True(t, p.AllowsHost("a.tiles.example.com"))
+	assert.False(t, p.AllowsHost("tiles.example.com"))
+	assert.False(t, p.AllowsHost("example.com"))
+	assert.Equal(t, p, p.Clone())
+
+	p = NewPermissions(nil, LayerAccessWrite)
+	assert.True(t, p.CanWriteLayers())
+	assert.Nil(t, p.Network())
+
+	var p2 *Permissions
+	assert.Equal(t, LayerAccessRead, p2.Layers())
+	assert.False(t, p2.AllowsHost("api.example.com"))
+}
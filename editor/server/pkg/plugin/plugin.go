@@ -14,6 +14,8 @@ type Plugin struct {
 	extensions     map[ExtensionID]*Extension
 	extensionOrder []ExtensionID
 	schema         *PropertySchemaID
+	permissions    *Permissions
+	signature      *Signature
 }
 
 func (p *Plugin) ID() ID {
@@ -111,6 +113,24 @@ func (p *Plugin) PropertySchemas() PropertySchemaIDList {
 	return ps
 }
 
+func (p *Plugin) Permissions() *Permissions {
+	if p == nil {
+		return nil
+	}
+	return p.permissions
+}
+
+func (p *Plugin) Signature() *Signature {
+	if p == nil {
+		return nil
+	}
+	return p.signature
+}
+
+func (p *Plugin) SetSignature(s *Signature) {
+	p.signature = s
+}
+
 func (p *Plugin) Clone() *Plugin {
 	if p == nil {
 		return nil
@@ -138,5 +158,7 @@ func (p *Plugin) Clone() *Plugin {
 		extensions:     extensions,
 		extensionOrder: extensionOrder,
 		schema:         p.schema.CopyRef(),
+		permissions:    p.permissions.Clone(),
+		signature:      p.signature.Clone(),
 	}
 }
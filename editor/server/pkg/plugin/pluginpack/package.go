@@ -3,11 +3,18 @@ package pluginpack
 import (
 	"archive/zip"
 	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/plugin"
@@ -17,11 +24,21 @@ import (
 
 const manfiestFilePath = "reearth.yml"
 
+// SignatureFilePath is the path of the detached signature of the package.
+// It contains the base64-encoded Ed25519 signature of the package digest.
+const SignatureFilePath = "reearth.sig"
+
+var ErrInvalidSignature = errors.New("invalid plugin signature")
+
 var translationFileNameRegexp = regexp.MustCompile(`reearth_([a-zA-Z]+(?:-[a-zA-Z]+)?).yml`)
 
 type Package struct {
 	Manifest *manifest.Manifest
 	Files    file.Iterator
+	// Digest is the SHA-256 hash of the output of "sha256sum" for all files of the package except the signature, sorted by path.
+	Digest []byte
+	// Signature is nil if the package is not signed.
+	Signature []byte
 }
 
 func PackageFromZip(r io.Reader, scene *plugin.SceneID, sizeLimit int64) (*Package, error) {
@@ -55,12 +72,80 @@ func PackageFromZip(r io.Reader, scene *plugin.SceneID, sizeLimit int64) (*Packa
 		return nil, rerror.From("invalid manifest", err)
 	}
 
+	digest, err := packageDigest(zr, basePath)
+	if err != nil {
+		return nil, rerror.From("digest error", err)
+	}
+
+	sig, err := readSignature(zr, basePath)
+	if err != nil {
+		return nil, err
+	}
+	if sig != nil {
+		m.Plugin.SetSignature(plugin.NewSignature(digest, sig))
+	}
+
 	return &Package{
-		Manifest: m,
-		Files:    iterator(file.NewZipReader(zr), basePath),
+		Manifest:  m,
+		Files:     iterator(file.NewZipReader(zr), basePath),
+		Digest:    digest,
+		Signature: sig,
 	}, nil
 }
 
+func packageDigest(zr *zip.Reader, basePath string) ([]byte, error) {
+	files := make([]*zip.File, 0, len(zr.File))
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || f.Name == path.Join(basePath, SignatureFilePath) {
+			continue
+		}
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+
+	h := sha256.New()
+	for _, f := range files {
+		r, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		fh := sha256.New()
+		_, err = io.Copy(fh, r)
+		_ = r.Close()
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(f.Name, basePath), "/")
+		_, _ = fmt.Fprintf(h, "%x  %s\n", fh.Sum(nil), name)
+	}
+	return h.Sum(nil), nil
+}
+
+func readSignature(zr *zip.Reader, basePath string) ([]byte, error) {
+	f, err := zr.Open(path.Join(basePath, SignatureFilePath))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, rerror.From("signature open error", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	b, err := io.ReadAll(io.LimitReader(f, 1024))
+	if err != nil {
+		return nil, rerror.From("signature read error", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return nil, ErrInvalidSignature
+	}
+	return sig, nil
+}
+
 func iterator(a file.Iterator, prefix string) file.Iterator {
 	return file.NewFilteredIterator(file.NewPrefixIterator(a, prefix), func(p string) bool {
 		return p == manfiestFilePath || filepath.Ext(p) != ".js"
@@ -2,6 +2,11 @@ package pluginpack
 
 import (
 	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
 	"os"
 	"testing"
 
@@ -51,3 +56,50 @@ func TestPackageFromZip2(t *testing.T) {
 	_, err = PackageFromZip(f, nil, 100)
 	assert.ErrorIs(t, err, zip.ErrFormat)
 }
+
+func TestPackageFromZip_Signature(t *testing.T) {
+	files := map[string]string{
+		"plugin/":            "",
+		"plugin/reearth.yml": "id: testplugin\nversion: 1.0.1\nname: testplugin\n",
+		"plugin/index.js":    "console.log('hello')",
+	}
+	listing := sha256.New()
+	_, _ = fmt.Fprintf(listing, "%x  index.js\n", sha256.Sum256([]byte(files["plugin/index.js"])))
+	_, _ = fmt.Fprintf(listing, "%x  reearth.yml\n", sha256.Sum256([]byte(files["plugin/reearth.yml"])))
+	digest := listing.Sum(nil)
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	sig := ed25519.Sign(priv, digest)
+
+	// unsigned
+	p, err := PackageFromZip(bytes.NewReader(zipFiles(files)), nil, 10000)
+	assert.NoError(t, err)
+	assert.Equal(t, digest, p.Digest)
+	assert.Nil(t, p.Signature)
+	assert.Nil(t, p.Manifest.Plugin.Signature())
+
+	// signed
+	files["plugin/reearth.sig"] = base64.StdEncoding.EncodeToString(sig) + "\n"
+	p, err = PackageFromZip(bytes.NewReader(zipFiles(files)), nil, 10000)
+	assert.NoError(t, err)
+	assert.Equal(t, digest, p.Digest)
+	assert.Equal(t, sig, p.Signature)
+	assert.True(t, p.Manifest.Plugin.Signature().Verify([]ed25519.PublicKey{pub}))
+
+	// malformed signature
+	files["plugin/reearth.sig"] = "xxx"
+	_, err = PackageFromZip(bytes.NewReader(zipFiles(files)), nil, 10000)
+	assert.Equal(t, ErrInvalidSignature, err)
+}
+
+func zipFiles(files map[string]string) []byte {
+	buf := bytes.Buffer{}
+	zw := zip.NewWriter(&buf)
+	for p, c := range files {
+		w, _ := zw.Create(p)
+		_, _ = w.Write([]byte(c))
+	}
+	_ = zw.Close()
+	return buf.Bytes()
+}
@@ -0,0 +1,50 @@
+package plugin
+
+import "crypto/ed25519"
+
+// Signature is a detached Ed25519 signature over the digest of a plugin package.
+type Signature struct {
+	digest []byte
+	value  []byte
+}
+
+func NewSignature(digest, value []byte) *Signature {
+	return &Signature{
+		digest: append([]byte{}, digest...),
+		value:  append([]byte{}, value...),
+	}
+}
+
+func (s *Signature) Digest() []byte {
+	if s == nil {
+		return nil
+	}
+	return append([]byte{}, s.digest...)
+}
+
+func (s *Signature) Value() []byte {
+	if s == nil {
+		return nil
+	}
+	return append([]byte{}, s.value...)
+}
+
+// Verify reports whether the signature was made with one of the private keys of the public keys.
+func (s *Signature) Verify(keys []ed25519.PublicKey) bool {
+	if s == nil || len(s.value) != ed25519.SignatureSize {
+		return false
+	}
+	for _, k := range keys {
+		if len(k) == ed25519.PublicKeySize && ed25519.Verify(k, s.digest, s.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Signature) Clone() *Signature {
+	if s == nil {
+		return nil
+	}
+	return NewSignature(s.digest, s.value)
+}
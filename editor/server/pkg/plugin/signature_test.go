@@ -0,0 +1,22 @@
+package plugin
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignature_Verify(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	pub2, _, _ := ed25519.GenerateKey(nil)
+	digest := []byte("digest")
+
+	s := NewSignature(digest, ed25519.Sign(priv, digest))
+	assert.True(t, s.Verify([]ed25519.PublicKey{pub2, pub}))
+	assert.False(t, s.Verify([]ed25519.PublicKey{pub2}))
+	assert.False(t, s.Verify(nil))
+	assert.False(t, NewSignature([]byte("other"), s.Value()).Verify([]ed25519.PublicKey{pub}))
+	assert.False(t, (*Signature)(nil).Verify([]ed25519.PublicKey{pub}))
+	assert.Equal(t, s, s.Clone())
+}
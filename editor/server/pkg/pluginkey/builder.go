@@ -0,0 +1,54 @@
+package pluginkey
+
+import "crypto/ed25519"
+
+type Builder struct {
+	k *Key
+}
+
+func New() *Builder {
+	return &Builder{k: &Key{}}
+}
+
+func (b *Builder) Build() (*Key, error) {
+	if b.k.id.IsNil() || b.k.workspace.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if len(b.k.publicKey) != ed25519.PublicKeySize {
+		return nil, ErrInvalidPublicKey
+	}
+	return b.k, nil
+}
+
+func (b *Builder) MustBuild() *Key {
+	k, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.k.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.k.id = NewID()
+	return b
+}
+
+func (b *Builder) Workspace(w WorkspaceID) *Builder {
+	b.k.workspace = w
+	return b
+}
+
+func (b *Builder) Name(name string) *Builder {
+	b.k.name = name
+	return b
+}
+
+func (b *Builder) PublicKey(k ed25519.PublicKey) *Builder {
+	b.k.publicKey = append(ed25519.PublicKey{}, k...)
+	return b
+}
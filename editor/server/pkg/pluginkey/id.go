@@ -0,0 +1,20 @@
+package pluginkey
+
+import (
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type ID = id.PluginKeyID
+type WorkspaceID = accountdomain.WorkspaceID
+
+type IDList = id.PluginKeyIDList
+
+var NewID = id.NewPluginKeyID
+var MustID = id.MustPluginKeyID
+var IDFrom = id.PluginKeyIDFrom
+var IDFromRef = id.PluginKeyIDFromRef
+
+var NewWorkspaceID = accountdomain.NewWorkspaceID
+
+var ErrInvalidID = id.ErrInvalidID
@@ -0,0 +1,97 @@
+package pluginkey
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/plugin"
+)
+
+var ErrInvalidPublicKey = errors.New("invalid plugin signing public key")
+
+// Key is a public key registered in a workspace to approve plugins signed with its private key.
+type Key struct {
+	id        ID
+	workspace WorkspaceID
+	name      string
+	publicKey ed25519.PublicKey
+}
+
+func (k *Key) ID() ID {
+	return k.id
+}
+
+func (k *Key) Workspace() WorkspaceID {
+	return k.workspace
+}
+
+func (k *Key) Name() string {
+	return k.name
+}
+
+func (k *Key) PublicKey() ed25519.PublicKey {
+	return append(ed25519.PublicKey{}, k.publicKey...)
+}
+
+// String returns the public key encoded in base64.
+func (k *Key) String() string {
+	return base64.StdEncoding.EncodeToString(k.publicKey)
+}
+
+func (k *Key) CreatedAt() time.Time {
+	return k.id.Timestamp()
+}
+
+func (k *Key) Rename(name string) {
+	k.name = name
+}
+
+type List []*Key
+
+func (l List) PublicKeys() []ed25519.PublicKey {
+	res := make([]ed25519.PublicKey, 0, len(l))
+	for _, k := range l {
+		if k != nil {
+			res = append(res, k.publicKey)
+		}
+	}
+	return res
+}
+
+// Approves reports whether the plugin can run in scenes of a workspace with the keys.
+// If no key is registered, all plugins are approved. Otherwise the official plugin and plugins signed with one of the keys are approved.
+func (l List) Approves(p *plugin.Plugin) bool {
+	if len(l) == 0 {
+		return true
+	}
+	if p == nil {
+		return false
+	}
+	return p.ID().System() || p.Signature().Verify(l.PublicKeys())
+}
+
+// ParsePublicKey parses an Ed25519 public key encoded in base64 or in a PEM "PUBLIC KEY" block such as the output of "openssl pkey -pubout".
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	s = strings.TrimSpace(s)
+	if b, _ := pem.Decode([]byte(s)); b != nil {
+		k, err := x509.ParsePKIXPublicKey(b.Bytes)
+		if err != nil {
+			return nil, ErrInvalidPublicKey
+		}
+		if pk, ok := k.(ed25519.PublicKey); ok {
+			return pk, nil
+		}
+		return nil, ErrInvalidPublicKey
+	}
+
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(b) != ed25519.PublicKeySize {
+		return nil, ErrInvalidPublicKey
+	}
+	return ed25519.PublicKey(b), nil
+}
@@ -0,0 +1,59 @@
+package pluginkey
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(nil)
+	der, _ := x509.MarshalPKIXPublicKey(pub)
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	k, err := ParsePublicKey(base64.StdEncoding.EncodeToString(pub))
+	assert.NoError(t, err)
+	assert.Equal(t, pub, k)
+
+	k, err = ParsePublicKey(pemKey)
+	assert.NoError(t, err)
+	assert.Equal(t, pub, k)
+
+	_, err = ParsePublicKey("xxx")
+	assert.Equal(t, ErrInvalidPublicKey, err)
+	_, err = ParsePublicKey(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.Equal(t, ErrInvalidPublicKey, err)
+}
+
+func TestList_Approves(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	key := New().NewID().Workspace(NewWorkspaceID()).Name("key").PublicKey(pub).MustBuild()
+	digest := []byte("digest")
+
+	signed := plugin.New().ID(plugin.MustID("a~1.0.0")).Signature(plugin.NewSignature(digest, ed25519.Sign(priv, digest))).MustBuild()
+	unsigned := plugin.New().ID(plugin.MustID("b~1.0.0")).MustBuild()
+	official := plugin.New().ID(plugin.OfficialPluginID).MustBuild()
+
+	assert.True(t, List{}.Approves(unsigned))
+	assert.True(t, List{key}.Approves(signed))
+	assert.True(t, List{key}.Approves(official))
+	assert.False(t, List{key}.Approves(unsigned))
+	assert.False(t, List{key}.Approves(nil))
+}
+
+func TestBuilder(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(nil)
+	_, err := New().NewID().Workspace(NewWorkspaceID()).Build()
+	assert.Equal(t, ErrInvalidPublicKey, err)
+	_, err = New().NewID().PublicKey(pub).Build()
+	assert.Equal(t, ErrInvalidID, err)
+
+	k := New().NewID().Workspace(NewWorkspaceID()).Name("key").PublicKey(pub).MustBuild()
+	assert.Equal(t, pub, k.PublicKey())
+	assert.Equal(t, base64.StdEncoding.EncodeToString(pub), k.String())
+}
@@ -25,6 +25,11 @@ type Option struct {
 	AssetStorageSize      *int64
 	DatasetSchemaCount    *int
 	DatasetCount          *int
+	NLSLayerCount         *int
+	SketchFeatureCount    *int
+	StoryCount            *int
+	StoryPageCount        *int
+	PublishedStoryCount   *int
 }
 
 func New(opts Option) *Policy {
@@ -71,6 +76,31 @@ func (p *Policy) EnforceDatasetCount(count int) error {
 	return p.error(p == nil || p.opts.DatasetCount == nil || *p.opts.DatasetCount >= count)
 }
 
+// EnforceNLSLayerCount checks the number of NLS layers in a scene.
+func (p *Policy) EnforceNLSLayerCount(count int) error {
+	return p.error(p == nil || p.opts.NLSLayerCount == nil || *p.opts.NLSLayerCount >= count)
+}
+
+// EnforceSketchFeatureCount checks the number of sketch features in a NLS layer.
+func (p *Policy) EnforceSketchFeatureCount(count int) error {
+	return p.error(p == nil || p.opts.SketchFeatureCount == nil || *p.opts.SketchFeatureCount >= count)
+}
+
+// EnforceStoryCount checks the number of stories in a scene.
+func (p *Policy) EnforceStoryCount(count int) error {
+	return p.error(p == nil || p.opts.StoryCount == nil || *p.opts.StoryCount >= count)
+}
+
+// EnforceStoryPageCount checks the number of pages in a story.
+func (p *Policy) EnforceStoryPageCount(count int) error {
+	return p.error(p == nil || p.opts.StoryPageCount == nil || *p.opts.StoryPageCount >= count)
+}
+
+// EnforcePublishedStoryCount checks the number of published stories in a workspace.
+func (p *Policy) EnforcePublishedStoryCount(count int) error {
+	return p.error(p == nil || p.opts.PublishedStoryCount == nil || *p.opts.PublishedStoryCount >= count)
+}
+
 func (*Policy) error(ok bool) error {
 	if !ok {
 		return ErrPolicyViolation
@@ -96,5 +126,10 @@ func (p Option) Clone() Option {
 		AssetStorageSize:      util.CloneRef(p.AssetStorageSize),
 		DatasetSchemaCount:    util.CloneRef(p.DatasetSchemaCount),
 		DatasetCount:          util.CloneRef(p.DatasetCount),
+		NLSLayerCount:         util.CloneRef(p.NLSLayerCount),
+		SketchFeatureCount:    util.CloneRef(p.SketchFeatureCount),
+		StoryCount:            util.CloneRef(p.StoryCount),
+		StoryPageCount:        util.CloneRef(p.StoryPageCount),
+		PublishedStoryCount:   util.CloneRef(p.PublishedStoryCount),
 	}
 }
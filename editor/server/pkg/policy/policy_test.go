@@ -150,6 +150,91 @@ func TestPolicy_EnforceDatasetCount(t *testing.T) {
 	})
 }
 
+func TestPolicy_EnforceNLSLayerCount(t *testing.T) {
+	tests := []policyTest[int]{
+		{limit: 0, arg: 0, fail: false},
+		{limit: 1, arg: 0, fail: false},
+		{limit: 1, arg: 1, fail: false},
+		{limit: 1, arg: 2, fail: true},
+		{limitNil: true, arg: 100, fail: false},
+		{policyNil: true, arg: 100, fail: false},
+	}
+
+	testPolicy(t, tests, func(d int) Option {
+		return Option{NLSLayerCount: lo.ToPtr(d)}
+	}, func(p *Policy, a int) error {
+		return p.EnforceNLSLayerCount(a)
+	})
+}
+
+func TestPolicy_EnforceSketchFeatureCount(t *testing.T) {
+	tests := []policyTest[int]{
+		{limit: 0, arg: 0, fail: false},
+		{limit: 1, arg: 0, fail: false},
+		{limit: 1, arg: 1, fail: false},
+		{limit: 1, arg: 2, fail: true},
+		{limitNil: true, arg: 100, fail: false},
+		{policyNil: true, arg: 100, fail: false},
+	}
+
+	testPolicy(t, tests, func(d int) Option {
+		return Option{SketchFeatureCount: lo.ToPtr(d)}
+	}, func(p *Policy, a int) error {
+		return p.EnforceSketchFeatureCount(a)
+	})
+}
+
+func TestPolicy_EnforceStoryCount(t *testing.T) {
+	tests := []policyTest[int]{
+		{limit: 0, arg: 0, fail: false},
+		{limit: 1, arg: 0, fail: false},
+		{limit: 1, arg: 1, fail: false},
+		{limit: 1, arg: 2, fail: true},
+		{limitNil: true, arg: 100, fail: false},
+		{policyNil: true, arg: 100, fail: false},
+	}
+
+	testPolicy(t, tests, func(d int) Option {
+		return Option{StoryCount: lo.ToPtr(d)}
+	}, func(p *Policy, a int) error {
+		return p.EnforceStoryCount(a)
+	})
+}
+
+func TestPolicy_EnforceStoryPageCount(t *testing.T) {
+	tests := []policyTest[int]{
+		{limit: 0, arg: 0, fail: false},
+		{limit: 1, arg: 0, fail: false},
+		{limit: 1, arg: 1, fail: false},
+		{limit: 1, arg: 2, fail: true},
+		{limitNil: true, arg: 100, fail: false},
+		{policyNil: true, arg: 100, fail: false},
+	}
+
+	testPolicy(t, tests, func(d int) Option {
+		return Option{StoryPageCount: lo.ToPtr(d)}
+	}, func(p *Policy, a int) error {
+		return p.EnforceStoryPageCount(a)
+	})
+}
+
+func TestPolicy_EnforcePublishedStoryCount(t *testing.T) {
+	tests := []policyTest[int]{
+		{limit: 0, arg: 0, fail: false},
+		{limit: 1, arg: 0, fail: false},
+		{limit: 1, arg: 1, fail: false},
+		{limit: 1, arg: 2, fail: true},
+		{limitNil: true, arg: 100, fail: false},
+		{policyNil: true, arg: 100, fail: false},
+	}
+
+	testPolicy(t, tests, func(d int) Option {
+		return Option{PublishedStoryCount: lo.ToPtr(d)}
+	}, func(p *Policy, a int) error {
+		return p.EnforcePublishedStoryCount(a)
+	})
+}
+
 func testPolicy[T any](t *testing.T, tests []policyTest[T], f func(d T) Option, tf func(p *Policy, a T) error) {
 	t.Helper()
 	for _, tt := range tests {
@@ -192,6 +277,11 @@ func TestPolicy_Clone(t *testing.T) {
 			AssetStorageSize:      lo.ToPtr(int64(1)),
 			DatasetSchemaCount:    lo.ToPtr(1),
 			DatasetCount:          lo.ToPtr(2),
+			NLSLayerCount:         lo.ToPtr(1),
+			SketchFeatureCount:    lo.ToPtr(1),
+			StoryCount:            lo.ToPtr(1),
+			StoryPageCount:        lo.ToPtr(1),
+			PublishedStoryCount:   lo.ToPtr(1),
 		},
 	}
 	got := p.Clone()
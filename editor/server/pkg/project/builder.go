@@ -76,6 +76,11 @@ func (b *Builder) PublishedAt(publishedAt time.Time) *Builder {
 	return b
 }
 
+func (b *Builder) PublishSchedule(publishSchedule *PublishSchedule) *Builder {
+	b.p.publishSchedule = publishSchedule
+	return b
+}
+
 func (b *Builder) Name(name string) *Builder {
 	b.p.name = name
 	return b
@@ -37,6 +37,7 @@ type Project struct {
 	enableGa          bool
 	trackingId        string
 	sceneId           SceneID
+	publishSchedule   *PublishSchedule
 }
 
 func (p *Project) ID() ID {
@@ -124,6 +125,10 @@ func (p *Project) PublishmentStatus() PublishmentStatus {
 	return p.publishmentStatus
 }
 
+func (p *Project) PublishSchedule() *PublishSchedule {
+	return p.publishSchedule
+}
+
 func (p *Project) Workspace() WorkspaceID {
 	return p.workspace
 }
@@ -215,6 +220,10 @@ func (p *Project) UpdatePublishmentStatus(publishmentStatus PublishmentStatus) {
 	p.publishmentStatus = publishmentStatus
 }
 
+func (p *Project) SetPublishSchedule(publishSchedule *PublishSchedule) {
+	p.publishSchedule = publishSchedule
+}
+
 func (p *Project) UpdateEnableGA(enableGa bool) {
 	p.enableGa = enableGa
 }
@@ -0,0 +1,497 @@
+package projectpack
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearth/server/pkg/visualizer"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+// Models is a set of models which compose a project.
+type Models struct {
+	Project         *project.Project
+	Scene           *scene.Scene
+	Properties      property.List
+	NLSLayers       nlslayer.NLSLayerList
+	Styles          scene.StyleList
+	Stories         storytelling.StoryList
+	Plugins         []*plugin.Plugin
+	PropertySchemas property.SchemaList
+}
+
+// Document is a JSON representation of Models stored in a project package.
+type Document struct {
+	Project         *ProjectDocument          `json:"project"`
+	Scene           *SceneDocument            `json:"scene"`
+	Properties      []*PropertyDocument       `json:"properties,omitempty"`
+	NLSLayers       []*NLSLayerDocument       `json:"nlsLayers,omitempty"`
+	Styles          []*StyleDocument          `json:"styles,omitempty"`
+	Stories         []*StoryDocument          `json:"stories,omitempty"`
+	Plugins         []*PluginDocument         `json:"plugins,omitempty"`
+	PropertySchemas []*PropertySchemaDocument `json:"propertySchemas,omitempty"`
+}
+
+// ProjectDocument does not contain the basic auth password, so basic auth is disabled in imported projects.
+type ProjectDocument struct {
+	ID                string    `json:"id"`
+	Workspace         string    `json:"workspace"`
+	Archived          bool      `json:"archived,omitempty"`
+	BasicAuthUsername string    `json:"basicAuthUsername,omitempty"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+	PublishedAt       time.Time `json:"publishedAt"`
+	Name              string    `json:"name"`
+	Description       string    `json:"description,omitempty"`
+	Alias             string    `json:"alias,omitempty"`
+	ImageURL          string    `json:"imageUrl,omitempty"`
+	PublicTitle       string    `json:"publicTitle,omitempty"`
+	PublicDescription string    `json:"publicDescription,omitempty"`
+	PublicImage       string    `json:"publicImage,omitempty"`
+	PublicNoIndex     bool      `json:"publicNoIndex,omitempty"`
+	Visualizer        string    `json:"visualizer"`
+	PublishmentStatus string    `json:"publishmentStatus,omitempty"`
+	CoreSupport       bool      `json:"coreSupport,omitempty"`
+	EnableGA          bool      `json:"enableGa,omitempty"`
+	TrackingID        string    `json:"trackingId,omitempty"`
+}
+
+type SceneDocument struct {
+	ID          string                     `json:"id"`
+	Project     string                     `json:"project"`
+	Workspace   string                     `json:"workspace"`
+	RootLayer   string                     `json:"rootLayer"`
+	Property    string                     `json:"property"`
+	Widgets     []SceneWidgetDocument      `json:"widgets,omitempty"`
+	AlignSystem *WidgetAlignSystemDocument `json:"alignSystem,omitempty"`
+	Plugins     []ScenePluginDocument      `json:"plugins,omitempty"`
+	Clusters    []SceneClusterDocument     `json:"clusters,omitempty"`
+	Template    bool                       `json:"template,omitempty"`
+	UpdatedAt   time.Time                  `json:"updatedAt"`
+}
+
+type SceneWidgetDocument struct {
+	ID        string `json:"id"`
+	Plugin    string `json:"plugin"`
+	Extension string `json:"extension"`
+	Property  string `json:"property"`
+	Enabled   bool   `json:"enabled,omitempty"`
+	Extended  bool   `json:"extended,omitempty"`
+}
+
+type ScenePluginDocument struct {
+	Plugin   string  `json:"plugin"`
+	Property *string `json:"property,omitempty"`
+}
+
+type SceneClusterDocument struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Property string `json:"property"`
+}
+
+type StyleDocument struct {
+	ID      string             `json:"id"`
+	Name    string             `json:"name"`
+	Value   map[string]any     `json:"value,omitempty"`
+	Scene   string             `json:"scene"`
+	Library *StyleLinkDocument `json:"library,omitempty"`
+}
+
+type StyleLinkDocument struct {
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+}
+
+func NewDocument(m *Models) *Document {
+	if m == nil {
+		return nil
+	}
+
+	d := &Document{
+		Project: NewProject(m.Project),
+		Scene:   NewScene(m.Scene),
+	}
+	for _, p := range m.Properties {
+		if p != nil {
+			d.Properties = append(d.Properties, NewProperty(p))
+		}
+	}
+	for _, l := range m.NLSLayers {
+		if l != nil && *l != nil {
+			d.NLSLayers = append(d.NLSLayers, NewNLSLayer(*l))
+		}
+	}
+	for _, s := range m.Styles {
+		if s != nil {
+			d.Styles = append(d.Styles, NewStyle(s))
+		}
+	}
+	for _, s := range m.Stories {
+		if s != nil {
+			d.Stories = append(d.Stories, NewStory(s))
+		}
+	}
+	for _, p := range m.Plugins {
+		if p != nil {
+			d.Plugins = append(d.Plugins, NewPlugin(p))
+		}
+	}
+	for _, s := range m.PropertySchemas {
+		if s != nil {
+			d.PropertySchemas = append(d.PropertySchemas, NewPropertySchema(s))
+		}
+	}
+	return d
+}
+
+func (d *Document) Models() (*Models, error) {
+	if d == nil || d.Project == nil || d.Scene == nil {
+		return nil, ErrInvalidPackage
+	}
+
+	prj, err := d.Project.Model()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := d.Scene.Model()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Models{
+		Project: prj,
+		Scene:   s,
+	}
+
+	for _, p := range d.Properties {
+		p2, err := p.Model()
+		if err != nil {
+			return nil, err
+		}
+		m.Properties = append(m.Properties, p2)
+	}
+	for _, l := range d.NLSLayers {
+		l2, err := l.Model()
+		if err != nil {
+			return nil, err
+		}
+		m.NLSLayers = append(m.NLSLayers, &l2)
+	}
+	for _, s := range d.Styles {
+		s2, err := s.Model()
+		if err != nil {
+			return nil, err
+		}
+		m.Styles = append(m.Styles, s2)
+	}
+	for _, s := range d.Stories {
+		s2, err := s.Model()
+		if err != nil {
+			return nil, err
+		}
+		m.Stories = append(m.Stories, s2)
+	}
+	// only private plugins of the scene can be included so that a package cannot overwrite other plugins
+	for _, p := range d.Plugins {
+		p2, err := p.Model()
+		if err != nil {
+			return nil, err
+		}
+		if ps := p2.ID().Scene(); ps == nil || *ps != s.ID() {
+			return nil, ErrInvalidPackage
+		}
+		m.Plugins = append(m.Plugins, p2)
+	}
+	for _, ps := range d.PropertySchemas {
+		s2, err := ps.Model()
+		if err != nil {
+			return nil, err
+		}
+		if ss := s2.ID().Plugin().Scene(); ss == nil || *ss != s.ID() {
+			return nil, ErrInvalidPackage
+		}
+		m.PropertySchemas = append(m.PropertySchemas, s2)
+	}
+
+	return m, nil
+}
+
+// Unpublish makes the project and stories private and clears their aliases so that imported projects do not conflict with published ones.
+func (d *Document) Unpublish() {
+	if d == nil {
+		return
+	}
+	if d.Project != nil {
+		d.Project.Alias = ""
+		d.Project.PublishmentStatus = string(project.PublishmentStatusPrivate)
+		d.Project.PublishedAt = time.Time{}
+	}
+	for _, s := range d.Stories {
+		s.Alias = ""
+		s.Status = string(storytelling.PublishmentStatusPrivate)
+		s.PublishedAt = nil
+	}
+}
+
+// Unsign removes the signatures of the plugins. A signature cannot be verified against the files in a package
+// because the digest is calculated over the original plugin package, so an imported plugin has to be approved again.
+func (d *Document) Unsign() {
+	if d == nil {
+		return
+	}
+	for _, p := range d.Plugins {
+		p.Signature = nil
+	}
+}
+
+func NewProject(p *project.Project) *ProjectDocument {
+	if p == nil {
+		return nil
+	}
+
+	imageURL := ""
+	if u := p.ImageURL(); u != nil {
+		imageURL = u.String()
+	}
+
+	return &ProjectDocument{
+		ID:                p.ID().String(),
+		Workspace:         p.Workspace().String(),
+		Archived:          p.IsArchived(),
+		BasicAuthUsername: p.BasicAuthUsername(),
+		UpdatedAt:         p.UpdatedAt(),
+		PublishedAt:       p.PublishedAt(),
+		Name:              p.Name(),
+		Description:       p.Description(),
+		Alias:             p.Alias(),
+		ImageURL:          imageURL,
+		PublicTitle:       p.PublicTitle(),
+		PublicDescription: p.PublicDescription(),
+		PublicImage:       p.PublicImage(),
+		PublicNoIndex:     p.PublicNoIndex(),
+		Visualizer:        string(p.Visualizer()),
+		PublishmentStatus: string(p.PublishmentStatus()),
+		CoreSupport:       p.CoreSupport(),
+		EnableGA:          p.EnableGA(),
+		TrackingID:        p.TrackingID(),
+	}
+}
+
+func (d *ProjectDocument) Model() (*project.Project, error) {
+	pid, err := id.ProjectIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	wid, err := accountdomain.WorkspaceIDFrom(d.Workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	var imageURL *url.URL
+	if d.ImageURL != "" {
+		if imageURL, err = url.Parse(d.ImageURL); err != nil {
+			imageURL = nil
+		}
+	}
+
+	return project.New().
+		ID(pid).
+		Workspace(wid).
+		IsArchived(d.Archived).
+		BasicAuthUsername(d.BasicAuthUsername).
+		UpdatedAt(d.UpdatedAt).
+		PublishedAt(d.PublishedAt).
+		Name(d.Name).
+		Description(d.Description).
+		Alias(d.Alias).
+		ImageURL(imageURL).
+		PublicTitle(d.PublicTitle).
+		PublicDescription(d.PublicDescription).
+		PublicImage(d.PublicImage).
+		PublicNoIndex(d.PublicNoIndex).
+		Visualizer(visualizer.Visualizer(d.Visualizer)).
+		PublishmentStatus(project.PublishmentStatus(d.PublishmentStatus)).
+		CoreSupport(d.CoreSupport).
+		EnableGA(d.EnableGA).
+		TrackingID(d.TrackingID).
+		Build()
+}
+
+func NewScene(s *scene.Scene) *SceneDocument {
+	if s == nil {
+		return nil
+	}
+
+	d := &SceneDocument{
+		ID:          s.ID().String(),
+		Project:     s.Project().String(),
+		Workspace:   s.Workspace().String(),
+		RootLayer:   s.RootLayer().String(),
+		Property:    s.Property().String(),
+		AlignSystem: NewWidgetAlignSystem(s.Widgets().Alignment()),
+		UpdatedAt:   s.UpdatedAt(),
+		Template:    s.Template(),
+	}
+
+	for _, w := range s.Widgets().Widgets() {
+		d.Widgets = append(d.Widgets, SceneWidgetDocument{
+			ID:        w.ID().String(),
+			Plugin:    w.Plugin().String(),
+			Extension: string(w.Extension()),
+			Property:  w.Property().String(),
+			Enabled:   w.Enabled(),
+			Extended:  w.Extended(),
+		})
+	}
+	for _, p := range s.Plugins().Plugins() {
+		d.Plugins = append(d.Plugins, ScenePluginDocument{
+			Plugin:   p.Plugin().String(),
+			Property: p.Property().StringRef(),
+		})
+	}
+	for _, c := range s.Clusters().Clusters() {
+		d.Clusters = append(d.Clusters, SceneClusterDocument{
+			ID:       c.ID().String(),
+			Name:     c.Name(),
+			Property: c.Property().String(),
+		})
+	}
+	return d
+}
+
+func (d *SceneDocument) Model() (*scene.Scene, error) {
+	sid, err := id.SceneIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	pid, err := id.ProjectIDFrom(d.Project)
+	if err != nil {
+		return nil, err
+	}
+	wid, err := accountdomain.WorkspaceIDFrom(d.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	lid, err := id.LayerIDFrom(d.RootLayer)
+	if err != nil {
+		return nil, err
+	}
+	prid, err := id.PropertyIDFrom(d.Property)
+	if err != nil {
+		return nil, err
+	}
+
+	widgets := make([]*scene.Widget, 0, len(d.Widgets))
+	for _, w := range d.Widgets {
+		wid, err := id.WidgetIDFrom(w.ID)
+		if err != nil {
+			return nil, err
+		}
+		pid, err := id.PluginIDFrom(w.Plugin)
+		if err != nil {
+			return nil, err
+		}
+		prid, err := id.PropertyIDFrom(w.Property)
+		if err != nil {
+			return nil, err
+		}
+		sw, err := scene.NewWidget(wid, pid, id.PluginExtensionID(w.Extension), prid, w.Enabled, w.Extended)
+		if err != nil {
+			return nil, err
+		}
+		widgets = append(widgets, sw)
+	}
+
+	plugins := make([]*scene.Plugin, 0, len(d.Plugins))
+	for _, p := range d.Plugins {
+		pid, err := id.PluginIDFrom(p.Plugin)
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, scene.NewPlugin(pid, id.PropertyIDFromRef(p.Property)))
+	}
+
+	clusters := make([]*scene.Cluster, 0, len(d.Clusters))
+	for _, c := range d.Clusters {
+		cid, err := id.ClusterIDFrom(c.ID)
+		if err != nil {
+			return nil, err
+		}
+		prid, err := id.PropertyIDFrom(c.Property)
+		if err != nil {
+			return nil, err
+		}
+		cl, err := scene.NewCluster(cid, c.Name, prid)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, cl)
+	}
+
+	return scene.New().
+		ID(sid).
+		Project(pid).
+		Workspace(wid).
+		RootLayer(lid).
+		Property(prid).
+		Widgets(scene.NewWidgets(widgets, d.AlignSystem.Model())).
+		Plugins(scene.NewPlugins(plugins)).
+		Clusters(scene.NewClusterListFrom(clusters)).
+		UpdatedAt(d.UpdatedAt).
+		Template(d.Template).
+		Build()
+}
+
+func NewStyle(s *scene.Style) *StyleDocument {
+	var value map[string]any
+	if v := s.Value(); v != nil {
+		value = *v
+	}
+	var library *StyleLinkDocument
+	if l := s.Link(); l != nil {
+		library = &StyleLinkDocument{ID: l.Library().String(), Version: l.Version()}
+	}
+	return &StyleDocument{
+		ID:      s.ID().String(),
+		Name:    s.Name(),
+		Value:   value,
+		Scene:   s.Scene().String(),
+		Library: library,
+	}
+}
+
+func (d *StyleDocument) Model() (*scene.Style, error) {
+	sid, err := id.StyleIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	scid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+	var link *scene.StyleLink
+	if d.Library != nil {
+		lid, err := id.LibraryStyleIDFrom(d.Library.ID)
+		if err != nil {
+			return nil, err
+		}
+		link = scene.NewStyleLink(lid, d.Library.Version)
+	}
+	value := scene.StyleValue(d.Value)
+	return scene.NewStyle().
+		ID(sid).
+		Name(d.Name).
+		Value(&value).
+		Scene(scid).
+		Link(link).
+		Build()
+}
@@ -0,0 +1,143 @@
+package projectpack
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+// Mapping maps strings in a document, such as IDs and asset URLs, to new ones.
+type Mapping map[string]string
+
+// NewIDMapping generates new IDs for all entities in the document.
+// Private plugins and their property schemas are also remapped because their IDs contain the scene ID.
+func (d *Document) NewIDMapping() Mapping {
+	m := Mapping{}
+	if d == nil {
+		return m
+	}
+
+	add := func(old string, gen func() string) {
+		if old == "" {
+			return
+		}
+		if _, ok := m[old]; !ok {
+			m[old] = gen()
+		}
+	}
+
+	if d.Project != nil {
+		add(d.Project.ID, func() string { return id.NewProjectID().String() })
+	}
+
+	if s := d.Scene; s != nil {
+		add(s.ID, func() string { return id.NewSceneID().String() })
+		add(s.RootLayer, func() string { return id.NewLayerID().String() })
+		for _, w := range s.Widgets {
+			add(w.ID, func() string { return id.NewWidgetID().String() })
+		}
+		for _, c := range s.Clusters {
+			add(c.ID, func() string { return id.NewClusterID().String() })
+		}
+	}
+
+	var addItems func([]*PropertyItemDocument)
+	addItems = func(items []*PropertyItemDocument) {
+		for _, i := range items {
+			add(i.ID, func() string { return id.NewPropertyItemID().String() })
+			addItems(i.Groups)
+		}
+	}
+	for _, p := range d.Properties {
+		add(p.ID, func() string { return id.NewPropertyID().String() })
+		addItems(p.Items)
+	}
+
+	for _, l := range d.NLSLayers {
+		add(l.ID, func() string { return id.NewNLSLayerID().String() })
+		if l.Infobox != nil {
+			for _, b := range l.Infobox.Blocks {
+				add(b.ID, func() string { return id.NewInfoboxBlockID().String() })
+			}
+		}
+		if l.Sketch != nil && l.Sketch.FeatureCollection != nil {
+			for _, f := range l.Sketch.FeatureCollection.Features {
+				add(f.ID, func() string { return id.NewFeatureID().String() })
+			}
+		}
+	}
+
+	for _, s := range d.Styles {
+		add(s.ID, func() string { return id.NewStyleID().String() })
+	}
+
+	for _, s := range d.Stories {
+		add(s.ID, func() string { return id.NewStoryID().String() })
+		for _, p := range s.Pages {
+			add(p.ID, func() string { return id.NewPageID().String() })
+			for _, b := range p.Blocks {
+				add(b.ID, func() string { return id.NewBlockID().String() })
+			}
+		}
+	}
+
+	return m
+}
+
+func (m Mapping) replacer() *strings.Replacer {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	// longer strings such as URLs take precedence over IDs contained in them
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	pairs := make([]string, 0, len(keys)*2)
+	for _, k := range keys {
+		pairs = append(pairs, k, m[k])
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+func (m Mapping) Replace(s string) string {
+	return m.replacer().Replace(s)
+}
+
+// Replace returns a new document in which all occurrences of the mapping keys are replaced.
+func (d *Document) Replace(m Mapping) (*Document, error) {
+	b := &bytes.Buffer{}
+	if err := newJSONEncoder(b).Encode(d); err != nil {
+		return nil, err
+	}
+
+	res := &Document{}
+	if err := json.Unmarshal([]byte(m.replacer().Replace(b.String())), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Referenced returns the strings, such as asset URLs, which appear in the document.
+func (d *Document) Referenced(ss []string) ([]string, error) {
+	b := &bytes.Buffer{}
+	if err := newJSONEncoder(b).Encode(d); err != nil {
+		return nil, err
+	}
+
+	s := b.String()
+	res := make([]string, 0, len(ss))
+	for _, s2 := range ss {
+		if s2 != "" && strings.Contains(s, s2) {
+			res = append(res, s2)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,295 @@
+package projectpack
+
+import (
+	"errors"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+)
+
+type NLSLayerDocument struct {
+	ID        string                   `json:"id"`
+	Title     string                   `json:"title"`
+	Visible   bool                     `json:"visible"`
+	Scene     string                   `json:"scene"`
+	LayerType string                   `json:"layerType,omitempty"`
+	Infobox   *NLSLayerInfoboxDocument `json:"infobox,omitempty"`
+	Simple    *NLSLayerSimpleDocument  `json:"simple,omitempty"`
+	Group     *NLSLayerGroupDocument   `json:"group,omitempty"`
+	IsSketch  bool                     `json:"isSketch,omitempty"`
+	Sketch    *NLSLayerSketchDocument  `json:"sketch,omitempty"`
+	Cluster   *string                  `json:"cluster,omitempty"`
+}
+
+type NLSLayerSimpleDocument struct {
+	Config map[string]any `json:"config,omitempty"`
+}
+
+type NLSLayerGroupDocument struct {
+	Children []string       `json:"children,omitempty"`
+	Root     bool           `json:"root,omitempty"`
+	Config   map[string]any `json:"config,omitempty"`
+}
+
+type NLSLayerInfoboxDocument struct {
+	Property string                         `json:"property"`
+	Blocks   []NLSLayerInfoboxBlockDocument `json:"blocks,omitempty"`
+}
+
+type NLSLayerInfoboxBlockDocument struct {
+	ID        string `json:"id"`
+	Property  string `json:"property"`
+	Plugin    string `json:"plugin"`
+	Extension string `json:"extension"`
+}
+
+type NLSLayerSketchDocument struct {
+	CustomPropertySchema *map[string]any                    `json:"customPropertySchema,omitempty"`
+	FeatureCollection    *NLSLayerFeatureCollectionDocument `json:"featureCollection,omitempty"`
+}
+
+type NLSLayerFeatureCollectionDocument struct {
+	Type     string                    `json:"type"`
+	Features []NLSLayerFeatureDocument `json:"features"`
+}
+
+type NLSLayerFeatureDocument struct {
+	ID         string         `json:"id"`
+	Type       string         `json:"type"`
+	Geometry   map[string]any `json:"geometry"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+func NewNLSLayer(l nlslayer.NLSLayer) *NLSLayerDocument {
+	d := &NLSLayerDocument{
+		ID:        l.ID().String(),
+		Title:     l.Title(),
+		Visible:   l.IsVisible(),
+		Scene:     l.Scene().String(),
+		LayerType: string(l.LayerType()),
+		Infobox:   newNLSLayerInfobox(l.Infobox()),
+		IsSketch:  l.IsSketch(),
+		Sketch:    newNLSLayerSketch(l.Sketch()),
+		Cluster:   l.Cluster().StringRef(),
+	}
+
+	if lg := nlslayer.NLSLayerGroupFromLayer(l); lg != nil {
+		d.Group = &NLSLayerGroupDocument{
+			Children: lg.Children().Strings(),
+			Root:     lg.IsRoot(),
+			Config:   configMap(lg.Config()),
+		}
+	} else if ls := nlslayer.NLSLayerSimpleFromLayer(l); ls != nil {
+		d.Simple = &NLSLayerSimpleDocument{
+			Config: configMap(ls.Config()),
+		}
+	}
+
+	return d
+}
+
+func (d *NLSLayerDocument) Model() (nlslayer.NLSLayer, error) {
+	lid, err := id.NLSLayerIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	sid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+	ib, err := d.Infobox.Model()
+	if err != nil {
+		return nil, err
+	}
+	sketch, err := d.Sketch.Model()
+	if err != nil {
+		return nil, err
+	}
+	lt, _ := nlslayer.NewLayerType(d.LayerType)
+
+	if d.Group != nil {
+		children, err := id.NLSLayerIDListFrom(d.Group.Children)
+		if err != nil {
+			return nil, err
+		}
+		config := nlslayer.Config(d.Group.Config)
+		return nlslayer.NewNLSLayerGroup().
+			ID(lid).
+			Title(d.Title).
+			LayerType(lt).
+			IsVisible(d.Visible).
+			Infobox(ib).
+			Scene(sid).
+			Root(d.Group.Root).
+			Layers(nlslayer.NewIDList(children)).
+			Config(&config).
+			IsSketch(d.IsSketch).
+			Sketch(sketch).
+			Cluster(id.ClusterIDFromRef(d.Cluster)).
+			Build()
+	}
+
+	if d.Simple != nil {
+		config := nlslayer.Config(d.Simple.Config)
+		return nlslayer.NewNLSLayerSimple().
+			ID(lid).
+			Title(d.Title).
+			LayerType(lt).
+			IsVisible(d.Visible).
+			Infobox(ib).
+			Scene(sid).
+			Config(&config).
+			IsSketch(d.IsSketch).
+			Sketch(sketch).
+			Cluster(id.ClusterIDFromRef(d.Cluster)).
+			Build()
+	}
+
+	return nil, errors.New("invalid layer")
+}
+
+func configMap(c *nlslayer.Config) map[string]any {
+	if c == nil {
+		return nil
+	}
+	return *c
+}
+
+func newNLSLayerInfobox(ib *nlslayer.Infobox) *NLSLayerInfoboxDocument {
+	if ib == nil {
+		return nil
+	}
+	d := &NLSLayerInfoboxDocument{
+		Property: ib.Property().String(),
+	}
+	for _, b := range ib.Blocks() {
+		d.Blocks = append(d.Blocks, NLSLayerInfoboxBlockDocument{
+			ID:        b.ID().String(),
+			Property:  b.Property().String(),
+			Plugin:    b.Plugin().String(),
+			Extension: string(b.Extension()),
+		})
+	}
+	return d
+}
+
+func (d *NLSLayerInfoboxDocument) Model() (*nlslayer.Infobox, error) {
+	if d == nil {
+		return nil, nil
+	}
+	prid, err := id.PropertyIDFrom(d.Property)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*nlslayer.InfoboxBlock, 0, len(d.Blocks))
+	for _, b := range d.Blocks {
+		bid, err := id.InfoboxBlockIDFrom(b.ID)
+		if err != nil {
+			return nil, err
+		}
+		bprid, err := id.PropertyIDFrom(b.Property)
+		if err != nil {
+			return nil, err
+		}
+		pid, err := id.PluginIDFrom(b.Plugin)
+		if err != nil {
+			return nil, err
+		}
+		block, err := nlslayer.NewInfoboxBlock().
+			ID(bid).
+			Plugin(pid).
+			Extension(id.PluginExtensionID(b.Extension)).
+			Property(bprid).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return nlslayer.NewInfobox(blocks, prid), nil
+}
+
+func newNLSLayerSketch(s *nlslayer.SketchInfo) *NLSLayerSketchDocument {
+	if s == nil {
+		return nil
+	}
+
+	d := &NLSLayerSketchDocument{
+		CustomPropertySchema: s.CustomPropertySchema(),
+	}
+	if fc := s.FeatureCollection(); fc != nil {
+		features := fc.Features()
+		d.FeatureCollection = &NLSLayerFeatureCollectionDocument{
+			Type:     fc.FeatureCollectionType(),
+			Features: make([]NLSLayerFeatureDocument, 0, len(features)),
+		}
+		for _, f := range features {
+			var props map[string]any
+			if p := f.Properties(); p != nil {
+				props = *p
+			}
+			d.FeatureCollection.Features = append(d.FeatureCollection.Features, NLSLayerFeatureDocument{
+				ID:         f.ID().String(),
+				Type:       f.FeatureType(),
+				Geometry:   geometryMap(f.Geometry()),
+				Properties: props,
+			})
+		}
+	}
+	return d
+}
+
+func (d *NLSLayerSketchDocument) Model() (*nlslayer.SketchInfo, error) {
+	if d == nil {
+		return nil, nil
+	}
+	if d.FeatureCollection == nil {
+		return nlslayer.NewSketchInfo(d.CustomPropertySchema, nil), nil
+	}
+
+	features := make([]nlslayer.Feature, 0, len(d.FeatureCollection.Features))
+	for _, f := range d.FeatureCollection.Features {
+		fid, err := id.FeatureIDFrom(f.ID)
+		if err != nil {
+			return nil, err
+		}
+		g, err := nlslayer.NewGeometryFromMap(f.Geometry)
+		if err != nil {
+			return nil, err
+		}
+		feature, err := nlslayer.NewFeature(fid, f.Type, g)
+		if err != nil {
+			return nil, err
+		}
+		if f.Properties != nil {
+			feature.UpdateProperties(&f.Properties)
+		}
+		features = append(features, *feature)
+	}
+
+	return nlslayer.NewSketchInfo(
+		d.CustomPropertySchema,
+		nlslayer.NewFeatureCollection(d.FeatureCollection.Type, features),
+	), nil
+}
+
+func geometryMap(g nlslayer.Geometry) map[string]any {
+	switch g := g.(type) {
+	case *nlslayer.Point:
+		return map[string]any{"type": g.PointType(), "coordinates": g.Coordinates()}
+	case *nlslayer.LineString:
+		return map[string]any{"type": g.LineStringType(), "coordinates": g.Coordinates()}
+	case *nlslayer.Polygon:
+		return map[string]any{"type": g.PolygonType(), "coordinates": g.Coordinates()}
+	case *nlslayer.MultiPolygon:
+		return map[string]any{"type": g.MultiPolygonType(), "coordinates": g.Coordinates()}
+	case *nlslayer.GeometryCollection:
+		geometries := make([]map[string]any, 0, len(g.Geometries()))
+		for _, g2 := range g.Geometries() {
+			geometries = append(geometries, geometryMap(g2))
+		}
+		return map[string]any{"type": g.GeometryCollectionType(), "geometries": geometries}
+	}
+	return nil
+}
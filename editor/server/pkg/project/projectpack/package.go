@@ -0,0 +1,194 @@
+// Package projectpack reads and writes project packages, which are zip archives containing a project with its scene, layers, stories, properties, private plugins and assets.
+package projectpack
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"time"
+
+	"github.com/kennygrant/sanitize"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+const (
+	Version          = 1
+	manifestFilePath = "manifest.json"
+	projectFilePath  = "project.json"
+	assetDir         = "assets"
+	pluginDir        = "plugins"
+)
+
+var (
+	ErrInvalidPackage     = errors.New("invalid project package")
+	ErrUnsupportedVersion = errors.New("unsupported project package version")
+)
+
+type Manifest struct {
+	Version     int          `json:"version"`
+	ExportedAt  time.Time    `json:"exportedAt"`
+	Project     string       `json:"project"`
+	Scene       string       `json:"scene"`
+	Assets      []Asset      `json:"assets,omitempty"`
+	PluginFiles []PluginFile `json:"pluginFiles,omitempty"`
+}
+
+// Asset is an asset referenced by the project. URL is the original URL which appears in the document.
+type Asset struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	ContentType string `json:"contentType,omitempty"`
+	Size        int64  `json:"size"`
+	Path        string `json:"path"`
+}
+
+type PluginFile struct {
+	Plugin string `json:"plugin"`
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+}
+
+type Writer struct {
+	zw       *zip.Writer
+	manifest Manifest
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		zw: zip.NewWriter(w),
+		manifest: Manifest{
+			Version:    Version,
+			ExportedAt: util.Now(),
+		},
+	}
+}
+
+func (w *Writer) WriteDocument(d *Document) error {
+	if d == nil || d.Project == nil || d.Scene == nil {
+		return ErrInvalidPackage
+	}
+
+	w.manifest.Project = d.Project.ID
+	w.manifest.Scene = d.Scene.ID
+	return w.writeJSON(projectFilePath, d)
+}
+
+func (w *Writer) WriteAsset(a Asset, r io.Reader) error {
+	a.Path = path.Join(assetDir, sanitize.Path(a.ID), sanitize.Path(a.Name))
+	if err := w.write(a.Path, r); err != nil {
+		return err
+	}
+	w.manifest.Assets = append(w.manifest.Assets, a)
+	return nil
+}
+
+func (w *Writer) WritePluginFile(plugin, name string, r io.Reader) error {
+	f := PluginFile{
+		Plugin: plugin,
+		Name:   name,
+		Path:   path.Join(pluginDir, sanitize.Path(plugin), sanitize.Path(name)),
+	}
+	if err := w.write(f.Path, r); err != nil {
+		return err
+	}
+	w.manifest.PluginFiles = append(w.manifest.PluginFiles, f)
+	return nil
+}
+
+// Close writes the manifest and closes the archive.
+func (w *Writer) Close() error {
+	if err := w.writeJSON(manifestFilePath, w.manifest); err != nil {
+		return err
+	}
+	return w.zw.Close()
+}
+
+func (w *Writer) write(name string, r io.Reader) error {
+	f, err := w.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	return err
+}
+
+func (w *Writer) writeJSON(name string, v any) error {
+	f, err := w.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	return newJSONEncoder(f).Encode(v)
+}
+
+type Package struct {
+	Manifest Manifest
+	Document *Document
+	zr       *zip.Reader
+}
+
+func Read(r io.Reader, sizeLimit int64) (*Package, error) {
+	b, err := io.ReadAll(io.LimitReader(r, sizeLimit))
+	if err != nil {
+		return nil, rerror.From("zip read error", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, rerror.From("zip open error", err)
+	}
+
+	p := &Package{zr: zr}
+	if err := p.readJSON(manifestFilePath, &p.Manifest); err != nil {
+		return nil, err
+	}
+	if p.Manifest.Version != Version {
+		return nil, ErrUnsupportedVersion
+	}
+
+	d := &Document{}
+	if err := p.readJSON(projectFilePath, d); err != nil {
+		return nil, err
+	}
+	if d.Project == nil || d.Scene == nil {
+		return nil, ErrInvalidPackage
+	}
+	p.Document = d
+
+	return p, nil
+}
+
+// Open opens a file of an asset or a plugin file listed in the manifest.
+func (p *Package) Open(name string) (io.ReadCloser, error) {
+	f, err := p.zr.Open(name)
+	if err != nil {
+		return nil, rerror.From("file open error", err)
+	}
+	return f, nil
+}
+
+func (p *Package) readJSON(name string, v any) error {
+	f, err := p.zr.Open(name)
+	if err != nil {
+		return rerror.From(name+" open error", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if err := json.NewDecoder(f).Decode(v); err != nil {
+		return rerror.From("invalid "+name, err)
+	}
+	return nil
+}
+
+// newJSONEncoder returns an encoder which does not escape HTML characters so that URLs in documents can be found and replaced as they are.
+func newJSONEncoder(w io.Writer) *json.Encoder {
+	e := json.NewEncoder(w)
+	e.SetEscapeHTML(false)
+	return e
+}
@@ -0,0 +1,257 @@
+package projectpack
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/i18n"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearth/server/pkg/visualizer"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackage(t *testing.T) {
+	m := testModels(t)
+	assetURL := "https://example.com/assets/01h.png"
+
+	// export
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf)
+	require.NoError(t, w.WriteDocument(NewDocument(m)))
+	require.NoError(t, w.WriteAsset(Asset{ID: "a", Name: "a.png", URL: assetURL, Size: 3}, bytes.NewReader([]byte("png"))))
+	require.NoError(t, w.WritePluginFile(m.Plugins[0].ID().String(), "widget.js", bytes.NewReader([]byte("js"))))
+	require.NoError(t, w.Close())
+
+	// import
+	p, err := Read(buf, 1024*1024)
+	require.NoError(t, err)
+	// basic auth passwords are not exported
+	doc, _ := json.Marshal(p.Document)
+	assert.NotContains(t, string(doc), "password")
+	assert.Equal(t, Version, p.Manifest.Version)
+	assert.Equal(t, m.Project.ID().String(), p.Manifest.Project)
+	assert.Equal(t, m.Scene.ID().String(), p.Manifest.Scene)
+	assert.Equal(t, []Asset{{ID: "a", Name: "a.png", URL: assetURL, Size: 3, Path: "assets/a/a.png"}}, p.Manifest.Assets)
+	assert.Len(t, p.Manifest.PluginFiles, 1)
+
+	f, err := p.Open(p.Manifest.Assets[0].Path)
+	require.NoError(t, err)
+	data, _ := io.ReadAll(f)
+	assert.Equal(t, "png", string(data))
+
+	refs, err := p.Document.Referenced([]string{assetURL, "https://example.com/assets/unused.png"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{assetURL}, refs)
+
+	ws := accountdomain.NewWorkspaceID()
+	mapping := p.Document.NewIDMapping()
+	mapping[assetURL] = "https://example.com/assets/new.png"
+	mapping[m.Project.Workspace().String()] = ws.String()
+
+	d, err := p.Document.Replace(mapping)
+	require.NoError(t, err)
+	d.Unpublish()
+
+	m2, err := d.Models()
+	require.NoError(t, err)
+
+	// project
+	assert.NotEqual(t, m.Project.ID(), m2.Project.ID())
+	assert.Equal(t, ws, m2.Project.Workspace())
+	assert.Equal(t, m.Project.Name(), m2.Project.Name())
+	assert.Equal(t, "", m2.Project.Alias())
+	assert.Equal(t, project.PublishmentStatusPrivate, m2.Project.PublishmentStatus())
+	assert.Equal(t, "https://example.com/assets/new.png", m2.Project.ImageURL().String())
+	assert.False(t, m2.Project.IsBasicAuthActive())
+	assert.Equal(t, "user", m2.Project.BasicAuthUsername())
+	assert.Equal(t, "", m2.Project.BasicAuthPassword())
+
+	// scene
+	s2 := m2.Scene
+	assert.NotEqual(t, m.Scene.ID(), s2.ID())
+	assert.Equal(t, m2.Project.ID(), s2.Project())
+	assert.Equal(t, ws, s2.Workspace())
+	assert.NotEqual(t, m.Scene.RootLayer(), s2.RootLayer())
+	w2 := s2.Widgets().Widgets()[0]
+	assert.NotEqual(t, m.Scene.Widgets().Widgets()[0].ID(), w2.ID())
+	assert.Equal(t, m2.Plugins[0].ID(), w2.Plugin())
+	assert.Equal(t, id.WidgetIDList{w2.ID()}, s2.Widgets().Alignment().Area(widgetLocation).WidgetIDs())
+
+	// private plugins are moved to the new scene
+	assert.Equal(t, s2.ID().Ref(), m2.Plugins[0].ID().Scene())
+	assert.Equal(t, m2.Plugins[0].ID(), m2.PropertySchemas[0].ID().Plugin())
+	assert.Equal(t, mapping.Replace(p.Manifest.PluginFiles[0].Plugin), m2.Plugins[0].ID().String())
+
+	// properties
+	assert.Len(t, m2.Properties, 2)
+	pr := m2.Properties[0]
+	assert.Equal(t, w2.Property(), pr.ID())
+	assert.Equal(t, s2.ID(), pr.Scene())
+	assert.Equal(t, m2.PropertySchemas[0].ID(), pr.Schema())
+	field, _, _ := pr.Field(property.PointFieldOnly("url"))
+	assert.Equal(t, "https://example.com/assets/new.png", field.Value().ValueURL().String())
+
+	// layers
+	assert.Len(t, m2.NLSLayers, 2)
+	l := nlslayer.NLSLayerSimpleFromLayer(*m2.NLSLayers[0])
+	g := nlslayer.NLSLayerGroupFromLayer(*m2.NLSLayers[1])
+	assert.Equal(t, s2.ID(), l.Scene())
+	assert.Equal(t, []id.NLSLayerID{l.ID()}, g.Children().Layers())
+	assert.Equal(t, "https://example.com/assets/new.png", (*l.Config())["data"].(map[string]any)["url"])
+	assert.Equal(t, m2.Properties[1].ID(), l.Infobox().Property())
+	assert.Equal(t, s2.Clusters().Clusters()[0].ID().Ref(), l.Cluster())
+	assert.NotEqual(t, m.Scene.Clusters().Clusters()[0].ID(), s2.Clusters().Clusters()[0].ID())
+	fs := l.Sketch().FeatureCollection().Features()
+	assert.Len(t, fs, 1)
+	assert.NotEqual(t, nlslayer.NLSLayerSimpleFromLayer(*m.NLSLayers[0]).Sketch().FeatureCollection().Features()[0].ID(), fs[0].ID())
+	assert.Equal(t, []float64{139.1, 35.1}, fs[0].Geometry().(*nlslayer.Point).Coordinates())
+
+	// styles
+	assert.Equal(t, s2.ID(), m2.Styles[0].Scene())
+	assert.Equal(t, m.Styles[0].Name(), m2.Styles[0].Name())
+
+	// stories
+	st := m2.Stories[0]
+	assert.Equal(t, s2.ID(), st.Scene())
+	assert.Equal(t, "", st.Alias())
+	assert.Equal(t, storytelling.PublishmentStatusPrivate, st.Status())
+	assert.False(t, st.IsBasicAuthActive())
+	assert.Equal(t, "", st.BasicAuthPassword())
+	assert.Equal(t, storytelling.LayerIDList{l.ID()}, st.Pages().Pages()[0].Layers())
+}
+
+func TestDocument_Models_ForeignPlugins(t *testing.T) {
+	other := id.NewSceneID()
+	otherPlugin := lo.Must(id.NewPluginID("other", "1.0.0", &other))
+
+	newDocument := func() *Document {
+		return lo.Must(NewDocument(testModels(t)).Replace(Mapping{}))
+	}
+
+	_, err := newDocument().Models()
+	require.NoError(t, err)
+
+	// a plugin of another scene
+	d := newDocument()
+	d.Plugins[0].ID = otherPlugin.String()
+	_, err = d.Models()
+	assert.Equal(t, ErrInvalidPackage, err)
+
+	// a public plugin
+	d = newDocument()
+	d.Plugins[0].ID = "public~1.0.0"
+	_, err = d.Models()
+	assert.Equal(t, ErrInvalidPackage, err)
+
+	// a property schema of another plugin
+	d = newDocument()
+	d.PropertySchemas[0].ID = id.NewPropertySchemaID(otherPlugin, "widget").String()
+	_, err = d.Models()
+	assert.Equal(t, ErrInvalidPackage, err)
+}
+
+func TestRead(t *testing.T) {
+	_, err := Read(bytes.NewReader([]byte("invalid")), 1024)
+	assert.Error(t, err)
+
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf)
+	w.manifest.Version = 100
+	require.NoError(t, w.Close())
+	_, err = Read(buf, 1024)
+	assert.Equal(t, ErrUnsupportedVersion, err)
+}
+
+var widgetLocation = scene.WidgetLocation{
+	Zone:    scene.WidgetZoneInner,
+	Section: scene.WidgetSectionLeft,
+	Area:    scene.WidgetAreaTop,
+}
+
+func testModels(t *testing.T) *Models {
+	t.Helper()
+
+	ws := accountdomain.NewWorkspaceID()
+	sid := id.NewSceneID()
+	pid := lo.Must(id.NewPluginID("test", "1.0.0", &sid))
+	psid := id.NewPropertySchemaID(pid, "widget")
+
+	prj := project.New().NewID().Workspace(ws).Name("project").Alias("alias").
+		Visualizer(visualizer.VisualizerCesium).
+		PublishmentStatus(project.PublishmentStatusPublic).
+		IsBasicAuthActive(true).BasicAuthUsername("user").BasicAuthPassword("password").
+		ImageURL(lo.Must(url.Parse("https://example.com/assets/01h.png"))).MustBuild()
+
+	schema := property.NewSchema().ID(psid).Groups(property.NewSchemaGroupList([]*property.SchemaGroup{
+		property.NewSchemaGroup().ID("default").Fields([]*property.SchemaField{
+			property.NewSchemaField().ID("url").Type(property.ValueTypeURL).MustBuild(),
+		}).MustBuild(),
+	})).MustBuild()
+
+	pl := plugin.New().ID(pid).Name(i18n.StringFrom("test")).Extensions([]*plugin.Extension{
+		plugin.NewExtension().ID("widget").Type(plugin.ExtensionTypeWidget).Schema(psid).MustBuild(),
+	}).MustBuild()
+
+	widgetProperty := property.New().NewID().Scene(sid).Schema(psid).Items([]property.Item{
+		property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+			property.NewField("url").Value(property.NewOptionalValue(property.ValueTypeURL, property.ValueTypeURL.ValueFrom("https://example.com/assets/01h.png"))).MustBuild(),
+		}).MustBuild(),
+	}).MustBuild()
+	infoboxProperty := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/infobox")).MustBuild()
+
+	widget := scene.MustWidget(id.NewWidgetID(), pid, "widget", widgetProperty.ID(), true, false)
+	was := scene.NewWidgetAlignSystem()
+	was.Area(widgetLocation).Add(widget.ID(), -1)
+	cluster := lo.Must(scene.NewCluster(id.NewClusterID(), "cluster", id.NewPropertyID()))
+
+	s := scene.New().ID(sid).Project(prj.ID()).Workspace(ws).RootLayer(id.NewLayerID()).
+		Property(id.NewPropertyID()).
+		Widgets(scene.NewWidgets([]*scene.Widget{widget}, was)).
+		Plugins(scene.NewPlugins([]*scene.Plugin{scene.NewPlugin(pid, nil)})).
+		Clusters(scene.NewClusterListFrom([]*scene.Cluster{cluster})).
+		MustBuild()
+
+	feature, _ := nlslayer.NewFeatureWithNewId("Feature", nlslayer.NewPoint("Point", []float64{139.1, 35.1}))
+	var layer nlslayer.NLSLayer = nlslayer.NewNLSLayerSimple().NewID().Scene(sid).Title("layer").
+		LayerType(nlslayer.Simple).
+		Config(&nlslayer.Config{"data": map[string]any{"type": "geojson", "url": "https://example.com/assets/01h.png"}}).
+		Infobox(nlslayer.NewInfobox(nil, infoboxProperty.ID())).
+		IsSketch(true).
+		Sketch(nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", []nlslayer.Feature{*feature}))).
+		Cluster(cluster.ID().Ref()).
+		MustBuild()
+	var group nlslayer.NLSLayer = nlslayer.NewNLSLayerGroup().NewID().Scene(sid).Title("group").
+		Layers(nlslayer.NewIDList([]id.NLSLayerID{layer.ID()})).
+		MustBuild()
+
+	style := scene.NewStyle().NewID().Scene(sid).Name("style").Value(&scene.StyleValue{"color": "red"}).MustBuild()
+
+	page := storytelling.NewPage().NewID().Property(id.NewPropertyID()).Title("page").Layers([]id.NLSLayerID{layer.ID()}).MustBuild()
+	story := storytelling.NewStory().NewID().Scene(sid).Property(id.NewPropertyID()).Title("story").Alias("story").
+		Status(storytelling.PublishmentStatusPublic).
+		PublicBasicAuth(true, "user", "password").
+		Pages(storytelling.NewPageList([]*storytelling.Page{page})).MustBuild()
+
+	return &Models{
+		Project:         prj,
+		Scene:           s,
+		Properties:      property.List{widgetProperty, infoboxProperty},
+		NLSLayers:       nlslayer.NLSLayerList{&layer, &group},
+		Styles:          scene.StyleList{style},
+		Stories:         storytelling.StoryList{story},
+		Plugins:         []*plugin.Plugin{pl},
+		PropertySchemas: property.SchemaList{schema},
+	}
+}
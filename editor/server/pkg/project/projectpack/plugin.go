@@ -0,0 +1,388 @@
+package projectpack
+
+import (
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/property"
+)
+
+type PluginDocument struct {
+	ID            string                     `json:"id"`
+	Name          map[string]string          `json:"name,omitempty"`
+	Author        string                     `json:"author,omitempty"`
+	Description   map[string]string          `json:"description,omitempty"`
+	RepositoryURL string                     `json:"repositoryUrl,omitempty"`
+	Extensions    []PluginExtensionDocument  `json:"extensions,omitempty"`
+	Schema        *string                    `json:"schema,omitempty"`
+	Permissions   *PluginPermissionsDocument `json:"permissions,omitempty"`
+	Signature     *PluginSignatureDocument   `json:"signature,omitempty"`
+}
+
+type PluginPermissionsDocument struct {
+	Network []string `json:"network,omitempty"`
+	Layers  string   `json:"layers,omitempty"`
+}
+
+type PluginSignatureDocument struct {
+	Digest []byte `json:"digest"`
+	Value  []byte `json:"value"`
+}
+
+type PluginExtensionDocument struct {
+	ID           string                `json:"id"`
+	Type         string                `json:"type"`
+	Name         map[string]string     `json:"name,omitempty"`
+	Description  map[string]string     `json:"description,omitempty"`
+	Icon         string                `json:"icon,omitempty"`
+	Schema       string                `json:"schema"`
+	SingleOnly   bool                  `json:"singleOnly,omitempty"`
+	WidgetLayout *WidgetLayoutDocument `json:"widgetLayout,omitempty"`
+}
+
+type WidgetLayoutDocument struct {
+	Horizontally    bool                    `json:"horizontally,omitempty"`
+	Vertically      bool                    `json:"vertically,omitempty"`
+	Extended        bool                    `json:"extended,omitempty"`
+	Floating        bool                    `json:"floating,omitempty"`
+	DefaultLocation *WidgetLocationDocument `json:"defaultLocation,omitempty"`
+}
+
+type WidgetLocationDocument struct {
+	Zone    string `json:"zone"`
+	Section string `json:"section"`
+	Area    string `json:"area"`
+}
+
+type PropertySchemaDocument struct {
+	ID             string                          `json:"id"`
+	Version        int                             `json:"version"`
+	Groups         []*PropertySchemaGroupDocument  `json:"groups,omitempty"`
+	LinkableFields *PropertyLinkableFieldsDocument `json:"linkableFields,omitempty"`
+}
+
+type PropertySchemaGroupDocument struct {
+	ID                    string                         `json:"id"`
+	Fields                []*PropertySchemaFieldDocument `json:"fields,omitempty"`
+	List                  bool                           `json:"list,omitempty"`
+	IsAvailableIf         *PropertyConditionDocument     `json:"isAvailableIf,omitempty"`
+	Title                 map[string]string              `json:"title,omitempty"`
+	RepresentativeFieldID *string                        `json:"representativeField,omitempty"`
+}
+
+type PropertySchemaFieldDocument struct {
+	ID            string                              `json:"id"`
+	Type          string                              `json:"type"`
+	Name          map[string]string                   `json:"name,omitempty"`
+	Description   map[string]string                   `json:"description,omitempty"`
+	Prefix        string                              `json:"prefix,omitempty"`
+	Suffix        string                              `json:"suffix,omitempty"`
+	DefaultValue  any                                 `json:"defaultValue,omitempty"`
+	UI            *string                             `json:"ui,omitempty"`
+	Min           *float64                            `json:"min,omitempty"`
+	Max           *float64                            `json:"max,omitempty"`
+	Choices       []PropertySchemaFieldChoiceDocument `json:"choices,omitempty"`
+	IsAvailableIf *PropertyConditionDocument          `json:"isAvailableIf,omitempty"`
+}
+
+type PropertySchemaFieldChoiceDocument struct {
+	Key   string            `json:"key"`
+	Label map[string]string `json:"label,omitempty"`
+}
+
+type PropertyConditionDocument struct {
+	Field string `json:"field"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+type PropertyLinkableFieldsDocument struct {
+	LatLng *PropertySchemaFieldPointerDocument `json:"latlng,omitempty"`
+	URL    *PropertySchemaFieldPointerDocument `json:"url,omitempty"`
+}
+
+type PropertySchemaFieldPointerDocument struct {
+	SchemaGroup string `json:"schemaGroup"`
+	Field       string `json:"field"`
+}
+
+func NewPlugin(p *plugin.Plugin) *PluginDocument {
+	d := &PluginDocument{
+		ID:            p.ID().String(),
+		Name:          p.Name(),
+		Author:        p.Author(),
+		Description:   p.Description(),
+		RepositoryURL: p.RepositoryURL(),
+		Schema:        p.Schema().StringRef(),
+	}
+
+	if pp := p.Permissions(); pp != nil {
+		d.Permissions = &PluginPermissionsDocument{
+			Network: pp.Network(),
+			Layers:  string(pp.Layers()),
+		}
+	}
+	if s := p.Signature(); s != nil {
+		d.Signature = &PluginSignatureDocument{
+			Digest: s.Digest(),
+			Value:  s.Value(),
+		}
+	}
+
+	for _, e := range p.Extensions() {
+		var layout *WidgetLayoutDocument
+		if l := e.WidgetLayout(); l != nil {
+			layout = &WidgetLayoutDocument{
+				Horizontally: l.HorizontallyExtendable(),
+				Vertically:   l.VerticallyExtendable(),
+				Extended:     l.Extended(),
+				Floating:     l.Floating(),
+			}
+			if loc := l.DefaultLocation(); loc != nil {
+				layout.DefaultLocation = &WidgetLocationDocument{
+					Zone:    string(loc.Zone),
+					Section: string(loc.Section),
+					Area:    string(loc.Area),
+				}
+			}
+		}
+
+		d.Extensions = append(d.Extensions, PluginExtensionDocument{
+			ID:           string(e.ID()),
+			Type:         string(e.Type()),
+			Name:         e.Name(),
+			Description:  e.Description(),
+			Icon:         e.Icon(),
+			Schema:       e.Schema().String(),
+			SingleOnly:   e.SingleOnly(),
+			WidgetLayout: layout,
+		})
+	}
+
+	return d
+}
+
+func (d *PluginDocument) Model() (*plugin.Plugin, error) {
+	pid, err := id.PluginIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	extensions := make([]*plugin.Extension, 0, len(d.Extensions))
+	for _, e := range d.Extensions {
+		psid, err := id.PropertySchemaIDFrom(e.Schema)
+		if err != nil {
+			return nil, err
+		}
+
+		var layout *plugin.WidgetLayout
+		if l := e.WidgetLayout; l != nil {
+			var loc *plugin.WidgetLocation
+			if l.DefaultLocation != nil {
+				loc = &plugin.WidgetLocation{
+					Zone:    plugin.WidgetZoneType(l.DefaultLocation.Zone),
+					Section: plugin.WidgetSectionType(l.DefaultLocation.Section),
+					Area:    plugin.WidgetAreaType(l.DefaultLocation.Area),
+				}
+			}
+			layout = plugin.NewWidgetLayout(l.Horizontally, l.Vertically, l.Extended, l.Floating, loc).Ref()
+		}
+
+		ext, err := plugin.NewExtension().
+			ID(id.PluginExtensionID(e.ID)).
+			Type(plugin.ExtensionType(e.Type)).
+			Name(e.Name).
+			Description(e.Description).
+			Icon(e.Icon).
+			SingleOnly(e.SingleOnly).
+			WidgetLayout(layout).
+			Schema(psid).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		extensions = append(extensions, ext)
+	}
+
+	return plugin.New().
+		ID(pid).
+		Name(d.Name).
+		Author(d.Author).
+		Description(d.Description).
+		RepositoryURL(d.RepositoryURL).
+		Extensions(extensions).
+		Schema(id.PropertySchemaIDFromRef(d.Schema)).
+		Permissions(d.Permissions.model()).
+		Signature(d.Signature.model()).
+		Build()
+}
+
+func (d *PluginPermissionsDocument) model() *plugin.Permissions {
+	if d == nil {
+		return nil
+	}
+	return plugin.NewPermissions(d.Network, plugin.LayerAccess(d.Layers))
+}
+
+func (d *PluginSignatureDocument) model() *plugin.Signature {
+	if d == nil {
+		return nil
+	}
+	return plugin.NewSignature(d.Digest, d.Value)
+}
+
+func NewPropertySchema(s *property.Schema) *PropertySchemaDocument {
+	d := &PropertySchemaDocument{
+		ID:      s.ID().String(),
+		Version: s.Version(),
+	}
+
+	for _, g := range s.Groups().Groups() {
+		gd := &PropertySchemaGroupDocument{
+			ID:                    string(g.ID()),
+			List:                  g.IsList(),
+			IsAvailableIf:         newPropertyCondition(g.IsAvailableIf()),
+			Title:                 g.Title(),
+			RepresentativeFieldID: g.RepresentativeFieldID().StringRef(),
+		}
+		for _, f := range g.Fields() {
+			fd := &PropertySchemaFieldDocument{
+				ID:            string(f.ID()),
+				Type:          string(f.Type()),
+				Name:          f.Title(),
+				Description:   f.Description(),
+				Prefix:        f.Prefix(),
+				Suffix:        f.Suffix(),
+				DefaultValue:  f.DefaultValue().Interface(),
+				UI:            f.UI().StringRef(),
+				Min:           f.Min(),
+				Max:           f.Max(),
+				IsAvailableIf: newPropertyCondition(f.IsAvailableIf()),
+			}
+			for _, c := range f.Choices() {
+				fd.Choices = append(fd.Choices, PropertySchemaFieldChoiceDocument{
+					Key:   c.Key,
+					Label: c.Title,
+				})
+			}
+			gd.Fields = append(gd.Fields, fd)
+		}
+		d.Groups = append(d.Groups, gd)
+	}
+
+	if l := s.LinkableFields(); l.LatLng != nil || l.URL != nil {
+		d.LinkableFields = &PropertyLinkableFieldsDocument{
+			LatLng: newPropertySchemaFieldPointer(l.LatLng),
+			URL:    newPropertySchemaFieldPointer(l.URL),
+		}
+	}
+
+	return d
+}
+
+func (d *PropertySchemaDocument) Model() (*property.Schema, error) {
+	sid, err := id.PropertySchemaIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*property.SchemaGroup, 0, len(d.Groups))
+	for _, g := range d.Groups {
+		fields := make([]*property.SchemaField, 0, len(g.Fields))
+		for _, f := range g.Fields {
+			var choices []property.SchemaFieldChoice
+			for _, c := range f.Choices {
+				choices = append(choices, property.SchemaFieldChoice{
+					Key:   c.Key,
+					Title: c.Label,
+				})
+			}
+
+			vt := property.ValueType(f.Type)
+			f2, err := property.NewSchemaField().
+				ID(id.PropertyFieldID(f.ID)).
+				Type(vt).
+				Name(f.Name).
+				Description(f.Description).
+				Prefix(f.Prefix).
+				Suffix(f.Suffix).
+				DefaultValue(vt.ValueFrom(f.DefaultValue)).
+				UIRef(property.SchemaFieldUIFromRef(f.UI)).
+				MinRef(f.Min).
+				MaxRef(f.Max).
+				Choices(choices).
+				IsAvailableIf(f.IsAvailableIf.Model()).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, f2)
+		}
+
+		g2, err := property.NewSchemaGroup().
+			ID(id.PropertySchemaGroupID(g.ID)).
+			IsList(g.List).
+			Title(g.Title).
+			IsAvailableIf(g.IsAvailableIf.Model()).
+			Fields(fields).
+			RepresentativeField(id.PropertyFieldIDFromRef(g.RepresentativeFieldID)).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g2)
+	}
+
+	var linkable property.LinkableFields
+	if l := d.LinkableFields; l != nil {
+		linkable.LatLng = l.LatLng.Model()
+		linkable.URL = l.URL.Model()
+	}
+
+	return property.NewSchema().
+		ID(sid).
+		Version(d.Version).
+		Groups(property.NewSchemaGroupList(groups)).
+		LinkableFields(linkable).
+		Build()
+}
+
+func newPropertyCondition(c *property.Condition) *PropertyConditionDocument {
+	if c == nil {
+		return nil
+	}
+	return &PropertyConditionDocument{
+		Field: string(c.Field),
+		Type:  string(c.Value.Type()),
+		Value: c.Value.Interface(),
+	}
+}
+
+func (d *PropertyConditionDocument) Model() *property.Condition {
+	if d == nil {
+		return nil
+	}
+	return &property.Condition{
+		Field: id.PropertyFieldID(d.Field),
+		Value: property.ValueType(d.Type).ValueFrom(d.Value),
+	}
+}
+
+func newPropertySchemaFieldPointer(p *property.SchemaFieldPointer) *PropertySchemaFieldPointerDocument {
+	if p == nil {
+		return nil
+	}
+	return &PropertySchemaFieldPointerDocument{
+		SchemaGroup: p.SchemaGroup.String(),
+		Field:       p.Field.String(),
+	}
+}
+
+func (d *PropertySchemaFieldPointerDocument) Model() *property.SchemaFieldPointer {
+	if d == nil {
+		return nil
+	}
+	return &property.SchemaFieldPointer{
+		SchemaGroup: property.SchemaGroupID(d.SchemaGroup),
+		Field:       property.FieldID(d.Field),
+	}
+}
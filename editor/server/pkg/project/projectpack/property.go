@@ -0,0 +1,149 @@
+package projectpack
+
+import (
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/property"
+)
+
+const (
+	typePropertyItemGroup     = "group"
+	typePropertyItemGroupList = "grouplist"
+)
+
+type PropertyDocument struct {
+	ID           string                  `json:"id"`
+	Scene        string                  `json:"scene"`
+	SchemaPlugin string                  `json:"schemaPlugin"`
+	SchemaName   string                  `json:"schemaName"`
+	Items        []*PropertyItemDocument `json:"items,omitempty"`
+}
+
+type PropertyItemDocument struct {
+	Type        string                   `json:"type"`
+	ID          string                   `json:"id"`
+	SchemaGroup string                   `json:"schemaGroup"`
+	Groups      []*PropertyItemDocument  `json:"groups,omitempty"`
+	Fields      []*PropertyFieldDocument `json:"fields,omitempty"`
+}
+
+// PropertyFieldDocument does not have dataset links because datasets are not included in packages.
+type PropertyFieldDocument struct {
+	Field string `json:"field"`
+	Type  string `json:"type"`
+	Value any    `json:"value,omitempty"`
+}
+
+func NewProperty(p *property.Property) *PropertyDocument {
+	items := p.Items()
+	d := &PropertyDocument{
+		ID:           p.ID().String(),
+		Scene:        p.Scene().String(),
+		SchemaPlugin: p.Schema().Plugin().String(),
+		SchemaName:   p.Schema().ID(),
+		Items:        make([]*PropertyItemDocument, 0, len(items)),
+	}
+	for _, i := range items {
+		if i2 := newPropertyItem(i); i2 != nil {
+			d.Items = append(d.Items, i2)
+		}
+	}
+	return d
+}
+
+func newPropertyItem(i property.Item) *PropertyItemDocument {
+	if i == nil {
+		return nil
+	}
+
+	d := &PropertyItemDocument{
+		ID:          i.ID().String(),
+		SchemaGroup: string(i.SchemaGroup()),
+	}
+
+	if g := property.ToGroup(i); g != nil {
+		d.Type = typePropertyItemGroup
+		for _, f := range g.Fields(nil) {
+			d.Fields = append(d.Fields, &PropertyFieldDocument{
+				Field: string(f.Field()),
+				Type:  string(f.Type()),
+				Value: f.Value().Interface(),
+			})
+		}
+	} else if gl := property.ToGroupList(i); gl != nil {
+		d.Type = typePropertyItemGroupList
+		for _, g := range gl.Groups() {
+			if g2 := newPropertyItem(g); g2 != nil {
+				d.Groups = append(d.Groups, g2)
+			}
+		}
+	}
+
+	return d
+}
+
+func (d *PropertyDocument) Model() (*property.Property, error) {
+	pid, err := id.PropertyIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	sid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+	pl, err := id.PluginIDFrom(d.SchemaPlugin)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]property.Item, 0, len(d.Items))
+	for _, i := range d.Items {
+		i2, err := i.Model()
+		if err != nil {
+			return nil, err
+		}
+		if i2 != nil {
+			items = append(items, i2)
+		}
+	}
+
+	return property.New().
+		ID(pid).
+		Scene(sid).
+		Schema(id.NewPropertySchemaID(pl, d.SchemaName)).
+		Items(items).
+		Build()
+}
+
+func (d *PropertyItemDocument) Model() (property.Item, error) {
+	iid, err := id.PropertyItemIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	gid := id.PropertySchemaGroupID(d.SchemaGroup)
+
+	switch d.Type {
+	case typePropertyItemGroup:
+		fields := make([]*property.Field, 0, len(d.Fields))
+		for _, f := range d.Fields {
+			vt := property.ValueType(f.Type)
+			fields = append(fields, property.NewField(property.FieldID(f.Field)).
+				Value(property.NewOptionalValue(vt, vt.ValueFrom(f.Value))).
+				Build())
+		}
+		return property.NewGroup().ID(iid).SchemaGroup(gid).Fields(fields).Build()
+	case typePropertyItemGroupList:
+		groups := make([]*property.Group, 0, len(d.Groups))
+		for _, g := range d.Groups {
+			g2, err := g.Model()
+			if err != nil {
+				return nil, err
+			}
+			if g3 := property.ToGroup(g2); g3 != nil {
+				groups = append(groups, g3)
+			}
+		}
+		return property.NewGroupList().ID(iid).SchemaGroup(gid).Groups(groups).Build()
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,158 @@
+package projectpack
+
+import (
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene"
+)
+
+type WidgetAlignSystemDocument struct {
+	Inner *WidgetZoneDocument `json:"inner,omitempty"`
+	Outer *WidgetZoneDocument `json:"outer,omitempty"`
+}
+
+type WidgetZoneDocument struct {
+	Left   *WidgetSectionDocument `json:"left,omitempty"`
+	Center *WidgetSectionDocument `json:"center,omitempty"`
+	Right  *WidgetSectionDocument `json:"right,omitempty"`
+}
+
+type WidgetSectionDocument struct {
+	Top    *WidgetAreaDocument `json:"top,omitempty"`
+	Middle *WidgetAreaDocument `json:"middle,omitempty"`
+	Bottom *WidgetAreaDocument `json:"bottom,omitempty"`
+}
+
+type WidgetAreaDocument struct {
+	WidgetIDs  []string                   `json:"widgetIds,omitempty"`
+	Align      string                     `json:"align"`
+	Padding    *WidgetAreaPaddingDocument `json:"padding,omitempty"`
+	Gap        *int                       `json:"gap,omitempty"`
+	Centered   bool                       `json:"centered,omitempty"`
+	Background *string                    `json:"background,omitempty"`
+}
+
+type WidgetAreaPaddingDocument struct {
+	Top    int `json:"top"`
+	Bottom int `json:"bottom"`
+	Left   int `json:"left"`
+	Right  int `json:"right"`
+}
+
+func NewWidgetAlignSystem(was *scene.WidgetAlignSystem) *WidgetAlignSystemDocument {
+	if was == nil {
+		return nil
+	}
+	d := &WidgetAlignSystemDocument{
+		Inner: newWidgetZone(was.Zone(scene.WidgetZoneInner)),
+		Outer: newWidgetZone(was.Zone(scene.WidgetZoneOuter)),
+	}
+	if d.Inner == nil && d.Outer == nil {
+		return nil
+	}
+	return d
+}
+
+func newWidgetZone(z *scene.WidgetZone) *WidgetZoneDocument {
+	if z == nil {
+		return nil
+	}
+	d := &WidgetZoneDocument{
+		Left:   newWidgetSection(z.Section(scene.WidgetSectionLeft)),
+		Center: newWidgetSection(z.Section(scene.WidgetSectionCenter)),
+		Right:  newWidgetSection(z.Section(scene.WidgetSectionRight)),
+	}
+	if d.Left == nil && d.Center == nil && d.Right == nil {
+		return nil
+	}
+	return d
+}
+
+func newWidgetSection(s *scene.WidgetSection) *WidgetSectionDocument {
+	if s == nil {
+		return nil
+	}
+	d := &WidgetSectionDocument{
+		Top:    newWidgetArea(s.Area(scene.WidgetAreaTop)),
+		Middle: newWidgetArea(s.Area(scene.WidgetAreaMiddle)),
+		Bottom: newWidgetArea(s.Area(scene.WidgetAreaBottom)),
+	}
+	if d.Top == nil && d.Middle == nil && d.Bottom == nil {
+		return nil
+	}
+	return d
+}
+
+func newWidgetArea(a *scene.WidgetArea) *WidgetAreaDocument {
+	if a == nil {
+		return nil
+	}
+	var padding *WidgetAreaPaddingDocument
+	if p := a.Padding(); p != nil {
+		padding = &WidgetAreaPaddingDocument{
+			Top:    p.Top(),
+			Bottom: p.Bottom(),
+			Left:   p.Left(),
+			Right:  p.Right(),
+		}
+	}
+	return &WidgetAreaDocument{
+		WidgetIDs:  a.WidgetIDs().Strings(),
+		Align:      string(a.Alignment()),
+		Padding:    padding,
+		Gap:        a.Gap(),
+		Centered:   a.Centered(),
+		Background: a.Background(),
+	}
+}
+
+func (d *WidgetAlignSystemDocument) Model() *scene.WidgetAlignSystem {
+	if d == nil {
+		return nil
+	}
+	was := scene.NewWidgetAlignSystem()
+	was.SetZone(scene.WidgetZoneInner, d.Inner.Model())
+	was.SetZone(scene.WidgetZoneOuter, d.Outer.Model())
+	return was
+}
+
+func (d *WidgetZoneDocument) Model() *scene.WidgetZone {
+	if d == nil {
+		return nil
+	}
+	wz := scene.NewWidgetZone()
+	wz.SetSection(scene.WidgetSectionLeft, d.Left.Model())
+	wz.SetSection(scene.WidgetSectionCenter, d.Center.Model())
+	wz.SetSection(scene.WidgetSectionRight, d.Right.Model())
+	return wz
+}
+
+func (d *WidgetSectionDocument) Model() *scene.WidgetSection {
+	if d == nil {
+		return nil
+	}
+	ws := scene.NewWidgetSection()
+	ws.SetArea(scene.WidgetAreaTop, d.Top.Model())
+	ws.SetArea(scene.WidgetAreaMiddle, d.Middle.Model())
+	ws.SetArea(scene.WidgetAreaBottom, d.Bottom.Model())
+	return ws
+}
+
+func (d *WidgetAreaDocument) Model() *scene.WidgetArea {
+	if d == nil {
+		return nil
+	}
+
+	wids := make([]id.WidgetID, 0, len(d.WidgetIDs))
+	for _, w := range d.WidgetIDs {
+		if wid, err := id.WidgetIDFrom(w); err == nil {
+			wids = append(wids, wid)
+		}
+	}
+
+	var padding *scene.WidgetAreaPadding
+	if p := d.Padding; p != nil {
+		padding = scene.NewWidgetAreaPadding(p.Left, p.Right, p.Top, p.Bottom)
+	}
+
+	return scene.NewWidgetArea(wids, scene.WidgetAlignType(d.Align), padding, d.Gap, d.Centered, d.Background)
+}
@@ -0,0 +1,193 @@
+package projectpack
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+)
+
+// StoryDocument does not contain the basic auth password, so basic auth is disabled in imported stories.
+type StoryDocument struct {
+	ID                string          `json:"id"`
+	Property          string          `json:"property"`
+	Scene             string          `json:"scene"`
+	Title             string          `json:"title"`
+	Alias             string          `json:"alias,omitempty"`
+	Pages             []*PageDocument `json:"pages,omitempty"`
+	Status            string          `json:"status,omitempty"`
+	PublishedAt       *time.Time      `json:"publishedAt,omitempty"`
+	UpdatedAt         time.Time       `json:"updatedAt"`
+	PanelPosition     string          `json:"panelPosition,omitempty"`
+	BgColor           string          `json:"bgColor,omitempty"`
+	BasicAuthUsername string          `json:"basicAuthUsername,omitempty"`
+	PublicTitle       string          `json:"publicTitle,omitempty"`
+	PublicDescription string          `json:"publicDescription,omitempty"`
+	PublicImage       string          `json:"publicImage,omitempty"`
+	PublicNoIndex     bool            `json:"publicNoIndex,omitempty"`
+}
+
+type PageDocument struct {
+	ID          string          `json:"id"`
+	Property    string          `json:"property"`
+	Title       string          `json:"title"`
+	Swipeable   bool            `json:"swipeable,omitempty"`
+	Layers      []string        `json:"layers,omitempty"`
+	SwipeLayers []string        `json:"swipeLayers,omitempty"`
+	Blocks      []BlockDocument `json:"blocks,omitempty"`
+}
+
+type BlockDocument struct {
+	ID        string `json:"id"`
+	Plugin    string `json:"plugin"`
+	Extension string `json:"extension"`
+	Property  string `json:"property"`
+}
+
+func NewStory(s *storytelling.Story) *StoryDocument {
+	d := &StoryDocument{
+		ID:                s.Id().String(),
+		Property:          s.Property().String(),
+		Scene:             s.Scene().String(),
+		Title:             s.Title(),
+		Alias:             s.Alias(),
+		Status:            string(s.Status()),
+		PublishedAt:       s.PublishedAt(),
+		UpdatedAt:         s.UpdatedAt(),
+		PanelPosition:     string(s.PanelPosition()),
+		BgColor:           s.BgColor(),
+		BasicAuthUsername: s.BasicAuthUsername(),
+		PublicTitle:       s.PublicTitle(),
+		PublicDescription: s.PublicDescription(),
+		PublicImage:       s.PublicImage(),
+		PublicNoIndex:     s.PublicNoIndex(),
+	}
+
+	if pages := s.Pages(); pages != nil {
+		for _, p := range pages.Pages() {
+			if p == nil {
+				continue
+			}
+			pd := &PageDocument{
+				ID:          p.Id().String(),
+				Property:    p.Property().String(),
+				Title:       p.Title(),
+				Swipeable:   p.Swipeable(),
+				Layers:      p.Layers().Strings(),
+				SwipeLayers: p.SwipeableLayers().Strings(),
+			}
+			for _, b := range p.Blocks() {
+				if b == nil {
+					continue
+				}
+				pd.Blocks = append(pd.Blocks, BlockDocument{
+					ID:        b.ID().String(),
+					Plugin:    b.Plugin().String(),
+					Extension: b.Extension().String(),
+					Property:  b.Property().String(),
+				})
+			}
+			d.Pages = append(d.Pages, pd)
+		}
+	}
+
+	return d
+}
+
+func (d *StoryDocument) Model() (*storytelling.Story, error) {
+	sid, err := id.StoryIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	prid, err := id.PropertyIDFrom(d.Property)
+	if err != nil {
+		return nil, err
+	}
+	scid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := make([]*storytelling.Page, 0, len(d.Pages))
+	for _, p := range d.Pages {
+		p2, err := p.Model()
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, p2)
+	}
+
+	return storytelling.NewStory().
+		ID(sid).
+		Property(prid).
+		Scene(scid).
+		Title(d.Title).
+		Alias(d.Alias).
+		Status(storytelling.PublishmentStatus(d.Status)).
+		PanelPosition(storytelling.Position(d.PanelPosition)).
+		BgColor(d.BgColor).
+		PublishedAt(d.PublishedAt).
+		UpdatedAt(d.UpdatedAt).
+		Pages(storytelling.NewPageList(pages)).
+		PublicBasicAuth(false, d.BasicAuthUsername, "").
+		PublicTitle(d.PublicTitle).
+		PublicDescription(d.PublicDescription).
+		PublicImage(d.PublicImage).
+		PublicNoIndex(d.PublicNoIndex).
+		Build()
+}
+
+func (d *PageDocument) Model() (*storytelling.Page, error) {
+	pid, err := id.PageIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	prid, err := id.PropertyIDFrom(d.Property)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := id.NLSLayerIDListFrom(d.Layers)
+	if err != nil {
+		return nil, err
+	}
+	swipeLayers, err := id.NLSLayerIDListFrom(d.SwipeLayers)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*storytelling.Block, 0, len(d.Blocks))
+	for _, b := range d.Blocks {
+		bid, err := id.BlockIDFrom(b.ID)
+		if err != nil {
+			return nil, err
+		}
+		bprid, err := id.PropertyIDFrom(b.Property)
+		if err != nil {
+			return nil, err
+		}
+		plugin, err := id.PluginIDFrom(b.Plugin)
+		if err != nil {
+			return nil, err
+		}
+		block, err := storytelling.NewBlock().
+			ID(bid).
+			Property(bprid).
+			Plugin(plugin).
+			Extension(id.PluginExtensionID(b.Extension)).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+
+	return storytelling.NewPage().
+		ID(pid).
+		Property(prid).
+		Title(d.Title).
+		Swipeable(d.Swipeable).
+		Layers(layers).
+		SwipeableLayers(swipeLayers).
+		Blocks(blocks).
+		Build()
+}
@@ -0,0 +1,86 @@
+package project
+
+import (
+	"errors"
+	"time"
+
+	"github.com/reearth/reearthx/util"
+)
+
+var ErrInvalidPublishSchedule = errors.New("invalid publish schedule")
+
+// PublishSchedule is a publication which is applied later by the publish scheduler.
+type PublishSchedule struct {
+	alias       string
+	status      PublishmentStatus
+	publishAt   *time.Time
+	unpublishAt *time.Time
+}
+
+// NewPublishSchedule returns nil when neither publishAt nor unpublishAt is set.
+func NewPublishSchedule(alias string, status PublishmentStatus, publishAt, unpublishAt *time.Time) (*PublishSchedule, error) {
+	if publishAt == nil && unpublishAt == nil {
+		return nil, nil
+	}
+	if status == PublishmentStatusPrivate {
+		return nil, ErrInvalidPublishSchedule
+	}
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return nil, ErrInvalidPublishSchedule
+	}
+	if alias != "" && !CheckAliasPattern(alias) {
+		return nil, ErrInvalidAlias
+	}
+
+	return &PublishSchedule{
+		alias:       alias,
+		status:      status,
+		publishAt:   util.CloneRef(publishAt),
+		unpublishAt: util.CloneRef(unpublishAt),
+	}, nil
+}
+
+func (s *PublishSchedule) Alias() string {
+	if s == nil {
+		return ""
+	}
+	return s.alias
+}
+
+func (s *PublishSchedule) Status() PublishmentStatus {
+	if s == nil {
+		return ""
+	}
+	return s.status
+}
+
+func (s *PublishSchedule) PublishAt() *time.Time {
+	if s == nil {
+		return nil
+	}
+	return util.CloneRef(s.publishAt)
+}
+
+func (s *PublishSchedule) UnpublishAt() *time.Time {
+	if s == nil {
+		return nil
+	}
+	return util.CloneRef(s.unpublishAt)
+}
+
+func (s *PublishSchedule) IsPublishDue(now time.Time) bool {
+	return s != nil && s.publishAt != nil && !s.publishAt.After(now)
+}
+
+// IsUnpublishDue reports whether the unpublication is due. It waits for the pending publication.
+func (s *PublishSchedule) IsUnpublishDue(now time.Time) bool {
+	return s != nil && s.publishAt == nil && s.unpublishAt != nil && !s.unpublishAt.After(now)
+}
+
+// Published returns the schedule which remains after the publication has been applied.
+func (s *PublishSchedule) Published() *PublishSchedule {
+	if s == nil || s.unpublishAt == nil {
+		return nil
+	}
+	return &PublishSchedule{unpublishAt: util.CloneRef(s.unpublishAt)}
+}
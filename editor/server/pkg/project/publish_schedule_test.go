@@ -0,0 +1,58 @@
+package project
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPublishSchedule(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	s, err := NewPublishSchedule("", PublishmentStatusPublic, nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+
+	s, err = NewPublishSchedule("aliasalias", PublishmentStatusPublic, &now, &later)
+	assert.NoError(t, err)
+	assert.Equal(t, "aliasalias", s.Alias())
+	assert.Equal(t, PublishmentStatusPublic, s.Status())
+	assert.Equal(t, &now, s.PublishAt())
+	assert.Equal(t, &later, s.UnpublishAt())
+
+	_, err = NewPublishSchedule("", PublishmentStatusPublic, &later, &now)
+	assert.Same(t, ErrInvalidPublishSchedule, err)
+
+	_, err = NewPublishSchedule("", PublishmentStatusPrivate, &now, nil)
+	assert.Same(t, ErrInvalidPublishSchedule, err)
+
+	_, err = NewPublishSchedule("a", PublishmentStatusPublic, &now, nil)
+	assert.Same(t, ErrInvalidAlias, err)
+}
+
+func TestPublishSchedule_IsDue(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	s := lo.Must(NewPublishSchedule("", PublishmentStatusLimited, &now, &later))
+
+	assert.True(t, s.IsPublishDue(now))
+	assert.False(t, s.IsPublishDue(now.Add(-time.Second)))
+	// the unpublication waits for the publication
+	assert.False(t, s.IsUnpublishDue(later))
+
+	published := s.Published()
+	assert.Nil(t, published.PublishAt())
+	assert.Equal(t, &later, published.UnpublishAt())
+	assert.False(t, published.IsPublishDue(later))
+	assert.False(t, published.IsUnpublishDue(now))
+	assert.True(t, published.IsUnpublishDue(later))
+
+	var empty *PublishSchedule
+	assert.False(t, empty.IsPublishDue(now))
+	assert.False(t, empty.IsUnpublishDue(now))
+	assert.Nil(t, empty.Published())
+	assert.Nil(t, lo.Must(NewPublishSchedule("", PublishmentStatusPublic, &now, nil)).Published())
+}
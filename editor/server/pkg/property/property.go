@@ -175,6 +175,17 @@ func (p *Property) Clone() *Property {
 	}
 }
 
+// Duplicate returns a copy of the property which has the ID.
+func (p *Property) Duplicate(id ID) *Property {
+	if p == nil {
+		return nil
+	}
+
+	res := p.Clone()
+	res.id = id
+	return res
+}
+
 func (p *Property) Fields(ptr *Pointer) []*Field {
 	if p == nil || len(p.items) == 0 {
 		return nil
@@ -848,3 +848,18 @@ func TestProperty_AddItem(t *testing.T) {
 		})
 	}
 }
+
+func TestProperty_Duplicate(t *testing.T) {
+	p := New().NewID().Scene(NewSceneID()).Schema(MustSchemaID("xx~1.0.0/aa")).Items([]Item{
+		NewGroup().NewID().SchemaGroup("a").MustBuild(),
+	}).MustBuild()
+	pid := NewID()
+
+	got := p.Duplicate(pid)
+	assert.Equal(t, pid, got.ID())
+	assert.Equal(t, p.Scene(), got.Scene())
+	assert.Equal(t, p.Schema(), got.Schema())
+	assert.Equal(t, p.Clone().Items(), got.Items())
+	assert.NotSame(t, p.Items()[0], got.Items()[0])
+	assert.Nil(t, (*Property)(nil).Duplicate(pid))
+}
@@ -92,6 +92,11 @@ func (b *Builder) Clusters(cl *ClusterList) *Builder {
 	return b
 }
 
+func (b *Builder) Template(template bool) *Builder {
+	b.scene.template = template
+	return b
+}
+
 func (b *Builder) Styles(sl *StyleList) *Builder {
 	b.scene.styles = sl
 	return b
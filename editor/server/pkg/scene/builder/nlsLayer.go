@@ -16,11 +16,20 @@ type nlsLayerJSON struct {
 	Infobox    *nlsInfoboxJSON `json:"nlsInfobox,omitempty"`
 	IsSketch   bool            `json:"isSketch"`
 	SketchInfo *sketchInfoJSON `json:"sketchInfo,omitempty"`
+	Cluster    *nlsClusterJSON `json:"cluster,omitempty"`
 	Children   []*nlsLayerJSON `json:"children,omitempty"`
 }
 
 type configJSON map[string]any
 
+// nlsClusterJSON is the clustering of the features of a layer.
+// ID is empty when the parameters are given inline in the config of the layer.
+type nlsClusterJSON struct {
+	ID       string       `json:"id,omitempty"`
+	Name     string       `json:"name,omitempty"`
+	Property propertyJSON `json:"property"`
+}
+
 type nlsInfoboxJSON struct {
 	ID       string                `json:"id"`
 	Property propertyJSON          `json:"property"`
@@ -81,10 +90,26 @@ func (b *Builder) nlsLayersJSON(ctx context.Context) ([]*nlsLayerJSON, error) {
 
 	var res []*nlsLayerJSON
 
+	// children of groups are nested in their parents
+	children := map[nlslayer.ID]struct{}{}
 	for _, l := range *b.nlsLayer {
 		if l == nil {
 			continue
 		}
+		if lg := nlslayer.ToNLSLayerGroup(*l); lg != nil {
+			for _, c := range lg.Children().Layers() {
+				children[c] = struct{}{}
+			}
+		}
+	}
+
+	for _, l := range *b.nlsLayer {
+		if l == nil {
+			continue
+		}
+		if _, ok := children[(*l).ID()]; ok {
+			continue
+		}
 		if c, _ := b.getNLSLayerJSON(ctx, *l); c != nil {
 			res = append(res, c)
 		}
@@ -120,10 +145,34 @@ func (b *Builder) getNLSLayerJSON(ctx context.Context, layer nlslayer.NLSLayer)
 		Infobox:    b.nlsInfoboxJSON(ctx, layer.Infobox()),
 		IsSketch:   layer.IsSketch(),
 		SketchInfo: b.sketchInfoJSON(ctx, layer.Sketch()),
+		Cluster:    b.nlsClusterJSON(ctx, layer),
 		Children:   children,
 	}, nil
 }
 
+// nlsClusterJSON returns the scene cluster referenced by the layer, or the inline clustering parameters in its config.
+func (b *Builder) nlsClusterJSON(ctx context.Context, layer nlslayer.NLSLayer) *nlsClusterJSON {
+	if cid := layer.Cluster(); cid != nil {
+		if c := b.scene.Clusters().Get(*cid); c != nil {
+			p, _ := b.ploader(ctx, c.Property())
+			return &nlsClusterJSON{
+				ID:       c.ID().String(),
+				Name:     c.Name(),
+				Property: b.property(ctx, findProperty(p, c.Property())),
+			}
+		}
+	}
+
+	if c := layer.Config(); c != nil {
+		if p, ok := (*c)[nlslayer.ConfigKeyCluster].(map[string]any); ok {
+			return &nlsClusterJSON{
+				Property: p,
+			}
+		}
+	}
+	return nil
+}
+
 func (b *Builder) nlsInfoboxJSON(ctx context.Context, infobox *nlslayer.Infobox) *nlsInfoboxJSON {
 	if infobox == nil {
 		return nil
@@ -0,0 +1,64 @@
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_NLSClusterJSON(t *testing.T) {
+	ctx := context.Background()
+	sid := scene.NewID()
+	cid := id.NewClusterID()
+
+	clusterp := property.New().
+		NewID().
+		Scene(sid).
+		Schema(property.MustSchemaID("reearth/cluster")).
+		Items([]property.Item{
+			property.NewGroup().NewID().SchemaGroup("default").
+				Fields([]*property.Field{
+					property.NewField("clusterPixelRange").
+						Value(property.OptionalValueFrom(property.ValueTypeNumber.ValueFrom(30))).
+						MustBuild(),
+				}).MustBuild(),
+		}).
+		MustBuild()
+	cluster, _ := scene.NewCluster(cid, "shelters", clusterp.ID())
+	s := scene.New().
+		ID(sid).
+		Project(scene.NewProjectID()).
+		Workspace(accountdomain.NewWorkspaceID()).
+		RootLayer(id.NewLayerID()).
+		Clusters(scene.NewClusterListFrom([]*scene.Cluster{cluster})).
+		MustBuild()
+
+	b := New(nil, property.LoaderFrom([]*property.Property{clusterp}), nil, nil, nil, nil).ForScene(s)
+
+	referenced := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).Cluster(&cid).MustBuild()
+	assert.Equal(t, &nlsClusterJSON{
+		ID:   cid.String(),
+		Name: "shelters",
+		Property: propertyJSON{
+			"default": map[string]any{"clusterPixelRange": float64(30)},
+		},
+	}, b.nlsClusterJSON(ctx, referenced))
+
+	inline := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).Config(&nlslayer.Config{
+		nlslayer.ConfigKeyCluster: map[string]any{"clusterPixelRange": 10},
+	}).MustBuild()
+	assert.Equal(t, &nlsClusterJSON{
+		Property: propertyJSON{"clusterPixelRange": 10},
+	}, b.nlsClusterJSON(ctx, inline))
+
+	// a removed cluster is ignored
+	removed := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).Cluster(id.NewClusterID().Ref()).MustBuild()
+	assert.Nil(t, b.nlsClusterJSON(ctx, removed))
+	assert.Nil(t, b.nlsClusterJSON(ctx, nlslayer.NewNLSLayerSimple().NewID().Scene(sid).MustBuild()))
+}
@@ -0,0 +1,75 @@
+package history
+
+import "time"
+
+type Builder struct {
+	h *History
+}
+
+func New() *Builder {
+	return &Builder{h: &History{}}
+}
+
+func (b *Builder) Build() (*History, error) {
+	if b.h.id.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if b.h.scene.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if len(b.h.changes) == 0 {
+		return nil, ErrEmptyChanges
+	}
+	if b.h.createdAt.IsZero() {
+		b.h.createdAt = b.h.id.Timestamp()
+	}
+	return b.h, nil
+}
+
+func (b *Builder) MustBuild() *History {
+	h, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.h.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.h.id = NewID()
+	return b
+}
+
+func (b *Builder) Scene(scene SceneID) *Builder {
+	b.h.scene = scene
+	return b
+}
+
+func (b *Builder) User(user *UserID) *Builder {
+	b.h.user = user.CloneRef()
+	return b
+}
+
+func (b *Builder) Operation(operation string) *Builder {
+	b.h.operation = operation
+	return b
+}
+
+func (b *Builder) Changes(changes []Change) *Builder {
+	b.h.changes = append([]Change{}, changes...)
+	return b
+}
+
+func (b *Builder) CreatedAt(t time.Time) *Builder {
+	b.h.createdAt = t
+	return b
+}
+
+func (b *Builder) Undone(undone bool) *Builder {
+	b.h.undone = undone
+	return b
+}
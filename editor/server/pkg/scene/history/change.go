@@ -0,0 +1,53 @@
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+type EntityType string
+
+const (
+	EntityTypeScene    EntityType = "scene"
+	EntityTypeNLSLayer EntityType = "nlslayer"
+	EntityTypeStyle    EntityType = "style"
+	EntityTypeStory    EntityType = "story"
+	EntityTypeProperty EntityType = "property"
+)
+
+// Change is a change of a single entity. Before and After hold serialized states of the entity,
+// and nil means that the entity did not exist.
+type Change struct {
+	Type   EntityType
+	ID     string
+	Before *string
+	After  *string
+}
+
+func (c Change) IsCreated() bool {
+	return c.Before == nil && c.After != nil
+}
+
+func (c Change) IsRemoved() bool {
+	return c.Before != nil && c.After == nil
+}
+
+// Inverse returns a change which reverts the change.
+func (c Change) Inverse() Change {
+	return Change{
+		Type:   c.Type,
+		ID:     c.ID,
+		Before: c.After,
+		After:  c.Before,
+	}
+}
+
+// Version returns a digest of a serialized state of an entity which changes whenever the entity changes.
+// It returns an empty string for a nil state, which means that the entity does not exist.
+func Version(state *string) string {
+	if state == nil {
+		return ""
+	}
+	h := sha256.Sum256([]byte(*state))
+	return hex.EncodeToString(h[:16])
+}
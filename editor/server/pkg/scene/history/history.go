@@ -0,0 +1,63 @@
+package history
+
+import (
+	"errors"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+var ErrEmptyChanges = errors.New("history has no changes")
+
+// History is an entry of the operation log of a scene.
+type History struct {
+	id        ID
+	scene     SceneID
+	user      *UserID
+	operation string
+	changes   []Change
+	createdAt time.Time
+	undone    bool
+}
+
+func (h *History) ID() ID {
+	return h.id
+}
+
+func (h *History) Scene() SceneID {
+	return h.scene
+}
+
+func (h *History) User() *UserID {
+	return h.user.CloneRef()
+}
+
+func (h *History) Operation() string {
+	return h.operation
+}
+
+func (h *History) Changes() []Change {
+	return append([]Change{}, h.changes...)
+}
+
+func (h *History) CreatedAt() time.Time {
+	if h.createdAt.IsZero() {
+		return h.id.Timestamp()
+	}
+	return h.createdAt
+}
+
+func (h *History) Undone() bool {
+	return h.undone
+}
+
+func (h *History) SetUndone(undone bool) {
+	h.undone = undone
+}
+
+// Inverse returns changes which revert the history in the order they should be applied.
+func (h *History) Inverse() []Change {
+	return lo.Reverse(lo.Map(h.changes, func(c Change, _ int) Change {
+		return c.Inverse()
+	}))
+}
@@ -0,0 +1,51 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	hid := NewID()
+	sid := SceneID{}
+	_, err := New().ID(hid).Scene(sid).Changes([]Change{{Type: EntityTypeStyle, ID: "x"}}).Build()
+	assert.Equal(t, ErrInvalidID, err)
+
+	sid = NewSceneID()
+	_, err = New().ID(hid).Scene(sid).Build()
+	assert.Equal(t, ErrEmptyChanges, err)
+
+	h, err := New().ID(hid).Scene(sid).Operation("updateStyle").Changes([]Change{{Type: EntityTypeStyle, ID: "x"}}).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, hid, h.ID())
+	assert.Equal(t, sid, h.Scene())
+	assert.Equal(t, "updateStyle", h.Operation())
+	assert.Equal(t, hid.Timestamp(), h.CreatedAt())
+	assert.False(t, h.Undone())
+	assert.Nil(t, h.User())
+}
+
+func TestHistory_Inverse(t *testing.T) {
+	h := New().NewID().Scene(NewSceneID()).Changes([]Change{
+		{Type: EntityTypeNLSLayer, ID: "a", After: lo.ToPtr("1")},
+		{Type: EntityTypeProperty, ID: "b", Before: lo.ToPtr("2"), After: lo.ToPtr("3")},
+		{Type: EntityTypeStory, ID: "c", Before: lo.ToPtr("4")},
+	}).MustBuild()
+
+	assert.True(t, h.Changes()[0].IsCreated())
+	assert.True(t, h.Changes()[2].IsRemoved())
+	assert.Equal(t, []Change{
+		{Type: EntityTypeStory, ID: "c", After: lo.ToPtr("4")},
+		{Type: EntityTypeProperty, ID: "b", Before: lo.ToPtr("3"), After: lo.ToPtr("2")},
+		{Type: EntityTypeNLSLayer, ID: "a", Before: lo.ToPtr("1")},
+	}, h.Inverse())
+}
+
+func TestVersion(t *testing.T) {
+	assert.Equal(t, "", Version(nil))
+	assert.Len(t, Version(lo.ToPtr("a")), 32)
+	assert.Equal(t, Version(lo.ToPtr("a")), Version(lo.ToPtr("a")))
+	assert.NotEqual(t, Version(lo.ToPtr("a")), Version(lo.ToPtr("b")))
+}
@@ -0,0 +1,21 @@
+package history
+
+import (
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type ID = id.HistoryID
+type SceneID = id.SceneID
+type UserID = accountdomain.UserID
+
+type IDList = id.HistoryIDList
+
+var NewID = id.NewHistoryID
+var MustID = id.MustHistoryID
+var IDFrom = id.HistoryIDFrom
+var IDFromRef = id.HistoryIDFromRef
+
+var NewSceneID = id.NewSceneID
+
+var ErrInvalidID = id.ErrInvalidID
@@ -15,6 +15,7 @@ type PluginExtensionID = id.PluginExtensionID
 type ProjectID = id.ProjectID
 type WorkspaceID = accountdomain.WorkspaceID
 type StyleID = id.StyleID
+type LibraryStyleID = id.LibraryStyleID
 
 type IDList = id.SceneIDList
 type WidgetIDList = id.WidgetIDList
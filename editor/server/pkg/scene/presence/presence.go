@@ -0,0 +1,52 @@
+package presence
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+// Presence tells that a user is editing a scene and what the user is focusing on.
+type Presence struct {
+	Scene     id.SceneID
+	User      accountdomain.UserID
+	Layer     *id.NLSLayerID
+	StoryPage *id.PageID
+	UpdatedAt time.Time
+}
+
+// List is a list of presences of a scene.
+type List []Presence
+
+// FindByUser returns the presence of the user.
+func (l List) FindByUser(u accountdomain.UserID) *Presence {
+	for _, p := range l {
+		if p.User == u {
+			p := p
+			return &p
+		}
+	}
+	return nil
+}
+
+// Equal reports whether both lists hold the same presences regardless of the time they were updated.
+func (l List) Equal(m List) bool {
+	if len(l) != len(m) {
+		return false
+	}
+	for i := range l {
+		a, b := l[i], m[i]
+		if a.Scene != b.Scene || a.User != b.User || !sameRef(a.Layer, b.Layer) || !sameRef(a.StoryPage, b.StoryPage) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameRef[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
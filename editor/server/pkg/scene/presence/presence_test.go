@@ -0,0 +1,28 @@
+package presence
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList_FindByUser(t *testing.T) {
+	u1, u2 := accountdomain.NewUserID(), accountdomain.NewUserID()
+	l := List{{User: u1}}
+
+	assert.Equal(t, &Presence{User: u1}, l.FindByUser(u1))
+	assert.Nil(t, l.FindByUser(u2))
+}
+
+func TestList_Equal(t *testing.T) {
+	sid := id.NewSceneID()
+	u := accountdomain.NewUserID()
+	lid := id.NewNLSLayerID()
+	l := List{{Scene: sid, User: u, Layer: &lid}}
+
+	assert.True(t, l.Equal(List{{Scene: sid, User: u, Layer: lid.Ref()}}))
+	assert.False(t, l.Equal(List{{Scene: sid, User: u}}))
+	assert.False(t, l.Equal(nil))
+}
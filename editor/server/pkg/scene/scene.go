@@ -18,6 +18,7 @@ type Scene struct {
 	property  PropertyID
 	clusters  *ClusterList
 	styles    *StyleList
+	template  bool
 }
 
 func (s *Scene) ID() ID {
@@ -101,6 +102,21 @@ func (s *Scene) Properties() []PropertyID {
 	return ids
 }
 
+// Template reports whether new projects of the workspace can be created from the scene.
+func (s *Scene) Template() bool {
+	if s == nil {
+		return false
+	}
+	return s.template
+}
+
+func (s *Scene) SetTemplate(template bool) {
+	if s == nil {
+		return
+	}
+	s.template = template
+}
+
 func (s *Scene) Clusters() *ClusterList {
 	if s == nil {
 		return nil
@@ -17,6 +17,16 @@ func TestScene_SetUpdatedAt(t *testing.T) {
 	assert.NotEqual(t, time.Date(2020, 1, 1, 00, 00, 1, 1, time.UTC), s.UpdatedAt())
 }
 
+func TestScene_SetTemplate(t *testing.T) {
+	s := New().NewID().Workspace(accountdomain.NewWorkspaceID()).RootLayer(NewLayerID()).MustBuild()
+	assert.False(t, s.Template())
+	s.SetTemplate(true)
+	assert.True(t, s.Template())
+	s = nil
+	s.SetTemplate(true)
+	assert.False(t, s.Template())
+}
+
 func TestScene_Properties(t *testing.T) {
 	pid1 := NewPropertyID()
 	pid2 := NewPropertyID()
@@ -5,6 +5,38 @@ type Style struct {
 	name  string
 	value *StyleValue
 	scene ID
+	link  *StyleLink
+}
+
+// StyleLink records the workspace library style and its version that a scene style was imported from or published to.
+type StyleLink struct {
+	library LibraryStyleID
+	version int
+}
+
+func NewStyleLink(library LibraryStyleID, version int) *StyleLink {
+	return &StyleLink{library: library, version: version}
+}
+
+func (l *StyleLink) Library() LibraryStyleID {
+	if l == nil {
+		return LibraryStyleID{}
+	}
+	return l.library
+}
+
+func (l *StyleLink) Version() int {
+	if l == nil {
+		return 0
+	}
+	return l.version
+}
+
+func (l *StyleLink) Clone() *StyleLink {
+	if l == nil {
+		return nil
+	}
+	return &StyleLink{library: l.library, version: l.version}
 }
 
 func (s *Style) ID() StyleID {
@@ -28,6 +60,20 @@ func (s *Style) Value() *StyleValue {
 	return s.value
 }
 
+func (s *Style) Link() *StyleLink {
+	if s == nil {
+		return nil
+	}
+	return s.link
+}
+
+func (s *Style) SetLink(l *StyleLink) {
+	if s == nil {
+		return
+	}
+	s.link = l.Clone()
+}
+
 func (s *Style) Rename(name string) {
 	if s == nil {
 		return
@@ -51,5 +97,5 @@ func (s *Style) Duplicate() *Style {
 		return nil
 	}
 
-	return NewStyle().NewID().Name(s.name).Value(s.value).Scene(s.scene).MustBuild()
+	return NewStyle().NewID().Name(s.name).Value(s.value).Scene(s.scene).Link(s.link).MustBuild()
 }
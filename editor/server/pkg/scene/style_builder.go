@@ -43,6 +43,11 @@ func (b *StyleBuilder) Value(sv *StyleValue) *StyleBuilder {
 	return b
 }
 
+func (b *StyleBuilder) Link(l *StyleLink) *StyleBuilder {
+	b.s.link = l.Clone()
+	return b
+}
+
 func (b *StyleBuilder) Name(n string) *StyleBuilder {
 	b.s.name = n
 	return b
@@ -3,6 +3,8 @@ package scene
 import (
 	"testing"
 
+	"github.com/reearth/reearth/server/pkg/id"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,7 +16,8 @@ func TestStyle_Duplicate(t *testing.T) {
 	}
 	sid := NewID()
 
-	original := NewStyle().NewID().Name(name).Value(value).Scene(sid).MustBuild()
+	link := NewStyleLink(id.NewLibraryStyleID(), 2)
+	original := NewStyle().NewID().Name(name).Value(value).Scene(sid).Link(link).MustBuild()
 
 	duplicated := original.Duplicate()
 
@@ -23,4 +26,5 @@ func TestStyle_Duplicate(t *testing.T) {
 	assert.Equal(t, original.Name(), duplicated.Name())
 	assert.Equal(t, original.Value(), duplicated.Value())
 	assert.Equal(t, original.Scene(), duplicated.Scene())
+	assert.Equal(t, link, duplicated.Link())
 }
@@ -1,3 +1,207 @@
 package scene
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/scene/styleexpr"
+)
+
+var ErrInvalidStyle = errors.New("invalid style")
+
+// StyleAppearanceTypes are the top-level keys of a style value, one per kind of appearance the viewer can render.
+var StyleAppearanceTypes = []string{
+	"marker",
+	"polyline",
+	"polygon",
+	"model",
+	"3dtiles",
+	"ellipsoid",
+	"ellipse",
+	"box",
+	"photooverlay",
+	"resource",
+	"raster",
+	"heatMap",
+	"frustum",
+	"transition",
+}
+
 type StyleValue map[string]any
+
+// StyleError describes a problem found in a style value.
+// Path is the dotted path to the offending value and Pos is the 1-based column in the expression, or 0 if the problem is not in an expression.
+type StyleError struct {
+	Path    string
+	Pos     int
+	Message string
+}
+
+func (e *StyleError) Error() string {
+	if e.Pos > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.Path, e.Pos, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+type StyleErrors []*StyleError
+
+func (e StyleErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidStyle, strings.Join(msgs, "; "))
+}
+
+func (e StyleErrors) Unwrap() error {
+	return ErrInvalidStyle
+}
+
+// Validate checks the style value against the style schema and parses every expression in it.
+func (v *StyleValue) Validate() error {
+	if errs := v.Check(nil); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Check returns all problems found in the style value.
+// If attributes is not nil, variables in expressions must refer to one of them.
+func (v *StyleValue) Check(attributes []string) StyleErrors {
+	if v == nil {
+		return nil
+	}
+
+	c := styleChecker{}
+	if attributes != nil {
+		c.attributes = make(map[string]struct{}, len(attributes))
+		for _, a := range attributes {
+			c.attributes[a] = struct{}{}
+		}
+	}
+
+	keys := make([]string, 0, len(*v))
+	for k := range *v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if !isStyleAppearanceType(k) {
+			c.add(k, 0, "unknown appearance type")
+			continue
+		}
+		switch a := (*v)[k].(type) {
+		case nil:
+		case map[string]any:
+			c.object(k, a)
+		default:
+			c.add(k, 0, "appearance must be an object")
+		}
+	}
+	return c.errs
+}
+
+func isStyleAppearanceType(k string) bool {
+	for _, t := range StyleAppearanceTypes {
+		if t == k {
+			return true
+		}
+	}
+	return false
+}
+
+type styleChecker struct {
+	attributes map[string]struct{}
+	errs       StyleErrors
+}
+
+func (c *styleChecker) add(path string, pos int, msg string) {
+	c.errs = append(c.errs, &StyleError{Path: path, Pos: pos, Message: msg})
+}
+
+func (c *styleChecker) object(path string, o map[string]any) {
+	keys := make([]string, 0, len(o))
+	for k := range o {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := path + "." + k
+		child, ok := o[k].(map[string]any)
+		if !ok {
+			continue
+		}
+		if e, ok := child["expression"]; ok {
+			c.container(p+".expression", e)
+		} else {
+			c.object(p, child)
+		}
+	}
+}
+
+func (c *styleChecker) container(path string, e any) {
+	switch e := e.(type) {
+	case nil, bool, float64, float32, int, int32, int64:
+	case string:
+		c.expression(path, e)
+	case map[string]any:
+		conds, ok := e["conditions"]
+		if !ok {
+			c.add(path, 0, "expression object must have conditions")
+			return
+		}
+		list, ok := conds.([]any)
+		if !ok {
+			c.add(path+".conditions", 0, "conditions must be an array")
+			return
+		}
+		for i, cond := range list {
+			p := fmt.Sprintf("%s.conditions[%d]", path, i)
+			pair, ok := cond.([]any)
+			if !ok || len(pair) != 2 {
+				c.add(p, 0, "condition must be a pair of a condition and an expression")
+				continue
+			}
+			for j, s := range pair {
+				pp := fmt.Sprintf("%s[%d]", p, j)
+				str, ok := s.(string)
+				if !ok {
+					c.add(pp, 0, "condition must be a string")
+					continue
+				}
+				c.expression(pp, str)
+			}
+		}
+	default:
+		c.add(path, 0, "expression must be a string, number, boolean or conditions object")
+	}
+}
+
+func (c *styleChecker) expression(path, src string) {
+	expr, err := styleexpr.Parse(src)
+	if err != nil {
+		var e *styleexpr.Error
+		if errors.As(err, &e) {
+			c.add(path, e.Pos+1, e.Message)
+		} else {
+			c.add(path, 0, err.Error())
+		}
+		return
+	}
+	if c.attributes == nil {
+		return
+	}
+	for _, v := range expr.Variables() {
+		if v.JSONPath() {
+			continue
+		}
+		if _, ok := c.attributes[v.Name]; !ok {
+			c.add(path, v.Pos+1, fmt.Sprintf("unknown attribute %q", v.Name))
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package scene
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyleValue_Validate(t *testing.T) {
+	valid := &StyleValue{
+		"marker": map[string]any{
+			"pointColor": map[string]any{
+				"expression": map[string]any{
+					"conditions": []any{
+						[]any{"${height} > 10", `color("red")`},
+						[]any{"true", `color("blue")`},
+					},
+				},
+			},
+			"pointSize": map[string]any{"expression": 10.0},
+			"height":    5,
+		},
+		"polygon": map[string]any{
+			"fillColor": map[string]any{"expression": `rgba(255, 0, 0, ${alpha})`},
+		},
+	}
+	assert.NoError(t, valid.Validate())
+	assert.NoError(t, (*StyleValue)(nil).Validate())
+
+	invalid := &StyleValue{
+		"marker": map[string]any{
+			"pointColor": map[string]any{
+				"expression": map[string]any{
+					"conditions": []any{
+						[]any{"${height} >", `color("red")`},
+						[]any{"true"},
+					},
+				},
+			},
+		},
+		"polygon": "red",
+		"unknown": map[string]any{},
+	}
+	err := invalid.Validate()
+	assert.True(t, errors.Is(err, ErrInvalidStyle))
+	assert.Equal(t, StyleErrors{
+		{Path: "marker.pointColor.expression.conditions[0][0]", Pos: 12, Message: "unexpected end of expression"},
+		{Path: "marker.pointColor.expression.conditions[1]", Message: "condition must be a pair of a condition and an expression"},
+		{Path: "polygon", Message: "appearance must be an object"},
+		{Path: "unknown", Message: "unknown appearance type"},
+	}, err)
+	assert.Equal(t, "marker.pointColor.expression.conditions[0][0]:12: unexpected end of expression", err.(StyleErrors)[0].Error())
+}
+
+func TestStyleValue_Check(t *testing.T) {
+	v := &StyleValue{
+		"marker": map[string]any{
+			"pointColor": map[string]any{"expression": `${height} > 1 && ${$.name} === "${kind}"`},
+		},
+	}
+	assert.Empty(t, v.Check(nil))
+	assert.Empty(t, v.Check([]string{"height", "kind"}))
+	assert.Equal(t, StyleErrors{
+		{Path: "marker.pointColor.expression", Pos: 1, Message: `unknown attribute "height"`},
+		{Path: "marker.pointColor.expression", Pos: 32, Message: `unknown attribute "kind"`},
+	}, v.Check([]string{"name"}))
+}
@@ -0,0 +1,200 @@
+package styleexpr
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenVariable
+	tokenOperator
+	tokenPunct
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// operators are sorted so that longer operators are matched first.
+var operators = []string{
+	"===", "!==",
+	"==", "!=", "=~", "!~", ">=", "<=", "&&", "||",
+	"+", "-", "*", "/", "%", ">", "<", "!",
+}
+
+// unsupportedOperators are accepted by the viewer's tokenizer but rejected when the expression is evaluated.
+var unsupportedOperators = []string{
+	">>>", "<<", ">>", "|", "&", "^", "~",
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+func (l *lexer) all() ([]token, error) {
+	var res []token
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+		if t.kind == tokenEOF {
+			return res, nil
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) && isSpace(l.src[l.pos]) {
+		l.pos++
+	}
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, pos: l.pos}, nil
+	}
+
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case c == '$' && strings.HasPrefix(l.src[l.pos:], "${"):
+		end := strings.IndexByte(l.src[l.pos+2:], '}')
+		if end < 0 {
+			return token{}, errorf(start, "unterminated variable")
+		}
+		name := l.src[l.pos+2 : l.pos+2+end]
+		if strings.TrimSpace(name) == "" {
+			return token{}, errorf(start, "empty variable name")
+		}
+		l.pos += end + 3
+		return token{kind: tokenVariable, value: name, pos: start}, nil
+	case c == '"' || c == '\'':
+		return l.string(c)
+	case isDigit(c) || (c == '.' && l.pos+1 < len(l.src) && isDigit(l.src[l.pos+1])):
+		return l.number()
+	case isIdentStart(l.src[l.pos:]):
+		for l.pos < len(l.src) && isIdentPart(l.src[l.pos:]) {
+			_, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			l.pos += size
+		}
+		return token{kind: tokenIdent, value: l.src[start:l.pos], pos: start}, nil
+	case strings.IndexByte("()[],.?:", c) >= 0:
+		l.pos++
+		return token{kind: tokenPunct, value: string(c), pos: start}, nil
+	}
+
+	for _, op := range unsupportedOperators {
+		if strings.HasPrefix(l.src[l.pos:], op) && !hasOperatorPrefix(l.src[l.pos:]) {
+			return token{}, errorf(start, "unsupported operator %q", op)
+		}
+	}
+	for _, op := range operators {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.pos += len(op)
+			return token{kind: tokenOperator, value: op, pos: start}, nil
+		}
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+	return token{}, errorf(start, "unexpected character %q", r)
+}
+
+func (l *lexer) string(quote byte) (token, error) {
+	start := l.pos
+	l.pos++
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch c {
+		case quote:
+			l.pos++
+			return token{kind: tokenString, value: b.String(), pos: start}, nil
+		case '\\':
+			if l.pos+1 >= len(l.src) {
+				return token{}, errorf(start, "unterminated string")
+			}
+			l.pos++
+			switch e := l.src[l.pos]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'v':
+				b.WriteByte('\v')
+			default:
+				b.WriteByte(e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+		l.pos++
+	}
+	return token{}, errorf(start, "unterminated string")
+}
+
+func (l *lexer) number() (token, error) {
+	start := l.pos
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		l.pos++
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.pos++
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if l.pos >= len(l.src) || !isDigit(l.src[l.pos]) {
+			return token{}, errorf(l.pos, "expected exponent")
+		}
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.pos++
+		}
+	}
+	if l.pos < len(l.src) && isIdentStart(l.src[l.pos:]) {
+		return token{}, errorf(l.pos, "variable names cannot start with a number")
+	}
+	return token{kind: tokenNumber, value: l.src[start:l.pos], pos: start}, nil
+}
+
+// hasOperatorPrefix reports whether s starts with a supported operator that shares a prefix with an unsupported one, such as "&&" and "&".
+func hasOperatorPrefix(s string) bool {
+	return strings.HasPrefix(s, "&&") || strings.HasPrefix(s, "||") || strings.HasPrefix(s, "<=") || strings.HasPrefix(s, ">=")
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r == '_' || r == '$' || unicode.IsLetter(r)
+}
+
+func isIdentPart(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return isIdentStart(s) || unicode.IsDigit(r)
+}
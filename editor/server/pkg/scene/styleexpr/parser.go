@@ -0,0 +1,263 @@
+package styleexpr
+
+import (
+	"strconv"
+)
+
+type nodeKind int
+
+const (
+	nodeLiteral nodeKind = iota
+	nodeString
+	nodeIdent
+	nodeVariable
+	nodeUnary
+	nodeBinary
+	nodeConditional
+	nodeMember
+	nodeCall
+	nodeArray
+)
+
+type node struct {
+	kind     nodeKind
+	value    string
+	pos      int
+	computed bool
+	children []*node
+}
+
+// binaryPrecedence follows the precedence table of jsep, the parser used by the viewer.
+var binaryPrecedence = map[string]int{
+	"=~": 0, "!~": 0,
+	"||": 1,
+	"&&": 2,
+	"==": 6, "!=": 6, "===": 6, "!==": 6,
+	"<": 7, ">": 7, "<=": 7, ">=": 7,
+	"+": 9, "-": 9,
+	"*": 10, "/": 10, "%": 10,
+}
+
+type parser struct {
+	tokens []token
+	i      int
+}
+
+func parse(src string) (*node, error) {
+	tokens, err := (&lexer{src: src}).all()
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, errorf(0, "empty expression")
+	}
+	n, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, errorf(t.pos, "provide exactly one expression, found %s", describe(t))
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.i]
+	if t.kind != tokenEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) is(kind tokenKind, value string) bool {
+	t := p.peek()
+	return t.kind == kind && t.value == value
+}
+
+func (p *parser) expect(value string) (token, error) {
+	t := p.peek()
+	if t.kind != tokenPunct || t.value != value {
+		return t, errorf(t.pos, "expected %q, found %s", value, describe(t))
+	}
+	return p.advance(), nil
+}
+
+func (p *parser) expression() (*node, error) {
+	test, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.is(tokenPunct, "?") {
+		return test, nil
+	}
+	q := p.advance()
+	consequent, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	alternate, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	return &node{kind: nodeConditional, value: "?", pos: q.pos, children: []*node{test, consequent, alternate}}, nil
+}
+
+func (p *parser) binary(minPrec int) (*node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		prec, ok := binaryPrecedence[t.value]
+		if t.kind != tokenOperator || !ok || prec < minPrec {
+			return left, nil
+		}
+		p.advance()
+		right, err := p.binary(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &node{kind: nodeBinary, value: t.value, pos: t.pos, children: []*node{left, right}}
+	}
+}
+
+func (p *parser) unary() (*node, error) {
+	t := p.peek()
+	if t.kind == tokenOperator && (t.value == "!" || t.value == "-" || t.value == "+") {
+		p.advance()
+		arg, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &node{kind: nodeUnary, value: t.value, pos: t.pos, children: []*node{arg}}, nil
+	}
+	return p.postfix()
+}
+
+func (p *parser) postfix() (*node, error) {
+	n, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokenPunct {
+			return n, nil
+		}
+		switch t.value {
+		case ".":
+			p.advance()
+			prop := p.advance()
+			if prop.kind != tokenIdent {
+				return nil, errorf(prop.pos, "expected property name, found %s", describe(prop))
+			}
+			n = &node{kind: nodeMember, value: prop.value, pos: prop.pos, children: []*node{n}}
+		case "[":
+			p.advance()
+			prop, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			n = &node{kind: nodeMember, pos: t.pos, computed: true, children: []*node{n, prop}}
+		case "(":
+			p.advance()
+			args, err := p.list(")")
+			if err != nil {
+				return nil, err
+			}
+			n = &node{kind: nodeCall, pos: t.pos, children: append([]*node{n}, args...)}
+		default:
+			return n, nil
+		}
+	}
+}
+
+func (p *parser) primary() (*node, error) {
+	t := p.advance()
+	switch t.kind {
+	case tokenNumber:
+		if _, err := strconv.ParseFloat(t.value, 64); err != nil {
+			return nil, errorf(t.pos, "invalid number %q", t.value)
+		}
+		return &node{kind: nodeLiteral, value: t.value, pos: t.pos}, nil
+	case tokenString:
+		return &node{kind: nodeString, value: t.value, pos: t.pos}, nil
+	case tokenVariable:
+		return &node{kind: nodeVariable, value: t.value, pos: t.pos}, nil
+	case tokenIdent:
+		switch t.value {
+		case "true", "false", "null":
+			return &node{kind: nodeLiteral, value: t.value, pos: t.pos}, nil
+		}
+		return &node{kind: nodeIdent, value: t.value, pos: t.pos}, nil
+	case tokenPunct:
+		switch t.value {
+		case "(":
+			n, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case "[":
+			elems, err := p.list("]")
+			if err != nil {
+				return nil, err
+			}
+			return &node{kind: nodeArray, pos: t.pos, children: elems}, nil
+		}
+	}
+	return nil, errorf(t.pos, "unexpected %s", describe(t))
+}
+
+func (p *parser) list(closing string) ([]*node, error) {
+	var res []*node
+	if p.is(tokenPunct, closing) {
+		p.advance()
+		return res, nil
+	}
+	for {
+		n, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+		t := p.advance()
+		if t.kind == tokenPunct && t.value == closing {
+			return res, nil
+		}
+		if t.kind != tokenPunct || t.value != "," {
+			return nil, errorf(t.pos, "expected \",\" or %q, found %s", closing, describe(t))
+		}
+	}
+}
+
+func describe(t token) string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenNumber:
+		return "number " + t.value
+	case tokenString:
+		return "string " + strconv.Quote(t.value)
+	case tokenVariable:
+		return "variable ${" + t.value + "}"
+	case tokenIdent:
+		return "identifier " + t.value
+	}
+	return strconv.Quote(t.value)
+}
@@ -0,0 +1,237 @@
+// Package styleexpr parses and validates the expressions used in layer styles.
+// The grammar and the set of functions follow the expression evaluator of the viewer.
+package styleexpr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error is a syntax or semantic error in an expression. Pos is the byte offset in the expression where the error was found.
+type Error struct {
+	Pos     int
+	Message string
+}
+
+func errorf(pos int, format string, args ...any) *Error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d: %s", e.Pos+1, e.Message)
+}
+
+// Variable is a reference to a feature property written as ${name}.
+type Variable struct {
+	Name string
+	Pos  int
+}
+
+// JSONPath reports whether the variable is a JSONPath query such as ${$.properties.name} rather than a property name.
+func (v Variable) JSONPath() bool {
+	return strings.HasPrefix(v.Name, "$")
+}
+
+type Expr struct {
+	src  string
+	root *node
+}
+
+// Parse parses and checks an expression. The returned error is always an *Error.
+func Parse(src string) (*Expr, error) {
+	root, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := check(root); err != nil {
+		return nil, err
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+func (e *Expr) String() string {
+	if e == nil {
+		return ""
+	}
+	return e.src
+}
+
+// Variables returns the variables referenced by the expression, including the ones embedded in string literals, in order of appearance.
+func (e *Expr) Variables() []Variable {
+	if e == nil {
+		return nil
+	}
+	var res []Variable
+	walk(e.root, func(n *node) {
+		switch n.kind {
+		case nodeVariable:
+			res = append(res, Variable{Name: n.value, Pos: n.pos})
+		case nodeString:
+			res = append(res, stringVariables(n)...)
+		}
+	})
+	return res
+}
+
+func walk(n *node, f func(*node)) {
+	f(n)
+	for _, c := range n.children {
+		walk(c, f)
+	}
+}
+
+// stringVariables finds ${name} placeholders in a string literal. Positions are approximated by the start of the literal.
+func stringVariables(n *node) []Variable {
+	var res []Variable
+	s := n.value
+	for {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			return res
+		}
+		j := strings.IndexByte(s[i:], '}')
+		if j < 0 {
+			return res
+		}
+		if name := s[i+2 : i+j]; name != "" {
+			res = append(res, Variable{Name: name, Pos: n.pos})
+		}
+		s = s[i+j+1:]
+	}
+}
+
+type arity struct {
+	min, max int
+}
+
+const unlimited = -1
+
+var functions = map[string]arity{
+	"color":    {0, 2},
+	"rgb":      {3, unlimited},
+	"hsl":      {3, unlimited},
+	"rgba":     {4, unlimited},
+	"hsla":     {4, unlimited},
+	"regExp":   {0, 2},
+	"isNaN":    {0, 1},
+	"isFinite": {0, 1},
+	"Boolean":  {0, 1},
+	"Number":   {0, 1},
+	"String":   {0, 1},
+	// unary functions
+	"abs":     {1, 1},
+	"sqrt":    {1, 1},
+	"cos":     {1, 1},
+	"sin":     {1, 1},
+	"tan":     {1, 1},
+	"acos":    {1, 1},
+	"asin":    {1, 1},
+	"atan":    {1, 1},
+	"radians": {1, 1},
+	"degrees": {1, 1},
+	"sign":    {1, 1},
+	"floor":   {1, 1},
+	"ceil":    {1, 1},
+	"round":   {1, 1},
+	"exp2":    {1, 1},
+	"log":     {1, 1},
+	"log2":    {1, 1},
+	"fract":   {1, 1},
+	// binary functions
+	"atan2":      {2, 2},
+	"pow":        {2, 2},
+	"min":        {2, 2},
+	"max":        {2, 2},
+	"startsWith": {2, 2},
+}
+
+var constants = map[string][]string{
+	"Math":   {"PI", "E"},
+	"Number": {"POSITIVE_INFINITY"},
+}
+
+func check(n *node) error {
+	switch n.kind {
+	case nodeIdent:
+		switch n.value {
+		case "NaN", "Infinity", "undefined":
+			return nil
+		}
+		return errorf(n.pos, "%s is not defined", n.value)
+	case nodeMember:
+		if obj := n.children[0]; !n.computed && obj.kind == nodeIdent {
+			if names, ok := constants[obj.value]; ok {
+				for _, name := range names {
+					if name == n.value {
+						return nil
+					}
+				}
+				return errorf(n.pos, "%s.%s is not supported", obj.value, n.value)
+			}
+		}
+	case nodeCall:
+		if err := checkCall(n); err != nil {
+			return err
+		}
+		for _, arg := range n.children[1:] {
+			if err := check(arg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, c := range n.children {
+		if err := check(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkCall(n *node) error {
+	callee, args := n.children[0], n.children[1:]
+	switch callee.kind {
+	case nodeMember:
+		if callee.computed {
+			return errorf(callee.pos, "computed member is not a function")
+		}
+		obj := callee.children[0]
+		switch callee.value {
+		case "test", "exec":
+			if obj.kind != nodeCall || obj.children[0].kind != nodeIdent || obj.children[0].value != "regExp" {
+				return errorf(callee.pos, "%s can only be called on regExp()", callee.value)
+			}
+		case "toString":
+		default:
+			return errorf(callee.pos, "unexpected function call %q", callee.value)
+		}
+		return check(obj)
+	case nodeIdent:
+		a, ok := functions[callee.value]
+		if !ok {
+			return errorf(callee.pos, "unexpected function call %q", callee.value)
+		}
+		if len(args) < a.min || (a.max != unlimited && len(args) > a.max) {
+			return errorf(callee.pos, "%s requires %s", callee.value, a)
+		}
+		return nil
+	}
+	return errorf(n.pos, "expression is not a function")
+}
+
+func (a arity) String() string {
+	switch {
+	case a.min == a.max && a.min == 1:
+		return "exactly 1 argument"
+	case a.min == a.max:
+		return fmt.Sprintf("exactly %d arguments", a.min)
+	case a.max == unlimited:
+		return fmt.Sprintf("at least %d arguments", a.min)
+	case a.min == 0 && a.max == 1:
+		return "at most 1 argument"
+	case a.min == 0:
+		return fmt.Sprintf("at most %d arguments", a.max)
+	}
+	return fmt.Sprintf("%d to %d arguments", a.min, a.max)
+}
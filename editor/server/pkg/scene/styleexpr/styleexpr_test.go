@@ -0,0 +1,71 @@
+package styleexpr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  *Error
+	}{
+		{name: "number", src: "1.5e3"},
+		{name: "color", src: `color("red", 0.5)`},
+		{name: "rgba", src: "rgba(255, 0, 0, 0.5)"},
+		{name: "condition", src: `${height} >= 100 && ${type} === "building"`},
+		{name: "ternary", src: `${a} > 1 ? color("red") : color("blue")`},
+		{name: "regexp", src: `regExp("^a").test(${name})`},
+		{name: "regexp operator", src: `${name} =~ regExp("^a")`},
+		{name: "member", src: `${tags}[0] + ${obj}.value`},
+		{name: "constants", src: "Math.PI * Number.POSITIVE_INFINITY + NaN"},
+		{name: "functions", src: "pow(abs(-${x}), 2) + min(1, 2)"},
+		{name: "to string", src: `${x}.toString() + "m"`},
+		{name: "array", src: "[1, 2, ${x}]"},
+		{name: "json path", src: `${$.properties.name} === "a"`},
+		{name: "empty", src: "  ", err: &Error{Pos: 0, Message: "empty expression"}},
+		{name: "unterminated string", src: `color("red)`, err: &Error{Pos: 6, Message: "unterminated string"}},
+		{name: "unterminated variable", src: "${a > 1", err: &Error{Pos: 0, Message: "unterminated variable"}},
+		{name: "missing operand", src: "${a} >", err: &Error{Pos: 6, Message: "unexpected end of expression"}},
+		{name: "missing paren", src: "abs(1", err: &Error{Pos: 5, Message: `expected "," or ")", found end of expression`}},
+		{name: "extra token", src: "1 2", err: &Error{Pos: 2, Message: "provide exactly one expression, found number 2"}},
+		{name: "bitwise", src: "${a} | 1", err: &Error{Pos: 5, Message: `unsupported operator "|"`}},
+		{name: "unknown identifier", src: "height > 1", err: &Error{Pos: 0, Message: "height is not defined"}},
+		{name: "unknown function", src: "foo(1)", err: &Error{Pos: 0, Message: `unexpected function call "foo"`}},
+		{name: "arity", src: "abs(1, 2)", err: &Error{Pos: 0, Message: "abs requires exactly 1 argument"}},
+		{name: "rgb arity", src: "rgb(1, 2)", err: &Error{Pos: 0, Message: "rgb requires at least 3 arguments"}},
+		{name: "member function", src: `${a}.foo()`, err: &Error{Pos: 5, Message: `unexpected function call "foo"`}},
+		{name: "test on non regexp", src: `${a}.test("b")`, err: &Error{Pos: 5, Message: "test can only be called on regExp()"}},
+		{name: "math constant", src: "Math.TAU", err: &Error{Pos: 5, Message: "Math.TAU is not supported"}},
+		{name: "nested error", src: "abs(foo)", err: &Error{Pos: 4, Message: "foo is not defined"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Parse(tt.src)
+			if tt.err != nil {
+				assert.Nil(t, got)
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.src, got.String())
+		})
+	}
+}
+
+func TestExpr_Variables(t *testing.T) {
+	e, err := Parse(`${a} > 1 && "${b} m" !== ${$.c}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Variable{
+		{Name: "a", Pos: 0},
+		{Name: "b", Pos: 12},
+		{Name: "$.c", Pos: 25},
+	}, e.Variables())
+	assert.True(t, e.Variables()[2].JSONPath())
+	assert.False(t, e.Variables()[0].JSONPath())
+}
@@ -3,6 +3,7 @@ package shp
 import (
 	"encoding/binary"
 	"io"
+	"strings"
 )
 
 //go:generate stringer -type=ShapeType
@@ -1024,13 +1025,14 @@ type Field struct {
 	Padding   [14]byte
 }
 
-/* Note: not used
 // Returns a string representation of the Field. Currently
 // this only returns field name.
 func (f Field) String() string {
 	return strings.TrimRight(string(f.Name[:]), "\x00")
 }
 
+/* Note: not used
+
 // StringField returns a Field that can be used in SetFields to initialize the
 // DBF file.
 func StringField(name string, length uint8) Field {
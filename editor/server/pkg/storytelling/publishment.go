@@ -1,11 +1,16 @@
 package storytelling
 
-type PublishmentStatus string
+import "github.com/reearth/reearth/server/pkg/project"
+
+// PublishmentStatus and PublishSchedule are shared with projects since stories are published in the same way.
+type PublishmentStatus = project.PublishmentStatus
+
+type PublishSchedule = project.PublishSchedule
 
 const (
-	PublishmentStatusPublic PublishmentStatus = "public"
+	PublishmentStatusPublic = project.PublishmentStatusPublic
 
-	PublishmentStatusLimited PublishmentStatus = "limited"
+	PublishmentStatusLimited = project.PublishmentStatusLimited
 
-	PublishmentStatusPrivate PublishmentStatus = "private"
+	PublishmentStatusPrivate = project.PublishmentStatusPrivate
 )
@@ -26,10 +26,12 @@ type Story struct {
 	panelPosition Position
 	bgColor       string
 	updatedAt     time.Time
+	index         int
 
 	alias             string
 	status            PublishmentStatus
 	publishedAt       *time.Time
+	publishSchedule   *PublishSchedule
 	isBasicAuthActive bool
 	basicAuthUsername string
 	basicAuthPassword string
@@ -71,6 +73,10 @@ func (s *Story) PublishedAt() *time.Time {
 	return s.publishedAt
 }
 
+func (s *Story) PublishSchedule() *PublishSchedule {
+	return s.publishSchedule
+}
+
 func (s *Story) CreatedAt() time.Time {
 	return s.id.Timestamp()
 }
@@ -79,6 +85,11 @@ func (s *Story) UpdatedAt() time.Time {
 	return s.updatedAt
 }
 
+// Index returns the position of the story in the scene.
+func (s *Story) Index() int {
+	return s.index
+}
+
 func (s *Story) IsBasicAuthActive() bool {
 	return s.isBasicAuthActive
 }
@@ -213,6 +224,10 @@ func (s *Story) SetPublishedAt(now time.Time) {
 	s.publishedAt = &now
 }
 
+func (s *Story) SetPublishSchedule(publishSchedule *PublishSchedule) {
+	s.publishSchedule = publishSchedule
+}
+
 func (s *Story) UpdateAlias(alias string) error {
 	if CheckAliasPattern(alias) {
 		s.alias = alias
@@ -232,3 +247,62 @@ func (s *Story) MatchWithPublicName(name string) bool {
 func CheckAliasPattern(alias string) bool {
 	return alias != "" && aliasRegexp.Match([]byte(alias))
 }
+
+// Duplicate returns an unpublished copy of the story whose pages and blocks have new IDs.
+// The copy refers to new properties. The returned map associates the original property IDs with the new ones
+// so that the properties can be copied.
+func (s *Story) Duplicate() (*Story, map[PropertyID]PropertyID) {
+	if s == nil {
+		return nil, nil
+	}
+
+	properties := map[PropertyID]PropertyID{}
+	newProperty := func(p PropertyID) PropertyID {
+		np := NewPropertyID()
+		properties[p] = np
+		return np
+	}
+
+	pages := make([]*Page, 0, len(s.pages.Pages()))
+	for _, p := range s.pages.Pages() {
+		blocks := make(BlockList, 0, len(p.blocks))
+		for _, b := range p.blocks {
+			blocks = append(blocks, &Block{
+				id:        NewBlockID(),
+				plugin:    b.plugin.Clone(),
+				extension: b.extension,
+				property:  newProperty(b.property),
+			})
+		}
+
+		pages = append(pages, &Page{
+			id:              NewPageID(),
+			property:        newProperty(p.property),
+			title:           p.title,
+			swipeable:       p.swipeable,
+			layers:          p.layers.Clone(),
+			swipeableLayers: p.swipeableLayers.Clone(),
+			blocks:          blocks,
+		})
+	}
+
+	sid := NewStoryID()
+	return &Story{
+		id:                sid,
+		property:          newProperty(s.property),
+		scene:             s.scene,
+		title:             fmt.Sprintf("%s (copy)", s.title),
+		pages:             NewPageList(pages),
+		panelPosition:     s.panelPosition,
+		bgColor:           s.bgColor,
+		updatedAt:         sid.Timestamp(),
+		status:            PublishmentStatusPrivate,
+		isBasicAuthActive: s.isBasicAuthActive,
+		basicAuthUsername: s.basicAuthUsername,
+		basicAuthPassword: s.basicAuthPassword,
+		publicTitle:       s.publicTitle,
+		publicDescription: s.publicDescription,
+		publicImage:       s.publicImage,
+		publicNoIndex:     s.publicNoIndex,
+	}, properties
+}
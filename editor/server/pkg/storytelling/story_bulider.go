@@ -88,6 +88,16 @@ func (b *StoryBuilder) PublishedAt(t *time.Time) *StoryBuilder {
 	return b
 }
 
+func (b *StoryBuilder) PublishSchedule(publishSchedule *PublishSchedule) *StoryBuilder {
+	b.s.publishSchedule = publishSchedule
+	return b
+}
+
+func (b *StoryBuilder) Index(index int) *StoryBuilder {
+	b.s.index = index
+	return b
+}
+
 func (b *StoryBuilder) UpdatedAt(at time.Time) *StoryBuilder {
 	b.s.updatedAt = at
 	return b
@@ -1,3 +1,84 @@
 package storytelling
 
+import (
+	"sort"
+
+	"github.com/reearth/reearthx/util"
+)
+
 type StoryList []*Story
+
+func (l *StoryList) Story(id StoryID) *Story {
+	if l == nil {
+		return nil
+	}
+	return util.Get[StoryID, Story](*l, (*Story).Id, id)
+}
+
+func (l *StoryList) IndexOf(id StoryID) int {
+	if l == nil {
+		return -1
+	}
+	return util.IndexOf[StoryID, Story](*l, (*Story).Id, id)
+}
+
+// Sort sorts the stories by their indexes.
+func (l *StoryList) Sort() {
+	if l == nil {
+		return
+	}
+	sort.SliceStable(*l, func(i, j int) bool {
+		a, b := (*l)[i], (*l)[j]
+		if a.index != b.index {
+			return a.index < b.index
+		}
+		return a.id.Compare(b.id) < 0
+	})
+}
+
+func (l *StoryList) AddAt(s *Story, index *int) {
+	if s == nil || l == nil {
+		return
+	}
+	if index == nil || *index < 0 || len(*l) <= *index {
+		*l = append(*l, s)
+	} else {
+		*l = append((*l)[:*index], append(StoryList{s}, (*l)[*index:]...)...)
+	}
+	l.reindex()
+}
+
+func (l *StoryList) Move(id StoryID, i int) {
+	if l == nil {
+		return
+	}
+	le := len(*l)
+	if i < 0 || le <= i {
+		i = le
+	}
+	for index, s := range *l {
+		if s.Id() == id {
+			if index == i {
+				return
+			}
+			*l = append((*l)[:index], (*l)[index+1:]...)
+			l.AddAt(s, &i)
+			return
+		}
+	}
+}
+
+func (l *StoryList) Remove(id StoryID) {
+	if l == nil {
+		return
+	}
+	*l = util.RemoveById[StoryID, Story](*l, (*Story).Id, id)
+	l.reindex()
+}
+
+// reindex updates the indexes of the stories to match their positions in the list.
+func (l *StoryList) reindex() {
+	for i, s := range *l {
+		s.index = i
+	}
+}
@@ -0,0 +1,55 @@
+package storytelling
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoryList(t *testing.T) {
+	var nl *StoryList
+	assert.Nil(t, nl.Story(NewStoryID()))
+	assert.Equal(t, -1, nl.IndexOf(NewStoryID()))
+	assert.NotPanics(t, func() {
+		nl.Sort()
+		nl.AddAt(&Story{id: NewStoryID()}, nil)
+		nl.Move(NewStoryID(), 0)
+		nl.Remove(NewStoryID())
+	})
+
+	s1 := &Story{id: NewStoryID()}
+	s2 := &Story{id: NewStoryID()}
+	s3 := &Story{id: NewStoryID()}
+	l := &StoryList{}
+
+	l.AddAt(s1, nil)
+	l.AddAt(s2, lo.ToPtr(5))
+	l.AddAt(s3, lo.ToPtr(0))
+	assert.Equal(t, &StoryList{s3, s1, s2}, l)
+	assert.Equal(t, []int{0, 1, 2}, []int{s3.Index(), s1.Index(), s2.Index()})
+	assert.Equal(t, s1, l.Story(s1.Id()))
+	assert.Equal(t, 1, l.IndexOf(s1.Id()))
+
+	l.Move(s3.Id(), 2)
+	assert.Equal(t, &StoryList{s1, s2, s3}, l)
+	assert.Equal(t, []int{0, 1, 2}, []int{s1.Index(), s2.Index(), s3.Index()})
+	l.Move(s3.Id(), 0)
+	assert.Equal(t, &StoryList{s3, s1, s2}, l)
+	l.Move(s3.Id(), 5)
+	assert.Equal(t, &StoryList{s1, s2, s3}, l)
+
+	l.Remove(s1.Id())
+	assert.Equal(t, &StoryList{s2, s3}, l)
+	assert.Equal(t, []int{0, 1}, []int{s2.Index(), s3.Index()})
+}
+
+func TestStoryList_Sort(t *testing.T) {
+	s1 := &Story{id: NewStoryID(), index: 1}
+	s2 := &Story{id: NewStoryID(), index: 1}
+	s3 := &Story{id: NewStoryID(), index: 0}
+
+	l := &StoryList{s2, s1, s3}
+	l.Sort()
+	assert.Equal(t, &StoryList{s3, s1, s2}, l)
+}
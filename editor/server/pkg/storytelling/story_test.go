@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearthx/util"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
@@ -99,3 +100,52 @@ func TestStory_SettersGetters(t *testing.T) {
 
 func TestStory_ValidateProperties(t *testing.T) {
 }
+
+func TestStory_Duplicate(t *testing.T) {
+	block := &Block{
+		id:        NewBlockID(),
+		plugin:    id.OfficialPluginID,
+		extension: PluginExtensionID("block"),
+		property:  NewPropertyID(),
+	}
+	page := &Page{
+		id:       NewPageID(),
+		property: NewPropertyID(),
+		title:    "page",
+		layers:   LayerIDList{NewLayerID()},
+		blocks:   BlockList{block},
+	}
+	s := &Story{
+		id:       NewStoryID(),
+		property: NewPropertyID(),
+		scene:    NewSceneID(),
+		title:    "story",
+		alias:    "storyalias",
+		status:   PublishmentStatusPublic,
+		pages:    NewPageList([]*Page{page}),
+		index:    3,
+	}
+
+	got, properties := s.Duplicate()
+	assert.NotEqual(t, s.Id(), got.Id())
+	assert.Equal(t, s.Scene(), got.Scene())
+	assert.Equal(t, "story (copy)", got.Title())
+	assert.Equal(t, "", got.Alias())
+	assert.Equal(t, PublishmentStatusPrivate, got.Status())
+	assert.Len(t, properties, 3)
+	assert.Equal(t, properties[s.Property()], got.Property())
+
+	gotPage := got.Pages().Pages()[0]
+	assert.NotEqual(t, page.Id(), gotPage.Id())
+	assert.Equal(t, properties[page.Property()], gotPage.Property())
+	assert.Equal(t, page.Layers(), gotPage.Layers())
+
+	gotBlock := gotPage.Blocks()[0]
+	assert.NotEqual(t, block.ID(), gotBlock.ID())
+	assert.Equal(t, block.Plugin(), gotBlock.Plugin())
+	assert.Equal(t, properties[block.Property()], gotBlock.Property())
+
+	got, properties = (*Story)(nil).Duplicate()
+	assert.Nil(t, got)
+	assert.Nil(t, properties)
+}
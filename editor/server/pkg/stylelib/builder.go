@@ -0,0 +1,57 @@
+package stylelib
+
+import "sort"
+
+type Builder struct {
+	s *Style
+}
+
+func New() *Builder {
+	return &Builder{s: &Style{}}
+}
+
+func (b *Builder) Build() (*Style, error) {
+	if b.s.id.IsNil() || b.s.workspace.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if len(b.s.versions) == 0 {
+		return nil, ErrEmptyVersions
+	}
+	sort.SliceStable(b.s.versions, func(i, j int) bool {
+		return b.s.versions[i].version < b.s.versions[j].version
+	})
+	return b.s, nil
+}
+
+func (b *Builder) MustBuild() *Style {
+	s, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.s.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.s.id = NewID()
+	return b
+}
+
+func (b *Builder) Workspace(w WorkspaceID) *Builder {
+	b.s.workspace = w
+	return b
+}
+
+func (b *Builder) Name(name string) *Builder {
+	b.s.name = name
+	return b
+}
+
+func (b *Builder) Versions(versions []*Version) *Builder {
+	b.s.versions = append([]*Version{}, versions...)
+	return b
+}
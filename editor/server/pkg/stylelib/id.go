@@ -0,0 +1,22 @@
+package stylelib
+
+import (
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+type ID = id.LibraryStyleID
+type WorkspaceID = accountdomain.WorkspaceID
+type UserID = accountdomain.UserID
+
+type IDList = id.LibraryStyleIDList
+
+var NewID = id.NewLibraryStyleID
+var MustID = id.MustLibraryStyleID
+var IDFrom = id.LibraryStyleIDFrom
+var IDFromRef = id.LibraryStyleIDFromRef
+
+var NewWorkspaceID = accountdomain.NewWorkspaceID
+var NewUserID = accountdomain.NewUserID
+
+var ErrInvalidID = id.ErrInvalidID
@@ -0,0 +1,130 @@
+package stylelib
+
+import (
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/scene"
+)
+
+var (
+	ErrEmptyVersions   = errors.New("library style has no versions")
+	ErrVersionNotFound = errors.New("library style version not found")
+)
+
+// Style is a style shared in a workspace library. Every publish appends a new version, and scene styles link to one of them.
+type Style struct {
+	id        ID
+	workspace WorkspaceID
+	name      string
+	versions  []*Version
+}
+
+type Version struct {
+	version   int
+	value     scene.StyleValue
+	user      *UserID
+	createdAt time.Time
+}
+
+func NewVersion(version int, value *scene.StyleValue, user *UserID, createdAt time.Time) *Version {
+	v := &Version{
+		version:   version,
+		user:      user.CloneRef(),
+		createdAt: createdAt,
+	}
+	if value != nil {
+		v.value = cloneValue(*value)
+	}
+	return v
+}
+
+func cloneValue(v scene.StyleValue) scene.StyleValue {
+	res := make(scene.StyleValue, len(v))
+	for k, val := range v {
+		res[k] = val
+	}
+	return res
+}
+
+func (v *Version) Version() int {
+	return v.version
+}
+
+func (v *Version) Value() *scene.StyleValue {
+	if v.value == nil {
+		return nil
+	}
+	res := cloneValue(v.value)
+	return &res
+}
+
+func (v *Version) User() *UserID {
+	return v.user.CloneRef()
+}
+
+func (v *Version) CreatedAt() time.Time {
+	return v.createdAt
+}
+
+func (s *Style) ID() ID {
+	return s.id
+}
+
+func (s *Style) Workspace() WorkspaceID {
+	return s.workspace
+}
+
+func (s *Style) Name() string {
+	return s.name
+}
+
+func (s *Style) Rename(name string) {
+	s.name = name
+}
+
+func (s *Style) Versions() []*Version {
+	return append([]*Version{}, s.versions...)
+}
+
+// Latest returns the most recently published version.
+func (s *Style) Latest() *Version {
+	if len(s.versions) == 0 {
+		return nil
+	}
+	return s.versions[len(s.versions)-1]
+}
+
+func (s *Style) LatestVersion() int {
+	if l := s.Latest(); l != nil {
+		return l.version
+	}
+	return 0
+}
+
+func (s *Style) Version(version int) (*Version, error) {
+	for _, v := range s.versions {
+		if v.version == version {
+			return v, nil
+		}
+	}
+	return nil, ErrVersionNotFound
+}
+
+// Publish appends the value as a new version numbered after the latest one.
+func (s *Style) Publish(value *scene.StyleValue, user *UserID, t time.Time) *Version {
+	v := NewVersion(s.LatestVersion()+1, value, user, t)
+	s.versions = append(s.versions, v)
+	return v
+}
+
+func (s *Style) CreatedAt() time.Time {
+	return s.id.Timestamp()
+}
+
+func (s *Style) UpdatedAt() time.Time {
+	if l := s.Latest(); l != nil {
+		return l.createdAt
+	}
+	return s.CreatedAt()
+}
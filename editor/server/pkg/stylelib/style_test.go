@@ -0,0 +1,56 @@
+package stylelib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	wid := NewWorkspaceID()
+	now := time.Now()
+	v1 := NewVersion(1, &scene.StyleValue{"marker": map[string]any{}}, nil, now)
+
+	_, err := New().NewID().Name("a").Versions([]*Version{v1}).Build()
+	assert.Equal(t, ErrInvalidID, err)
+
+	_, err = New().NewID().Workspace(wid).Name("a").Build()
+	assert.Equal(t, ErrEmptyVersions, err)
+
+	v2 := NewVersion(2, &scene.StyleValue{"polygon": map[string]any{}}, nil, now.Add(time.Hour))
+	s, err := New().NewID().Workspace(wid).Name("a").Versions([]*Version{v2, v1}).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, wid, s.Workspace())
+	assert.Equal(t, "a", s.Name())
+	assert.Equal(t, []*Version{v1, v2}, s.Versions())
+	assert.Equal(t, v2, s.Latest())
+	assert.Equal(t, now.Add(time.Hour), s.UpdatedAt())
+}
+
+func TestStyle_Publish(t *testing.T) {
+	uid := NewUserID()
+	now := time.Now()
+	s := New().NewID().Workspace(NewWorkspaceID()).Versions([]*Version{
+		NewVersion(1, &scene.StyleValue{"marker": map[string]any{}}, nil, now),
+	}).MustBuild()
+
+	value := &scene.StyleValue{"polygon": map[string]any{"fill": true}}
+	v := s.Publish(value, &uid, now)
+	assert.Equal(t, 2, v.Version())
+	assert.Equal(t, 2, s.LatestVersion())
+	assert.Equal(t, value, v.Value())
+	assert.Equal(t, &uid, v.User())
+
+	got, err := s.Version(1)
+	assert.NoError(t, err)
+	assert.Equal(t, &scene.StyleValue{"marker": map[string]any{}}, got.Value())
+
+	_, err = s.Version(3)
+	assert.Equal(t, ErrVersionNotFound, err)
+
+	// published values are not affected by later changes to the source
+	(*value)["marker"] = map[string]any{}
+	assert.Equal(t, &scene.StyleValue{"polygon": map[string]any{"fill": true}}, v.Value())
+}
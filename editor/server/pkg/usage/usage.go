@@ -0,0 +1,163 @@
+package usage
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+)
+
+// Usage is the amount of data a workspace uses, aggregated at a time.
+type Usage struct {
+	workspace        accountdomain.WorkspaceID
+	assetStorageSize int64
+	projects         []Project
+	updatedAt        time.Time
+}
+
+// Project is the usage of a project and its scene.
+type Project struct {
+	ID                  id.ProjectID
+	Name                string
+	Scene               *id.SceneID
+	Published           bool
+	LayerCount          int
+	NLSLayerCount       int
+	StoryCount          int
+	PublishedStoryCount int
+}
+
+func New(workspace accountdomain.WorkspaceID, assetStorageSize int64, projects []Project, updatedAt time.Time) *Usage {
+	return &Usage{
+		workspace:        workspace,
+		assetStorageSize: assetStorageSize,
+		projects:         append([]Project{}, projects...),
+		updatedAt:        updatedAt,
+	}
+}
+
+func (u *Usage) Workspace() accountdomain.WorkspaceID {
+	return u.workspace
+}
+
+func (u *Usage) AssetStorageSize() int64 {
+	return u.assetStorageSize
+}
+
+func (u *Usage) Projects() []Project {
+	return append([]Project{}, u.projects...)
+}
+
+func (u *Usage) UpdatedAt() time.Time {
+	return u.updatedAt
+}
+
+func (u *Usage) ProjectCount() int {
+	return len(u.projects)
+}
+
+func (u *Usage) PublishedProjectCount() int {
+	return u.count(func(p Project) int {
+		if p.Published {
+			return 1
+		}
+		return 0
+	})
+}
+
+func (u *Usage) SceneCount() int {
+	return u.count(func(p Project) int {
+		if p.Scene != nil {
+			return 1
+		}
+		return 0
+	})
+}
+
+func (u *Usage) LayerCount() int {
+	return u.count(func(p Project) int { return p.LayerCount })
+}
+
+func (u *Usage) NLSLayerCount() int {
+	return u.count(func(p Project) int { return p.NLSLayerCount })
+}
+
+func (u *Usage) StoryCount() int {
+	return u.count(func(p Project) int { return p.StoryCount })
+}
+
+func (u *Usage) PublishedStoryCount() int {
+	return u.count(func(p Project) int { return p.PublishedStoryCount })
+}
+
+// PublishedSiteCount returns the number of published projects and stories, each of which is served as a site.
+func (u *Usage) PublishedSiteCount() int {
+	return u.PublishedProjectCount() + u.PublishedStoryCount()
+}
+
+func (u *Usage) count(f func(Project) int) (n int) {
+	for _, p := range u.projects {
+		n += f(p)
+	}
+	return
+}
+
+// WriteCSV writes a row of the workspace total followed by a row of each project.
+func (u *Usage) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{
+		{"type", "id", "name", "published", "scenes", "layers", "nls_layers", "stories", "published_stories", "asset_storage_size", "updated_at"},
+		{
+			"workspace",
+			u.workspace.String(),
+			"",
+			strconv.Itoa(u.PublishedProjectCount()),
+			strconv.Itoa(u.SceneCount()),
+			strconv.Itoa(u.LayerCount()),
+			strconv.Itoa(u.NLSLayerCount()),
+			strconv.Itoa(u.StoryCount()),
+			strconv.Itoa(u.PublishedStoryCount()),
+			strconv.FormatInt(u.assetStorageSize, 10),
+			u.updatedAt.UTC().Format(time.RFC3339),
+		},
+	}
+	for _, p := range u.projects {
+		published, scenes := 0, 0
+		if p.Published {
+			published = 1
+		}
+		if p.Scene != nil {
+			scenes = 1
+		}
+		rows = append(rows, []string{
+			"project",
+			p.ID.String(),
+			csvText(p.Name),
+			strconv.Itoa(published),
+			strconv.Itoa(scenes),
+			strconv.Itoa(p.LayerCount),
+			strconv.Itoa(p.NLSLayerCount),
+			strconv.Itoa(p.StoryCount),
+			strconv.Itoa(p.PublishedStoryCount),
+			"",
+			"",
+		})
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// csvText prefixes the text with a quote if it starts with a character which makes spreadsheets evaluate it as a formula.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,63 @@
+package usage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/account/accountdomain"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage(t *testing.T) {
+	wid := accountdomain.NewWorkspaceID()
+	p1, p2 := id.NewProjectID(), id.NewProjectID()
+	sid := id.NewSceneID()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	u := New(wid, 100, []Project{
+		{ID: p1, Name: "a", Scene: &sid, Published: true, LayerCount: 1, NLSLayerCount: 2, StoryCount: 3, PublishedStoryCount: 1},
+		{ID: p2, Name: "b, c"},
+	}, now)
+
+	assert.Equal(t, wid, u.Workspace())
+	assert.Equal(t, int64(100), u.AssetStorageSize())
+	assert.Equal(t, now, u.UpdatedAt())
+	assert.Equal(t, 2, u.ProjectCount())
+	assert.Equal(t, 1, u.PublishedProjectCount())
+	assert.Equal(t, 1, u.SceneCount())
+	assert.Equal(t, 1, u.LayerCount())
+	assert.Equal(t, 2, u.NLSLayerCount())
+	assert.Equal(t, 3, u.StoryCount())
+	assert.Equal(t, 1, u.PublishedStoryCount())
+	assert.Equal(t, 2, u.PublishedSiteCount())
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, u.WriteCSV(buf))
+	assert.Equal(t, "type,id,name,published,scenes,layers,nls_layers,stories,published_stories,asset_storage_size,updated_at\n"+
+		"workspace,"+wid.String()+",,1,1,1,2,3,1,100,2024-01-02T03:04:05Z\n"+
+		"project,"+p1.String()+",a,1,1,1,2,3,1,,\n"+
+		"project,"+p2.String()+",\"b, c\",0,0,0,0,0,0,,\n", buf.String())
+}
+
+func TestUsage_WriteCSV_Formula(t *testing.T) {
+	wid := accountdomain.NewWorkspaceID()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	names := []string{"=HYPERLINK(\"https://example.com\")", "+1", "-1", "@SUM(A1)", "a=b"}
+
+	var projects []Project
+	for _, n := range names {
+		projects = append(projects, Project{ID: id.NewProjectID(), Name: n})
+	}
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, New(wid, 0, projects, now).WriteCSV(buf))
+	rows, err := csv.NewReader(buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"'=HYPERLINK(\"https://example.com\")", "'+1", "'-1", "'@SUM(A1)", "a=b"}, lo.Map(rows[2:], func(r []string, _ int) string {
+		return r[2]
+	}))
+}
@@ -3,11 +3,13 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/eukarya-inc/reearth-plateauview/server/cmsintegration"
 	"github.com/eukarya-inc/reearth-plateauview/server/datacatalog"
 	"github.com/eukarya-inc/reearth-plateauview/server/opinion"
 	"github.com/eukarya-inc/reearth-plateauview/server/plateaucms"
+	"github.com/eukarya-inc/reearth-plateauview/server/proxy"
 	"github.com/eukarya-inc/reearth-plateauview/server/sdkapi/sdkapiv3"
 	"github.com/eukarya-inc/reearth-plateauview/server/searchindex"
 	"github.com/eukarya-inc/reearth-plateauview/server/sidebar"
@@ -27,57 +29,78 @@ func init() {
 const configPrefix = "REEARTH_PLATEAUVIEW"
 
 type Config struct {
-	Port                               uint     `default:"8080" envconfig:"PORT"`
-	Host                               string   `default:"http://localhost:8080"`
-	GOOGLE_CLOUD_PROJECT               string   `envconfig:"GOOGLE_CLOUD_PROJECT" pp:",omitempty"`
-	GOOGLE_CLOUD_REGION                string   `envconfig:"GOOGLE_CLOUD_REGION" pp:",omitempty"`
-	Debug                              bool     `pp:",omitempty"`
-	Origin                             []string `pp:",omitempty"`
-	Secret                             string   `pp:",omitempty"`
-	Delegate_URL                       string   `pp:",omitempty"`
-	CMS_Webhook_Secret                 string   `pp:",omitempty"`
-	CMS_BaseURL                        string   `pp:",omitempty"`
-	CMS_Token                          string   `pp:",omitempty"`
-	CMS_IntegrationID                  string   `pp:",omitempty"`
-	CMS_PlateauProject                 string   `pp:",omitempty"`
-	CMS_SystemProject                  string   `pp:",omitempty"`
-	CMS_TokenProject                   string   `pp:",omitempty"`
-	FME_BaseURL                        string   `pp:",omitempty"`
-	FME_BaseURL_V2                     string   `pp:",omitempty"`
-	FME_URL_V3                         string   `pp:",omitempty"`
-	FME_Mock                           bool     `pp:",omitempty"`
-	FME_Token                          string   `pp:",omitempty"`
-	FME_SkipQualityCheck               bool     `pp:",omitempty"`
-	Ckan_BaseURL                       string   `pp:",omitempty"`
-	Ckan_Org                           string   `pp:",omitempty"`
-	Ckan_Token                         string   `pp:",omitempty"`
-	Ckan_Private                       bool     `pp:",omitempty"`
-	SDK_Token                          string   `pp:",omitempty"`
-	SendGrid_APIKey                    string   `pp:",omitempty"`
-	Opinion_From                       string   `pp:",omitempty"`
-	Opinion_FromName                   string   `pp:",omitempty"`
-	Opinion_To                         string   `pp:",omitempty"`
-	Opinion_ToName                     string   `pp:",omitempty"`
-	Sidebar_Token                      string   `pp:",omitempty"`
-	Share_Disable                      bool     `pp:",omitempty"`
-	Geospatialjp_Publication_Disable   bool     `pp:",omitempty"`
-	Geospatialjp_CatalocCheck_Disable  bool     `pp:",omitempty"`
-	Geospatialjp_BuildType             string   `pp:",omitempty"`
-	Geospatialjp_JobName               string   `pp:",omitempty"`
-	Geospatialjp_CloudBuildImage       string   `pp:",omitempty"`
-	Geospatialjp_CloudBuildMachineType string   `pp:",omitempty"`
-	Geospatialjp_CloudBuildProject     string   `pp:",omitempty"`
-	Geospatialjp_CloudBuildRegion      string   `pp:",omitempty"`
-	Geospatialjp_CloudBuildDiskSizeGb  int64    `pp:",omitempty"`
-	DataConv_Disable                   bool     `pp:",omitempty"`
-	Indexer_Delegate                   bool     `pp:",omitempty"`
-	DataCatalog_DisableCache           bool     `pp:",omitempty"`
-	DataCatalog_CacheUpdateKey         string   `pp:",omitempty"`
-	DataCatalog_PlaygroundEndpoint     string   `pp:",omitempty"`
-	DataCatalog_CacheTTL               int      `pp:",omitempty"`
-	DataCatalog_GQL_MaxComplexity      int      `pp:",omitempty"`
-	DataCatalog_PanicOnInit            bool     `pp:",omitempty"`
-	GCParcent                          int      `pp:",omitempty"`
+	Port                               uint          `default:"8080" envconfig:"PORT"`
+	Host                               string        `default:"http://localhost:8080"`
+	GOOGLE_CLOUD_PROJECT               string        `envconfig:"GOOGLE_CLOUD_PROJECT" pp:",omitempty"`
+	GOOGLE_CLOUD_REGION                string        `envconfig:"GOOGLE_CLOUD_REGION" pp:",omitempty"`
+	Debug                              bool          `pp:",omitempty"`
+	Origin                             []string      `pp:",omitempty"`
+	Secret                             string        `pp:",omitempty"`
+	Delegate_URL                       string        `pp:",omitempty"`
+	CMS_Webhook_Secret                 string        `pp:",omitempty"`
+	CMS_BaseURL                        string        `pp:",omitempty"`
+	CMS_Token                          string        `pp:",omitempty"`
+	CMS_IntegrationID                  string        `pp:",omitempty"`
+	CMS_PlateauProject                 string        `pp:",omitempty"`
+	CMS_SystemProject                  string        `pp:",omitempty"`
+	CMS_TokenProject                   string        `pp:",omitempty"`
+	FME_BaseURL                        string        `pp:",omitempty"`
+	FME_BaseURL_V2                     string        `pp:",omitempty"`
+	FME_URL_V3                         string        `pp:",omitempty"`
+	FME_Mock                           bool          `pp:",omitempty"`
+	FME_Token                          string        `pp:",omitempty"`
+	FME_SkipQualityCheck               bool          `pp:",omitempty"`
+	Ckan_BaseURL                       string        `pp:",omitempty"`
+	Ckan_Org                           string        `pp:",omitempty"`
+	Ckan_Token                         string        `pp:",omitempty"`
+	Ckan_Private                       bool          `pp:",omitempty"`
+	SDK_Token                          string        `pp:",omitempty"`
+	SendGrid_APIKey                    string        `pp:",omitempty"`
+	Opinion_From                       string        `pp:",omitempty"`
+	Opinion_FromName                   string        `pp:",omitempty"`
+	Opinion_To                         string        `pp:",omitempty"`
+	Opinion_ToName                     string        `pp:",omitempty"`
+	Opinion_SMTP_Host                  string        `pp:",omitempty"`
+	Opinion_SMTP_Port                  int           `pp:",omitempty"`
+	Opinion_SMTP_User                  string        `pp:",omitempty"`
+	Opinion_SMTP_Password              string        `pp:",omitempty"`
+	Opinion_CMSProject                 string        `pp:",omitempty"`
+	Opinion_CMSModel                   string        `pp:",omitempty"`
+	Opinion_RateLimit                  float64       `pp:",omitempty"`
+	Opinion_RateBurst                  int           `pp:",omitempty"`
+	Opinion_CaptchaSecret              string        `pp:",omitempty"`
+	Opinion_CaptchaVerifyURL           string        `pp:",omitempty"`
+	Sidebar_Token                      string        `pp:",omitempty"`
+	Share_Disable                      bool          `pp:",omitempty"`
+	Share_DefaultTTL                   time.Duration `pp:",omitempty"`
+	Share_InactiveTTL                  time.Duration `pp:",omitempty"`
+	Geospatialjp_Publication_Disable   bool          `pp:",omitempty"`
+	Geospatialjp_CatalocCheck_Disable  bool          `pp:",omitempty"`
+	Geospatialjp_BuildType             string        `pp:",omitempty"`
+	Geospatialjp_JobName               string        `pp:",omitempty"`
+	Geospatialjp_CloudBuildImage       string        `pp:",omitempty"`
+	Geospatialjp_CloudBuildMachineType string        `pp:",omitempty"`
+	Geospatialjp_CloudBuildProject     string        `pp:",omitempty"`
+	Geospatialjp_CloudBuildRegion      string        `pp:",omitempty"`
+	Geospatialjp_CloudBuildDiskSizeGb  int64         `pp:",omitempty"`
+	DataConv_Disable                   bool          `pp:",omitempty"`
+	Indexer_Delegate                   bool          `pp:",omitempty"`
+	DataCatalog_DisableCache           bool          `pp:",omitempty"`
+	DataCatalog_CacheUpdateKey         string        `pp:",omitempty"`
+	DataCatalog_PlaygroundEndpoint     string        `pp:",omitempty"`
+	DataCatalog_CacheTTL               int           `pp:",omitempty"`
+	DataCatalog_GQL_MaxComplexity      int           `pp:",omitempty"`
+	DataCatalog_PanicOnInit            bool          `pp:",omitempty"`
+	Proxy_Disable                      bool          `pp:",omitempty"`
+	Proxy_AllowedHosts                 []string      `pp:",omitempty"`
+	Proxy_AllowPrivateNetworks         bool          `pp:",omitempty"`
+	Proxy_MaxBodySize                  int64         `pp:",omitempty"`
+	Proxy_Timeout                      time.Duration `pp:",omitempty"`
+	Proxy_RateLimit                    float64       `pp:",omitempty"`
+	Proxy_RateBurst                    int           `pp:",omitempty"`
+	Proxy_Cache                        bool          `pp:",omitempty"`
+	Proxy_CacheTTL                     time.Duration `pp:",omitempty"`
+	GCParcent                          int           `pp:",omitempty"`
 }
 
 func NewConfig() (*Config, error) {
@@ -170,13 +193,29 @@ func (c *Config) Opinion() opinion.Config {
 		FromName:       c.Opinion_FromName,
 		To:             c.Opinion_To,
 		ToName:         c.Opinion_ToName,
+		SMTP: opinion.SMTPConfig{
+			Host:     c.Opinion_SMTP_Host,
+			Port:     c.Opinion_SMTP_Port,
+			User:     c.Opinion_SMTP_User,
+			Password: c.Opinion_SMTP_Password,
+		},
+		CMSBaseURL:       c.CMS_BaseURL,
+		CMSToken:         c.CMS_Token,
+		CMSProject:       c.Opinion_CMSProject,
+		CMSModel:         c.Opinion_CMSModel,
+		RateLimit:        c.Opinion_RateLimit,
+		RateBurst:        c.Opinion_RateBurst,
+		CaptchaSecret:    c.Opinion_CaptchaSecret,
+		CaptchaVerifyURL: c.Opinion_CaptchaVerifyURL,
 	}
 }
 
 func (c *Config) Sidebar() sidebar.Config {
 	return sidebar.Config{
-		Config:       c.plateauCMS(),
-		DisableShare: c.Share_Disable,
+		Config:           c.plateauCMS(),
+		DisableShare:     c.Share_Disable,
+		ShareDefaultTTL:  c.Share_DefaultTTL,
+		ShareInactiveTTL: c.Share_InactiveTTL,
 	}
 }
 
@@ -192,6 +231,20 @@ func (c *Config) DataCatalog() datacatalog.Config {
 	}
 }
 
+func (c *Config) Proxy() proxy.Config {
+	return proxy.Config{
+		AllowedHosts:         c.Proxy_AllowedHosts,
+		AllowedOrigins:       c.Origin,
+		AllowPrivateNetworks: c.Proxy_AllowPrivateNetworks,
+		MaxBodySize:          c.Proxy_MaxBodySize,
+		Timeout:              c.Proxy_Timeout,
+		RateLimit:            c.Proxy_RateLimit,
+		RateBurst:            c.Proxy_RateBurst,
+		Cache:                c.Proxy_Cache,
+		CacheTTL:             c.Proxy_CacheTTL,
+	}
+}
+
 func (c *Config) plateauCMS() plateaucms.Config {
 	return plateaucms.Config{
 		CMSBaseURL:      c.CMS_BaseURL,
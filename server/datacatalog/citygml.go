@@ -11,6 +11,7 @@ import (
 
 	"github.com/eukarya-inc/reearth-plateauview/server/datacatalog/plateauapi"
 	"github.com/eukarya-inc/reearth-plateauview/server/plateaucms"
+	cms "github.com/reearth/reearth-cms-api/go"
 	"github.com/reearth/reearthx/util"
 	"github.com/samber/lo"
 )
@@ -31,6 +32,16 @@ type CityGMLFile struct {
 	MeshCode string `json:"code"`
 	MaxLOD   int    `json:"maxLod"`
 	URL      string `json:"url"`
+	// Size and Texture are available only when the CityGML asset is stored as an extracted asset in CMS.
+	Size    int   `json:"size,omitempty"`
+	Texture *bool `json:"texture,omitempty"`
+}
+
+// gmlFile is a GML file included in the CityGML asset.
+type gmlFile struct {
+	URL     *url.URL
+	Size    int
+	Texture bool
 }
 
 func fetchCityGMLFiles(ctx context.Context, r plateauapi.Repo, id string) (*CityGMLFilesResponse, error) {
@@ -79,7 +90,7 @@ func fetchCityGMLFiles(ctx context.Context, r plateauapi.Repo, id string) (*City
 		return nil, nil
 	}
 
-	var gurls []*url.URL
+	var gfiles []gmlFile
 	citygmlAssetID, _ := admin["citygmlAssetId"].(string)
 	if citygmlAssetID != "" {
 		mds := plateaucms.GetAllCMSMetadataFromContext(ctx)
@@ -104,7 +115,9 @@ func fetchCityGMLFiles(ctx context.Context, r plateauapi.Repo, id string) (*City
 		}
 
 		assetBase.Path = path.Dir(assetBase.Path)
-		gurls = gmlURLs(asset.File.Paths(), assetBase)
+		if asset.File != nil {
+			gfiles = gmlFiles(*asset.File, assetBase)
+		}
 	}
 
 	data, err := fetchCSVs(ctx, maxlodURLs, citygmlURLs)
@@ -112,7 +125,7 @@ func fetchCityGMLFiles(ctx context.Context, r plateauapi.Repo, id string) (*City
 		return nil, err
 	}
 
-	files := csvToCityGMLFilesResponse(data, gurls)
+	files := csvToCityGMLFilesResponse(data, gfiles)
 	return &CityGMLFilesResponse{
 		CityCode:         string(citygml.CityCode),
 		CityName:         city.Name,
@@ -124,7 +137,7 @@ func fetchCityGMLFiles(ctx context.Context, r plateauapi.Repo, id string) (*City
 	}, nil
 }
 
-func csvToCityGMLFilesResponse(data [][]string, gmlURLs []*url.URL) CityGMLFiles {
+func csvToCityGMLFilesResponse(data [][]string, gmlFiles []gmlFile) CityGMLFiles {
 	res := make(CityGMLFiles)
 
 	for _, record := range data {
@@ -144,17 +157,19 @@ func csvToCityGMLFilesResponse(data [][]string, gmlURLs []*url.URL) CityGMLFiles
 		citygmlURL := ""
 		gmlPath := record[4]
 
-		if len(record) > 4 && gmlURLs == nil {
+		var gf *gmlFile
+		if len(record) > 4 && gmlFiles == nil {
 			citygmlURL = citygmlItemURLFrom(base, gmlPath, featureType)
 		} else {
 			// compat for datacatalogv2
 			prefix := fmt.Sprintf("%s_%s_", meshCode, featureType)
 
-			u, ok := lo.Find(gmlURLs, func(u *url.URL) bool {
-				return strings.HasPrefix(path.Base(u.Path), prefix) && path.Ext(u.Path) == ".gml"
+			f, ok := lo.Find(gmlFiles, func(f gmlFile) bool {
+				return strings.HasPrefix(path.Base(f.URL.Path), prefix)
 			})
 			if ok {
-				citygmlURL = u.String()
+				citygmlURL = f.URL.String()
+				gf = &f
 			}
 			// warning = append(warning, fmt.Sprintf("unmatched:type=%s,code=%s,path=%s", ty, code, f))
 		}
@@ -168,6 +183,10 @@ func csvToCityGMLFilesResponse(data [][]string, gmlURLs []*url.URL) CityGMLFiles
 			MaxLOD:   maxlod,
 			URL:      citygmlURL,
 		}
+		if gf != nil {
+			item.Size = gf.Size
+			item.Texture = lo.ToPtr(gf.Texture)
+		}
 
 		if _, ok := res[featureType]; !ok {
 			res[featureType] = make([]CityGMLFile, 0)
@@ -192,29 +211,61 @@ func citygmlItemURLFrom(base, p, typeCode string) string {
 	return u
 }
 
-func gmlURLs(paths []string, base *url.URL) []*url.URL {
-	res := lo.FilterMap(paths, func(u string, _ int) (*url.URL, bool) {
-		if path.Ext(u) != ".gml" {
-			return nil, false
+func gmlFiles(root cms.File, base *url.URL) []gmlFile {
+	files := flattenFiles(root)
+
+	// textures of "53394525_bldg_6697_op.gml" are stored in "53394525_bldg_6697_appearance/"
+	appearances := map[string]struct{}{}
+	for _, f := range files {
+		if d := path.Dir(f.Path); strings.HasSuffix(d, appearanceSuffix) {
+			appearances[strings.TrimSuffix(d, appearanceSuffix)] = struct{}{}
+		}
+	}
+
+	res := lo.FilterMap(files, func(f cms.File, _ int) (gmlFile, bool) {
+		if path.Ext(f.Path) != ".gml" {
+			return gmlFile{}, false
 		}
 
-		u2, err := url.Parse(u)
+		u, err := url.Parse(f.Path)
 		if err != nil {
-			return nil, false
+			return gmlFile{}, false
 		}
 
-		if base == nil {
-			return u2, true
+		if base != nil {
+			fu := util.CloneRef(base)
+			fu.Path = path.Join(fu.Path, f.Path)
+			u = fu
 		}
 
-		fu := util.CloneRef(base)
-		fu.Path = path.Join(fu.Path, u)
-		return fu, true
+		_, texture := appearances[strings.TrimSuffix(nameWithoutExt(f.Path), "_op")]
+		return gmlFile{
+			URL:     u,
+			Size:    f.Size,
+			Texture: texture,
+		}, true
 	})
 
 	return res
 }
 
+const appearanceSuffix = "_appearance"
+
+func flattenFiles(f cms.File) []cms.File {
+	if len(f.Children) == 0 {
+		if f.Path == "" {
+			return nil
+		}
+		return []cms.File{f}
+	}
+
+	var res []cms.File
+	for _, c := range f.Children {
+		res = append(res, flattenFiles(c)...)
+	}
+	return res
+}
+
 func isNumeric(r rune) bool {
 	return r >= '0' && r <= '9'
 }
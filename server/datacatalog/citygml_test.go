@@ -0,0 +1,63 @@
+package datacatalog
+
+import (
+	"net/url"
+	"testing"
+
+	cms "github.com/reearth/reearth-cms-api/go"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVToCityGMLFilesResponse(t *testing.T) {
+	base := lo.Must(url.Parse("https://example.com/assets/xx"))
+	root := cms.File{
+		Children: []cms.File{
+			{
+				Path: "udx",
+				Children: []cms.File{
+					{Path: "udx/bldg/53394525_bldg_6697_op.gml", Size: 100},
+					{Path: "udx/bldg/53394525_bldg_6697_appearance/hnap0001.jpg", Size: 10},
+					{Path: "udx/bldg/53394526_bldg_6697_op.gml", Size: 200},
+					{Path: "udx/tran/53394525_tran_6697_op.gml", Size: 300},
+				},
+			},
+		},
+	}
+
+	data := [][]string{
+		{"base", "code", "type", "maxlod", "path"},
+		{"xx.zip", "53394526", "bldg", "1", ""},
+		{"xx.zip", "53394525", "bldg", "2", ""},
+		{"xx.zip", "53394525", "tran", "1", ""},
+		{"xx.zip", "53394527", "bldg", "1", ""},
+	}
+
+	assert.Equal(t, CityGMLFiles{
+		"bldg": {
+			{
+				MeshCode: "53394525",
+				MaxLOD:   2,
+				URL:      "https://example.com/assets/xx/udx/bldg/53394525_bldg_6697_op.gml",
+				Size:     100,
+				Texture:  lo.ToPtr(true),
+			},
+			{
+				MeshCode: "53394526",
+				MaxLOD:   1,
+				URL:      "https://example.com/assets/xx/udx/bldg/53394526_bldg_6697_op.gml",
+				Size:     200,
+				Texture:  lo.ToPtr(false),
+			},
+		},
+		"tran": {
+			{
+				MeshCode: "53394525",
+				MaxLOD:   1,
+				URL:      "https://example.com/assets/xx/udx/tran/53394525_tran_6697_op.gml",
+				Size:     300,
+				Texture:  lo.ToPtr(false),
+			},
+		},
+	}, csvToCityGMLFilesResponse(data, gmlFiles(root, base)))
+}
@@ -29,6 +29,7 @@ require (
 	github.com/thanhpk/randstr v1.0.6
 	github.com/vektah/gqlparser/v2 v2.5.11
 	github.com/vincent-petithory/dataurl v1.0.0
+	github.com/xeipuuv/gojsonschema v1.1.0
 	golang.org/x/net v0.22.0
 	golang.org/x/sync v0.6.0
 	gonum.org/v1/gonum v0.14.0
@@ -91,6 +92,8 @@ require (
 	github.com/uber/jaeger-lib v2.4.1+incompatible // indirect
 	github.com/urfave/cli/v2 v2.27.1 // indirect
 	github.com/wcharczuk/go-chart/v2 v2.1.1 // indirect
+	github.com/xeipuuv/gojsonpointer v0.0.0-20190905194746-02993c407bfb // indirect
+	github.com/xeipuuv/gojsonreference v0.0.0-20180127040603-bd5ef7bd5415 // indirect
 	github.com/xrash/smetrics v0.0.0-20231213231151-1d8dd44e695e // indirect
 	go.opencensus.io v0.24.0 // indirect
 	go.opentelemetry.io/contrib v1.22.0 // indirect
@@ -143,10 +146,10 @@ require (
 	go.uber.org/atomic v1.11.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	go.uber.org/zap v1.26.0 // indirect
-	golang.org/x/crypto v0.21.0 // indirect
+	golang.org/x/crypto v0.21.0
 	golang.org/x/exp v0.0.0-20240222234643-814bf88cf225
 	golang.org/x/sys v0.18.0 // indirect
 	golang.org/x/text v0.14.0
-	golang.org/x/time v0.5.0 // indirect
+	golang.org/x/time v0.5.0
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,72 @@
+package opinion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Captcha verifies a CAPTCHA token sent with an opinion.
+type Captcha interface {
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// SiteVerifyCaptcha verifies tokens with a siteverify API, which is compatible among reCAPTCHA, hCaptcha and Cloudflare Turnstile.
+type SiteVerifyCaptcha struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+const defaultCaptchaVerifyURL = "https://www.google.com/recaptcha/api/siteverify"
+
+func (c *SiteVerifyCaptcha) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	u := c.URL
+	if u == "" {
+		u = defaultCaptchaVerifyURL
+	}
+
+	form := url.Values{
+		"secret":   {c.Secret},
+		"response": {token},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("status code is %d", res.StatusCode)
+	}
+
+	var r struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+		return false, err
+	}
+	return r.Success, nil
+}
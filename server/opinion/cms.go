@@ -0,0 +1,90 @@
+package opinion
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cms "github.com/reearth/reearth-cms-api/go"
+	"github.com/reearth/reearthx/log"
+)
+
+const defaultCMSModel = "opinion"
+
+// StatusNew is the initial triage status of opinions stored in CMS. Staff change it in CMS after they handle the opinion.
+const StatusNew = "未対応"
+
+type Item struct {
+	ID             string   `json:"id,omitempty" cms:"id"`
+	Title          string   `json:"title,omitempty" cms:"title,text"`
+	Name           string   `json:"name,omitempty" cms:"name,text"`
+	Email          string   `json:"email,omitempty" cms:"email,text"`
+	Content        string   `json:"content,omitempty" cms:"content,textarea"`
+	Category       string   `json:"category,omitempty" cms:"category,text"`
+	Org            string   `json:"org,omitempty" cms:"org,text"`
+	Attachments    []string `json:"attachments,omitempty" cms:"attachments,asset"`
+	Status         string   `json:"status,omitempty" cms:"status,select"`
+	DeliveryErrors string   `json:"delivery_errors,omitempty" cms:"delivery_errors,textarea"`
+	CreatedAt      string   `json:"created_at,omitempty" cms:"created_at,date"`
+}
+
+func (i Item) Fields() (fields []*cms.Field) {
+	item := &cms.Item{}
+	cms.Marshal(i, item)
+	return item.Fields
+}
+
+// CMSDelivery stores opinions as items of the CMS model so that staff can triage them in CMS.
+type CMSDelivery struct {
+	cms     cms.Interface
+	project string
+	model   string
+}
+
+func NewCMSDelivery(c cms.Interface, project, model string) *CMSDelivery {
+	if model == "" {
+		model = defaultCMSModel
+	}
+
+	return &CMSDelivery{
+		cms:     c,
+		project: project,
+		model:   model,
+	}
+}
+
+func (d *CMSDelivery) Name() string {
+	return "cms"
+}
+
+func (d *CMSDelivery) Deliver(ctx context.Context, o *Opinion) error {
+	item := Item{
+		Title:          o.Title,
+		Name:           o.Name,
+		Email:          o.Email,
+		Content:        o.Content,
+		Category:       o.Category,
+		Org:            o.Org,
+		Status:         StatusNew,
+		DeliveryErrors: strings.Join(o.DeliveryErrors, "\n"),
+		CreatedAt:      o.CreatedAt.Format(time.RFC3339),
+	}
+
+	// attachments that cannot be uploaded are skipped so that at least the opinion itself is stored
+	for _, a := range o.Attachments {
+		aid, err := d.cms.UploadAssetDirectly(ctx, d.project, a.Name, bytes.NewReader(a.Data))
+		if err != nil {
+			log.Errorfc(ctx, "opinion: failed to upload an attachment %s: %v", a.Name, err)
+			item.DeliveryErrors = strings.TrimSpace(fmt.Sprintf("%s\n%s: failed to upload an attachment %s: %v", item.DeliveryErrors, d.Name(), a.Name, err))
+			continue
+		}
+		item.Attachments = append(item.Attachments, aid)
+	}
+
+	if _, err := d.cms.CreateItemByKey(ctx, d.project, d.model, item.Fields(), nil); err != nil {
+		return fmt.Errorf("failed to create item: %w", err)
+	}
+	return nil
+}
@@ -1,22 +1,33 @@
 package opinion
 
 import (
-	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	netmail "net/mail"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	cms "github.com/reearth/reearth-cms-api/go"
 	"github.com/reearth/reearthx/log"
-	"github.com/sendgrid/sendgrid-go"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"golang.org/x/time/rate"
 )
 
 const defaultToName = "PLATEAU VIEW ご意見ご要望"
 const titlePrefix = "【PLATEAU VIEW ご意見ご要望】"
 const defaultFromName = "PLATEAU CMS"
+const maxAttachments = 5
+const defaultRateLimit = 0.1 // 6 requests per minute
+const defaultRateBurst = 5
+
+// attachment fields of multipart forms. "file" is kept for compatibility.
+var attachmentFields = []string{"file", "files", "screenshot"}
+
+var attachmentTypes = []string{"image/", "application/pdf"}
 
 type Config struct {
 	SendGridAPIKey string
@@ -26,6 +37,56 @@ type Config struct {
 	FromName string
 	// optional
 	ToName string
+	// SMTP is used to send emails instead of SendGrid when its host is set.
+	SMTP SMTPConfig
+	// Opinions are stored as items of CMSModel in CMSProject when CMSProject is set.
+	CMSBaseURL string
+	CMSToken   string
+	CMSProject string
+	CMSModel   string
+	// RateLimit is the number of requests per second allowed for each client. Negative value disables rate limiting.
+	RateLimit float64
+	RateBurst int
+	// Captcha verifies CAPTCHA tokens if set. If it is nil and CaptchaSecret is set, SiteVerifyCaptcha is used.
+	Captcha          Captcha
+	CaptchaSecret    string
+	CaptchaVerifyURL string
+}
+
+func (c Config) Enabled() bool {
+	return c.From != "" && c.To != "" && (c.SendGridAPIKey != "" || c.SMTP.Host != "") || c.CMSProject != ""
+}
+
+func (c Config) deliveries() ([]Delivery, error) {
+	fromName := c.FromName
+	if fromName == "" {
+		fromName = defaultFromName
+	}
+
+	toName := c.ToName
+	if toName == "" {
+		toName = defaultToName
+	}
+
+	var res []Delivery
+	if c.From != "" && c.To != "" {
+		if c.SMTP.Host != "" {
+			res = append(res, NewSMTPDelivery(c.SMTP, &netmail.Address{Name: fromName, Address: c.From}, &netmail.Address{Name: toName, Address: c.To}))
+		} else if c.SendGridAPIKey != "" {
+			res = append(res, NewSendGridDelivery(c.SendGridAPIKey, mail.NewEmail(fromName, c.From), mail.NewEmail(toName, c.To)))
+		}
+	}
+
+	// CMS is placed last to record errors of the other deliveries
+	if c.CMSProject != "" {
+		cmsc, err := cms.New(c.CMSBaseURL, c.CMSToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init cms: %w", err)
+		}
+		res = append(res, NewCMSDelivery(cmsc, c.CMSProject, c.CMSModel))
+	}
+
+	return res, nil
 }
 
 type req struct {
@@ -35,19 +96,39 @@ type req struct {
 	Content  string `json:"content" form:"content" validate:"required"`
 	Category string `json:"category" form:"category"`
 	Org      string `json:"org" form:"org"`
+	Captcha  string `json:"captcha" form:"captcha"`
 }
 
-func Echo(g *echo.Group, conf Config) {
-	fromName := conf.FromName
-	if fromName == "" {
-		fromName = defaultFromName
+func Echo(g *echo.Group, conf Config) error {
+	deliveries, err := conf.deliveries()
+	if err != nil {
+		return err
+	}
+	return EchoWith(g, conf, NewService(deliveries...))
+}
+
+func EchoWith(g *echo.Group, conf Config, s *Service) error {
+	captcha := conf.Captcha
+	if captcha == nil && conf.CaptchaSecret != "" {
+		captcha = &SiteVerifyCaptcha{URL: conf.CaptchaVerifyURL, Secret: conf.CaptchaSecret}
 	}
 
-	toName := conf.ToName
-	if toName == "" {
-		toName = defaultToName
+	middlewares := []echo.MiddlewareFunc{middleware.BodyLimit("10M"), middleware.CORS()}
+	if conf.RateLimit >= 0 {
+		r, b := conf.RateLimit, conf.RateBurst
+		if r == 0 {
+			r = defaultRateLimit
+		}
+		if b <= 0 {
+			b = defaultRateBurst
+		}
+		middlewares = append(middlewares, middleware.RateLimiter(
+			middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+				Rate:  rate.Limit(r),
+				Burst: b,
+			}),
+		))
 	}
-	client := sendgrid.NewSendClient(conf.SendGridAPIKey)
 
 	g.POST("", func(c echo.Context) error {
 		ctx := c.Request().Context()
@@ -59,67 +140,111 @@ func Echo(g *echo.Group, conf Config) {
 			return err
 		}
 
-		from := mail.NewEmail(fromName, conf.From)
-		to := mail.NewEmail(toName, conf.To)
-		title := fmt.Sprintf("%s%s", titlePrefix, r.Title)
-		message := mail.NewSingleEmailPlainText(from, title, to, r.MessageContent())
-		replyToEmail := mail.NewEmail(r.Name, r.Email)
-		message.SetReplyTo(replyToEmail)
-
-		// handle an image file
-		if mfh, err := c.FormFile("file"); err == nil {
-			mf, err := mfh.Open()
+		if captcha != nil {
+			ok, err := captcha.Verify(ctx, r.Captcha, c.RealIP())
 			if err != nil {
-				return c.JSON(http.StatusUnprocessableEntity, "cannot open file")
+				log.Errorfc(ctx, "opinion: failed to verify captcha: %v", err)
+				return c.JSON(http.StatusBadGateway, "failed to verify captcha")
 			}
-
-			defer func() { _ = mf.Close() }()
-			data, err := io.ReadAll(mf)
-			if err != nil {
-				return c.JSON(http.StatusUnprocessableEntity, "cannot read file")
-			}
-
-			ty := http.DetectContentType(data)
-			if !strings.HasPrefix(ty, "image/") {
-				return c.JSON(http.StatusBadRequest, "invalid file")
+			if !ok {
+				return c.JSON(http.StatusForbidden, "invalid captcha")
 			}
-
-			a := mail.NewAttachment().
-				SetContent(base64.StdEncoding.EncodeToString(data)).
-				SetType(ty).
-				SetFilename(mfh.Filename).
-				SetDisposition("attachment")
-			message.AddAttachment(a)
 		}
 
-		response, err := client.Send(message)
+		attachments, err := attachmentsFrom(c)
 		if err != nil {
-			e := ""
-			if err != nil {
-				e = err.Error()
-			} else {
-				e = fmt.Sprintf("code=%d,body=%s", response.StatusCode, response.Body)
+			if errors.Is(err, errInvalidFile) || errors.Is(err, errTooManyFiles) {
+				return c.JSON(http.StatusBadRequest, err.Error())
 			}
+			return c.JSON(http.StatusUnprocessableEntity, err.Error())
+		}
 
-			log.Errorfc(ctx, "opinion: failed to send email: %s", e)
-			return c.JSON(http.StatusBadGateway, "failed to send email")
+		o := r.Opinion()
+		o.Attachments = attachments
+		if err := s.Submit(ctx, o); err != nil {
+			return c.JSON(http.StatusBadGateway, "failed to send opinion")
 		}
 
 		return c.JSON(http.StatusOK, "ok")
-	}, middleware.BodyLimit("10M"), middleware.CORS())
+	}, middlewares...)
+
+	return nil
 }
 
-func (r req) MessageContent() string {
-	content := ""
-	if r.Category != "" {
-		content += fmt.Sprintf("カテゴリ：%s\n", r.Category)
+var (
+	errInvalidFile  = errors.New("invalid file")
+	errTooManyFiles = errors.New("too many files")
+)
+
+func attachmentsFrom(c echo.Context) ([]Attachment, error) {
+	if !strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		return nil, nil
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, errors.New("cannot read form")
+	}
+
+	var files []*multipart.FileHeader
+	for _, k := range attachmentFields {
+		files = append(files, form.File[k]...)
 	}
-	if r.Org != "" {
-		content += fmt.Sprintf("所属組織：%s\n", r.Org)
+	if len(files) > maxAttachments {
+		return nil, errTooManyFiles
 	}
-	if r.Category != "" || r.Org != "" {
-		content += "\n"
+
+	res := make([]Attachment, 0, len(files))
+	for _, fh := range files {
+		a, err := attachmentFrom(fh)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, a)
+	}
+	return res, nil
+}
+
+func attachmentFrom(fh *multipart.FileHeader) (Attachment, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return Attachment{}, errors.New("cannot open file")
+	}
+	defer func() { _ = f.Close() }()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return Attachment{}, errors.New("cannot read file")
+	}
+
+	ty := http.DetectContentType(data)
+	if !isAllowedAttachmentType(ty) {
+		return Attachment{}, errInvalidFile
+	}
+
+	return Attachment{
+		Name:        fh.Filename,
+		ContentType: ty,
+		Data:        data,
+	}, nil
+}
+
+func isAllowedAttachmentType(ty string) bool {
+	for _, t := range attachmentTypes {
+		if strings.HasPrefix(ty, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r req) Opinion() *Opinion {
+	return &Opinion{
+		Title:    r.Title,
+		Name:     r.Name,
+		Email:    r.Email,
+		Content:  r.Content,
+		Category: r.Category,
+		Org:      r.Org,
 	}
-	content += r.Content
-	return content
 }
@@ -2,6 +2,7 @@ package opinion
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -114,10 +115,68 @@ func (cv *customValidator) Validate(i any) error {
 	return nil
 }
 
-func TestReq_MessageContent(t *testing.T) {
-	assert.Equal(t, "カテゴリ：cate\n所属組織：org\n\naaa", req{
+func TestOpinion_MessageContent(t *testing.T) {
+	assert.Equal(t, "カテゴリ：cate\n所属組織：org\n\naaa", (&Opinion{
 		Content:  "aaa",
 		Category: "cate",
 		Org:      "org",
-	}.MessageContent())
+	}).MessageContent())
+}
+
+func TestEcho_CaptchaAndRateLimit(t *testing.T) {
+	e := echo.New()
+	e.Validator = &customValidator{validator: validator.New()}
+	assert.NoError(t, EchoWith(e.Group(""), Config{
+		Captcha:   captchaMock("valid"),
+		RateLimit: 1,
+		RateBurst: 2,
+	}, NewService(&deliveryMock{name: "mock"})))
+
+	send := func(captcha string) *httptest.ResponseRecorder {
+		rb := `{"email":"from@examle.com","content":"aaaa","name":"name","captcha":"` + captcha + `"}`
+		r := httptest.NewRequest("POST", "/", strings.NewReader(rb))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, r)
+		return w
+	}
+
+	w := send("invalid")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, `"invalid captcha"`, strings.TrimSpace(w.Body.String()))
+
+	w = send("valid")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = send("valid")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+func TestSiteVerifyCaptcha(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.Deactivate()
+	httpmock.RegisterResponder("POST", "https://example.com/siteverify", func(r *http.Request) (*http.Response, error) {
+		_ = r.ParseForm()
+		success := r.Form.Get("secret") == "secret" && r.Form.Get("response") == "token"
+		return httpmock.NewJsonResponse(http.StatusOK, map[string]any{"success": success})
+	})
+
+	c := &SiteVerifyCaptcha{URL: "https://example.com/siteverify", Secret: "secret"}
+	ok, err := c.Verify(context.Background(), "token", "")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = c.Verify(context.Background(), "token2", "")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = c.Verify(context.Background(), "", "")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+type captchaMock string
+
+func (c captchaMock) Verify(_ context.Context, token, _ string) (bool, error) {
+	return token == string(c), nil
 }
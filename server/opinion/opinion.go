@@ -0,0 +1,88 @@
+package opinion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/util"
+)
+
+var ErrDeliveryFailed = errors.New("failed to deliver opinion")
+
+type Opinion struct {
+	Title       string
+	Name        string
+	Email       string
+	Content     string
+	Category    string
+	Org         string
+	Attachments []Attachment
+	CreatedAt   time.Time
+	// DeliveryErrors are errors of the deliveries that have been already tried. They are stored so that staff can notice undelivered opinions.
+	DeliveryErrors []string
+}
+
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Delivery delivers an opinion to staff, e.g. by sending an email or storing it in CMS.
+type Delivery interface {
+	Name() string
+	Deliver(ctx context.Context, o *Opinion) error
+}
+
+// Service delivers opinions with all of the deliveries in order. Deliveries that store opinions should be placed last so that they can record errors of the other deliveries.
+type Service struct {
+	deliveries []Delivery
+}
+
+func NewService(deliveries ...Delivery) *Service {
+	return &Service{deliveries: deliveries}
+}
+
+// Submit delivers the opinion. It succeeds if at least one of the deliveries succeeds, so the opinion is not lost even if some of them are down.
+func (s *Service) Submit(ctx context.Context, o *Opinion) error {
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = util.Now()
+	}
+
+	delivered := false
+	for _, d := range s.deliveries {
+		if err := d.Deliver(ctx, o); err != nil {
+			log.Errorfc(ctx, "opinion: failed to deliver with %s: %v", d.Name(), err)
+			o.DeliveryErrors = append(o.DeliveryErrors, fmt.Sprintf("%s: %v", d.Name(), err))
+			continue
+		}
+		delivered = true
+	}
+
+	if !delivered {
+		return ErrDeliveryFailed
+	}
+	return nil
+}
+
+func (o *Opinion) Subject() string {
+	return fmt.Sprintf("%s%s", titlePrefix, o.Title)
+}
+
+func (o *Opinion) MessageContent() string {
+	content := ""
+	if o.Category != "" {
+		content += fmt.Sprintf("カテゴリ：%s\n", o.Category)
+	}
+	if o.Org != "" {
+		content += fmt.Sprintf("所属組織：%s\n", o.Org)
+	}
+	if o.Category != "" || o.Org != "" {
+		content += "\n"
+	}
+	content += o.Content
+	return content
+}
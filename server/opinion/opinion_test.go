@@ -0,0 +1,157 @@
+package opinion
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	cms "github.com/reearth/reearth-cms-api/go"
+	"github.com/reearth/reearthx/util"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Submit(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	c := &cmsMock{}
+	s := NewService(
+		&deliveryMock{name: "mail", err: errors.New("ERR")},
+		NewCMSDelivery(c, "prj", ""),
+	)
+
+	o := &Opinion{
+		Title:       "title",
+		Name:        "name",
+		Email:       "a@example.com",
+		Content:     "content",
+		Attachments: []Attachment{{Name: "a.png", ContentType: "image/png", Data: []byte("png")}},
+	}
+	assert.NoError(t, s.Submit(context.Background(), o))
+	assert.Equal(t, "prj", c.project)
+	assert.Equal(t, "opinion", c.model)
+	assert.Equal(t, []string{"a.png"}, c.uploaded)
+
+	item := Item{}
+	(&cms.Item{Fields: c.fields}).Unmarshal(&item)
+	assert.Equal(t, Item{
+		Title:          "title",
+		Name:           "name",
+		Email:          "a@example.com",
+		Content:        "content",
+		Attachments:    []string{"asset-a.png"},
+		Status:         StatusNew,
+		DeliveryErrors: "mail: ERR",
+		CreatedAt:      "2024-01-02T03:04:05Z",
+	}, item)
+
+	// all deliveries failed
+	c.err = errors.New("CMS")
+	assert.Equal(t, ErrDeliveryFailed, s.Submit(context.Background(), &Opinion{}))
+
+	// at least one delivery succeeded
+	s = NewService(&deliveryMock{name: "mail"}, NewCMSDelivery(c, "prj", ""))
+	assert.NoError(t, s.Submit(context.Background(), &Opinion{}))
+}
+
+func TestSMTPDelivery(t *testing.T) {
+	var addr, from string
+	var to []string
+	var msg []byte
+
+	d := NewSMTPDelivery(SMTPConfig{Host: "smtp.example.com"}, &mail.Address{Address: "from@example.com"}, &mail.Address{Name: "To", Address: "to@example.com"})
+	d.sendMail = func(_ context.Context, addr2 string, _ smtp.Auth, from2 string, to2 []string, msg2 []byte) error {
+		addr, from, to, msg = addr2, from2, to2, msg2
+		return nil
+	}
+
+	assert.NoError(t, d.Deliver(context.Background(), &Opinion{
+		Title:       "タイトル",
+		Name:        "name",
+		Email:       "a@example.com",
+		Content:     "content",
+		Attachments: []Attachment{{Name: "a.png", ContentType: "image/png", Data: []byte("png")}},
+	}))
+	assert.Equal(t, "smtp.example.com:587", addr)
+	assert.Equal(t, "from@example.com", from)
+	assert.Equal(t, []string{"to@example.com"}, to)
+
+	m, err := mail.ReadMessage(strings.NewReader(string(msg)))
+	assert.NoError(t, err)
+	subject, _ := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	assert.Equal(t, titlePrefix+"タイトル", subject)
+	assert.Equal(t, `"name" <a@example.com>`, m.Header.Get("Reply-To"))
+	assert.True(t, strings.HasPrefix(m.Header.Get("Content-Type"), "multipart/mixed; boundary="))
+	body, _ := io.ReadAll(m.Body)
+	assert.Contains(t, string(body), `Content-Disposition: attachment; filename=a.png`)
+}
+
+func TestSMTPDelivery_Timeout(t *testing.T) {
+	// a server which accepts connections but never responds
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer l.Close()
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer c.Close()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	d := NewSMTPDelivery(SMTPConfig{Host: host, Port: lo.Must(strconv.Atoi(port))}, &mail.Address{Address: "from@example.com"}, &mail.Address{Address: "to@example.com"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = d.Deliver(ctx, &Opinion{Title: "title"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+type deliveryMock struct {
+	name string
+	err  error
+}
+
+func (d *deliveryMock) Name() string {
+	return d.name
+}
+
+func (d *deliveryMock) Deliver(context.Context, *Opinion) error {
+	return d.err
+}
+
+type cmsMock struct {
+	cms.Interface
+	project  string
+	model    string
+	fields   []*cms.Field
+	uploaded []string
+	err      error
+}
+
+func (c *cmsMock) UploadAssetDirectly(_ context.Context, _, name string, _ io.Reader) (string, error) {
+	c.uploaded = append(c.uploaded, name)
+	return "asset-" + name, nil
+}
+
+func (c *cmsMock) CreateItemByKey(_ context.Context, projectID, modelID string, fields []*cms.Field, _ []*cms.Field) (*cms.Item, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.project, c.model, c.fields = projectID, modelID, fields
+	return &cms.Item{}, nil
+}
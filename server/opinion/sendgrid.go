@@ -0,0 +1,50 @@
+package opinion
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/sendgrid/sendgrid-go"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+type SendGridDelivery struct {
+	client *sendgrid.Client
+	from   *mail.Email
+	to     *mail.Email
+}
+
+func NewSendGridDelivery(apiKey string, from, to *mail.Email) *SendGridDelivery {
+	return &SendGridDelivery{
+		client: sendgrid.NewSendClient(apiKey),
+		from:   from,
+		to:     to,
+	}
+}
+
+func (d *SendGridDelivery) Name() string {
+	return "sendgrid"
+}
+
+func (d *SendGridDelivery) Deliver(ctx context.Context, o *Opinion) error {
+	message := mail.NewSingleEmailPlainText(d.from, o.Subject(), d.to, o.MessageContent())
+	message.SetReplyTo(mail.NewEmail(o.Name, o.Email))
+
+	for _, a := range o.Attachments {
+		message.AddAttachment(mail.NewAttachment().
+			SetContent(base64.StdEncoding.EncodeToString(a.Data)).
+			SetType(a.ContentType).
+			SetFilename(a.Name).
+			SetDisposition("attachment"))
+	}
+
+	res, err := d.client.SendWithContext(ctx, message)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("code=%d,body=%s", res.StatusCode, res.Body)
+	}
+	return nil
+}
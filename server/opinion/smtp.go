@@ -0,0 +1,187 @@
+package opinion
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+)
+
+// smtpTimeout limits the time to send an email when the context has no deadline, so that a stuck SMTP server does not block the other deliveries.
+const smtpTimeout = 30 * time.Second
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+}
+
+type SMTPDelivery struct {
+	conf SMTPConfig
+	from *mail.Address
+	to   *mail.Address
+	// sendMail is replaced in tests
+	sendMail func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSMTPDelivery(conf SMTPConfig, from, to *mail.Address) *SMTPDelivery {
+	if conf.Port == 0 {
+		conf.Port = 587
+	}
+
+	return &SMTPDelivery{
+		conf:     conf,
+		from:     from,
+		to:       to,
+		sendMail: sendMail,
+	}
+}
+
+func (d *SMTPDelivery) Name() string {
+	return "smtp"
+}
+
+func (d *SMTPDelivery) Deliver(ctx context.Context, o *Opinion) error {
+	msg, err := d.message(o)
+	if err != nil {
+		return fmt.Errorf("failed to build message: %w", err)
+	}
+
+	var auth smtp.Auth
+	if d.conf.User != "" {
+		auth = smtp.PlainAuth("", d.conf.User, d.conf.Password, d.conf.Host)
+	}
+
+	addr := net.JoinHostPort(d.conf.Host, strconv.Itoa(d.conf.Port))
+	return d.sendMail(ctx, addr, auth, d.from.Address, []string{d.to.Address}, msg)
+}
+
+// sendMail works like smtp.SendMail, but aborts when the context is done.
+func sendMail(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) (err error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, smtpTimeout)
+		defer cancel()
+	}
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	// closing the connection interrupts the SMTP conversation blocked on reading or writing
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer func() {
+		if !stop() && err != nil {
+			err = fmt.Errorf("%w: %w", ctx.Err(), err)
+		}
+	}()
+
+	host, _, _ := net.SplitHostPort(addr)
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if a != nil {
+		if err := c.Auth(a); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, t := range to {
+		if err := c.Rcpt(t); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (d *SMTPDelivery) message(o *Opinion) ([]byte, error) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	text, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := text.Write(encodeBase64Lines([]byte(o.MessageContent()))); err != nil {
+		return nil, err
+	}
+
+	for _, a := range o.Attachments {
+		p, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {a.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Name})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.Write(encodeBase64Lines(a.Data)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	replyTo := &mail.Address{Name: o.Name, Address: o.Email}
+	msg := &bytes.Buffer{}
+	fmt.Fprintf(msg, "From: %s\r\n", d.from)
+	fmt.Fprintf(msg, "To: %s\r\n", d.to)
+	fmt.Fprintf(msg, "Reply-To: %s\r\n", replyTo)
+	fmt.Fprintf(msg, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", o.Subject()))
+	fmt.Fprintf(msg, "Date: %s\r\n", o.CreatedAt.Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+	fmt.Fprintf(msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(msg, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", w.Boundary())
+	_, _ = msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// encodeBase64Lines encodes data with base64 and splits it into lines of 76 characters as required by RFC 2045.
+func encodeBase64Lines(data []byte) []byte {
+	const lineLen = 76
+	s := base64.StdEncoding.EncodeToString(data)
+	b := &bytes.Buffer{}
+	for len(s) > lineLen {
+		b.WriteString(s[:lineLen] + "\r\n")
+		s = s[lineLen:]
+	}
+	b.WriteString(s)
+	return b.Bytes()
+}
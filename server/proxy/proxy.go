@@ -0,0 +1,359 @@
+package proxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/eukarya-inc/reearth-plateauview/server/putil"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/reearth/reearthx/log"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultMaxBodySize = 20 * 1024 * 1024 // 20MB
+	defaultTimeout     = 30 * time.Second
+	defaultRateLimit   = 10
+	defaultRateBurst   = 30
+)
+
+var (
+	ErrHostNotAllowed = errors.New("host is not allowed")
+	ErrAddrNotAllowed = errors.New("address is not allowed")
+	ErrTooLarge       = errors.New("response is too large")
+)
+
+// responseHeaders are headers of upstream responses that are passed to clients.
+var responseHeaders = []string{
+	echo.HeaderContentType,
+	echo.HeaderContentDisposition,
+	echo.HeaderLastModified,
+	"ETag",
+}
+
+// requestHeaders are headers of client requests that are passed to upstreams.
+var requestHeaders = []string{
+	"Accept",
+	"Accept-Language",
+	echo.HeaderIfModifiedSince,
+	"If-None-Match",
+}
+
+type Config struct {
+	// AllowedHosts is a list of hosts that can be proxied. "*.example.com" matches subdomains of example.com. Empty means all public hosts are allowed.
+	AllowedHosts []string
+	// AllowedOrigins is a list of origins that are set to Access-Control-Allow-Origin. Empty means all origins.
+	AllowedOrigins []string
+	// AllowPrivateNetworks disables blocking of private, loopback and link-local addresses. It should be used only for development.
+	AllowPrivateNetworks bool
+	MaxBodySize          int64
+	Timeout              time.Duration
+	// RateLimit is the number of requests per second allowed for each client. Negative value disables rate limiting.
+	RateLimit float64
+	RateBurst int
+	Cache     bool
+	CacheTTL  time.Duration
+}
+
+type Handler struct {
+	conf   Config
+	client *http.Client
+}
+
+func Echo(g *echo.Group, conf Config) error {
+	h := New(conf)
+
+	middlewares := []echo.MiddlewareFunc{h.CORSMiddleware}
+	if h.conf.RateLimit > 0 {
+		middlewares = append(middlewares, middleware.RateLimiter(
+			middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+				Rate:  rate.Limit(h.conf.RateLimit),
+				Burst: h.conf.RateBurst,
+			}),
+		))
+	}
+	if h.conf.Cache {
+		middlewares = append(middlewares, putil.NewCacheMiddleware(putil.CacheConfig{
+			TTL:          h.conf.CacheTTL,
+			CacheControl: true,
+			Key:          cacheKey,
+		}).Middleware())
+	}
+
+	g.GET("/*", h.Handle, middlewares...)
+	return nil
+}
+
+func New(conf Config) *Handler {
+	if conf.MaxBodySize <= 0 {
+		conf.MaxBodySize = defaultMaxBodySize
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = defaultTimeout
+	}
+	if conf.RateLimit == 0 {
+		conf.RateLimit = defaultRateLimit
+	}
+	if conf.RateBurst <= 0 {
+		conf.RateBurst = defaultRateBurst
+	}
+
+	dialer := &net.Dialer{
+		Timeout: conf.Timeout,
+	}
+	if !conf.AllowPrivateNetworks {
+		// the address is checked after DNS resolution to prevent DNS rebinding
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			return checkAddr(address)
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	transport.ResponseHeaderTimeout = conf.Timeout
+
+	return &Handler{
+		conf: conf,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   conf.Timeout,
+			// redirects are returned to clients to let them be proxied again
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (h *Handler) Handle(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	target, err := h.targetURL(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid target URL",
+		})
+	}
+
+	if !h.isHostAllowed(target.Hostname()) {
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": ErrHostNotAllowed.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.conf.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid target URL",
+		})
+	}
+	for _, k := range requestHeaders {
+		if v := c.Request().Header.Get(k); v != "" {
+			req.Header.Set(k, v)
+		}
+	}
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrAddrNotAllowed) {
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": ErrAddrNotAllowed.Error(),
+			})
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return c.JSON(http.StatusGatewayTimeout, map[string]string{
+				"error": "timeout",
+			})
+		}
+		log.Debugfc(ctx, "proxy: failed to request to %s: %v", target, err)
+		return c.JSON(http.StatusBadGateway, map[string]string{
+			"error": "failed to request",
+		})
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	body, err := h.readBody(res)
+	if err != nil {
+		if errors.Is(err, ErrTooLarge) {
+			return c.JSON(http.StatusBadGateway, map[string]string{
+				"error": ErrTooLarge.Error(),
+			})
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return c.JSON(http.StatusGatewayTimeout, map[string]string{
+				"error": "timeout",
+			})
+		}
+		return c.JSON(http.StatusBadGateway, map[string]string{
+			"error": "failed to read response",
+		})
+	}
+
+	header := c.Response().Header()
+	for _, k := range responseHeaders {
+		if v := res.Header.Get(k); v != "" {
+			header.Set(k, v)
+		}
+	}
+	if l := res.Header.Get(echo.HeaderLocation); l != "" && res.StatusCode >= 300 && res.StatusCode < 400 {
+		// redirects are also proxied
+		if u, err := target.Parse(l); err == nil {
+			header.Set(echo.HeaderLocation, strings.TrimSuffix(c.Path(), "*")+u.String())
+		}
+	}
+
+	c.Response().WriteHeader(res.StatusCode)
+	_, err = c.Response().Write(body)
+	return err
+}
+
+func (h *Handler) CORSMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Response().Before(func() {
+			origin := c.Request().Header.Get(echo.HeaderOrigin)
+			header := c.Response().Header()
+			if len(h.conf.AllowedOrigins) == 0 {
+				header.Set(echo.HeaderAccessControlAllowOrigin, "*")
+			} else if origin != "" && slices.Contains(h.conf.AllowedOrigins, origin) {
+				header.Set(echo.HeaderAccessControlAllowOrigin, origin)
+				header.Add(echo.HeaderVary, echo.HeaderOrigin)
+			} else {
+				header.Del(echo.HeaderAccessControlAllowOrigin)
+			}
+		})
+		return next(c)
+	}
+}
+
+func (h *Handler) targetURL(c echo.Context) (*url.URL, error) {
+	// Extract the target URL from the request path
+	targetPath := c.Param("*")
+
+	// This shouldn't be done by us but It'll do for now: @pyshx
+	if strings.HasPrefix(targetPath, "http:/") && len(targetPath) > 6 && targetPath[6] != '/' {
+		targetPath = "http://" + strings.TrimPrefix(targetPath, "http:/")
+	} else if strings.HasPrefix(targetPath, "https:/") && len(targetPath) > 7 && targetPath[7] != '/' {
+		targetPath = "https://" + strings.TrimPrefix(targetPath, "https:/")
+	}
+
+	u, err := url.Parse(targetPath)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return nil, fmt.Errorf("invalid target URL: %s", targetPath)
+	}
+
+	u.RawQuery = c.Request().URL.RawQuery
+	u.Fragment = ""
+	return u, nil
+}
+
+func (h *Handler) isHostAllowed(host string) bool {
+	if len(h.conf.AllowedHosts) == 0 {
+		return true
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, a := range h.conf.AllowedHosts {
+		a = strings.ToLower(a)
+		if suffix, ok := strings.CutPrefix(a, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+		} else if host == a {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) readBody(res *http.Response) ([]byte, error) {
+	if res.ContentLength > h.conf.MaxBodySize {
+		return nil, ErrTooLarge
+	}
+
+	b, err := io.ReadAll(io.LimitReader(res.Body, h.conf.MaxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > h.conf.MaxBodySize {
+		return nil, ErrTooLarge
+	}
+	return b, nil
+}
+
+func cacheKey(c echo.Context) string {
+	// the URL can be too long to be a file name
+	s := sha256.Sum256([]byte(c.Request().URL.RequestURI()))
+	return "proxy_" + hex.EncodeToString(s[:])
+}
+
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"),  // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"),  // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),    // reserved
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local-use IPv4/IPv6 translation
+	netip.MustParsePrefix("2001:db8::/32"),  // documentation
+	netip.MustParsePrefix("fec0::/10"),      // site-local (deprecated)
+	netip.MustParsePrefix("100::/64"),       // discard-only
+	netip.MustParsePrefix("169.254.0.0/16"), // link-local (cloud metadata endpoints)
+	netip.MustParsePrefix("255.255.255.255/32"),
+}
+
+func checkAddr(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+
+	if !isPublicAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrAddrNotAllowed, addr)
+	}
+	return nil
+}
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() {
+		return false
+	}
+
+	for _, p := range blockedPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
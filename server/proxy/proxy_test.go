@@ -0,0 +1,155 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEcho(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data.json":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Set-Cookie", "a=b")
+			_, _ = w.Write([]byte(`{"q":"` + r.URL.Query().Get("q") + `"}`))
+		case "/large":
+			_, _ = w.Write([]byte(strings.Repeat("a", 100)))
+		case "/redirect":
+			http.Redirect(w, r, "/data.json", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	e := echo.New()
+	assert.NoError(t, Echo(e.Group("/proxy"), Config{
+		AllowPrivateNetworks: true,
+		AllowedOrigins:       []string{"https://example.com"},
+		MaxBodySize:          50,
+		RateLimit:            -1,
+	}))
+
+	// ok
+	r := httptest.NewRequest("GET", "/proxy/"+upstream.URL+"/data.json?q=1", nil)
+	r.Header.Set(echo.HeaderOrigin, "https://example.com")
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"q":"1"}`, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "https://example.com", w.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Empty(t, w.Header().Get("Set-Cookie"))
+
+	// origin not allowed
+	r = httptest.NewRequest("GET", "/proxy/"+upstream.URL+"/data.json", nil)
+	r.Header.Set(echo.HeaderOrigin, "https://evil.example.com")
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get(echo.HeaderAccessControlAllowOrigin))
+
+	// too large
+	r = httptest.NewRequest("GET", "/proxy/"+upstream.URL+"/large", nil)
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.Equal(t, `{"error":"response is too large"}`, strings.TrimSpace(w.Body.String()))
+
+	// redirect
+	r = httptest.NewRequest("GET", "/proxy/"+upstream.URL+"/redirect", nil)
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "/proxy/"+upstream.URL+"/data.json", w.Header().Get(echo.HeaderLocation))
+
+	// invalid URL
+	r = httptest.NewRequest("GET", "/proxy/file:///etc/passwd", nil)
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestEcho_Blocked(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("secret"))
+	}))
+	defer upstream.Close()
+
+	e := echo.New()
+	assert.NoError(t, Echo(e.Group("/proxy"), Config{
+		RateLimit: 1,
+		RateBurst: 1,
+	}))
+
+	// private address
+	r := httptest.NewRequest("GET", "/proxy/"+upstream.URL, nil)
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, `{"error":"address is not allowed"}`, strings.TrimSpace(w.Body.String()))
+	assert.Equal(t, "*", w.Header().Get(echo.HeaderAccessControlAllowOrigin))
+
+	// rate limit
+	r = httptest.NewRequest("GET", "/proxy/"+upstream.URL, nil)
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	// host not allowed
+	e = echo.New()
+	assert.NoError(t, Echo(e.Group("/proxy"), Config{
+		AllowedHosts:         []string{"example.com"},
+		AllowPrivateNetworks: true,
+	}))
+
+	r = httptest.NewRequest("GET", "/proxy/"+upstream.URL, nil)
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, `{"error":"host is not allowed"}`, strings.TrimSpace(w.Body.String()))
+}
+
+func TestHandler_isHostAllowed(t *testing.T) {
+	h := New(Config{AllowedHosts: []string{"example.com", "*.example.org"}})
+	assert.True(t, h.isHostAllowed("example.com"))
+	assert.True(t, h.isHostAllowed("EXAMPLE.com."))
+	assert.False(t, h.isHostAllowed("a.example.com"))
+	assert.True(t, h.isHostAllowed("a.example.org"))
+	assert.True(t, h.isHostAllowed("a.b.example.org"))
+	assert.False(t, h.isHostAllowed("example.org"))
+	assert.False(t, h.isHostAllowed("evilexample.org"))
+
+	assert.True(t, New(Config{}).isHostAllowed("example.net"))
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := map[string]bool{
+		"8.8.8.8":          true,
+		"2001:4860::8888":  true,
+		"127.0.0.1":        false,
+		"10.0.0.1":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"100.64.0.1":       false,
+		"0.0.0.0":          false,
+		"::1":              false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"::ffff:127.0.0.1": false,
+		"224.0.0.1":        false,
+	}
+
+	for addr, expected := range tests {
+		t.Run(addr, func(t *testing.T) {
+			assert.Equal(t, expected, isPublicAddr(netip.MustParseAddr(addr)))
+		})
+	}
+}
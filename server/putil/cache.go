@@ -22,6 +22,8 @@ type CacheConfig struct {
 	TTL          time.Duration
 	FS           afero.Fs
 	CacheControl bool
+	// Key returns a cache key of the request. An empty key disables the cache for the request. The default key is made from the URL path.
+	Key func(c echo.Context) string
 }
 
 type CacheMiddleware struct {
@@ -117,6 +119,9 @@ func (c cacheEntry) Active(now time.Time) bool {
 }
 
 func (m *CacheMiddleware) key(c echo.Context) string {
+	if m.cfg.Key != nil {
+		return m.cfg.Key(c)
+	}
 	return strings.ReplaceAll(c.Request().URL.Path, "/", "_")
 }
 
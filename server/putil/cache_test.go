@@ -162,3 +162,28 @@ func TestCacheEntry_Active(t *testing.T) {
 	assert.True(t, cacheEntry{Expires: expires}.Active(now.Add(defaultCacheTTL).Add(-time.Second)))
 	assert.False(t, cacheEntry{Expires: expires}.Active(now.Add(defaultCacheTTL)))
 }
+
+func TestCacheMiddleware_Key(t *testing.T) {
+	called := 0
+	mfs := afero.NewMemMapFs()
+	m := NewCacheMiddleware(CacheConfig{FS: mfs, Key: func(c echo.Context) string {
+		return "key_" + c.QueryParam("q")
+	}})
+	e := echo.New()
+	e.Use(m.Middleware())
+	e.GET("/aaa", func(c echo.Context) error {
+		called++
+		return c.String(http.StatusOK, c.QueryParam("q"))
+	})
+
+	for _, q := range []string{"a", "b", "a"} {
+		r := httptest.NewRequest("GET", "/aaa?q="+q, nil)
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, r)
+		assert.Equal(t, q, w.Body.String())
+	}
+
+	assert.Equal(t, 2, called)
+	assert.Equal(t, "a", string(lo.Must(afero.ReadFile(mfs, "key_a"))))
+	assert.Equal(t, "b", string(lo.Must(afero.ReadFile(mfs, "key_b"))))
+}
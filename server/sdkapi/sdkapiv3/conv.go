@@ -1,21 +1,89 @@
 package sdkapiv3
 
-import "github.com/hasura/go-graphql-client"
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hasura/go-graphql-client"
+)
 
 const bldg = "bldg"
 const tokyo = "東京都"
 
-func (d *DatasetsQuery) ToDatasets() *DatasetsResponse {
+// DatasetsFilter narrows down datasets returned by the datasets endpoint. Empty fields match everything.
+type DatasetsFilter struct {
+	// Prefectures are prefecture codes or names.
+	Prefectures []string
+	// Cities are city codes or names.
+	Cities []string
+	// Specs are PLATEAU spec versions. "3" matches "3.4", "3.5" and so on.
+	Specs []string
+	Years []int
+	// Types are feature type codes such as "bldg".
+	Types []string
+}
+
+func DatasetsFilterFrom(q url.Values) (f DatasetsFilter, err error) {
+	f.Prefectures = splitQuery(q, "prefecture")
+	f.Cities = splitQuery(q, "city")
+	f.Specs = splitQuery(q, "spec")
+	f.Types = splitQuery(q, "type")
+	for _, y := range splitQuery(q, "year") {
+		year, err := strconv.Atoi(y)
+		if err != nil {
+			return f, err
+		}
+		f.Years = append(f.Years, year)
+	}
+	return
+}
+
+func (f DatasetsFilter) matchPrefecture(p QueryArea) bool {
+	return len(f.Prefectures) == 0 || slices.Contains(f.Prefectures, string(p.Code)) || slices.Contains(f.Prefectures, string(p.Name))
+}
+
+func (f DatasetsFilter) matchCity(c QueryCity) bool {
+	if len(f.Cities) > 0 && !slices.Contains(f.Cities, string(c.Code)) && !slices.Contains(f.Cities, string(c.Name)) {
+		return false
+	}
+
+	if len(f.Years) > 0 && !slices.Contains(f.Years, int(c.Citygml.Year)) {
+		return false
+	}
+
+	if len(f.Specs) > 0 {
+		v := string(c.Citygml.PlateauSpecMinor.Version)
+		if !slices.ContainsFunc(f.Specs, func(s string) bool {
+			return v == s || strings.HasPrefix(v, s+".")
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f DatasetsFilter) matchType(t string) bool {
+	return len(f.Types) == 0 || slices.Contains(f.Types, t)
+}
+
+func (d *DatasetsQuery) ToDatasets(f DatasetsFilter) *DatasetsResponse {
 	datasets := &DatasetsResponse{}
 
 	for _, prefecture := range d.Areas {
+		if !f.matchPrefecture(prefecture) {
+			continue
+		}
+
 		p := &DatasetPrefectureResponse{
 			ID:    string(prefecture.Code),
 			Title: string(prefecture.Name),
 		}
 
 		for _, city := range prefecture.Prefecture.Cities {
-			if city.Citygml == nil || len(city.Datasets) == 0 {
+			if city.Citygml == nil || len(city.Datasets) == 0 || !f.matchCity(city) {
 				continue
 			}
 
@@ -24,13 +92,29 @@ func (d *DatasetsQuery) ToDatasets() *DatasetsResponse {
 				Title:        string(city.Name),
 				FeatureTypes: toStrings(city.Citygml.FeatureTypes),
 				Spec:         string(city.Citygml.PlateauSpecMinor.Version),
+				Year:         int(city.Citygml.Year),
 			}
 
 			for _, dataset := range city.Datasets {
 				if dataset.TypeCode == bldg {
 					c.Description = string(dataset.Description)
-					break
 				}
+
+				if !f.matchType(string(dataset.TypeCode)) {
+					continue
+				}
+
+				c.Datasets = append(c.Datasets, &DatasetResponse{
+					ID:          string(dataset.ID),
+					Title:       string(dataset.Name),
+					Type:        string(dataset.TypeCode),
+					Year:        int(dataset.Year),
+					Description: string(dataset.Description),
+				})
+			}
+
+			if len(c.Datasets) == 0 {
+				continue
 			}
 
 			p.Data = append(p.Data, c)
@@ -52,6 +136,21 @@ func (d *DatasetsQuery) ToDatasets() *DatasetsResponse {
 	return datasets
 }
 
+// Filter returns files of the feature types. If types is empty, all files are returned.
+func (r DatasetFilesResponse) Filter(types []string) DatasetFilesResponse {
+	if len(types) == 0 {
+		return r
+	}
+
+	res := DatasetFilesResponse{}
+	for t, files := range r {
+		if slices.Contains(types, t) {
+			res[t] = files
+		}
+	}
+	return res
+}
+
 func toStrings(s []graphql.String) []string {
 	var r []string
 	for _, v := range s {
@@ -59,3 +158,15 @@ func toStrings(s []graphql.String) []string {
 	}
 	return r
 }
+
+// splitQuery returns values of the query parameter. Both "?type=a&type=b" and "?type=a,b" are accepted.
+func splitQuery(q url.Values, key string) (res []string) {
+	for _, v := range q[key] {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				res = append(res, s)
+			}
+		}
+	}
+	return
+}
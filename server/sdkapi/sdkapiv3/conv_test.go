@@ -1,6 +1,7 @@
 package sdkapiv3
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/hasura/go-graphql-client"
@@ -59,12 +60,127 @@ func TestQueryToDatasets(t *testing.T) {
 						Spec:         "3.4",
 						Description:  "Description",
 						FeatureTypes: []string{"bldg"},
+						Datasets: []*DatasetResponse{
+							{Type: "bldg", Description: "Description"},
+							{Type: "DatasetType1"},
+						},
 					},
 				},
 			},
 		},
 	}
 
-	datasets := query.ToDatasets()
+	datasets := query.ToDatasets(DatasetsFilter{})
 	assert.Equal(t, expected, datasets)
 }
+
+func TestQueryToDatasets_Filter(t *testing.T) {
+	city := func(code, name string, year int, spec string, types ...string) QueryCity {
+		c := QueryCity{
+			Code: graphql.String(code),
+			Name: graphql.String(name),
+			Citygml: &QueryCityCityGML{
+				Year:             graphql.Int(year),
+				PlateauSpecMinor: QueryPlateauSpecMinor{Version: graphql.String(spec)},
+			},
+		}
+		for _, ty := range types {
+			c.Datasets = append(c.Datasets, QueryDataset{TypeCode: graphql.String(ty)})
+		}
+		return c
+	}
+
+	query := &DatasetsQuery{
+		Areas: []QueryArea{
+			{
+				Code: "01",
+				Name: "北海道",
+				Prefecture: QueryPrefecture{
+					Cities: []QueryCity{
+						city("01100", "札幌市", 2022, "3.0", "bldg", "tran"),
+					},
+				},
+			},
+			{
+				Code: "13",
+				Name: "東京都",
+				Prefecture: QueryPrefecture{
+					Cities: []QueryCity{
+						city("13101", "千代田区", 2023, "3.4", "bldg"),
+						city("13102", "中央区", 2022, "2.3", "bldg", "luse"),
+					},
+				},
+			},
+		},
+	}
+
+	ids := func(r *DatasetsResponse) (res []string) {
+		for _, p := range r.Data {
+			for _, c := range p.Data {
+				for _, d := range c.Datasets {
+					res = append(res, c.ID+":"+d.Type)
+				}
+			}
+		}
+		return
+	}
+
+	tests := []struct {
+		name     string
+		filter   DatasetsFilter
+		expected []string
+	}{
+		{
+			name:     "all",
+			expected: []string{"13101:bldg", "13102:bldg", "13102:luse", "01100:bldg", "01100:tran"},
+		},
+		{
+			name:     "prefecture",
+			filter:   DatasetsFilter{Prefectures: []string{"北海道"}},
+			expected: []string{"01100:bldg", "01100:tran"},
+		},
+		{
+			name:     "city",
+			filter:   DatasetsFilter{Cities: []string{"13102", "札幌市"}},
+			expected: []string{"13102:bldg", "13102:luse", "01100:bldg", "01100:tran"},
+		},
+		{
+			name:     "spec",
+			filter:   DatasetsFilter{Specs: []string{"3"}},
+			expected: []string{"13101:bldg", "01100:bldg", "01100:tran"},
+		},
+		{
+			name:     "year",
+			filter:   DatasetsFilter{Years: []int{2022}},
+			expected: []string{"13102:bldg", "13102:luse", "01100:bldg", "01100:tran"},
+		},
+		{
+			name:     "type",
+			filter:   DatasetsFilter{Types: []string{"tran", "luse"}},
+			expected: []string{"13102:luse", "01100:tran"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ids(query.ToDatasets(tt.filter)))
+		})
+	}
+}
+
+func TestDatasetsFilterFrom(t *testing.T) {
+	f, err := DatasetsFilterFrom(url.Values{
+		"prefecture": {"13"},
+		"type":       {"bldg,tran", "luse"},
+		"year":       {"2022,2023"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, DatasetsFilter{
+		Prefectures: []string{"13"},
+		Types:       []string{"bldg", "tran", "luse"},
+		Years:       []int{2022, 2023},
+	}, f)
+
+	_, err = DatasetsFilterFrom(url.Values{"year": {"x"}})
+	assert.Error(t, err)
+}
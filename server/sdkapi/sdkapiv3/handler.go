@@ -1,6 +1,9 @@
 package sdkapiv3
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -33,18 +36,23 @@ func Handler(conf Config, g *echo.Group) (bool, error) {
 
 	g.GET("/datasets", func(c echo.Context) error {
 		ctx := c.Request().Context()
+		f, err := DatasetsFilterFrom(c.QueryParams())
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]any{"error": "invalid year"})
+		}
+
 		res, err := client.QueryDatasets(ctx)
 		if err != nil {
 			log.Errorfc(ctx, "sdkapiv3: error querying datasets: %v", err)
 			return c.JSON(http.StatusBadGateway, map[string]any{"error": "bad gateway"})
 		}
 
-		res2 := res.ToDatasets()
+		res2 := res.ToDatasets(f)
 		if res2 == nil {
 			return c.JSON(http.StatusNotFound, map[string]any{"error": "not found"})
 		}
 
-		return c.JSON(http.StatusOK, res2)
+		return jsonWithETag(c, res2)
 	})
 
 	g.GET("/datasets/:id/files", func(c echo.Context) error {
@@ -60,13 +68,41 @@ func Handler(conf Config, g *echo.Group) (bool, error) {
 			return c.JSON(http.StatusNotFound, map[string]any{"error": "not found"})
 		}
 
-		return c.JSON(http.StatusOK, res)
+		return jsonWithETag(c, res.Filter(splitQuery(c.QueryParams(), "type")))
 	})
 
 	log.Infof("sdkapiv3: initialized")
 	return true, nil
 }
 
+// jsonWithETag responds with JSON and its ETag. If the client already has the same response, 304 is returned instead.
+func jsonWithETag(c echo.Context, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	h := sha256.Sum256(b)
+	etag := `"` + hex.EncodeToString(h[:16]) + `"`
+	c.Response().Header().Set("ETag", etag)
+
+	if matchETag(c.Request().Header.Get("If-None-Match"), etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.JSONBlob(http.StatusOK, b)
+}
+
+func matchETag(header, etag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || strings.TrimPrefix(t, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
 func auth(expected string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -0,0 +1,67 @@
+package sdkapiv3
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Files(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/citygml/13101" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"files":{"bldg":[{"code":"533946","maxLod":2,"url":"https://example.com/a.gml","size":100,"texture":true}],"tran":[{"code":"533946","maxLod":1,"url":"https://example.com/b.gml"}]}}`))
+	}))
+	defer upstream.Close()
+
+	e := echo.New()
+	ok, err := Handler(Config{DataCatagloAPIURL: upstream.URL, Token: "token"}, e.Group("/sdk"))
+	assert.True(t, ok)
+	assert.NoError(t, err)
+
+	// unauthorized
+	r := httptest.NewRequest("GET", "/sdk/datasets/13101/files", nil)
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// ok
+	r = httptest.NewRequest("GET", "/sdk/datasets/13101/files?type=bldg", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"bldg":[{"code":"533946","maxLod":2,"url":"https://example.com/a.gml","size":100,"texture":true}]}`, w.Body.String())
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	// not modified
+	r = httptest.NewRequest("GET", "/sdk/datasets/13101/files?type=bldg", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	r.Header.Set("If-None-Match", "W/"+etag)
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	// another response
+	r = httptest.NewRequest("GET", "/sdk/datasets/13101/files", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+	// not found
+	r = httptest.NewRequest("GET", "/sdk/datasets/13102/files", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
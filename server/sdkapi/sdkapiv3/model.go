@@ -23,7 +23,7 @@ type QueryCity struct {
 	ID       graphql.String
 	Name     graphql.String
 	Code     graphql.String
-	Datasets []QueryDataset `graphql:"datasets(input: {includeTypes: [\"bldg\"]})"`
+	Datasets []QueryDataset `graphql:"datasets(input: {includeTypes: [\"plateau\"]})"`
 	Citygml  *QueryCityCityGML
 }
 
@@ -32,9 +32,11 @@ type QueryDataset struct {
 	Name        graphql.String
 	TypeCode    graphql.String
 	Description graphql.String
+	Year        graphql.Int
 }
 
 type QueryCityCityGML struct {
+	Year             graphql.Int
 	FeatureTypes     []graphql.String
 	PlateauSpecMinor QueryPlateauSpecMinor
 }
@@ -11,17 +11,29 @@ type DatasetPrefectureResponse struct {
 }
 
 type DatasetCityResponse struct {
-	ID           string   `json:"id"`
-	Title        string   `json:"title"`
-	Spec         string   `json:"spec"`
-	Description  string   `json:"description"`
-	FeatureTypes []string `json:"featureTypes"`
+	ID           string             `json:"id"`
+	Title        string             `json:"title"`
+	Spec         string             `json:"spec"`
+	Year         int                `json:"year"`
+	Description  string             `json:"description"`
+	FeatureTypes []string           `json:"featureTypes"`
+	Datasets     []*DatasetResponse `json:"datasets"`
+}
+
+type DatasetResponse struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Type        string `json:"type"`
+	Year        int    `json:"year"`
+	Description string `json:"description"`
 }
 
 type DatasetFilesResponse map[string][]DatasetFilesResponseItem
 
 type DatasetFilesResponseItem struct {
-	Code   string `json:"code"`
-	MaxLod int    `json:"maxLod"`
-	URL    string `json:"url"`
+	Code    string `json:"code"`
+	MaxLod  int    `json:"maxLod"`
+	URL     string `json:"url"`
+	Size    int    `json:"size,omitempty"`
+	Texture *bool  `json:"texture,omitempty"`
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/eukarya-inc/reearth-plateauview/server/datacatalog"
 	"github.com/eukarya-inc/reearth-plateauview/server/govpolygon"
 	"github.com/eukarya-inc/reearth-plateauview/server/opinion"
+	"github.com/eukarya-inc/reearth-plateauview/server/proxy"
 	"github.com/eukarya-inc/reearth-plateauview/server/putil"
 	"github.com/eukarya-inc/reearth-plateauview/server/sdkapi/sdkapiv3"
 	"github.com/eukarya-inc/reearth-plateauview/server/searchindex"
@@ -32,6 +33,7 @@ var services = [](func(*Config) (*Service, error)){
 	DataCatalog,
 	GovPolygon,
 	Embed,
+	Proxy,
 }
 
 func Services(conf *Config) (srv []*Service, _ error) {
@@ -100,15 +102,14 @@ func SDKAPI(conf *Config) (*Service, error) {
 
 func Opinion(conf *Config) (*Service, error) {
 	c := conf.Opinion()
-	if c.SendGridAPIKey == "" || c.From == "" || c.To == "" {
+	if !c.Enabled() {
 		return nil, nil
 	}
 
 	return &Service{
 		Name: "opinion",
 		Echo: func(g *echo.Group) error {
-			opinion.Echo(g.Group("/opinion"), c)
-			return nil
+			return opinion.Echo(g.Group("/opinion"), c)
 		},
 	}, nil
 }
@@ -172,3 +173,18 @@ func Embed(conf *Config) (*Service, error) {
 		},
 	}, nil
 }
+
+func Proxy(conf *Config) (*Service, error) {
+	if conf.Proxy_Disable {
+		return nil, nil
+	}
+
+	c := conf.Proxy()
+	return &Service{
+		Name:           "proxy",
+		DisableNoCache: c.Cache,
+		Echo: func(g *echo.Group) error {
+			return proxy.Echo(g.Group("/proxy"), c)
+		},
+	}, nil
+}
@@ -1,16 +1,24 @@
 package sidebar
 
 import (
+	"time"
+
 	"github.com/eukarya-inc/reearth-plateauview/server/plateaucms"
 )
 
 type Config struct {
 	plateaucms.Config
 	DisableShare bool
+	// ShareDefaultTTL is applied to shares created without an explicit expiry. Zero means shares never expire.
+	ShareDefaultTTL time.Duration
+	// ShareInactiveTTL is a duration after which shares that have not been accessed are deleted by the cleanup. Zero disables it.
+	ShareInactiveTTL time.Duration
 }
 
 type Handler struct {
-	cms *plateaucms.CMS
+	cms              *plateaucms.CMS
+	shareDefaultTTL  time.Duration
+	shareInactiveTTL time.Duration
 }
 
 func NewHandler(c Config) (*Handler, error) {
@@ -20,6 +28,8 @@ func NewHandler(c Config) (*Handler, error) {
 	}
 
 	return &Handler{
-		cms: cms,
+		cms:              cms,
+		shareDefaultTTL:  c.ShareDefaultTTL,
+		shareInactiveTTL: c.ShareInactiveTTL,
 	}, nil
 }
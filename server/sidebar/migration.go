@@ -0,0 +1,159 @@
+package sidebar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	cms "github.com/reearth/reearth-cms-api/go"
+	"github.com/reearth/reearthx/log"
+)
+
+// Migration upgrades a document by one schema version.
+type Migration func(doc any) (any, error)
+
+// migrations[kind][i] migrates a document of the kind from version i to version i+1.
+// Documents stored before the schemas were introduced have version 0.
+var migrations = map[SchemaKind][]Migration{
+	SchemaKindData:     {migrateRemoveStaleID},
+	SchemaKindTemplate: {migrateRemoveStaleID},
+	SchemaKindShare:    {migrateNop},
+}
+
+var schemaModels = map[SchemaKind]string{
+	SchemaKindData:     dataModelKey,
+	SchemaKindTemplate: templateModelKey,
+	SchemaKindShare:    shareCMSModel,
+}
+
+func init() {
+	for kind, version := range CurrentSchemaVersions {
+		if len(migrations[kind]) != version {
+			panic(fmt.Sprintf("sidebar: migrations for %s do not reach v%d", kind, version))
+		}
+	}
+}
+
+// Migrate upgrades the document of the version to the current schema version.
+func Migrate(kind SchemaKind, version int, doc any) (any, error) {
+	current := CurrentSchemaVersions[kind]
+	if version > current {
+		return nil, fmt.Errorf("%s schema v%d is newer than the current version v%d", kind, version, current)
+	}
+
+	for v := version; v < current; v++ {
+		var err error
+		doc, err = migrations[kind][v](doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate %s from v%d to v%d: %w", kind, v, v+1, err)
+		}
+	}
+	return doc, nil
+}
+
+// MigrateJSON is the same as Migrate, but it accepts and returns a JSON document. If no migration is needed, doc is returned as it is.
+func MigrateJSON(kind SchemaKind, version int, doc []byte) ([]byte, error) {
+	if version == CurrentSchemaVersions[kind] {
+		return doc, nil
+	}
+
+	var j any
+	if err := json.Unmarshal(doc, &j); err != nil {
+		return nil, err
+	}
+
+	j, err := Migrate(kind, version, j)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(j)
+}
+
+func schemaVersionOf(item *cms.Item) int {
+	v := item.FieldByKey(schemaVersionField).GetValue()
+	if i := v.Int(); i != nil {
+		return *i
+	}
+	if f := v.Float(); f != nil {
+		return int(*f)
+	}
+	return 0
+}
+
+func schemaVersionCMSField(kind SchemaKind) *cms.Field {
+	return &cms.Field{Key: schemaVersionField, Type: "integer", Value: CurrentSchemaVersions[kind]}
+}
+
+type MigrationResult struct {
+	Total    int
+	Migrated int
+	Failed   []string
+}
+
+// MigrateItems migrates all items of the kind stored in the project to the current schema version.
+// If dryRun is true, items are only migrated and validated, but not updated.
+func MigrateItems(ctx context.Context, c cms.Interface, project string, kind SchemaKind, dryRun bool) (res MigrationResult, _ error) {
+	model := schemaModels[kind]
+	if model == "" {
+		return res, fmt.Errorf("unknown schema kind: %s", kind)
+	}
+
+	items, err := c.GetItemsByKeyInParallel(ctx, project, model, false, limit)
+	if err != nil {
+		return res, fmt.Errorf("failed to get items of %s: %w", model, err)
+	}
+	if items == nil {
+		return res, nil
+	}
+
+	current := CurrentSchemaVersions[kind]
+	for _, item := range items.Items {
+		res.Total++
+
+		version := schemaVersionOf(&item)
+		if version == current {
+			continue
+		}
+
+		data := item.FieldByKey(dataField).GetValue().String()
+		if data == nil {
+			continue
+		}
+
+		doc, err := MigrateJSON(kind, version, []byte(*data))
+		if err == nil {
+			err = Validate(kind, doc)
+		}
+		if err != nil {
+			log.Warnf("sidebar: failed to migrate %s item %s: %v", kind, item.ID, err)
+			res.Failed = append(res.Failed, item.ID)
+			continue
+		}
+
+		if !dryRun {
+			fields := []*cms.Field{
+				{Key: dataField, Type: "textarea", Value: string(doc)},
+				schemaVersionCMSField(kind),
+			}
+			if _, err := c.UpdateItem(ctx, item.ID, fields, nil); err != nil {
+				return res, fmt.Errorf("failed to update item %s: %w", item.ID, err)
+			}
+		}
+
+		res.Migrated++
+	}
+
+	return res, nil
+}
+
+// v0 -> v1: the viewer sends back the ID that was attached by the API when it updates a document, but the item ID is authoritative.
+func migrateRemoveStaleID(doc any) (any, error) {
+	if o, ok := doc.(map[string]any); ok {
+		delete(o, "id")
+	}
+	return doc, nil
+}
+
+func migrateNop(doc any) (any, error) {
+	return doc, nil
+}
@@ -0,0 +1,75 @@
+package sidebar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	cms "github.com/reearth/reearth-cms-api/go"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	res, err := Migrate(SchemaKindData, 0, map[string]any{"id": "a", "datasetId": "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"datasetId": "b"}, res)
+
+	res, err = Migrate(SchemaKindData, 1, map[string]any{"id": "a", "datasetId": "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"id": "a", "datasetId": "b"}, res)
+
+	_, err = Migrate(SchemaKindData, 2, map[string]any{})
+	assert.Error(t, err)
+}
+
+func TestMigrateJSON(t *testing.T) {
+	res, err := MigrateJSON(SchemaKindTemplate, 0, []byte(`{"id":"a","name":"b"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"b"}`, string(res))
+
+	res, err = MigrateJSON(SchemaKindTemplate, 1, []byte(`{"id":"a","name":"b"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":"a","name":"b"}`, string(res))
+}
+
+func TestMigrateItems(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.Deactivate()
+
+	httpmock.RegisterResponder("GET", "https://cms.example.com/api/projects/prj/models/sidebar-data/items", lo.Must(httpmock.NewJsonResponder(http.StatusOK, map[string]any{
+		"items": []map[string]any{
+			{"id": "a", "fields": []map[string]any{{"key": "data", "value": `{"id":"a","datasetId":"d","dataId":"e"}`}}},
+			{"id": "b", "fields": []map[string]any{{"key": "data", "value": `{"datasetId":"d","dataId":"e"}`}, {"key": "schema_version", "value": 1}}},
+			{"id": "c", "fields": []map[string]any{{"key": "data", "value": `{"id":"c"}`}}},
+		},
+		"page":       1,
+		"perPage":    100,
+		"totalCount": 3,
+	})))
+
+	updated := map[string]string{}
+	httpmock.RegisterResponder("PATCH", `=~^https://cms.example.com/api/items/`, func(r *http.Request) (*http.Response, error) {
+		var body struct {
+			Fields []*cms.Field `json:"fields"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		item := cms.Item{Fields: body.Fields}
+		updated[r.URL.Path] = *item.FieldByKey(dataField).GetValue().String()
+		return httpmock.NewJsonResponse(http.StatusOK, map[string]any{"id": "a"})
+	})
+
+	c := lo.Must(cms.New("https://cms.example.com", "token"))
+
+	res, err := MigrateItems(context.Background(), c, "prj", SchemaKindData, true)
+	assert.NoError(t, err)
+	assert.Equal(t, MigrationResult{Total: 3, Migrated: 1, Failed: []string{"c"}}, res)
+	assert.Empty(t, updated)
+
+	res, err = MigrateItems(context.Background(), c, "prj", SchemaKindData, false)
+	assert.NoError(t, err)
+	assert.Equal(t, MigrationResult{Total: 3, Migrated: 1, Failed: []string{"c"}}, res)
+	assert.Equal(t, map[string]string{"/api/items/a": `{"dataId":"e","datasetId":"d"}`}, updated)
+}
@@ -0,0 +1,81 @@
+package sidebar
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// SchemaKind is a kind of JSON documents stored by the sidebar and share APIs.
+type SchemaKind string
+
+const (
+	SchemaKindData     SchemaKind = "data"
+	SchemaKindTemplate SchemaKind = "template"
+	SchemaKindShare    SchemaKind = "share"
+	// schemaVersionField is a CMS field that holds the schema version of the document stored in the item.
+	schemaVersionField = "schema_version"
+)
+
+// CurrentSchemaVersions are the schema versions that documents are validated against on write and migrated to on read.
+var CurrentSchemaVersions = map[SchemaKind]int{
+	SchemaKindData:     1,
+	SchemaKindTemplate: 1,
+	SchemaKindShare:    1,
+}
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+var schemas = map[SchemaKind]*gojsonschema.Schema{}
+
+func init() {
+	for kind, version := range CurrentSchemaVersions {
+		b, err := schemaFS.ReadFile(fmt.Sprintf("schemas/%s_v%d.json", kind, version))
+		if err != nil {
+			panic(fmt.Sprintf("sidebar: schema for %s v%d is not found: %v", kind, version, err))
+		}
+
+		s, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(b))
+		if err != nil {
+			panic(fmt.Sprintf("sidebar: failed to load schema for %s v%d: %v", kind, version, err))
+		}
+		schemas[kind] = s
+	}
+}
+
+// SchemaError is returned when a document does not satisfy the schema.
+type SchemaError struct {
+	Kind    SchemaKind
+	Version int
+	Errors  []string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("invalid %s (schema v%d): %s", e.Kind, e.Version, strings.Join(e.Errors, "; "))
+}
+
+// Validate validates a JSON document against the current schema of the kind.
+func Validate(kind SchemaKind, doc []byte) error {
+	s := schemas[kind]
+	if s == nil {
+		return fmt.Errorf("unknown schema kind: %s", kind)
+	}
+
+	res, err := s.Validate(gojsonschema.NewBytesLoader(doc))
+	if err != nil {
+		return &SchemaError{Kind: kind, Version: CurrentSchemaVersions[kind], Errors: []string{err.Error()}}
+	}
+
+	if res.Valid() {
+		return nil
+	}
+
+	errs := make([]string, 0, len(res.Errors()))
+	for _, e := range res.Errors() {
+		errs = append(errs, e.String())
+	}
+	return &SchemaError{Kind: kind, Version: CurrentSchemaVersions[kind], Errors: errs}
+}
@@ -0,0 +1,28 @@
+package sidebar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(SchemaKindData, []byte(`{"datasetId":"a","dataId":"b","fieldComponents":{"groups":[{"id":"g","components":[{"id":"c","type":"TEST"}]}]}}`)))
+	assert.NoError(t, Validate(SchemaKindTemplate, []byte(`{"type":"component","name":"a","groups":[]}`)))
+	assert.NoError(t, Validate(SchemaKindTemplate, []byte(`{"type":"emphasis","name":"a","properties":[]}`)))
+	assert.NoError(t, Validate(SchemaKindShare, []byte(`{"$sharedLayers":[{"type":"dataset"}]}`)))
+
+	err := Validate(SchemaKindData, []byte(`{"datasetId":"a","fieldComponents":{"groups":[{}]}}`))
+	var serr *SchemaError
+	assert.ErrorAs(t, err, &serr)
+	assert.Equal(t, SchemaKindData, serr.Kind)
+	assert.Equal(t, 1, serr.Version)
+	assert.ElementsMatch(t, []string{
+		"(root): dataId is required",
+		"fieldComponents.groups.0: id is required",
+	}, serr.Errors)
+
+	assert.Error(t, Validate(SchemaKindTemplate, []byte(`{"type":"aaa","name":"a"}`)))
+	assert.Error(t, Validate(SchemaKindShare, []byte(`[]`)))
+	assert.Error(t, Validate(SchemaKind("unknown"), []byte(`{}`)))
+}
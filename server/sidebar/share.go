@@ -1,11 +1,15 @@
 package sidebar
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/eukarya-inc/reearth-plateauview/server/plateaucms"
 	"github.com/labstack/echo/v4"
@@ -13,13 +17,87 @@ import (
 	cms "github.com/reearth/reearth-cms-api/go"
 	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+	"golang.org/x/crypto/bcrypt"
 )
 
 const (
 	shareCMSModel        = "share"
 	shareCMSDataFieldKey = "data"
+	// SharePasswordHeader is a header to set a password when a share is created, and to send it when a protected share is fetched.
+	SharePasswordHeader = "X-Share-Password"
+	// ShareTokenHeader is a header to return an access token of a token-protected share.
+	ShareTokenHeader  = "X-Share-Token"
+	shareTokenParam   = "token"
+	shareExpiresParam = "expires"
 )
 
+// ShareItem is a share stored in the CMS. Password is stored as a bcrypt hash.
+type ShareItem struct {
+	ID             string `json:"id,omitempty" cms:"id"`
+	Data           string `json:"data,omitempty" cms:"data,textarea"`
+	ExpiresAt      string `json:"expires_at,omitempty" cms:"expires_at,date"`
+	Password       string `json:"-" cms:"password,text"`
+	Token          string `json:"-" cms:"token,text"`
+	Revoked        bool   `json:"revoked,omitempty" cms:"revoked,bool"`
+	AccessCount    int    `json:"access_count" cms:"access_count,integer"`
+	LastAccessedAt string `json:"last_accessed_at,omitempty" cms:"last_accessed_at,date"`
+	CreatedAt      string `json:"created_at,omitempty" cms:"created_at,date"`
+	SchemaVersion  int    `json:"schema_version,omitempty" cms:"schema_version,integer"`
+}
+
+func ShareItemFrom(item *cms.Item) (i ShareItem) {
+	item.Unmarshal(&i)
+	return
+}
+
+func (i ShareItem) Fields() (fields []*cms.Field) {
+	item := &cms.Item{}
+	cms.Marshal(i, item)
+	return item.Fields
+}
+
+func (i ShareItem) IsExpired(now time.Time) bool {
+	t := parseShareTime(i.ExpiresAt)
+	return !t.IsZero() && !now.Before(t)
+}
+
+// IsAbandoned returns true if the share has not been accessed for the given duration since the last access or its creation.
+func (i ShareItem) IsAbandoned(now time.Time, inactive time.Duration) bool {
+	if inactive <= 0 {
+		return false
+	}
+	t := parseShareTime(i.LastAccessedAt)
+	if t.IsZero() {
+		t = parseShareTime(i.CreatedAt)
+	}
+	return !t.IsZero() && now.Sub(t) >= inactive
+}
+
+func (i ShareItem) ShareStats() ShareStats {
+	return ShareStats{
+		ID:                i.ID,
+		AccessCount:       i.AccessCount,
+		LastAccessedAt:    i.LastAccessedAt,
+		ExpiresAt:         i.ExpiresAt,
+		CreatedAt:         i.CreatedAt,
+		Revoked:           i.Revoked,
+		PasswordProtected: i.Password != "",
+		TokenProtected:    i.Token != "",
+	}
+}
+
+type ShareStats struct {
+	ID                string `json:"id"`
+	AccessCount       int    `json:"accessCount"`
+	LastAccessedAt    string `json:"lastAccessedAt,omitempty"`
+	ExpiresAt         string `json:"expiresAt,omitempty"`
+	CreatedAt         string `json:"createdAt,omitempty"`
+	Revoked           bool   `json:"revoked"`
+	PasswordProtected bool   `json:"passwordProtected"`
+	TokenProtected    bool   `json:"tokenProtected"`
+}
+
 func ShareEcho(g *echo.Group, c Config) error {
 	if c.DisableShare {
 		return nil
@@ -31,7 +109,9 @@ func ShareEcho(g *echo.Group, c Config) error {
 	}
 
 	g.Use(
-		middleware.CORS(),
+		middleware.CORSWithConfig(middleware.CORSConfig{
+			ExposeHeaders: []string{ShareTokenHeader},
+		}),
 		middleware.BodyLimit("10M"),
 		h.cms.AuthMiddleware(plateaucms.AuthMiddlewareConfig{
 			Key: "pid",
@@ -39,13 +119,17 @@ func ShareEcho(g *echo.Group, c Config) error {
 	)
 
 	g.GET("/:pid/:id", h.GetShare())
+	g.GET("/:pid/:id/stats", h.GetShareStats())
 	g.POST("/:pid", h.CreateShare())
+	g.POST("/:pid/cleanup", h.CleanUpShares())
+	g.DELETE("/:pid/:id", h.RevokeShare())
 	return nil
 }
 
 func (s *Handler) GetShare() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
+		md := plateaucms.GetCMSMetadataFromContext(ctx)
 		cmsh := plateaucms.GetCMSFromContext(ctx)
 		if cmsh == nil {
 			return rerror.ErrNotFound
@@ -72,7 +156,43 @@ func (s *Handler) GetShare() echo.HandlerFunc {
 			return rerror.ErrNotFound
 		}
 
-		return c.Blob(http.StatusOK, "application/json", []byte(v))
+		item := ShareItemFrom(res)
+		now := util.Now()
+		if item.Revoked {
+			return c.JSON(http.StatusGone, "revoked")
+		}
+		if item.IsExpired(now) {
+			return c.JSON(http.StatusGone, "expired")
+		}
+
+		// the sidebar access token bypasses protections of shares
+		if !md.Auth {
+			if item.Token != "" && !secureCompare(item.Token, c.QueryParam(shareTokenParam)) {
+				return c.JSON(http.StatusForbidden, "forbidden")
+			}
+
+			if item.Password != "" {
+				password := c.Request().Header.Get(SharePasswordHeader)
+				if password == "" {
+					return c.JSON(http.StatusUnauthorized, "password required")
+				}
+				if bcrypt.CompareHashAndPassword([]byte(item.Password), []byte(password)) != nil {
+					return c.JSON(http.StatusUnauthorized, "invalid password")
+				}
+			}
+
+			// the access count is not critical, so failures are only logged
+			if err := countShareAccess(c, cmsh, item, now); err != nil {
+				log.Errorfc(ctx, "share: failed to count an access to %s: %v", item.ID, err)
+			}
+		}
+
+		data, err := MigrateJSON(SchemaKindShare, item.SchemaVersion, []byte(v))
+		if err != nil {
+			return rerror.ErrInternalBy(fmt.Errorf("share: failed to migrate %s: %v", item.ID, err))
+		}
+
+		return c.Blob(http.StatusOK, "application/json", data)
 	}
 }
 
@@ -95,9 +215,42 @@ func (s *Handler) CreateShare() echo.HandlerFunc {
 			return c.JSON(http.StatusBadRequest, "invalid json")
 		}
 
-		res, err := cmsh.CreateItemByKey(c.Request().Context(), md.ProjectAlias, shareCMSModel, []*cms.Field{
-			{Key: shareCMSDataFieldKey, Type: "textarea", Value: string(body)},
-		}, nil)
+		if err := Validate(SchemaKindShare, body); err != nil {
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+
+		now := util.Now()
+		item := ShareItem{
+			Data:          string(body),
+			CreatedAt:     formatShareTime(now),
+			SchemaVersion: CurrentSchemaVersions[SchemaKindShare],
+		}
+
+		expiresAt, err := parseShareExpiry(c.QueryParam(shareExpiresParam), now, s.shareDefaultTTL)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, "invalid expires")
+		}
+		if !expiresAt.IsZero() {
+			item.ExpiresAt = formatShareTime(expiresAt)
+		}
+
+		if password := c.Request().Header.Get(SharePasswordHeader); password != "" {
+			hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, "invalid password")
+			}
+			item.Password = string(hash)
+		}
+
+		if c.QueryParam(shareTokenParam) == "true" {
+			token, err := generateShareToken()
+			if err != nil {
+				return rerror.ErrInternalBy(fmt.Errorf("share: failed to generate a token: %v", err))
+			}
+			item.Token = token
+		}
+
+		res, err := cmsh.CreateItemByKey(c.Request().Context(), md.ProjectAlias, shareCMSModel, item.Fields(), nil)
 
 		if err != nil {
 			if errors.Is(err, cms.ErrNotFound) {
@@ -107,6 +260,167 @@ func (s *Handler) CreateShare() echo.HandlerFunc {
 			return rerror.ErrInternalBy(fmt.Errorf("share: failed to create an item: %v", err))
 		}
 
+		if item.Token != "" {
+			c.Response().Header().Set(ShareTokenHeader, item.Token)
+		}
 		return c.JSON(http.StatusOK, res.ID)
 	}
 }
+
+// GET /:pid/:id/stats
+func (s *Handler) GetShareStats() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		md := plateaucms.GetCMSMetadataFromContext(ctx)
+		cmsh := plateaucms.GetCMSFromContext(ctx)
+		if cmsh == nil {
+			return rerror.ErrNotFound
+		}
+		if !md.Auth {
+			return c.JSON(http.StatusUnauthorized, "unauthorized")
+		}
+
+		res, err := cmsh.GetItem(ctx, c.Param("id"), false)
+		if err != nil {
+			if errors.Is(err, cms.ErrNotFound) {
+				return c.JSON(http.StatusNotFound, "not found")
+			}
+			return rerror.ErrInternalBy(fmt.Errorf("share: failed to get an item: %v", err))
+		}
+
+		return c.JSON(http.StatusOK, ShareItemFrom(res).ShareStats())
+	}
+}
+
+// DELETE /:pid/:id
+func (s *Handler) RevokeShare() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		md := plateaucms.GetCMSMetadataFromContext(ctx)
+		cmsh := plateaucms.GetCMSFromContext(ctx)
+		if cmsh == nil {
+			return rerror.ErrNotFound
+		}
+		if !md.Auth {
+			return c.JSON(http.StatusUnauthorized, "unauthorized")
+		}
+
+		// revoked items are kept to be able to tell revoked shares from missing ones
+		if _, err := cmsh.UpdateItem(ctx, c.Param("id"), ShareItem{Revoked: true}.Fields(), nil); err != nil {
+			if errors.Is(err, cms.ErrNotFound) {
+				return c.JSON(http.StatusNotFound, "not found")
+			}
+			return rerror.ErrInternalBy(fmt.Errorf("share: failed to revoke an item: %v", err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// POST /:pid/cleanup
+// It deletes revoked, expired and abandoned shares. It is supposed to be called periodically by a scheduler.
+func (s *Handler) CleanUpShares() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		md := plateaucms.GetCMSMetadataFromContext(ctx)
+		if md.ProjectAlias == "" {
+			return rerror.ErrNotFound
+		}
+		cmsh := plateaucms.GetCMSFromContext(ctx)
+		if cmsh == nil {
+			return rerror.ErrNotFound
+		}
+		if !md.Auth {
+			return c.JSON(http.StatusUnauthorized, "unauthorized")
+		}
+
+		items, err := cmsh.GetItemsByKeyInParallel(ctx, md.ProjectAlias, shareCMSModel, false, limit)
+		if err != nil {
+			if errors.Is(err, cms.ErrNotFound) {
+				return c.JSON(http.StatusNotFound, "not found")
+			}
+			return rerror.ErrInternalBy(fmt.Errorf("share: failed to get items: %v", err))
+		}
+
+		now := util.Now()
+		deleted := 0
+		if items != nil {
+			for _, i := range items.Items {
+				item := ShareItemFrom(&i)
+				if !item.Revoked && !item.IsExpired(now) && !item.IsAbandoned(now, s.shareInactiveTTL) {
+					continue
+				}
+
+				if err := cmsh.DeleteItem(ctx, item.ID); err != nil && !errors.Is(err, cms.ErrNotFound) {
+					return rerror.ErrInternalBy(fmt.Errorf("share: failed to delete an item %s: %v", item.ID, err))
+				}
+				deleted++
+			}
+		}
+
+		log.Infofc(ctx, "share: %d shares deleted in %s", deleted, md.ProjectAlias)
+		return c.JSON(http.StatusOK, map[string]any{"deleted": deleted})
+	}
+}
+
+func countShareAccess(c echo.Context, cmsh cms.Interface, item ShareItem, now time.Time) error {
+	fields := ShareItem{
+		AccessCount:    item.AccessCount + 1,
+		LastAccessedAt: formatShareTime(now),
+	}.Fields()
+	_, err := cmsh.UpdateItem(c.Request().Context(), item.ID, fields, nil)
+	return err
+}
+
+// parseShareExpiry accepts a duration (e.g. "72h") or a RFC3339 time. An empty string means the default TTL.
+func parseShareExpiry(s string, now time.Time, defaultTTL time.Duration) (time.Time, error) {
+	if s == "" {
+		if defaultTTL <= 0 {
+			return time.Time{}, nil
+		}
+		return now.Add(defaultTTL), nil
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return time.Time{}, errors.New("expires must be positive")
+		}
+		return now.Add(d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !t.After(now) {
+		return time.Time{}, errors.New("expires must be in the future")
+	}
+	return t, nil
+}
+
+func parseShareTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func formatShareTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func generateShareToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func secureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -6,13 +6,16 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/eukarya-inc/reearth-plateauview/server/plateaucms"
 	"github.com/jarcoal/httpmock"
 	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestShareEcho(t *testing.T) {
@@ -61,6 +64,145 @@ func TestShareEcho(t *testing.T) {
 	e.ServeHTTP(w, r)
 	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
 	assert.Equal(t, `"invalid json"`, strings.TrimSpace(w.Body.String()))
+
+	r = httptest.NewRequest("POST", "/share/prj", strings.NewReader(`[]`))
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Equal(t, `"invalid share (schema v1): (root): Invalid type. Expected: object, given: array"`, strings.TrimSpace(w.Body.String()))
+
+	r = httptest.NewRequest("POST", "/share/prj?token=true&expires=24h", strings.NewReader(`{"a":"b"}`))
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Equal(t, `"aaa"`, strings.TrimSpace(w.Body.String()))
+	assert.NotEmpty(t, w.Header().Get(ShareTokenHeader))
+
+	r = httptest.NewRequest("POST", "/share/prj?expires=xxx", strings.NewReader(`{"a":"b"}`))
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Equal(t, `"invalid expires"`, strings.TrimSpace(w.Body.String()))
+}
+
+func TestShareEcho_Protection(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.Deactivate()
+	mockShareCMS(t)
+	defer util.MockNow(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))()
+
+	password := string(lo.Must(bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.MinCost)))
+	mockShareItem("expired", map[string]any{"expires_at": "2023-12-31T00:00:00Z"})
+	mockShareItem("revoked", map[string]any{"revoked": true})
+	mockShareItem("token", map[string]any{"token": "secret"})
+	mockShareItem("password", map[string]any{"password": password})
+
+	e := echo.New()
+	g := e.Group("/share")
+	assert.NoError(t, ShareEcho(g, Config{
+		Config: plateaucms.Config{
+			CMSBaseURL:     "https://cms.example.com",
+			CMSMainToken:   "token",
+			CMSMainProject: "prj",
+			AdminToken:     "admin",
+		},
+	}))
+
+	tests := []struct {
+		name     string
+		path     string
+		password string
+		auth     bool
+		status   int
+		body     string
+	}{
+		{name: "expired", path: "/share/prj/expired", status: http.StatusGone, body: `"expired"`},
+		{name: "revoked", path: "/share/prj/revoked", status: http.StatusGone, body: `"revoked"`},
+		{name: "token missing", path: "/share/prj/token", status: http.StatusForbidden, body: `"forbidden"`},
+		{name: "token invalid", path: "/share/prj/token?token=aaa", status: http.StatusForbidden, body: `"forbidden"`},
+		{name: "token", path: "/share/prj/token?token=secret", status: http.StatusOK, body: `{"a":"b"}`},
+		{name: "token with admin", path: "/share/prj/token", auth: true, status: http.StatusOK, body: `{"a":"b"}`},
+		{name: "password missing", path: "/share/prj/password", status: http.StatusUnauthorized, body: `"password required"`},
+		{name: "password invalid", path: "/share/prj/password", password: "aaa", status: http.StatusUnauthorized, body: `"invalid password"`},
+		{name: "password", path: "/share/prj/password", password: "pass", status: http.StatusOK, body: `{"a":"b"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.path, nil)
+			if tt.password != "" {
+				r.Header.Set(SharePasswordHeader, tt.password)
+			}
+			if tt.auth {
+				r.Header.Set("Authorization", "Bearer admin")
+			}
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, r)
+			assert.Equal(t, tt.status, w.Result().StatusCode)
+			assert.Equal(t, tt.body, strings.TrimSpace(w.Body.String()))
+		})
+	}
+
+	// revocation requires the sidebar access token
+	r := httptest.NewRequest("DELETE", "/share/prj/token", nil)
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+
+	r = httptest.NewRequest("DELETE", "/share/prj/token", nil)
+	r.Header.Set("Authorization", "Bearer admin")
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+
+	r = httptest.NewRequest("GET", "/share/prj/token/stats", nil)
+	r.Header.Set("Authorization", "Bearer admin")
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Equal(t, `{"id":"token","accessCount":3,"revoked":false,"passwordProtected":false,"tokenProtected":true}`, strings.TrimSpace(w.Body.String()))
+
+	r = httptest.NewRequest("POST", "/share/prj/cleanup", nil)
+	r.Header.Set("Authorization", "Bearer admin")
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Equal(t, `{"deleted":2}`, strings.TrimSpace(w.Body.String()))
+}
+
+func TestParseShareExpiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	res, err := parseShareExpiry("", now, 0)
+	assert.NoError(t, err)
+	assert.True(t, res.IsZero())
+
+	res, err = parseShareExpiry("", now, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), res)
+
+	res, err = parseShareExpiry("48h", now, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(48*time.Hour), res)
+
+	res, err = parseShareExpiry("2024-02-01T00:00:00Z", now, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), res)
+
+	_, err = parseShareExpiry("2023-02-01T00:00:00Z", now, 0)
+	assert.Error(t, err)
+
+	_, err = parseShareExpiry("-1h", now, 0)
+	assert.Error(t, err)
+}
+
+func TestShareItem_IsAbandoned(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.False(t, ShareItem{}.IsAbandoned(now, time.Hour))
+	assert.False(t, ShareItem{CreatedAt: "2023-01-01T00:00:00Z"}.IsAbandoned(now, 0))
+	assert.True(t, ShareItem{CreatedAt: "2023-01-01T00:00:00Z"}.IsAbandoned(now, time.Hour))
+	assert.False(t, ShareItem{CreatedAt: "2023-01-01T00:00:00Z", LastAccessedAt: "2023-12-31T23:30:00Z"}.IsAbandoned(now, time.Hour))
 }
 
 func mockShareCMS(t *testing.T) {
@@ -81,4 +223,32 @@ func mockShareCMS(t *testing.T) {
 		}
 		return httpmock.NewJsonResponse(http.StatusOK, map[string]string{"id": "aaa"})
 	})
+
+	httpmock.RegisterResponder("PATCH", `=~^https://cms.example.com/api/items/`, func(r *http.Request) (*http.Response, error) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			return httpmock.NewBytesResponse(http.StatusUnauthorized, nil), nil
+		}
+		return httpmock.NewJsonResponse(http.StatusOK, map[string]string{"id": "aaa"})
+	})
+
+	httpmock.RegisterResponder("DELETE", `=~^https://cms.example.com/api/items/`, httpmock.NewBytesResponder(http.StatusNoContent, nil))
+
+	httpmock.RegisterResponder("GET", "https://cms.example.com/api/projects/prj/models/share/items", lo.Must(httpmock.NewJsonResponder(http.StatusOK, map[string]any{
+		"items": []map[string]any{
+			{"id": "aaa", "fields": []map[string]any{{"key": "data", "value": `{"a":"b"}`}}},
+			{"id": "expired", "fields": []map[string]any{{"key": "data", "value": `{"a":"b"}`}, {"key": "expires_at", "value": "2023-12-31T00:00:00Z"}}},
+			{"id": "revoked", "fields": []map[string]any{{"key": "data", "value": `{"a":"b"}`}, {"key": "revoked", "value": true}}},
+		},
+		"page":       1,
+		"perPage":    100,
+		"totalCount": 3,
+	})))
+}
+
+func mockShareItem(id string, fields map[string]any) {
+	f := []map[string]any{{"key": "data", "value": `{"a":"b"}`}, {"key": "access_count", "value": 3}}
+	for k, v := range fields {
+		f = append(f, map[string]any{"key": k, "value": v})
+	}
+	httpmock.RegisterResponder("GET", "https://cms.example.com/api/items/"+id, lo.Must(httpmock.NewJsonResponder(http.StatusOK, map[string]any{"id": id, "fields": f})))
 }
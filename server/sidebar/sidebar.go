@@ -10,6 +10,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	cms "github.com/reearth/reearth-cms-api/go"
+	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/samber/lo"
 )
@@ -122,8 +123,8 @@ func (h *Handler) fetchRoot() func(c echo.Context) error {
 		}
 
 		return c.JSON(http.StatusOK, map[string]any{
-			"data":      itemsToJSONs(<-dataCh),
-			"templates": itemsToJSONs(<-templatesCh),
+			"data":      itemsToJSONs(SchemaKindData, <-dataCh),
+			"templates": itemsToJSONs(SchemaKindTemplate, <-templatesCh),
 		})
 	}
 }
@@ -158,9 +159,9 @@ func (h *Handler) getAllDataHandler() func(c echo.Context) error {
 		}
 
 		if data == nil {
-			return c.JSON(http.StatusOK, itemsToJSONs(nil))
+			return c.JSON(http.StatusOK, itemsToJSONs(SchemaKindData, nil))
 		}
-		return c.JSON(http.StatusOK, itemsToJSONs(data.Items))
+		return c.JSON(http.StatusOK, itemsToJSONs(SchemaKindData, data.Items))
 	}
 }
 
@@ -192,7 +193,7 @@ func (h *Handler) getDataHandler() func(c echo.Context) error {
 			return err
 		}
 
-		res := itemJSON(item.FieldByKey(dataField), item.ID)
+		res := itemJSON(SchemaKindData, item)
 		if res == nil {
 			return c.JSON(http.StatusNotFound, "not found")
 		}
@@ -219,14 +220,10 @@ func (h *Handler) createDataHandler() func(c echo.Context) error {
 			return err
 		}
 
-		if !json.Valid(b) {
-			return errors.New("invalid json")
+		fields, err := documentFields(SchemaKindData, b)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err.Error())
 		}
-
-		fields := []*cms.Field{{
-			Key:   dataField,
-			Value: string(b),
-		}}
 		item, err := cmsh.CreateItemByKey(ctx, md.ProjectAlias, dataModelKey, fields, nil)
 		if err != nil {
 			if errors.Is(err, cms.ErrNotFound) {
@@ -235,7 +232,7 @@ func (h *Handler) createDataHandler() func(c echo.Context) error {
 			return err
 		}
 
-		res := itemJSON(item.FieldByKey(dataField), item.ID)
+		res := itemJSON(SchemaKindData, item)
 		if res == nil {
 			return c.JSON(http.StatusNotFound, "not found")
 		}
@@ -263,15 +260,11 @@ func (h *Handler) updateDataHandler() func(c echo.Context) error {
 			return err
 		}
 
-		if !json.Valid(b) {
-			return errors.New("invalid json")
+		fields, err := documentFields(SchemaKindData, b)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err.Error())
 		}
 
-		fields := []*cms.Field{{
-			Key:   dataField,
-			Value: string(b),
-		}}
-
 		item, err := cmsh.UpdateItem(ctx, itemID, fields, nil)
 		if err != nil {
 			if errors.Is(err, cms.ErrNotFound) {
@@ -280,7 +273,7 @@ func (h *Handler) updateDataHandler() func(c echo.Context) error {
 			return err
 		}
 
-		res := itemJSON(item.FieldByKey(dataField), item.ID)
+		res := itemJSON(SchemaKindData, item)
 		if res == nil {
 			return c.JSON(http.StatusNotFound, "not found")
 		}
@@ -344,7 +337,7 @@ func (h *Handler) fetchTemplatesHandler() func(c echo.Context) error {
 			return err
 		}
 
-		return c.JSON(http.StatusOK, itemsToJSONs(res.Items))
+		return c.JSON(http.StatusOK, itemsToJSONs(SchemaKindTemplate, res.Items))
 	}
 }
 
@@ -370,7 +363,7 @@ func (h *Handler) fetchTemplateHandler() func(c echo.Context) error {
 			return err
 		}
 
-		res := itemJSON(template.FieldByKey(dataField), template.ID)
+		res := itemJSON(SchemaKindTemplate, template)
 		if res == nil {
 			return c.JSON(http.StatusNotFound, "not found")
 		}
@@ -397,15 +390,11 @@ func (h *Handler) createTemplateHandler() func(c echo.Context) error {
 			return err
 		}
 
-		if !json.Valid(b) {
-			return errors.New("invalid json")
+		fields, err := documentFields(SchemaKindTemplate, b)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err.Error())
 		}
 
-		fields := []*cms.Field{{
-			Key:   dataField,
-			Value: string(b),
-		}}
-
 		template, err := cmsh.CreateItemByKey(ctx, md.ProjectAlias, templateModelKey, fields, nil)
 		if err != nil {
 			if errors.Is(err, cms.ErrNotFound) {
@@ -414,7 +403,7 @@ func (h *Handler) createTemplateHandler() func(c echo.Context) error {
 			return err
 		}
 
-		res := itemJSON(template.FieldByKey(dataField), template.ID)
+		res := itemJSON(SchemaKindTemplate, template)
 		if res == nil {
 			return c.JSON(http.StatusNotFound, "not found")
 		}
@@ -442,15 +431,11 @@ func (h *Handler) updateTemplateHandler() func(c echo.Context) error {
 			return err
 		}
 
-		if !json.Valid(b) {
-			return errors.New("invalid json")
+		fields, err := documentFields(SchemaKindTemplate, b)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err.Error())
 		}
 
-		fields := []*cms.Field{{
-			Key:   dataField,
-			Value: string(b),
-		}}
-
 		template, err := cmsh.UpdateItem(ctx, templateID, fields, nil)
 		if err != nil {
 			if errors.Is(err, cms.ErrNotFound) {
@@ -459,7 +444,7 @@ func (h *Handler) updateTemplateHandler() func(c echo.Context) error {
 			return err
 		}
 
-		res := itemJSON(template.FieldByKey(dataField), template.ID)
+		res := itemJSON(SchemaKindTemplate, template)
 		if res == nil {
 			return c.JSON(http.StatusNotFound, "not found")
 		}
@@ -494,24 +479,60 @@ func (h *Handler) deleteTemplateHandler() func(c echo.Context) error {
 	}
 }
 
-func itemsToJSONs(items []cms.Item) []any {
+func itemsToJSONs(kind SchemaKind, items []cms.Item) []any {
 	return lo.FilterMap(items, func(i cms.Item, _ int) (any, bool) {
-		j := itemJSON(i.FieldByKey(dataField), i.ID)
+		j := itemJSON(kind, &i)
 		return j, j != nil
 	})
 }
 
-func itemJSON(f *cms.Field, id string) any {
+// itemJSON returns the document of the item migrated to the current schema version.
+func itemJSON(kind SchemaKind, item *cms.Item) any {
+	f := item.FieldByKey(dataField)
 	var j any
 	err := f.GetValue().JSON(&j)
 	if j == nil || err != nil {
 		return nil
 	}
+
+	if j, err = Migrate(kind, schemaVersionOf(item), j); err != nil {
+		log.Errorf("sidebar: failed to migrate item %s: %v", item.ID, err)
+		return nil
+	}
+
 	if f.ID != "" {
 		if o, ok := j.(map[string]any); ok {
-			o["id"] = id
+			o["id"] = item.ID
 			return o
 		}
 	}
 	return j
 }
+
+// documentFields validates the document and returns CMS fields to store it with the current schema version.
+func documentFields(kind SchemaKind, b []byte) ([]*cms.Field, error) {
+	var j any
+	if err := json.Unmarshal(b, &j); err != nil {
+		return nil, errors.New("invalid json")
+	}
+
+	// the ID is attached by the API on read, so it is not stored
+	j, err := migrateRemoveStaleID(j)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err = json.Marshal(j)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Validate(kind, b); err != nil {
+		return nil, err
+	}
+
+	return []*cms.Field{
+		{Key: dataField, Value: string(b)},
+		schemaVersionCMSField(kind),
+	}, nil
+}
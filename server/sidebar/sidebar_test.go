@@ -217,7 +217,7 @@ func TestHandler_createDataHandler(t *testing.T) {
 	defer httpmock.Deactivate()
 	mockCMS(t)
 
-	expected := `{"hoge":"foo"}` + "\n"
+	expected := `{"dataId":"d","datasetId":"ds"}` + "\n"
 	responder := func(req *http.Request) (*http.Response, error) {
 		i := cms.Item{}
 		_ = json.Unmarshal(lo.Must(io.ReadAll(req.Body)), &i)
@@ -238,7 +238,7 @@ func TestHandler_createDataHandler(t *testing.T) {
 		AuthMethods: authMethods,
 	})(h.createDataHandler())
 
-	req := httptest.NewRequest(http.MethodPost, path.Join("/", testCMSProject, "data"), strings.NewReader(`{"hoge":"foo"}`))
+	req := httptest.NewRequest(http.MethodPost, path.Join("/", testCMSProject, "data"), strings.NewReader(`{"dataId":"d","datasetId":"ds"}`))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+testSidebarAccessToken)
 	rec := httptest.NewRecorder()
@@ -252,7 +252,7 @@ func TestHandler_createDataHandler(t *testing.T) {
 	assert.Equal(t, expected, rec.Body.String())
 
 	// invalid token
-	req = httptest.NewRequest(http.MethodPost, path.Join("/", testCMSProject, "data"), strings.NewReader(`{"hoge":"foo"}`))
+	req = httptest.NewRequest(http.MethodPost, path.Join("/", testCMSProject, "data"), strings.NewReader(`{"dataId":"d","datasetId":"ds"}`))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer INVALID")
 	rec = httptest.NewRecorder()
@@ -271,7 +271,7 @@ func TestHandler_updateDataHandler(t *testing.T) {
 	defer httpmock.Deactivate()
 	mockCMS(t)
 
-	expected := `{"hoge":"hoge"}` + "\n"
+	expected := `{"dataId":"d","datasetId":"ds"}` + "\n"
 	responder := func(req *http.Request) (*http.Response, error) {
 		i := cms.Item{}
 		_ = json.Unmarshal(lo.Must(io.ReadAll(req.Body)), &i)
@@ -287,7 +287,7 @@ func TestHandler_updateDataHandler(t *testing.T) {
 	httpmock.RegisterResponder("PATCH", lo.Must(url.JoinPath(testCMSHost, "api", "items", itemID)), responder)
 
 	p := path.Join("/", testCMSProject, "data/", itemID)
-	req := httptest.NewRequest(http.MethodGet, p, strings.NewReader(`{"hoge":"hoge"}`))
+	req := httptest.NewRequest(http.MethodGet, p, strings.NewReader(`{"dataId":"d","datasetId":"ds"}`))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+testSidebarAccessToken)
 	rec := httptest.NewRecorder()
@@ -424,7 +424,7 @@ func TestHandler_createTemplateHandler(t *testing.T) {
 	defer httpmock.Deactivate()
 	mockCMS(t)
 
-	expected := `{"hoge":"hoge"}` + "\n"
+	expected := `{"name":"t","type":"component"}` + "\n"
 	responder := func(req *http.Request) (*http.Response, error) {
 		i := cms.Item{}
 		_ = json.Unmarshal(lo.Must(io.ReadAll(req.Body)), &i)
@@ -445,7 +445,7 @@ func TestHandler_createTemplateHandler(t *testing.T) {
 		AuthMethods: authMethods,
 	})(h.createTemplateHandler())
 
-	req := httptest.NewRequest(http.MethodGet, path.Join("/", testCMSProject, "templates"), strings.NewReader(`{"hoge":"hoge"}`))
+	req := httptest.NewRequest(http.MethodGet, path.Join("/", testCMSProject, "templates"), strings.NewReader(`{"name":"t","type":"component"}`))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+testSidebarAccessToken)
 	rec := httptest.NewRecorder()
@@ -485,7 +485,7 @@ func TestHandler_updateTemplateHandler(t *testing.T) {
 	})(h.updateTemplateHandler())
 
 	p := path.Join("/", testCMSProject, "templates", itemID)
-	req := httptest.NewRequest(http.MethodGet, p, strings.NewReader(`{"hoge":"hoge"}`))
+	req := httptest.NewRequest(http.MethodGet, p, strings.NewReader(`{"name":"t","type":"component"}`))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+testSidebarAccessToken)
 	rec := httptest.NewRecorder()
@@ -495,7 +495,7 @@ func TestHandler_updateTemplateHandler(t *testing.T) {
 
 	assert.NoError(t, handler(ctx))
 	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
-	assert.Equal(t, `{"hoge":"hoge"}`+"\n", rec.Body.String())
+	assert.Equal(t, `{"name":"t","type":"component"}`+"\n", rec.Body.String())
 }
 
 func TestHandler_deleteTemplateHandler(t *testing.T) {
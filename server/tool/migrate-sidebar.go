@@ -0,0 +1,63 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/eukarya-inc/reearth-plateauview/server/sidebar"
+	cms "github.com/reearth/reearth-cms-api/go"
+)
+
+func migrateSidebar(conf *Config, args []string) error {
+	println("migrate-sidebar")
+
+	var base, token, project, kinds string
+	var dryRun bool
+
+	flags := flag.NewFlagSet("migrate-sidebar", flag.ExitOnError)
+	flags.StringVar(&base, "base", conf.CMS_BaseURL, "CMS base URL")
+	flags.StringVar(&token, "token", conf.CMS_Token, "CMS token")
+	flags.StringVar(&project, "project", "", "project ID or alias")
+	flags.StringVar(&kinds, "kinds", "data,template,share", "comma-separated kinds of items to migrate")
+	flags.BoolVar(&dryRun, "dryrun", false, "dryrun")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if base == "" || token == "" || project == "" {
+		return errors.New("CMS base URL, CMS token, and project are required")
+	}
+
+	fmt.Printf("base: %s\nproject: %s\nkinds: %s\ndryrun: %t\n", base, project, kinds, dryRun)
+
+	c, err := cms.New(base, token)
+	if err != nil {
+		return fmt.Errorf("failed to init cms: %w", err)
+	}
+
+	ctx := context.Background()
+	failed := false
+	for _, k := range strings.Split(kinds, ",") {
+		kind := sidebar.SchemaKind(strings.TrimSpace(k))
+		res, err := sidebar.MigrateItems(ctx, c, project, kind, dryRun)
+		if err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", kind, err)
+		}
+
+		fmt.Printf("%s: total=%d migrated=%d failed=%d\n", kind, res.Total, res.Migrated, len(res.Failed))
+		for _, id := range res.Failed {
+			fmt.Printf("ERROR: %s | %s\n", kind, id)
+		}
+		if len(res.Failed) > 0 {
+			failed = true
+		}
+	}
+
+	if failed {
+		return errors.New("some items could not be migrated")
+	}
+	return nil
+}
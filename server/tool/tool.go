@@ -22,6 +22,8 @@ func Main(conf *Config, args []string) {
 		err = migrateV1(conf, args[1:])
 	case "upload-assets":
 		err = uploadAssets(conf, args[1:])
+	case "migrate-sidebar":
+		err = migrateSidebar(conf, args[1:])
 	case "help":
 		err = help(conf)
 	default: